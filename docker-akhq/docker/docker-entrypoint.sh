@@ -33,6 +33,9 @@ SECURITY_GROUPS_CONFIGURATION="$(resolve_secret_value "security_groups_config" "
 BASIC_AUTH_USERS_CONFIGURATION="$(resolve_secret_value "basic_auth_users_config" "BASIC_AUTH_USERS_CONFIGURATION")"
 LDAP_SERVER_CONFIGURATION="$(resolve_secret_value "ldap_server_config" "LDAP_SERVER_CONFIGURATION")"
 LDAP_USERS_CONFIGURATION="$(resolve_secret_value "ldap_users_config" "LDAP_USERS_CONFIGURATION")"
+ACCESS_GROUPS_CONFIGURATION="$(resolve_secret_value "access_groups_config" "ACCESS_GROUPS_CONFIGURATION")"
+OIDC_CONFIGURATION="$(resolve_secret_value "oidc_config" "OIDC_CONFIGURATION")"
+OIDC_CLIENT_SECRET="$(resolve_secret_value "oidc_client_secret" "OIDC_CLIENT_SECRET")"
 SCHEMA_REGISTRY_USERNAME="$(resolve_secret_value "username" "SCHEMA_REGISTRY_USERNAME")"
 SCHEMA_REGISTRY_PASSWORD="$(resolve_secret_value "password" "SCHEMA_REGISTRY_PASSWORD")"
 
@@ -321,7 +324,7 @@ if [[ -n ${SCHEMA_REGISTRY_URL} ]]; then
   SCHEMA_REGISTRY="schema-registry:${schema_registry_template}"
 fi
 MICRONAUT_SECURITY_CONFIG=""
-if [[ -n ${DEFAULT_USER_CONFIGURATION} || -n ${BASIC_AUTH_USERS_CONFIGURATION} || -n ${LDAP_SERVER_CONFIGURATION} ]]; then
+if [[ -n ${DEFAULT_USER_CONFIGURATION} || -n ${BASIC_AUTH_USERS_CONFIGURATION} || -n ${LDAP_SERVER_CONFIGURATION} || -n ${OIDC_CONFIGURATION} ]]; then
   MICRONAUT_SECURITY_CONFIG=\
 "micronaut:
    security:
@@ -370,6 +373,23 @@ ${DEFAULT_USER_CONFIGURATION}
 ${SHIFTED_LDAP_USERS_CONFIGURATION}
 EOL
 
+  # OpenID Connect configuration is generated by operator without client secret,
+  # Micronaut resolves the secret from OIDC_CLIENT_SECRET environment variable
+  if [[ -n ${OIDC_CONFIGURATION} ]]; then
+    echo "OpenID Connect configuration is provided, so OpenID Connect login will be configured"
+    export OIDC_CLIENT_SECRET
+    echo "${OIDC_CONFIGURATION}" > ${AKHQ_WORK}/oidc.yml
+    export MICRONAUT_CONFIG_FILES="${MICRONAUT_CONFIG_FILES},${AKHQ_WORK}/oidc.yml"
+  fi
+
+  # Access groups are generated by operator from AkhqConfig resources as a separate file,
+  # it is loaded after application.yml, so its roles and groups are merged with the ones above
+  if [[ -n ${ACCESS_GROUPS_CONFIGURATION} ]]; then
+    echo "Access groups configuration is provided, so namespace access groups will be configured"
+    echo "${ACCESS_GROUPS_CONFIGURATION}" > ${AKHQ_WORK}/access-groups.yml
+    export MICRONAUT_CONFIG_FILES="${MICRONAUT_CONFIG_FILES},${AKHQ_WORK}/access-groups.yml"
+  fi
+
 fi
 
 echo "Import trustcerts to application keystore"
@@ -0,0 +1,83 @@
+# Copyright 2024-2025 NetCracker Technology Corporation
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+
+# Runs integration tests once and publishes Robot Framework results to config map for Kafka service operator.
+# It is a command of integration tests Job, the operator reads the results and writes them to custom resource status.
+
+import base64
+import gzip
+import os
+import subprocess
+import sys
+
+from kubernetes import client, config
+from kubernetes.client.rest import ApiException
+
+environ = os.environ
+robot_home = environ.get("ROBOT_HOME", "/opt/robot")
+output_dir = environ.get("ROBOT_OUTPUT", f'{robot_home}/output')
+results_config_map = environ.get("RESULTS_CONFIG_MAP")
+run_trigger = environ.get("RUN_TRIGGER", "")
+namespace = environ.get("OS_PROJECT")
+tags = environ.get("TAGS")
+service_checker_script = environ.get("SERVICE_CHECKER_SCRIPT")
+# Robot Framework return codes greater than 250 mean that tests were not executed properly
+robot_error_code = 251
+
+
+def run_tests() -> str:
+    if service_checker_script:
+        if subprocess.call([sys.executable, service_checker_script]) != 0:
+            print("Kafka is not ready, tests are not started")
+            sys.exit(1)
+    command = ["robot", "--outputdir", output_dir, "--log", "NONE", "--report", "NONE"]
+    if tags:
+        command += ["-i", tags]
+    command.append(f'{robot_home}/tests')
+    code = subprocess.call(command)
+    output = f'{output_dir}/output.xml'
+    if code >= robot_error_code or not os.path.exists(output):
+        print(f'Robot Framework failed with {code} code')
+        sys.exit(1)
+    # Keywords are removed to keep the results within config map size limit
+    results = f'{output_dir}/results.xml'
+    subprocess.call(["rebot", "--removekeywords", "all", "--log", "NONE", "--report", "NONE",
+                     "--output", results, output])
+    return results
+
+
+def publish_results(results: str):
+    with open(results, "rb") as file:
+        content = base64.b64encode(gzip.compress(file.read())).decode()
+    config.load_incluster_config()
+    api = client.CoreV1Api()
+    body = client.V1ConfigMap(
+        metadata=client.V1ObjectMeta(name=results_config_map, namespace=namespace),
+        data={"run-trigger": run_trigger},
+        binary_data={"output.xml.gz": content})
+    try:
+        api.create_namespaced_config_map(namespace, body)
+    except ApiException as e:
+        if e.status != 409:
+            raise
+        api.replace_namespaced_config_map(results_config_map, namespace, body)
+    print(f'Results are published to [{results_config_map}] config map')
+
+
+if __name__ == '__main__':
+    if not results_config_map or not namespace:
+        print("RESULTS_CONFIG_MAP and OS_PROJECT environment variables must be specified")
+        sys.exit(1)
+    publish_results(run_tests())
@@ -0,0 +1,168 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package kafkaconntest provides certificates and local TLS server for tests of Kafka connections
+package kafkaconntest
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+)
+
+// Certificates are PEM-encoded CA certificate, server certificate for localhost and client certificate signed by CA
+type Certificates struct {
+	CACert     []byte
+	ServerCert []byte
+	ServerKey  []byte
+	ClientCert []byte
+	ClientKey  []byte
+}
+
+// NewCertificates generates CA and certificates signed by it
+func NewCertificates(t testing.TB) Certificates {
+	t.Helper()
+	caKey := newKey(t)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "kafka-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+	}
+	caDer, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca, err := x509.ParseCertificate(caDer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	issue := func(serial int64, name string, usage x509.ExtKeyUsage) ([]byte, []byte) {
+		key := newKey(t)
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(serial),
+			Subject:      pkix.Name{CommonName: name},
+			DNSNames:     []string{"localhost"},
+			IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			KeyUsage:     x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		keyDer, err := x509.MarshalECPrivateKey(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+			pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+	}
+
+	certificates := Certificates{CACert: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDer})}
+	certificates.ServerCert, certificates.ServerKey = issue(2, "kafka", x509.ExtKeyUsageServerAuth)
+	certificates.ClientCert, certificates.ClientKey = issue(3, "kafka-client", x509.ExtKeyUsageClientAuth)
+	return certificates
+}
+
+func newKey(t testing.TB) *ecdsa.PrivateKey {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+// ServerConfig returns TLS configuration of server which requires client certificate signed by CA if mutual is true
+func (c Certificates) ServerConfig(t testing.TB, mutual bool) *tls.Config {
+	t.Helper()
+	cert, err := tls.X509KeyPair(c.ServerCert, c.ServerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := &tls.Config{Certificates: []tls.Certificate{cert}}
+	if mutual {
+		pool := x509.NewCertPool()
+		pool.AppendCertsFromPEM(c.CACert)
+		config.ClientCAs = pool
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return config
+}
+
+// TLSServer accepts TLS connections on local address and reports completed handshakes
+type TLSServer struct {
+	Listener   net.Listener
+	Handshakes chan tls.ConnectionState
+}
+
+// NewTLSServer starts TLS server which closes connections right after handshake.
+// The server is stopped when test is finished.
+func NewTLSServer(t testing.TB, config *tls.Config) *TLSServer {
+	t.Helper()
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := &TLSServer{Listener: listener, Handshakes: make(chan tls.ConnectionState, 16)}
+	t.Cleanup(func() { _ = listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			tlsConn := conn.(*tls.Conn)
+			if tlsConn.Handshake() == nil {
+				select {
+				case server.Handshakes <- tlsConn.ConnectionState():
+				default:
+				}
+			}
+			_ = conn.Close()
+		}
+	}()
+	return server
+}
+
+// Addr returns address of the server
+func (s *TLSServer) Addr() string {
+	return s.Listener.Addr().String()
+}
+
+// WaitHandshake returns state of the first completed handshake or fails test after timeout
+func (s *TLSServer) WaitHandshake(t testing.TB, timeout time.Duration) tls.ConnectionState {
+	t.Helper()
+	select {
+	case state := <-s.Handshakes:
+		return state
+	case <-time.After(timeout):
+		t.Fatal("TLS handshake is not completed")
+		return tls.ConnectionState{}
+	}
+}
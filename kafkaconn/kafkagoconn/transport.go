@@ -0,0 +1,97 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package kafkagoconn creates kafka-go clients from Kafka connection settings
+package kafkagoconn
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Netcracker/qubership-kafka/kafkaconn"
+	kafka "github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl"
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/segmentio/kafka-go/sasl/scram"
+)
+
+// NewMechanism returns SASL mechanism for settings or nil if credentials are not specified
+func NewMechanism(settings kafkaconn.SASLSettings) (sasl.Mechanism, error) {
+	if !settings.Enabled() {
+		return nil, nil
+	}
+	if err := settings.Validate(); err != nil {
+		return nil, err
+	}
+	switch settings.GetMechanism() {
+	case kafkaconn.MechanismPlain:
+		return plain.Mechanism{Username: settings.Username, Password: settings.Password}, nil
+	case kafkaconn.MechanismScramSha256:
+		return scram.Mechanism(scram.SHA256, settings.Username, settings.Password)
+	case kafkaconn.MechanismOAuthBearer:
+		return oauthBearerMechanism{settings: settings}, nil
+	default:
+		return scram.Mechanism(scram.SHA512, settings.Username, settings.Password)
+	}
+}
+
+// NewTransport returns kafka-go transport with SASL and TLS applied from settings
+func NewTransport(settings kafkaconn.Settings) (*kafka.Transport, error) {
+	mechanism, err := NewMechanism(settings.SASL)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig, err := settings.TLS.Config()
+	if err != nil {
+		return nil, err
+	}
+	return &kafka.Transport{SASL: mechanism, TLS: tlsConfig}, nil
+}
+
+// NewClient creates kafka-go client for brokers from settings
+func NewClient(settings kafkaconn.Settings) (*kafka.Client, error) {
+	if len(settings.Brokers) == 0 {
+		return nil, fmt.Errorf("Kafka broker addresses are not specified")
+	}
+	transport, err := NewTransport(settings)
+	if err != nil {
+		return nil, err
+	}
+	return &kafka.Client{Addr: kafka.TCP(settings.Brokers...), Transport: transport}, nil
+}
+
+// oauthBearerMechanism implements OAUTHBEARER client message from RFC 7628, kafka-go does not provide it
+type oauthBearerMechanism struct {
+	settings kafkaconn.SASLSettings
+}
+
+func (m oauthBearerMechanism) Name() string {
+	return kafkaconn.MechanismOAuthBearer
+}
+
+func (m oauthBearerMechanism) Start(_ context.Context) (sasl.StateMachine, []byte, error) {
+	token, err := m.settings.AccessToken()
+	if err != nil {
+		return nil, nil, err
+	}
+	return m, []byte("n,,\x01auth=Bearer " + token + "\x01\x01"), nil
+}
+
+// Next fails if broker sends challenge, because challenge after the client message contains authentication error
+func (m oauthBearerMechanism) Next(_ context.Context, challenge []byte) (bool, []byte, error) {
+	if len(challenge) != 0 {
+		return false, nil, fmt.Errorf("OAuth bearer authentication failed: %s", challenge)
+	}
+	return true, nil, nil
+}
@@ -0,0 +1,105 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkagoconn
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Netcracker/qubership-kafka/kafkaconn"
+	"github.com/Netcracker/qubership-kafka/kafkaconn/kafkaconntest"
+	kafka "github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMechanism(t *testing.T) {
+	tests := []struct {
+		settings kafkaconn.SASLSettings
+		name     string
+	}{
+		{settings: kafkaconn.SASLSettings{Username: "admin", Password: "admin"}, name: "SCRAM-SHA-512"},
+		{settings: kafkaconn.SASLSettings{Mechanism: "PLAIN", Username: "admin", Password: "admin"}, name: "PLAIN"},
+		{settings: kafkaconn.SASLSettings{Mechanism: "SCRAM-SHA-256", Username: "admin", Password: "admin"}, name: "SCRAM-SHA-256"},
+		{settings: kafkaconn.SASLSettings{Mechanism: "OAUTHBEARER", Token: "token"}, name: "OAUTHBEARER"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mechanism, err := NewMechanism(tt.settings)
+			require.NoError(t, err)
+			assert.Equal(t, tt.name, mechanism.Name())
+		})
+	}
+}
+
+func TestNewMechanismWithoutCredentials(t *testing.T) {
+	mechanism, err := NewMechanism(kafkaconn.SASLSettings{Username: "admin"})
+	assert.NoError(t, err)
+	assert.Nil(t, mechanism)
+
+	_, err = NewMechanism(kafkaconn.SASLSettings{Mechanism: "GSSAPI", Username: "admin", Password: "admin"})
+	assert.Error(t, err)
+}
+
+func TestOAuthBearerMechanism(t *testing.T) {
+	mechanism, err := NewMechanism(kafkaconn.SASLSettings{Mechanism: kafkaconn.MechanismOAuthBearer, Token: "token"})
+	require.NoError(t, err)
+	session, initial, err := mechanism.Start(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "n,,\x01auth=Bearer token\x01\x01", string(initial))
+
+	done, _, err := session.Next(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.True(t, done)
+
+	_, _, err = session.Next(context.Background(), []byte(`{"status":"invalid_token"}`))
+	assert.Error(t, err)
+}
+
+func TestNewTransportWithoutTLS(t *testing.T) {
+	transport, err := NewTransport(kafkaconn.Settings{})
+	require.NoError(t, err)
+	assert.Nil(t, transport.TLS)
+	assert.Nil(t, transport.SASL)
+}
+
+func TestClientPresentsCertificateToBroker(t *testing.T) {
+	certificates := kafkaconntest.NewCertificates(t)
+	server := kafkaconntest.NewTLSServer(t, certificates.ServerConfig(t, true))
+
+	client, err := NewClient(kafkaconn.Settings{
+		Brokers: []string{server.Addr()},
+		TLS: kafkaconn.TLSSettings{
+			Enabled: true,
+			CACert:  certificates.CACert,
+			Cert:    certificates.ClientCert,
+			Key:     certificates.ClientKey,
+		},
+	})
+	require.NoError(t, err)
+	client.Timeout = 2 * time.Second
+	// server closes connection after handshake, so request fails, but handshake must be completed
+	_, _ = client.Metadata(context.Background(), &kafka.MetadataRequest{})
+
+	state := server.WaitHandshake(t, 5*time.Second)
+	require.Len(t, state.PeerCertificates, 1)
+	assert.Equal(t, "kafka-client", state.PeerCertificates[0].Subject.CommonName)
+}
+
+func TestNewClientWithoutBrokers(t *testing.T) {
+	_, err := NewClient(kafkaconn.Settings{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,86 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaconn
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+)
+
+// Keys of certificates in Kubernetes TLS secret
+const (
+	SecretCACertKey = "ca.crt"
+	SecretCertKey   = "tls.crt"
+	SecretKeyKey    = "tls.key"
+)
+
+// ValueFromFileOrEnv returns trimmed content of file, for example, mounted secret key,
+// or value of environment variable if file does not exist
+func ValueFromFileOrEnv(path string, env string) string {
+	if path != "" {
+		if value, err := os.ReadFile(path); err == nil {
+			return strings.TrimSpace(string(value))
+		}
+	}
+	return os.Getenv(env)
+}
+
+// LoadTLSFiles reads PEM-encoded certificates, files which do not exist are skipped
+func LoadTLSFiles(caCertFile string, certFile string, keyFile string) (TLSSettings, error) {
+	settings := TLSSettings{Enabled: true}
+	var err error
+	if settings.CACert, err = readOptionalFile(caCertFile); err != nil {
+		return settings, err
+	}
+	if settings.Cert, err = readOptionalFile(certFile); err != nil {
+		return settings, err
+	}
+	if settings.Key, err = readOptionalFile(keyFile); err != nil {
+		return settings, err
+	}
+	return settings, nil
+}
+
+// TLSFromSecretData returns certificates from data of Kubernetes TLS secret
+func TLSFromSecretData(data map[string][]byte) TLSSettings {
+	return TLSSettings{
+		Enabled: true,
+		CACert:  data[SecretCACertKey],
+		Cert:    data[SecretCertKey],
+		Key:     data[SecretKeyKey],
+	}
+}
+
+// CredentialsFromSecretData returns username and password stored with given keys in data of Kubernetes secret
+func CredentialsFromSecretData(data map[string][]byte, usernameKey string, passwordKey string) (string, string) {
+	return string(data[usernameKey]), string(data[passwordKey])
+}
+
+func readOptionalFile(path string) ([]byte, error) {
+	if path == "" {
+		return nil, nil
+	}
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return content, nil
+}
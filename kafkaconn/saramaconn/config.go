@@ -0,0 +1,115 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package saramaconn creates sarama clients from Kafka connection settings
+package saramaconn
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+
+	"github.com/IBM/sarama"
+	"github.com/Netcracker/qubership-kafka/kafkaconn"
+	"github.com/xdg-go/scram"
+)
+
+// NewConfig returns sarama configuration with SASL and TLS applied from settings
+func NewConfig(settings kafkaconn.Settings) (*sarama.Config, error) {
+	config := sarama.NewConfig()
+	if err := ApplySettings(config, settings); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// ApplySettings configures SASL and TLS of existing sarama configuration
+func ApplySettings(config *sarama.Config, settings kafkaconn.Settings) error {
+	if settings.SASL.Enabled() {
+		if err := settings.SASL.Validate(); err != nil {
+			return err
+		}
+		mechanism := settings.SASL.GetMechanism()
+		config.Net.SASL.Enable = true
+		config.Net.SASL.Mechanism = sarama.SASLMechanism(mechanism)
+		config.Net.SASL.User = settings.SASL.Username
+		config.Net.SASL.Password = settings.SASL.Password
+		switch mechanism {
+		case kafkaconn.MechanismScramSha256:
+			config.Net.SASL.SCRAMClientGeneratorFunc = func() sarama.SCRAMClient {
+				return &scramClient{HashGeneratorFcn: scram.HashGeneratorFcn(sha256.New)}
+			}
+		case kafkaconn.MechanismScramSha512:
+			config.Net.SASL.SCRAMClientGeneratorFunc = func() sarama.SCRAMClient {
+				return &scramClient{HashGeneratorFcn: scram.HashGeneratorFcn(sha512.New)}
+			}
+		case kafkaconn.MechanismOAuthBearer:
+			config.Net.SASL.TokenProvider = tokenProvider{settings: settings.SASL}
+		}
+	}
+	tlsConfig, err := settings.TLS.Config()
+	if err != nil {
+		return err
+	}
+	if tlsConfig != nil {
+		config.Net.TLS.Enable = true
+		config.Net.TLS.Config = tlsConfig
+	}
+	return nil
+}
+
+// NewClusterAdmin creates sarama cluster admin connected to brokers from settings
+func NewClusterAdmin(settings kafkaconn.Settings, version sarama.KafkaVersion) (sarama.ClusterAdmin, error) {
+	config, err := NewConfig(settings)
+	if err != nil {
+		return nil, err
+	}
+	config.Version = version
+	return sarama.NewClusterAdmin(settings.Brokers, config)
+}
+
+type scramClient struct {
+	*scram.Client
+	*scram.ClientConversation
+	scram.HashGeneratorFcn
+}
+
+func (c *scramClient) Begin(userName, password, authzID string) (err error) {
+	c.Client, err = c.HashGeneratorFcn.NewClient(userName, password, authzID)
+	if err != nil {
+		return err
+	}
+	c.ClientConversation = c.Client.NewConversation()
+	return nil
+}
+
+func (c *scramClient) Step(challenge string) (string, error) {
+	return c.ClientConversation.Step(challenge)
+}
+
+func (c *scramClient) Done() bool {
+	return c.ClientConversation.Done()
+}
+
+// tokenProvider reads token on each authentication, so rotated token file is used for new connections
+type tokenProvider struct {
+	settings kafkaconn.SASLSettings
+}
+
+func (p tokenProvider) Token() (*sarama.AccessToken, error) {
+	token, err := p.settings.AccessToken()
+	if err != nil {
+		return nil, err
+	}
+	return &sarama.AccessToken{Token: token}, nil
+}
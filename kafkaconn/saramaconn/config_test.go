@@ -0,0 +1,96 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package saramaconn
+
+import (
+	"crypto/tls"
+	"testing"
+
+	"github.com/IBM/sarama"
+	"github.com/Netcracker/qubership-kafka/kafkaconn"
+	"github.com/Netcracker/qubership-kafka/kafkaconn/kafkaconntest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewConfigWithScramMechanisms(t *testing.T) {
+	for _, mechanism := range []string{kafkaconn.MechanismScramSha256, kafkaconn.MechanismScramSha512} {
+		config, err := NewConfig(kafkaconn.Settings{
+			SASL: kafkaconn.SASLSettings{Mechanism: mechanism, Username: "admin", Password: "admin"},
+		})
+		require.NoError(t, err)
+		assert.True(t, config.Net.SASL.Enable)
+		assert.Equal(t, sarama.SASLMechanism(mechanism), config.Net.SASL.Mechanism)
+		require.NotNil(t, config.Net.SASL.SCRAMClientGeneratorFunc)
+		assert.NoError(t, config.Net.SASL.SCRAMClientGeneratorFunc().Begin("admin", "admin", ""))
+		assert.False(t, config.Net.TLS.Enable)
+	}
+}
+
+func TestNewConfigWithPlainMechanism(t *testing.T) {
+	config, err := NewConfig(kafkaconn.Settings{
+		SASL: kafkaconn.SASLSettings{Mechanism: kafkaconn.MechanismPlain, Username: "admin", Password: "admin"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, sarama.SASLMechanism(sarama.SASLTypePlaintext), config.Net.SASL.Mechanism)
+	assert.Nil(t, config.Net.SASL.SCRAMClientGeneratorFunc)
+}
+
+func TestNewConfigWithOAuthBearerMechanism(t *testing.T) {
+	config, err := NewConfig(kafkaconn.Settings{
+		SASL: kafkaconn.SASLSettings{Mechanism: kafkaconn.MechanismOAuthBearer, Token: "token"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, sarama.SASLMechanism(sarama.SASLTypeOAuth), config.Net.SASL.Mechanism)
+	require.NotNil(t, config.Net.SASL.TokenProvider)
+	token, err := config.Net.SASL.TokenProvider.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "token", token.Token)
+}
+
+func TestNewConfigWithUnsupportedMechanism(t *testing.T) {
+	_, err := NewConfig(kafkaconn.Settings{
+		SASL: kafkaconn.SASLSettings{Mechanism: "GSSAPI", Username: "admin", Password: "admin"},
+	})
+	assert.EqualError(t, err, "cannot use given SASL Mechanism: GSSAPI")
+}
+
+func TestClientConnectsToBrokerWithMutualTLS(t *testing.T) {
+	certificates := kafkaconntest.NewCertificates(t)
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", certificates.ServerConfig(t, true))
+	require.NoError(t, err)
+	broker := sarama.NewMockBrokerListener(t, 1, listener)
+	defer broker.Close()
+	broker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"ApiVersionsRequest": sarama.NewMockApiVersionsResponse(t),
+		"MetadataRequest": sarama.NewMockMetadataResponse(t).
+			SetBroker(broker.Addr(), broker.BrokerID()).
+			SetController(broker.BrokerID()),
+	})
+
+	config, err := NewConfig(kafkaconn.Settings{
+		TLS: kafkaconn.TLSSettings{
+			Enabled: true,
+			CACert:  certificates.CACert,
+			Cert:    certificates.ClientCert,
+			Key:     certificates.ClientKey,
+		},
+	})
+	require.NoError(t, err)
+	client, err := sarama.NewClient([]string{broker.Addr()}, config)
+	require.NoError(t, err)
+	defer client.Close()
+	assert.Len(t, client.Brokers(), 1)
+}
@@ -0,0 +1,120 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package kafkaconn describes how to connect to Kafka: broker addresses, SASL credentials and TLS certificates.
+// Settings are loaded from files, environment variables or Kubernetes secrets and are converted
+// to clients of sarama and kafka-go libraries by saramaconn and kafkagoconn packages.
+package kafkaconn
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	MechanismPlain       = "PLAIN"
+	MechanismScramSha256 = "SCRAM-SHA-256"
+	MechanismScramSha512 = "SCRAM-SHA-512"
+	MechanismOAuthBearer = "OAUTHBEARER"
+
+	// DefaultMechanism is used when credentials are specified without mechanism
+	DefaultMechanism = MechanismScramSha512
+)
+
+// Settings contains everything that is needed to connect to Kafka cluster
+type Settings struct {
+	Brokers []string
+	SASL    SASLSettings
+	TLS     TLSSettings
+}
+
+// SASLSettings contains SASL mechanism and its credentials.
+// PLAIN and SCRAM mechanisms use username and password, OAUTHBEARER uses token.
+type SASLSettings struct {
+	Mechanism string
+	Username  string
+	Password  string
+	// Token is the static OAuth bearer token
+	Token string
+	// TokenFile is the path to OAuth bearer token, it is read on each authentication
+	// to use the rotated token, for example, projected service account token
+	TokenFile string
+}
+
+// ParseBrokers splits comma-separated list of broker addresses
+func ParseBrokers(brokers string) []string {
+	var addresses []string
+	for _, address := range strings.Split(brokers, ",") {
+		if address = strings.TrimSpace(address); address != "" {
+			addresses = append(addresses, address)
+		}
+	}
+	return addresses
+}
+
+// GetMechanism returns upper-cased mechanism or DefaultMechanism if it is not specified
+func (s SASLSettings) GetMechanism() string {
+	if s.Mechanism == "" {
+		return DefaultMechanism
+	}
+	return strings.ToUpper(s.Mechanism)
+}
+
+// Enabled returns true if credentials for the mechanism are specified
+func (s SASLSettings) Enabled() bool {
+	if s.GetMechanism() == MechanismOAuthBearer {
+		return s.Token != "" || s.TokenFile != ""
+	}
+	return s.Username != "" && s.Password != ""
+}
+
+// Validate checks that mechanism is supported
+func (s SASLSettings) Validate() error {
+	switch s.GetMechanism() {
+	case MechanismPlain, MechanismScramSha256, MechanismScramSha512, MechanismOAuthBearer:
+		return nil
+	default:
+		return fmt.Errorf("cannot use given SASL Mechanism: %s", s.Mechanism)
+	}
+}
+
+// AccessToken returns OAuth bearer token, token file takes precedence over static token
+func (s SASLSettings) AccessToken() (string, error) {
+	if s.TokenFile == "" {
+		if s.Token == "" {
+			return "", fmt.Errorf("OAuth bearer token is not specified")
+		}
+		return s.Token, nil
+	}
+	token, err := os.ReadFile(s.TokenFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read OAuth bearer token: %w", err)
+	}
+	return strings.TrimSpace(string(token)), nil
+}
+
+// Validate checks that SASL mechanism is supported and TLS certificates are correct
+func (s Settings) Validate() error {
+	if len(s.Brokers) == 0 {
+		return fmt.Errorf("Kafka broker addresses are not specified")
+	}
+	if s.SASL.Enabled() {
+		if err := s.SASL.Validate(); err != nil {
+			return err
+		}
+	}
+	_, err := s.TLS.Config()
+	return err
+}
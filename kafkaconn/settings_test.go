@@ -0,0 +1,91 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaconn
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBrokers(t *testing.T) {
+	assert.Equal(t, []string{"kafka-1:9092", "kafka-2:9092"}, ParseBrokers("kafka-1:9092, kafka-2:9092,"))
+	assert.Nil(t, ParseBrokers(""))
+}
+
+func TestSASLSettingsValidate(t *testing.T) {
+	for _, mechanism := range []string{"", "plain", MechanismScramSha256, MechanismScramSha512, MechanismOAuthBearer} {
+		assert.NoError(t, SASLSettings{Mechanism: mechanism}.Validate(), mechanism)
+	}
+	err := SASLSettings{Mechanism: "GSSAPI"}.Validate()
+	assert.EqualError(t, err, "cannot use given SASL Mechanism: GSSAPI")
+}
+
+func TestSASLSettingsEnabled(t *testing.T) {
+	assert.False(t, SASLSettings{Username: "admin"}.Enabled())
+	assert.True(t, SASLSettings{Username: "admin", Password: "admin"}.Enabled())
+	assert.False(t, SASLSettings{Mechanism: MechanismOAuthBearer, Username: "admin", Password: "admin"}.Enabled())
+	assert.True(t, SASLSettings{Mechanism: MechanismOAuthBearer, TokenFile: "/var/run/secrets/token"}.Enabled())
+}
+
+func TestSASLSettingsAccessToken(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("rotated\n"), 0600))
+
+	token, err := SASLSettings{Token: "static"}.AccessToken()
+	require.NoError(t, err)
+	assert.Equal(t, "static", token)
+
+	token, err = SASLSettings{Token: "static", TokenFile: tokenFile}.AccessToken()
+	require.NoError(t, err)
+	assert.Equal(t, "rotated", token)
+
+	_, err = SASLSettings{}.AccessToken()
+	assert.Error(t, err)
+}
+
+func TestValueFromFileOrEnv(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "client_username"), []byte(" admin\n"), 0600))
+	t.Setenv("KAFKA_USER", "user")
+
+	assert.Equal(t, "admin", ValueFromFileOrEnv(filepath.Join(dir, "client_username"), "KAFKA_USER"))
+	assert.Equal(t, "user", ValueFromFileOrEnv(filepath.Join(dir, "missing"), "KAFKA_USER"))
+	assert.Equal(t, "user", ValueFromFileOrEnv("", "KAFKA_USER"))
+}
+
+func TestCredentialsFromSecretData(t *testing.T) {
+	username, password := CredentialsFromSecretData(
+		map[string][]byte{"client-username": []byte("client"), "client-password": []byte("secret")},
+		"client-username", "client-password")
+	assert.Equal(t, "client", username)
+	assert.Equal(t, "secret", password)
+}
+
+func TestSettingsValidate(t *testing.T) {
+	assert.Error(t, Settings{}.Validate())
+	assert.NoError(t, Settings{Brokers: []string{"kafka:9092"}}.Validate())
+	assert.Error(t, Settings{
+		Brokers: []string{"kafka:9092"},
+		SASL:    SASLSettings{Mechanism: "GSSAPI", Username: "admin", Password: "admin"},
+	}.Validate())
+	assert.Error(t, Settings{
+		Brokers: []string{"kafka:9092"},
+		TLS:     TLSSettings{Enabled: true, CACert: []byte("not a certificate")},
+	}.Validate())
+}
@@ -0,0 +1,61 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaconn
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+)
+
+// TLSSettings contains PEM-encoded certificates. System CA certificates are used if CA certificate is empty,
+// client certificate is presented to brokers for mTLS only if both certificate and key are specified.
+type TLSSettings struct {
+	Enabled bool
+	CACert  []byte
+	Cert    []byte
+	Key     []byte
+}
+
+// MutualTLS returns true if client certificate is specified
+func (s TLSSettings) MutualTLS() bool {
+	return len(s.Cert) != 0 && len(s.Key) != 0
+}
+
+// Config returns TLS configuration or nil if TLS is disabled
+func (s TLSSettings) Config() (*tls.Config, error) {
+	if !s.Enabled {
+		return nil, nil
+	}
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if len(s.CACert) != 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(s.CACert) {
+			return nil, fmt.Errorf("failed to parse CA certificate")
+		}
+		config.RootCAs = pool
+	}
+	if len(s.Cert) != 0 != (len(s.Key) != 0) {
+		return nil, fmt.Errorf("both client certificate and key must be specified for mTLS")
+	}
+	if s.MutualTLS() {
+		cert, err := tls.X509KeyPair(s.Cert, s.Key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client key pair: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return config, nil
+}
@@ -0,0 +1,108 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaconn
+
+import (
+	"crypto/tls"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Netcracker/qubership-kafka/kafkaconn/kafkaconntest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTLSConfigWhenDisabled(t *testing.T) {
+	config, err := TLSSettings{CACert: []byte("ignored")}.Config()
+	assert.NoError(t, err)
+	assert.Nil(t, config)
+}
+
+func TestTLSConfigWithoutCertificates(t *testing.T) {
+	config, err := TLSSettings{Enabled: true}.Config()
+	require.NoError(t, err)
+	assert.Nil(t, config.RootCAs)
+	assert.Empty(t, config.Certificates)
+}
+
+func TestTLSConfigRequiresCertAndKey(t *testing.T) {
+	certificates := kafkaconntest.NewCertificates(t)
+	_, err := TLSSettings{Enabled: true, Cert: certificates.ClientCert}.Config()
+	assert.Error(t, err)
+	_, err = TLSSettings{Enabled: true, Cert: certificates.ClientCert, Key: certificates.ServerKey}.Config()
+	assert.Error(t, err)
+}
+
+func TestTLSConfigConnectsToServer(t *testing.T) {
+	certificates := kafkaconntest.NewCertificates(t)
+	server := kafkaconntest.NewTLSServer(t, certificates.ServerConfig(t, false))
+
+	config, err := TLSSettings{Enabled: true, CACert: certificates.CACert}.Config()
+	require.NoError(t, err)
+	conn, err := tls.Dial("tcp", server.Addr(), config)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	state := server.WaitHandshake(t, 5*time.Second)
+	assert.Empty(t, state.PeerCertificates)
+}
+
+func TestTLSConfigConnectsToServerWithMutualTLS(t *testing.T) {
+	certificates := kafkaconntest.NewCertificates(t)
+	server := kafkaconntest.NewTLSServer(t, certificates.ServerConfig(t, true))
+
+	config, err := TLSFromSecretData(map[string][]byte{
+		SecretCACertKey: certificates.CACert,
+		SecretCertKey:   certificates.ClientCert,
+		SecretKeyKey:    certificates.ClientKey,
+	}).Config()
+	require.NoError(t, err)
+	conn, err := tls.Dial("tcp", server.Addr(), config)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	state := server.WaitHandshake(t, 5*time.Second)
+	require.Len(t, state.PeerCertificates, 1)
+	assert.Equal(t, "kafka-client", state.PeerCertificates[0].Subject.CommonName)
+}
+
+func TestTLSConfigRejectsUnknownServer(t *testing.T) {
+	certificates := kafkaconntest.NewCertificates(t)
+	server := kafkaconntest.NewTLSServer(t, certificates.ServerConfig(t, false))
+
+	config, err := TLSSettings{Enabled: true, CACert: kafkaconntest.NewCertificates(t).CACert}.Config()
+	require.NoError(t, err)
+	_, err = tls.Dial("tcp", server.Addr(), config)
+	assert.Error(t, err)
+}
+
+func TestLoadTLSFiles(t *testing.T) {
+	certificates := kafkaconntest.NewCertificates(t)
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "ca.crt"), certificates.CACert, 0600))
+
+	settings, err := LoadTLSFiles(filepath.Join(dir, "ca.crt"), filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key"))
+	require.NoError(t, err)
+	assert.True(t, settings.Enabled)
+	assert.Equal(t, certificates.CACert, settings.CACert)
+	assert.False(t, settings.MutualTLS())
+
+	config, err := settings.Config()
+	require.NoError(t, err)
+	assert.NotNil(t, config.RootCAs)
+	assert.Empty(t, config.Certificates)
+}
@@ -0,0 +1,161 @@
+//Copyright 2024-2025 NetCracker Technology Corporation
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	kafka "github.com/segmentio/kafka-go"
+)
+
+// CollectorConfig defines how often cluster is described and which optional metrics are collected
+type CollectorConfig struct {
+	// CacheTTL is the duration during which cluster snapshot is reused
+	CacheTTL time.Duration
+	// GroupFilter selects consumer groups for lag metrics, lag is not collected if it is nil
+	GroupFilter *GroupFilter
+	// StorageCapacity is the size of Kafka storage in bytes, usage percent of log dirs is not reported if it is 0
+	StorageCapacity int64
+	// LargestPartitionsCount is the number of the largest partitions which size is reported
+	LargestPartitionsCount int
+	// TopicPolicyFile is the path to YAML file with topic policy rules, topics are not audited if it is empty
+	TopicPolicyFile string
+	// OffsetHistoryFile is the path to file which keeps end offsets observed for time lag between runs,
+	// history is kept in memory only if it is empty
+	OffsetHistoryFile string
+}
+
+// Collector requests metadata and configs of Kafka cluster with one client
+// and caches them to not load brokers with requests on each scrape
+type Collector struct {
+	client  *kafka.Client
+	config  CollectorConfig
+	history *OffsetHistory
+
+	mutex       sync.Mutex
+	snapshot    *ClusterSnapshot
+	collectedAt time.Time
+}
+
+func NewCollector(client *kafka.Client, config CollectorConfig) *Collector {
+	history := NewOffsetHistory()
+	if config.GroupFilter != nil && config.OffsetHistoryFile != "" {
+		var err error
+		if history, err = LoadOffsetHistory(config.OffsetHistoryFile); err != nil {
+			log.Printf("Offset history is reset: %s", err)
+		}
+	}
+	return &Collector{client: client, config: config, history: history}
+}
+
+// Collect returns metrics calculated from cached cluster snapshot, snapshot is refreshed when it is expired
+func (c *Collector) Collect(ctx context.Context) ([]Metric, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	snapshot, err := c.getSnapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	metrics := CollectMetrics(*snapshot)
+	metrics = append(metrics, CalcLogDirMetrics(snapshot.LogDirs, c.config.StorageCapacity, c.config.LargestPartitionsCount)...)
+	if c.config.GroupFilter != nil {
+		metrics = append(metrics, CalcConsumerLagMetrics(*snapshot, c.history)...)
+	}
+	if c.config.TopicPolicyFile != "" {
+		// policy is read on each collection to apply changes of mounted config map without restart
+		policy, err := LoadTopicPolicy(c.config.TopicPolicyFile)
+		if err != nil {
+			log.Printf("Topic policy is not evaluated: %s", err)
+		} else {
+			violations := policy.Evaluate(snapshot.Metadata, snapshot.TopicConfigs)
+			metrics = append(metrics, CalcTopicPolicyMetrics(policy, violations)...)
+		}
+	}
+	return metrics, nil
+}
+
+func (c *Collector) getSnapshot(ctx context.Context) (*ClusterSnapshot, error) {
+	if c.snapshot != nil && time.Since(c.collectedAt) < c.config.CacheTTL {
+		return c.snapshot, nil
+	}
+	snapshot, err := c.loadSnapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.snapshot = snapshot
+	c.collectedAt = snapshot.CollectedAt
+	if c.config.GroupFilter != nil {
+		c.history.Add(snapshot.EndOffsets, snapshot.CollectedAt)
+		if c.config.OffsetHistoryFile != "" {
+			if err = c.history.Save(c.config.OffsetHistoryFile); err != nil {
+				log.Printf("Offset history is not saved: %s", err)
+			}
+		}
+	}
+	return snapshot, nil
+}
+
+func (c *Collector) loadSnapshot(ctx context.Context) (*ClusterSnapshot, error) {
+	metadata, err := c.client.Metadata(ctx, &kafka.MetadataRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get metadata: %w", err)
+	}
+
+	var topicResources []kafka.DescribeConfigRequestResource
+	for _, topic := range metadata.Topics {
+		topicResources = append(topicResources, kafka.DescribeConfigRequestResource{
+			ResourceType: kafka.ResourceTypeTopic,
+			ResourceName: topic.Name,
+		})
+	}
+	topicConfigs, err := c.client.DescribeConfigs(ctx, &kafka.DescribeConfigsRequest{Resources: topicResources})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe topic configs: %w", err)
+	}
+
+	var brokerResources []kafka.DescribeConfigRequestResource
+	for _, broker := range metadata.Brokers {
+		brokerResources = append(brokerResources, kafka.DescribeConfigRequestResource{
+			ResourceType: kafka.ResourceTypeBroker,
+			ResourceName: fmt.Sprintf("%d", broker.ID),
+		})
+	}
+	brokerConfigs, err := c.client.DescribeConfigs(ctx, &kafka.DescribeConfigsRequest{Resources: brokerResources})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe broker configs: %w", err)
+	}
+
+	snapshot := &ClusterSnapshot{
+		Metadata:      *metadata,
+		TopicConfigs:  *topicConfigs,
+		BrokerConfigs: *brokerConfigs,
+	}
+	if err = c.loadLogDirs(ctx, snapshot); err != nil {
+		// sizes of part of brokers would distort topic sizes, so log dir metrics are skipped altogether
+		log.Printf("Log dir metrics are skipped: %s", err)
+		snapshot.LogDirs = nil
+	}
+	if c.config.GroupFilter != nil {
+		if err = c.loadConsumerOffsets(ctx, snapshot); err != nil {
+			return nil, err
+		}
+	}
+	snapshot.CollectedAt = time.Now()
+	return snapshot, nil
+}
@@ -147,4 +147,9 @@ else
 fi
 enrich_kafkactl_yml_with_ssl_configs
 
+if [[ "${METRICS_MODE}" == "prometheus" ]]; then
+  # Additional metrics are served for Prometheus by long-living process instead of Telegraf exec plugin
+  /additional-metrics &
+fi
+
 /sbin/tini -- /entrypoint.sh telegraf
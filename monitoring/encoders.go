@@ -0,0 +1,115 @@
+//Copyright 2024-2025 NetCracker Technology Corporation
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	prometheusContentType  = "text/plain; version=0.0.4; charset=utf-8"
+	openMetricsContentType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+)
+
+var (
+	influxTagEscaper   = strings.NewReplacer(",", `\,`, "=", `\=`, " ", `\ `)
+	prometheusEscaper  = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+	prometheusReplacer = strings.NewReplacer(".", "_", "-", "_")
+)
+
+// EncodeInflux writes metrics in InfluxDB line protocol.
+// Metrics with the same measurement and labels are joined to one line in order of their first appearance.
+func EncodeInflux(w io.Writer, metrics []Metric) error {
+	var keys []string
+	fields := make(map[string][]string)
+	for _, metric := range metrics {
+		key := influxSeriesKey(metric)
+		if _, found := fields[key]; !found {
+			keys = append(keys, key)
+		}
+		value := fmt.Sprintf("%d", metric.Value)
+		if metric.Integer {
+			value += "i"
+		}
+		fields[key] = append(fields[key], fmt.Sprintf("%s=%s", metric.Field, value))
+	}
+	for _, key := range keys {
+		if _, err := fmt.Fprintf(w, "%s %s\n", key, strings.Join(fields[key], ",")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func influxSeriesKey(metric Metric) string {
+	key := metric.Measurement
+	for _, label := range metric.Labels {
+		key += fmt.Sprintf(",%s=%s", label.Name, influxTagEscaper.Replace(label.Value))
+	}
+	return key
+}
+
+// EncodePrometheus writes metrics in Prometheus text exposition format or in OpenMetrics format.
+// Each metric is a gauge named as <measurement>_<field>, so names are the same as Telegraf gives
+// to metrics of InfluxDB output.
+func EncodePrometheus(w io.Writer, metrics []Metric, openMetrics bool) error {
+	var names []string
+	samples := make(map[string][]Metric)
+	for _, metric := range metrics {
+		name := PrometheusName(metric)
+		if _, found := samples[name]; !found {
+			names = append(names, name)
+		}
+		samples[name] = append(samples[name], metric)
+	}
+	for _, name := range names {
+		if help := samples[name][0].Help; help != "" {
+			if _, err := fmt.Fprintf(w, "# HELP %s %s\n", name, help); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintf(w, "# TYPE %s gauge\n", name); err != nil {
+			return err
+		}
+		for _, metric := range samples[name] {
+			if _, err := fmt.Fprintf(w, "%s%s %d\n", name, prometheusLabels(metric.Labels), metric.Value); err != nil {
+				return err
+			}
+		}
+	}
+	if openMetrics {
+		_, err := fmt.Fprint(w, "# EOF\n")
+		return err
+	}
+	return nil
+}
+
+// PrometheusName returns name of metric in Prometheus format
+func PrometheusName(metric Metric) string {
+	return prometheusReplacer.Replace(fmt.Sprintf("%s_%s", metric.Measurement, metric.Field))
+}
+
+func prometheusLabels(labels []Label) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	pairs := make([]string, 0, len(labels))
+	for _, label := range labels {
+		pairs = append(pairs, fmt.Sprintf(`%s="%s"`, label.Name, prometheusEscaper.Replace(label.Value)))
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
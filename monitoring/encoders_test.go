@@ -0,0 +1,92 @@
+//Copyright 2024-2025 NetCracker Technology Corporation
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	kafka "github.com/segmentio/kafka-go"
+)
+
+func newTestSnapshot() ClusterSnapshot {
+	broker1 := kafka.Broker{ID: 1}
+	broker2 := kafka.Broker{ID: 2}
+	return ClusterSnapshot{
+		Metadata: kafka.MetadataResponse{
+			Brokers: []kafka.Broker{broker2, broker1},
+			Topics: []kafka.Topic{
+				{Name: "orders", Partitions: []kafka.Partition{
+					{ID: 0, Leader: broker1, Replicas: []kafka.Broker{broker1, broker2}, Isr: []kafka.Broker{broker1, broker2}},
+					{ID: 1, Leader: broker1, Replicas: []kafka.Broker{broker1}, Isr: []kafka.Broker{broker1}},
+				}},
+				{Name: "events", Partitions: []kafka.Partition{
+					{ID: 0, Leader: kafka.Broker{}, Replicas: []kafka.Broker{broker2}},
+				}},
+			},
+		},
+		TopicConfigs: kafka.DescribeConfigsResponse{Resources: []kafka.DescribeConfigResponseResource{
+			{ResourceName: "orders", ConfigEntries: []kafka.DescribeConfigResponseConfigEntry{
+				{ConfigName: "unclean.leader.election.enable", ConfigValue: "true"},
+			}},
+		}},
+		BrokerConfigs: kafka.DescribeConfigsResponse{Resources: []kafka.DescribeConfigResponseResource{
+			{ResourceName: "1", ConfigEntries: []kafka.DescribeConfigResponseConfigEntry{
+				{ConfigName: "log.cleaner.threads", ConfigValue: "1"},
+				{ConfigName: "num.replica.fetchers", ConfigValue: "2"},
+			}},
+			{ResourceName: "2", ConfigEntries: []kafka.DescribeConfigResponseConfigEntry{
+				{ConfigName: "log.cleaner.threads", ConfigValue: "1"},
+				{ConfigName: "num.replica.fetchers", ConfigValue: "2"},
+			}},
+		}},
+	}
+}
+
+func TestEncodeInflux(t *testing.T) {
+	buffer := new(bytes.Buffer)
+	if err := EncodeInflux(buffer, CollectMetrics(newTestSnapshot())); err != nil {
+		t.Fatal(err)
+	}
+	expected := "kafka,broker=kafka-1 log_cleaner_threads_count=1i,replica_fetcher_threads_count=2i,broker_skew=0i,broker_leader_skew=33i,under_replicated_partitions=0i,under_min_isr_partitions=0i,preferred_leader_imbalance=0i\n" +
+		"kafka,broker=kafka-2 log_cleaner_threads_count=1i,replica_fetcher_threads_count=2i,broker_skew=0i,under_replicated_partitions=0i,under_min_isr_partitions=0i,preferred_leader_imbalance=0i\n" +
+		"kafka_cluster,topic=orders unclean_election_topics=1\n" +
+		"kafka_cluster,topic=events topics_without_leader=1,offline_partitions=1i\n"
+	if buffer.String() != expected {
+		t.Errorf("unexpected InfluxDB output:\n%s", buffer.String())
+	}
+}
+
+func TestEncodePrometheus(t *testing.T) {
+	metrics := []Metric{
+		{Measurement: brokerMeasurement, Field: "broker_skew", Labels: []Label{{Name: "broker", Value: "kafka-1"}}, Value: 5, Integer: true, Help: "Skew."},
+		{Measurement: brokerMeasurement, Field: "broker_skew", Labels: []Label{{Name: "broker", Value: "kafka-2"}}, Value: -5, Integer: true, Help: "Skew."},
+		{Measurement: clusterMeasurement, Field: "topics_without_leader", Labels: []Label{{Name: "topic", Value: `a"b`}}, Value: 1},
+	}
+	buffer := new(bytes.Buffer)
+	if err := EncodePrometheus(buffer, metrics, true); err != nil {
+		t.Fatal(err)
+	}
+	expected := "# HELP kafka_broker_skew Skew.\n" +
+		"# TYPE kafka_broker_skew gauge\n" +
+		"kafka_broker_skew{broker=\"kafka-1\"} 5\n" +
+		"kafka_broker_skew{broker=\"kafka-2\"} -5\n" +
+		"# TYPE kafka_cluster_topics_without_leader gauge\n" +
+		"kafka_cluster_topics_without_leader{topic=\"a\\\"b\"} 1\n" +
+		"# EOF\n"
+	if buffer.String() != expected {
+		t.Errorf("unexpected Prometheus output:\n%s", buffer.String())
+	}
+}
@@ -2,13 +2,19 @@ module mod
 
 go 1.26
 
-require github.com/segmentio/kafka-go v0.4.51
+require (
+	github.com/Netcracker/qubership-kafka/kafkaconn v0.0.0
+	github.com/segmentio/kafka-go v0.4.51
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require (
-	github.com/klauspost/compress v1.18.3 // indirect
-	github.com/pierrec/lz4/v4 v4.1.25 // indirect
+	github.com/klauspost/compress v1.18.6 // indirect
+	github.com/pierrec/lz4/v4 v4.1.27 // indirect
 	github.com/xdg-go/pbkdf2 v1.0.0 // indirect
 	github.com/xdg-go/scram v1.1.2 // indirect
 	github.com/xdg-go/stringprep v1.0.4 // indirect
-	golang.org/x/text v0.23.0 // indirect
+	golang.org/x/text v0.38.0 // indirect
 )
+
+replace github.com/Netcracker/qubership-kafka/kafkaconn => ../kafkaconn
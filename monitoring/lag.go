@@ -0,0 +1,320 @@
+//Copyright 2024-2025 NetCracker Technology Corporation
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	kafka "github.com/segmentio/kafka-go"
+)
+
+const (
+	consumerGroupMeasurement = "kafka_consumergroup"
+	maxOffsetSamples         = 100
+)
+
+// GroupFilter selects consumer groups which lag is collected for
+type GroupFilter struct {
+	include *regexp.Regexp
+	exclude *regexp.Regexp
+}
+
+// NewGroupFilter compiles include and exclude regular expressions, empty exclude expression excludes nothing
+func NewGroupFilter(include string, exclude string) (*GroupFilter, error) {
+	filter := &GroupFilter{}
+	var err error
+	if filter.include, err = regexp.Compile(include); err != nil {
+		return nil, fmt.Errorf("incorrect consumer groups include expression: %w", err)
+	}
+	if exclude != "" {
+		if filter.exclude, err = regexp.Compile(exclude); err != nil {
+			return nil, fmt.Errorf("incorrect consumer groups exclude expression: %w", err)
+		}
+	}
+	return filter, nil
+}
+
+func (f *GroupFilter) Matches(group string) bool {
+	return f.include.MatchString(group) && (f.exclude == nil || !f.exclude.MatchString(group))
+}
+
+type offsetSample struct {
+	offset int64
+	time   time.Time
+}
+
+// OffsetHistory keeps observed end offsets of partitions to estimate how long ago
+// the message at committed offset of consumer group was produced
+type OffsetHistory struct {
+	samples map[string]map[int][]offsetSample
+}
+
+func NewOffsetHistory() *OffsetHistory {
+	return &OffsetHistory{samples: make(map[string]map[int][]offsetSample)}
+}
+
+// Add stores end offsets which are changed since the previous observation
+// and forgets partitions which are not observed anymore
+func (h *OffsetHistory) Add(endOffsets map[string]map[int]int64, at time.Time) {
+	samples := make(map[string]map[int][]offsetSample)
+	for topic, partitions := range endOffsets {
+		samples[topic] = make(map[int][]offsetSample)
+		for partition, offset := range partitions {
+			partitionSamples := h.samples[topic][partition]
+			if len(partitionSamples) == 0 || partitionSamples[len(partitionSamples)-1].offset < offset {
+				partitionSamples = append(partitionSamples, offsetSample{offset: offset, time: at})
+			}
+			if len(partitionSamples) > maxOffsetSamples {
+				partitionSamples = partitionSamples[len(partitionSamples)-maxOffsetSamples:]
+			}
+			samples[topic][partition] = partitionSamples
+		}
+	}
+	h.samples = samples
+}
+
+// storedOffsetSample is the representation of offset sample in offset history file
+type storedOffsetSample struct {
+	Offset int64     `json:"offset"`
+	Time   time.Time `json:"time"`
+}
+
+// LoadOffsetHistory reads offset history saved by previous run, history is empty if file does not exist
+func LoadOffsetHistory(path string) (*OffsetHistory, error) {
+	history := NewOffsetHistory()
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return history, nil
+	}
+	if err != nil {
+		return history, fmt.Errorf("failed to read offset history: %w", err)
+	}
+	var stored map[string]map[int][]storedOffsetSample
+	if err = json.Unmarshal(data, &stored); err != nil {
+		return history, fmt.Errorf("failed to parse offset history: %w", err)
+	}
+	for topic, partitions := range stored {
+		history.samples[topic] = make(map[int][]offsetSample)
+		for partition, samples := range partitions {
+			for _, sample := range samples {
+				history.samples[topic][partition] = append(history.samples[topic][partition],
+					offsetSample{offset: sample.Offset, time: sample.Time})
+			}
+		}
+	}
+	return history, nil
+}
+
+// Save writes offset history to file for the next run,
+// file is replaced atomically to not leave partially written history if process is killed
+func (h *OffsetHistory) Save(path string) error {
+	stored := make(map[string]map[int][]storedOffsetSample)
+	for topic, partitions := range h.samples {
+		stored[topic] = make(map[int][]storedOffsetSample)
+		for partition, samples := range partitions {
+			for _, sample := range samples {
+				stored[topic][partition] = append(stored[topic][partition],
+					storedOffsetSample{Offset: sample.offset, Time: sample.time})
+			}
+		}
+	}
+	data, err := json.Marshal(stored)
+	if err != nil {
+		return fmt.Errorf("failed to serialize offset history: %w", err)
+	}
+	file, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to save offset history: %w", err)
+	}
+	defer os.Remove(file.Name())
+	if _, err = file.Write(data); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to save offset history: %w", err)
+	}
+	if err = file.Close(); err != nil {
+		return fmt.Errorf("failed to save offset history: %w", err)
+	}
+	if err = os.Rename(file.Name(), path); err != nil {
+		return fmt.Errorf("failed to save offset history: %w", err)
+	}
+	return nil
+}
+
+// EstimateTimeLag returns time passed since end offset of partition was equal to committed offset.
+// Time is interpolated between observed end offsets or extrapolated by average produce rate
+// if committed offset is older than all observations, so at least two observations are required in that case.
+func (h *OffsetHistory) EstimateTimeLag(topic string, partition int, committed int64, now time.Time) (time.Duration, bool) {
+	samples := h.samples[topic][partition]
+	if len(samples) == 0 {
+		return 0, false
+	}
+	if committed >= samples[len(samples)-1].offset {
+		return 0, true
+	}
+	next := sort.Search(len(samples), func(i int) bool { return samples[i].offset > committed })
+	var producedAt time.Time
+	if next > 0 {
+		previous := samples[next-1]
+		ratio := float64(committed-previous.offset) / float64(samples[next].offset-previous.offset)
+		producedAt = previous.time.Add(time.Duration(ratio * float64(samples[next].time.Sub(previous.time))))
+	} else {
+		if len(samples) < 2 {
+			return 0, false
+		}
+		first, last := samples[0], samples[len(samples)-1]
+		rate := float64(last.offset-first.offset) / float64(last.time.Sub(first.time))
+		producedAt = first.time.Add(-time.Duration(float64(first.offset-committed) / rate))
+	}
+	if lag := now.Sub(producedAt); lag > 0 {
+		return lag, true
+	}
+	return 0, true
+}
+
+// loadConsumerOffsets reads committed offsets of matched consumer groups and end offsets of their partitions
+func (c *Collector) loadConsumerOffsets(ctx context.Context, snapshot *ClusterSnapshot) error {
+	groups, err := c.client.ListGroups(ctx, &kafka.ListGroupsRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to list consumer groups: %w", err)
+	}
+	snapshot.ConsumerGroups = make(map[string]map[string]map[int]int64)
+	requested := make(map[string]map[int]bool)
+	for _, group := range groups.Groups {
+		if !c.config.GroupFilter.Matches(group.GroupID) {
+			continue
+		}
+		offsets, err := c.client.OffsetFetch(ctx, &kafka.OffsetFetchRequest{GroupID: group.GroupID})
+		if err != nil {
+			return fmt.Errorf("failed to fetch offsets of consumer group %s: %w", group.GroupID, err)
+		}
+		if offsets.Error != nil {
+			log.Printf("Offsets of consumer group %s are skipped: %s", group.GroupID, offsets.Error)
+			continue
+		}
+		groupOffsets := make(map[string]map[int]int64)
+		for topic, partitions := range offsets.Topics {
+			for _, partition := range partitions {
+				if partition.Error != nil || partition.CommittedOffset < 0 {
+					continue
+				}
+				if groupOffsets[topic] == nil {
+					groupOffsets[topic] = make(map[int]int64)
+				}
+				groupOffsets[topic][partition.Partition] = partition.CommittedOffset
+				if requested[topic] == nil {
+					requested[topic] = make(map[int]bool)
+				}
+				requested[topic][partition.Partition] = true
+			}
+		}
+		snapshot.ConsumerGroups[group.GroupID] = groupOffsets
+	}
+
+	request := &kafka.ListOffsetsRequest{Topics: make(map[string][]kafka.OffsetRequest)}
+	for topic, partitions := range requested {
+		for partition := range partitions {
+			request.Topics[topic] = append(request.Topics[topic], kafka.LastOffsetOf(partition))
+		}
+	}
+	snapshot.EndOffsets = make(map[string]map[int]int64)
+	if len(request.Topics) == 0 {
+		return nil
+	}
+	endOffsets, err := c.client.ListOffsets(ctx, request)
+	if err != nil {
+		return fmt.Errorf("failed to list end offsets: %w", err)
+	}
+	for topic, partitions := range endOffsets.Topics {
+		snapshot.EndOffsets[topic] = make(map[int]int64)
+		for _, partition := range partitions {
+			if partition.Error == nil {
+				snapshot.EndOffsets[topic][partition.Partition] = partition.LastOffset
+			}
+		}
+	}
+	return nil
+}
+
+// CalcConsumerLagMetrics returns lag of consumer groups per partition, per topic and per group.
+// Time lag is reported only for partitions which it can be estimated for by offset history.
+func CalcConsumerLagMetrics(snapshot ClusterSnapshot, history *OffsetHistory) []Metric {
+	metrics := make([]Metric, 0)
+	for _, group := range sortedKeys(snapshot.ConsumerGroups) {
+		groupLabels := []Label{{Name: "consumergroup", Value: group}}
+		var groupLag int64
+		var groupTimeLag time.Duration
+		groupTimeLagFound := false
+		for _, topic := range sortedKeys(snapshot.ConsumerGroups[group]) {
+			topicLabels := []Label{groupLabels[0], {Name: "topic", Value: topic}}
+			var topicLag int64
+			topicLagFound := false
+			committedOffsets := snapshot.ConsumerGroups[group][topic]
+			for _, partition := range sortedKeys(committedOffsets) {
+				endOffset, found := snapshot.EndOffsets[topic][partition]
+				if !found {
+					continue
+				}
+				committed := committedOffsets[partition]
+				lag := max(endOffset-committed, 0)
+				partitionLabels := []Label{groupLabels[0], topicLabels[1], {Name: "partition", Value: strconv.Itoa(partition)}}
+				metrics = append(metrics, lagMetric("lag", partitionLabels, lag,
+					"Number of messages which consumer group has not committed in partition."))
+				if timeLag, found := history.EstimateTimeLag(topic, partition, committed, snapshot.CollectedAt); found {
+					metrics = append(metrics, lagMetric("lag_seconds", partitionLabels, int64(timeLag.Seconds()),
+						"Estimated time since the first message which consumer group has not committed in partition was produced."))
+					groupTimeLag = max(groupTimeLag, timeLag)
+					groupTimeLagFound = true
+				}
+				topicLag += lag
+				topicLagFound = true
+			}
+			if topicLagFound {
+				metrics = append(metrics, lagMetric("lag_sum", topicLabels, topicLag,
+					"Number of messages which consumer group has not committed in topic."))
+				groupLag += topicLag
+			}
+		}
+		metrics = append(metrics, lagMetric("group_lag", groupLabels, groupLag,
+			"Number of messages which consumer group has not committed in all topics."))
+		if groupTimeLagFound {
+			metrics = append(metrics, lagMetric("group_lag_seconds", groupLabels, int64(groupTimeLag.Seconds()),
+				"Maximum estimated time lag of consumer group among its partitions."))
+		}
+	}
+	return metrics
+}
+
+func lagMetric(field string, labels []Label, value int64, help string) Metric {
+	return Metric{Measurement: consumerGroupMeasurement, Field: field, Labels: labels, Value: value, Integer: true, Help: help}
+}
+
+func sortedKeys[K int | string, V any](values map[K]V) []K {
+	keys := make([]K, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
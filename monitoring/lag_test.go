@@ -0,0 +1,139 @@
+//Copyright 2024-2025 NetCracker Technology Corporation
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package main
+
+import (
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestGroupFilter(t *testing.T) {
+	filter, err := NewGroupFilter("^orders-.*", "-test$")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := map[string]bool{
+		"orders-processor": true,
+		"orders-test":      false,
+		"payments":         false,
+	}
+	for group, expected := range tests {
+		if filter.Matches(group) != expected {
+			t.Errorf("group %s is matched: %t, expected %t", group, !expected, expected)
+		}
+	}
+	if _, err = NewGroupFilter("(", ""); err == nil {
+		t.Error("incorrect include expression is accepted")
+	}
+}
+
+func TestEstimateTimeLag(t *testing.T) {
+	start := time.Unix(1000, 0)
+	history := NewOffsetHistory()
+	history.Add(map[string]map[int]int64{"orders": {0: 100}}, start)
+	history.Add(map[string]map[int]int64{"orders": {0: 100}}, start.Add(10*time.Second))
+	history.Add(map[string]map[int]int64{"orders": {0: 200}}, start.Add(20*time.Second))
+	history.Add(map[string]map[int]int64{"orders": {0: 300}}, start.Add(30*time.Second))
+	now := start.Add(30 * time.Second)
+
+	tests := []struct {
+		name      string
+		partition int
+		committed int64
+		lag       time.Duration
+		found     bool
+	}{
+		{name: "consumed", partition: 0, committed: 300, lag: 0, found: true},
+		{name: "interpolated", partition: 0, committed: 250, lag: 5 * time.Second, found: true},
+		{name: "observed offset", partition: 0, committed: 200, lag: 10 * time.Second, found: true},
+		{name: "extrapolated", partition: 0, committed: 50, lag: 37500 * time.Millisecond, found: true},
+		{name: "unknown partition", partition: 1, committed: 0, found: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			lag, found := history.EstimateTimeLag("orders", test.partition, test.committed, now)
+			if found != test.found || lag != test.lag {
+				t.Errorf("unexpected time lag %s (%t), expected %s (%t)", lag, found, test.lag, test.found)
+			}
+		})
+	}
+
+	history.Add(map[string]map[int]int64{"payments": {0: 10}}, now)
+	if _, found := history.EstimateTimeLag("payments", 0, 5, now); found {
+		t.Error("time lag is estimated by one observation")
+	}
+	if _, found := history.EstimateTimeLag("orders", 0, 250, now); found {
+		t.Error("history of not observed partition is kept")
+	}
+}
+
+func TestOffsetHistoryIsKeptBetweenRuns(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "offset-history.json")
+	start := time.Unix(1000, 0)
+	history, err := LoadOffsetHistory(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	history.Add(map[string]map[int]int64{"orders": {0: 100}}, start)
+	if err = history.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	history, err = LoadOffsetHistory(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	history.Add(map[string]map[int]int64{"orders": {0: 200}}, start.Add(10*time.Second))
+	lag, found := history.EstimateTimeLag("orders", 0, 150, start.Add(20*time.Second))
+	if !found || lag != 15*time.Second {
+		t.Errorf("unexpected time lag %s (found: %t), expected 15s", lag, found)
+	}
+}
+
+func TestCalcConsumerLagMetrics(t *testing.T) {
+	now := time.Unix(1000, 0)
+	snapshot := ClusterSnapshot{
+		ConsumerGroups: map[string]map[string]map[int]int64{
+			"processor": {"orders": {0: 90, 1: 50}},
+			"idle":      {"removed": {0: 10}},
+		},
+		EndOffsets:  map[string]map[int]int64{"orders": {0: 100, 1: 50}},
+		CollectedAt: now,
+	}
+	history := NewOffsetHistory()
+	history.Add(snapshot.EndOffsets, now)
+
+	expected := []string{
+		"kafka_consumergroup_group_lag{consumergroup=\"idle\"} 0",
+		"kafka_consumergroup_lag{consumergroup=\"processor\",topic=\"orders\",partition=\"0\"} 10",
+		"kafka_consumergroup_lag{consumergroup=\"processor\",topic=\"orders\",partition=\"1\"} 0",
+		"kafka_consumergroup_lag_seconds{consumergroup=\"processor\",topic=\"orders\",partition=\"1\"} 0",
+		"kafka_consumergroup_lag_sum{consumergroup=\"processor\",topic=\"orders\"} 10",
+		"kafka_consumergroup_group_lag{consumergroup=\"processor\"} 10",
+		"kafka_consumergroup_group_lag_seconds{consumergroup=\"processor\"} 0",
+	}
+	metrics := CalcConsumerLagMetrics(snapshot, history)
+	if len(metrics) != len(expected) {
+		t.Fatalf("unexpected number of metrics %d, expected %d", len(metrics), len(expected))
+	}
+	for i, metric := range metrics {
+		actual := PrometheusName(metric) + prometheusLabels(metric.Labels) + " " + strconv.FormatInt(metric.Value, 10)
+		if actual != expected[i] {
+			t.Errorf("unexpected metric %s, expected %s", actual, expected[i])
+		}
+	}
+}
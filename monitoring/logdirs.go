@@ -0,0 +1,226 @@
+//Copyright 2024-2025 NetCracker Technology Corporation
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/segmentio/kafka-go/protocol"
+)
+
+const logDirMeasurement = "kafka_logdir"
+
+// kafka-go does not provide DescribeLogDirs API, so its messages are registered here.
+// Detailed API definition: https://kafka.apache.org/protocol#The_Messages_DescribeLogDirs
+func init() {
+	protocol.Register(&describeLogDirsRequest{}, &describeLogDirsResponse{})
+}
+
+type describeLogDirsRequest struct {
+	// Topics is null to describe all topics
+	Topics []describeLogDirsRequestTopic `kafka:"min=v0,max=v1,nullable"`
+
+	brokerId int32
+}
+
+type describeLogDirsRequestTopic struct {
+	Topic      string  `kafka:"min=v0,max=v1"`
+	Partitions []int32 `kafka:"min=v0,max=v1"`
+}
+
+func (r *describeLogDirsRequest) ApiKey() protocol.ApiKey { return protocol.DescribeLogDirs }
+
+// Broker returns the broker which log dirs are described, because each broker reports only its own log dirs
+func (r *describeLogDirsRequest) Broker(cluster protocol.Cluster) (protocol.Broker, error) {
+	broker, found := cluster.Brokers[r.brokerId]
+	if !found {
+		return protocol.Broker{}, fmt.Errorf("broker %d is not found in cluster metadata", r.brokerId)
+	}
+	return broker, nil
+}
+
+type describeLogDirsResponse struct {
+	ThrottleTimeMs int32                           `kafka:"min=v0,max=v1"`
+	Results        []describeLogDirsResponseResult `kafka:"min=v0,max=v1"`
+}
+
+type describeLogDirsResponseResult struct {
+	ErrorCode int16                          `kafka:"min=v0,max=v1"`
+	LogDir    string                         `kafka:"min=v0,max=v1"`
+	Topics    []describeLogDirsResponseTopic `kafka:"min=v0,max=v1"`
+}
+
+type describeLogDirsResponseTopic struct {
+	Name       string                             `kafka:"min=v0,max=v1"`
+	Partitions []describeLogDirsResponsePartition `kafka:"min=v0,max=v1"`
+}
+
+type describeLogDirsResponsePartition struct {
+	PartitionIndex int32 `kafka:"min=v0,max=v1"`
+	PartitionSize  int64 `kafka:"min=v0,max=v1"`
+	OffsetLag      int64 `kafka:"min=v0,max=v1"`
+	IsFutureKey    bool  `kafka:"min=v0,max=v1"`
+}
+
+func (r *describeLogDirsResponse) ApiKey() protocol.ApiKey { return protocol.DescribeLogDirs }
+
+// LogDir contains size of partition replicas which are stored in log directory of broker
+type LogDir struct {
+	Path       string
+	ErrorCode  int16
+	Partitions []LogDirPartition
+}
+
+type LogDirPartition struct {
+	Topic     string
+	Partition int
+	Size      int64
+}
+
+// loadLogDirs describes log directories of each broker, replicas which are being moved
+// between log directories of the same broker are not counted twice
+func (c *Collector) loadLogDirs(ctx context.Context, snapshot *ClusterSnapshot) error {
+	snapshot.LogDirs = make(map[int][]LogDir)
+	for _, broker := range snapshot.Metadata.Brokers {
+		response, err := c.client.Transport.RoundTrip(ctx, c.client.Addr, &describeLogDirsRequest{brokerId: int32(broker.ID)})
+		if err != nil {
+			return fmt.Errorf("failed to describe log dirs of broker %d: %w", broker.ID, err)
+		}
+		var logDirs []LogDir
+		for _, result := range response.(*describeLogDirsResponse).Results {
+			logDir := LogDir{Path: result.LogDir, ErrorCode: result.ErrorCode}
+			for _, topic := range result.Topics {
+				for _, partition := range topic.Partitions {
+					if partition.IsFutureKey {
+						continue
+					}
+					logDir.Partitions = append(logDir.Partitions, LogDirPartition{
+						Topic:     topic.Name,
+						Partition: int(partition.PartitionIndex),
+						Size:      partition.PartitionSize,
+					})
+				}
+			}
+			logDirs = append(logDirs, logDir)
+		}
+		snapshot.LogDirs[broker.ID] = logDirs
+	}
+	return nil
+}
+
+// CalcLogDirMetrics returns size and error code of each log directory, size of each topic summed over replicas
+// and size of the largest partitions. Usage percent of log directory is reported if its capacity is known.
+func CalcLogDirMetrics(logDirs map[int][]LogDir, capacity int64, largestPartitionsCount int) []Metric {
+	metrics := make([]Metric, 0)
+	topicSizes := make(map[string]int64)
+	partitionSizes := make(map[LogDirPartition]int64)
+	for _, brokerId := range sortedKeys(logDirs) {
+		for _, logDir := range logDirs[brokerId] {
+			labels := []Label{
+				{Name: "broker", Value: fmt.Sprintf("%s-%d", serviceName, brokerId)},
+				{Name: "log_dir", Value: logDir.Path},
+			}
+			var size int64
+			for _, partition := range logDir.Partitions {
+				size += partition.Size
+				topicSizes[partition.Topic] += partition.Size
+				key := LogDirPartition{Topic: partition.Topic, Partition: partition.Partition}
+				partitionSizes[key] = max(partitionSizes[key], partition.Size)
+			}
+			metrics = append(metrics,
+				logDirMetric("size_bytes", labels, size, "Size of partition replicas in log directory in bytes."),
+				logDirMetric("error_code", labels, int64(logDir.ErrorCode),
+					"Error code of log directory, 0 means that log directory is available."))
+			if capacity > 0 {
+				metrics = append(metrics, logDirMetric("capacity_used_percent", labels, size*100/capacity,
+					"Size of partition replicas in log directory in percent of Kafka storage size."))
+			}
+		}
+	}
+
+	for _, topic := range sortedKeys(topicSizes) {
+		metrics = append(metrics, Metric{
+			Measurement: clusterMeasurement,
+			Field:       "topic_size_bytes",
+			Labels:      []Label{{Name: "topic", Value: topic}},
+			Value:       topicSizes[topic],
+			Integer:     true,
+			Help:        "Size of topic summed over all replicas in bytes.",
+		})
+	}
+
+	partitions := make([]LogDirPartition, 0, len(partitionSizes))
+	for partition, size := range partitionSizes {
+		partition.Size = size
+		partitions = append(partitions, partition)
+	}
+	sort.Slice(partitions, func(i, j int) bool {
+		if partitions[i].Size != partitions[j].Size {
+			return partitions[i].Size > partitions[j].Size
+		}
+		if partitions[i].Topic != partitions[j].Topic {
+			return partitions[i].Topic < partitions[j].Topic
+		}
+		return partitions[i].Partition < partitions[j].Partition
+	})
+	for _, partition := range partitions[:min(largestPartitionsCount, len(partitions))] {
+		metrics = append(metrics, Metric{
+			Measurement: clusterMeasurement,
+			Field:       "partition_size_bytes",
+			Labels: []Label{
+				{Name: "topic", Value: partition.Topic},
+				{Name: "partition", Value: strconv.Itoa(partition.Partition)},
+			},
+			Value:   partition.Size,
+			Integer: true,
+			Help:    "Size of the largest replica of partition in bytes, reported for the largest partitions only.",
+		})
+	}
+	return metrics
+}
+
+func logDirMetric(field string, labels []Label, value int64, help string) Metric {
+	return Metric{Measurement: logDirMeasurement, Field: field, Labels: labels, Value: value, Integer: true, Help: help}
+}
+
+var quantitySuffixes = map[string]int64{
+	"Ki": 1 << 10, "Mi": 1 << 20, "Gi": 1 << 30, "Ti": 1 << 40, "Pi": 1 << 50, "Ei": 1 << 60,
+	"k": 1e3, "M": 1e6, "G": 1e9, "T": 1e12, "P": 1e15, "E": 1e18,
+}
+
+// ParseStorageSize converts Kubernetes quantity, like storage size of Kafka custom resource, to bytes.
+// Empty size is converted to 0 which means that storage size is unknown.
+func ParseStorageSize(size string) (int64, error) {
+	size = strings.TrimSpace(size)
+	if size == "" {
+		return 0, nil
+	}
+	number, multiplier := size, int64(1)
+	for suffix, value := range quantitySuffixes {
+		if strings.HasSuffix(size, suffix) {
+			number, multiplier = strings.TrimSuffix(size, suffix), value
+			break
+		}
+	}
+	value, err := strconv.ParseFloat(number, 64)
+	if err != nil || value < 0 {
+		return 0, fmt.Errorf("incorrect storage size %q", size)
+	}
+	return int64(value * float64(multiplier)), nil
+}
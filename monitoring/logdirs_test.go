@@ -0,0 +1,108 @@
+//Copyright 2024-2025 NetCracker Technology Corporation
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/segmentio/kafka-go/protocol"
+)
+
+func TestDescribeLogDirsResponseEncoding(t *testing.T) {
+	response := &describeLogDirsResponse{Results: []describeLogDirsResponseResult{{
+		LogDir: "/var/opt/kafka/data",
+		Topics: []describeLogDirsResponseTopic{{
+			Name:       "orders",
+			Partitions: []describeLogDirsResponsePartition{{PartitionIndex: 1, PartitionSize: 1024}},
+		}},
+	}}}
+	buffer := new(bytes.Buffer)
+	if err := protocol.WriteResponse(buffer, 1, 7, response); err != nil {
+		t.Fatal(err)
+	}
+	_, decoded, err := protocol.ReadResponse(buffer, protocol.DescribeLogDirs, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(response, decoded) {
+		t.Errorf("unexpected decoded response %+v", decoded)
+	}
+}
+
+func TestCalcLogDirMetrics(t *testing.T) {
+	logDirs := map[int][]LogDir{
+		1: {{Path: "/data", Partitions: []LogDirPartition{
+			{Topic: "orders", Partition: 0, Size: 300},
+			{Topic: "orders", Partition: 1, Size: 100},
+		}}},
+		2: {{Path: "/data", Partitions: []LogDirPartition{
+			{Topic: "orders", Partition: 0, Size: 250},
+			{Topic: "events", Partition: 0, Size: 200},
+		}}, {Path: "/broken", ErrorCode: 57}},
+	}
+	expected := []string{
+		"kafka_logdir_size_bytes{broker=\"kafka-1\",log_dir=\"/data\"} 400",
+		"kafka_logdir_error_code{broker=\"kafka-1\",log_dir=\"/data\"} 0",
+		"kafka_logdir_capacity_used_percent{broker=\"kafka-1\",log_dir=\"/data\"} 40",
+		"kafka_logdir_size_bytes{broker=\"kafka-2\",log_dir=\"/data\"} 450",
+		"kafka_logdir_error_code{broker=\"kafka-2\",log_dir=\"/data\"} 0",
+		"kafka_logdir_capacity_used_percent{broker=\"kafka-2\",log_dir=\"/data\"} 45",
+		"kafka_logdir_size_bytes{broker=\"kafka-2\",log_dir=\"/broken\"} 0",
+		"kafka_logdir_error_code{broker=\"kafka-2\",log_dir=\"/broken\"} 57",
+		"kafka_logdir_capacity_used_percent{broker=\"kafka-2\",log_dir=\"/broken\"} 0",
+		"kafka_cluster_topic_size_bytes{topic=\"events\"} 200",
+		"kafka_cluster_topic_size_bytes{topic=\"orders\"} 650",
+		"kafka_cluster_partition_size_bytes{topic=\"orders\",partition=\"0\"} 300",
+		"kafka_cluster_partition_size_bytes{topic=\"events\",partition=\"0\"} 200",
+	}
+	metrics := CalcLogDirMetrics(logDirs, 1000, 2)
+	if len(metrics) != len(expected) {
+		t.Fatalf("unexpected number of metrics %d, expected %d", len(metrics), len(expected))
+	}
+	for i, metric := range metrics {
+		actual := PrometheusName(metric) + prometheusLabels(metric.Labels) + " " + strconv.FormatInt(metric.Value, 10)
+		if actual != expected[i] {
+			t.Errorf("unexpected metric %s, expected %s", actual, expected[i])
+		}
+	}
+}
+
+func TestParseStorageSize(t *testing.T) {
+	tests := []struct {
+		size     string
+		expected int64
+		err      bool
+	}{
+		{size: "", expected: 0},
+		{size: "1024", expected: 1024},
+		{size: "10Gi", expected: 10 << 30},
+		{size: "1.5Ki", expected: 1536},
+		{size: "2G", expected: 2e9},
+		{size: "500M", expected: 5e8},
+		{size: "10Gb", err: true},
+		{size: "-1Gi", err: true},
+	}
+	for _, test := range tests {
+		t.Run(test.size, func(t *testing.T) {
+			size, err := ParseStorageSize(test.size)
+			if (err != nil) != test.err || size != test.expected {
+				t.Errorf("unexpected size %d (error %v), expected %d", size, err, test.expected)
+			}
+		})
+	}
+}
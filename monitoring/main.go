@@ -15,32 +15,48 @@
 package main
 
 import (
-	"bytes"
 	"context"
-	"crypto/tls"
-	"crypto/x509"
 	"fmt"
 	"log"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/Netcracker/qubership-kafka/kafkaconn"
+	"github.com/Netcracker/qubership-kafka/kafkaconn/kafkagoconn"
 	kafka "github.com/segmentio/kafka-go"
-	"github.com/segmentio/kafka-go/sasl"
-	"github.com/segmentio/kafka-go/sasl/scram"
+)
+
+const (
+	// influxMode prints metrics once in InfluxDB line protocol for exec input of Telegraf
+	influxMode = "influx"
+	// prometheusMode serves metrics on HTTP /metrics endpoint in Prometheus or OpenMetrics format
+	prometheusMode = "prometheus"
 )
 
 var (
 	monitoringSecretsBaseDir = "/etc/secrets/monitoring-pod-secrets"
 	broker                   = getEnv("KAFKA_ADDRESSES", "")
-	saslUsername             = getSecretFromFileOrEnv(fmt.Sprintf("%s/%s", monitoringSecretsBaseDir, "client_username"), "KAFKA_USER")
-	saslPassword             = getSecretFromFileOrEnv(fmt.Sprintf("%s/%s", monitoringSecretsBaseDir, "client_password"), "KAFKA_PASSWORD")
+	saslUsername             = kafkaconn.ValueFromFileOrEnv(fmt.Sprintf("%s/%s", monitoringSecretsBaseDir, "client_username"), "KAFKA_USER")
+	saslPassword             = kafkaconn.ValueFromFileOrEnv(fmt.Sprintf("%s/%s", monitoringSecretsBaseDir, "client_password"), "KAFKA_PASSWORD")
 	serviceName              = getEnv("KAFKA_SERVICE_NAME", "kafka")
 	sslMechanism             = getEnv("KAFKA_SASL_MECHANISM", "SCRAM-SHA-512")
 	isDebugEnabled           = getBoolEnv("KAFKA_MONITORING_SCRIPT_DEBUG", "false")
 	sslEnabled               = getBoolEnv("KAFKA_ENABLE_SSL", "false")
 	monitoringLogs           = getEnv("MONITORING_LOGS", "/tmp/monitoring/logs")
+	metricsMode              = getEnv("METRICS_MODE", influxMode)
+	listenAddress            = getEnv("METRICS_LISTEN_ADDRESS", ":8097")
+	metadataCacheTTL         = getEnv("METADATA_CACHE_TTL", "30s")
+	consumerLagEnabled       = getBoolEnv("CONSUMER_LAG_ENABLED", "false")
+	consumerGroupsInclude    = getEnv("CONSUMER_GROUPS_INCLUDE", ".*")
+	consumerGroupsExclude    = getEnv("CONSUMER_GROUPS_EXCLUDE", "")
+	offsetHistoryFile        = getEnv("OFFSET_HISTORY_FILE", "/tmp/monitoring/offset-history.json")
+	kafkaStorageSize         = getEnv("KAFKA_STORAGE_SIZE", "")
+	largestPartitionsCount   = getEnv("LARGEST_PARTITIONS_COUNT", "10")
+	topicPolicyFile          = getEnv("TOPIC_POLICY_FILE", "")
+	oauthTokenFile           = getEnv("KAFKA_OAUTH_TOKEN_FILE", "")
 
 	caCertPath     = "/tls/ca.crt"
 	tlsCertPath    = "/tls/tls.crt"
@@ -59,14 +75,6 @@ func getBoolEnv(key string, defaultValue string) bool {
 	return strings.ToLower(getEnv(key, defaultValue)) == "true"
 }
 
-func getSecretFromFileOrEnv(filePath string, envKey string) string {
-	secretBytes, err := os.ReadFile(filePath)
-	if err == nil {
-		return strings.TrimSpace(string(secretBytes))
-	}
-	return getEnv(envKey, "")
-}
-
 type Logger struct {
 	logger *log.Logger
 	debug  bool
@@ -93,229 +101,75 @@ func (c *Logger) Debug(message string) {
 }
 
 func main() {
-
-	debugLogger.Debug("Start of additional metrics script execution")
-	startTime := time.Now().UnixMilli()
-
-	brokerAddress := strings.Split(broker, ",")
-	username := saslUsername
-	password := saslPassword
-
-	var mechanism sasl.Mechanism
-	if username != "" && password != "" {
-		if sslMechanism == "SCRAM-SHA-512" {
-			mechanism, _ = scram.Mechanism(scram.SHA512, username, password)
-		} else {
-			mechanism, _ = scram.Mechanism(scram.SHA256, username, password)
-		}
-	}
-
-	transport := kafka.Transport{
-		SASL: mechanism,
-	}
-
-	if sslEnabled {
-		tlsConfig, err := createTLSConfig(caCertPath, tlsCertPath, tlsKeyPath)
-		if err != nil {
-			log.Fatalf("Failed to create TLS config: %s", err)
-		}
-		transport.TLS = tlsConfig
-	}
-
-	client := kafka.Client{
-		Addr:      kafka.TCP(brokerAddress...),
-		Transport: &transport,
-	}
-
-	metadata, err := client.Metadata(context.Background(), &kafka.MetadataRequest{})
+	client, err := newKafkaClient()
 	if err != nil {
-		log.Fatalf("Failed to get metadata: %s", err)
-	}
-
-	var resources []kafka.DescribeConfigRequestResource
-	for _, topic := range metadata.Topics {
-		resources = append(resources, kafka.DescribeConfigRequestResource{
-			ResourceType: kafka.ResourceTypeTopic,
-			ResourceName: topic.Name,
-		})
+		log.Fatalf("Failed to create Kafka client: %s", err)
 	}
-
-	request := &kafka.DescribeConfigsRequest{
-		Resources: resources,
-	}
-
-	configs, err := client.DescribeConfigs(context.Background(), request)
+	config, err := newCollectorConfig()
 	if err != nil {
-		log.Fatalf("Failed to describe configs: %s", err)
+		log.Fatal(err)
 	}
 
-	fmt.Println(CollectMetrics(*configs, *metadata, client))
-
-	debugLogger.Debug(fmt.Sprintf("Time of additional metrics script execution: %f", float64((time.Now().UnixMilli()-startTime))/float64(1000)))
-
-}
-
-func CollectMetrics(configs kafka.DescribeConfigsResponse, metadata kafka.MetadataResponse, client kafka.Client) string {
-	uncleanTopics := strings.Join(calcUncleanElectionLeaderTopics(configs), "\n")
-	topicsWithoutLeader := strings.Join(CalcTopicsWithoutLeader(metadata.Topics), "\n")
-	brokerMetrics := CalcSkewNFormMetric(metadata.Topics, CalcCleanerNReplicaThreads(metadata, client))
-	result := brokerMetrics + uncleanTopics + "\n" + topicsWithoutLeader
-	return result
-}
-
-func calcUncleanElectionLeaderTopics(configs kafka.DescribeConfigsResponse) []string {
-	pattern := "kafka_cluster,topic=%s unclean_election_topics=1"
-	metricsArr := make([]string, 0)
-	for _, res := range configs.Resources {
-		uncleanElectionEnabled := false
-		for _, entry := range res.ConfigEntries {
-			if entry.ConfigName == "unclean.leader.election.enable" && entry.ConfigValue == "true" {
-				uncleanElectionEnabled = true
-				break
-			}
+	switch metricsMode {
+	case prometheusMode:
+		if config.CacheTTL, err = time.ParseDuration(metadataCacheTTL); err != nil {
+			log.Fatalf("Incorrect metadata cache TTL %q: %s", metadataCacheTTL, err)
 		}
-		if uncleanElectionEnabled {
-			metricsArr = append(metricsArr, fmt.Sprintf(pattern, res.ResourceName))
+		if err = serveMetrics(NewCollector(client, config), listenAddress); err != nil {
+			log.Fatal(err)
 		}
-	}
-	// kafka_cluster unclean_election_topics=1,topic="test0"
-	return metricsArr
-}
-
-func CalcTopicsWithoutLeader(topics []kafka.Topic) []string {
-	pattern := "kafka_cluster,topic=%s topics_without_leader=1"
-	topicsWithoutLeader := make([]string, 0)
-	for _, topic := range topics {
+	case influxMode:
+		debugLogger.Debug("Start of additional metrics script execution")
+		startTime := time.Now().UnixMilli()
+		// each run is a separate process, so observed end offsets are kept in file to estimate time lag
+		config.OffsetHistoryFile = offsetHistoryFile
 
-		for _, partition := range topic.Partitions {
-			if partition.Leader.ID == 0 {
-				topicsWithoutLeader = append(topicsWithoutLeader, fmt.Sprintf(pattern, topic.Name))
-				break
-			}
+		metrics, err := NewCollector(client, config).Collect(context.Background())
+		if err != nil {
+			log.Fatalf("Failed to collect metrics: %s", err)
 		}
-	}
-	return topicsWithoutLeader
-}
-
-func CalcSkewNFormMetric(topics []kafka.Topic, result map[string]string) string {
-	brokerPartitions := make(map[kafka.Broker]int)
-	brokerLeaders := make(map[kafka.Broker]int)
-	for _, topic := range topics {
-		for _, partition := range topic.Partitions {
-			brokerLeaders[partition.Leader]++
-			for _, replica := range partition.Replicas {
-				brokerPartitions[replica]++
-			}
+		if err = EncodeInflux(os.Stdout, metrics); err != nil {
+			log.Fatalf("Failed to write metrics: %s", err)
 		}
-	}
-
-	var sumPartitions, sumLeaders float64
-	for _, count := range brokerPartitions {
-		sumPartitions += float64(count)
-	}
-	for _, count := range brokerLeaders {
-		sumLeaders += float64(count)
-	}
-
-	numBrokers := float64(len(brokerPartitions))
-	meanPartitions := sumPartitions / numBrokers
-	meanLeaders := sumLeaders / numBrokers
 
-	for broker, count := range brokerPartitions {
-		deviation := ((float64(count) - meanPartitions) / meanPartitions) * 100
-		brokerSignature := fmt.Sprintf("%s-%d", serviceName, broker.ID)
-		result[brokerSignature] += fmt.Sprintf(",broker_skew=%di", int(deviation))
+		debugLogger.Debug(fmt.Sprintf("Time of additional metrics script execution: %f", float64((time.Now().UnixMilli()-startTime))/float64(1000)))
+	default:
+		log.Fatalf("Unknown metrics mode %q, supported modes are %q and %q", metricsMode, influxMode, prometheusMode)
 	}
-
-	for broker, count := range brokerLeaders {
-		deviation := ((float64(count) - meanLeaders) / meanLeaders) * 100
-		brokerSignature := fmt.Sprintf("%s-%d", serviceName, broker.ID)
-		result[brokerSignature] += fmt.Sprintf(",broker_leader_skew=%di", int(deviation))
-	}
-
-	metrics := new(bytes.Buffer)
-	for _, metricPerBroker := range result {
-		if strings.Contains(metricPerBroker, "kafka,broker=") {
-			_, err := fmt.Fprintf(metrics, "%s\n", metricPerBroker)
-			if err != nil {
-				return ""
-			}
-		}
-	}
-	return metrics.String()
 }
 
-func CalcCleanerNReplicaThreads(metadata kafka.MetadataResponse, client kafka.Client) map[string]string {
-	result := make(map[string]string)
-	var resources []kafka.DescribeConfigRequestResource
-	for _, broker := range metadata.Brokers {
-		brokerSignature := fmt.Sprintf("%s-%d", serviceName, broker.ID)
-		result[brokerSignature] = fmt.Sprintf("kafka,broker=%s ", brokerSignature)
-		resources = append(resources, kafka.DescribeConfigRequestResource{
-			ResourceType: kafka.ResourceTypeBroker,
-			ResourceName: fmt.Sprintf("%d", broker.ID),
-		})
-	}
-	request := &kafka.DescribeConfigsRequest{
-		Resources: resources,
-	}
-	var cleanerVal string
-	var fetchersVal string
-	response, err := client.DescribeConfigs(context.Background(), request)
-	if err != nil {
-		log.Fatalf("Failed to describe configs: %s", err)
-	}
-	for _, resource := range response.Resources {
-		brokerSignature := fmt.Sprintf("%s-%s", serviceName, resource.ResourceName)
-		for _, config := range resource.ConfigEntries {
-			if config.ConfigName == "log.cleaner.threads" {
-				cleanerVal = config.ConfigValue
-			}
-			if config.ConfigName == "num.replica.fetchers" {
-				fetchersVal = config.ConfigValue
-			}
-		}
-		result[brokerSignature] += fmt.Sprintf("log_cleaner_threads_count=%si,replica_fetcher_threads_count=%si", cleanerVal, fetchersVal)
-	}
-	return result
-}
-
-func createTLSConfig(caFile, certFile, keyFile string) (*tls.Config, error) {
-	var cert tls.Certificate
+func newCollectorConfig() (CollectorConfig, error) {
+	config := CollectorConfig{TopicPolicyFile: topicPolicyFile}
 	var err error
-	if fileExists(certFile) && fileExists(keyFile) {
-		cert, err = tls.LoadX509KeyPair(certFile, keyFile)
-		if err != nil {
-			return nil, fmt.Errorf("could not load client key pair: %w", err)
+	if consumerLagEnabled {
+		if config.GroupFilter, err = NewGroupFilter(consumerGroupsInclude, consumerGroupsExclude); err != nil {
+			return config, err
 		}
 	}
-
-	var caCertPool x509.CertPool
-	if fileExists(caFile) {
-		caCert, err := os.ReadFile(caFile)
-		if err != nil {
-			return nil, fmt.Errorf("could not read ca certificate: %w", err)
-		}
-
-		caCertPool = *x509.NewCertPool()
-		if ok := caCertPool.AppendCertsFromPEM(caCert); !ok {
-			return nil, fmt.Errorf("failed to append ca cert to pool")
-		}
+	if config.StorageCapacity, err = ParseStorageSize(kafkaStorageSize); err != nil {
+		return config, err
 	}
-
-	tlsConfig := &tls.Config{
-		Certificates:       []tls.Certificate{cert},
-		RootCAs:            &caCertPool,
-		InsecureSkipVerify: false,
+	if config.LargestPartitionsCount, err = strconv.Atoi(largestPartitionsCount); err != nil {
+		return config, fmt.Errorf("incorrect largest partitions count %q: %w", largestPartitionsCount, err)
 	}
-
-	return tlsConfig, nil
+	return config, nil
 }
 
-func fileExists(filename string) bool {
-	info, err := os.Stat(filename)
-	if os.IsNotExist(err) {
-		return false
+func newKafkaClient() (*kafka.Client, error) {
+	settings := kafkaconn.Settings{
+		Brokers: kafkaconn.ParseBrokers(broker),
+		SASL: kafkaconn.SASLSettings{
+			Mechanism: sslMechanism,
+			Username:  saslUsername,
+			Password:  saslPassword,
+			TokenFile: oauthTokenFile,
+		},
+	}
+	if sslEnabled {
+		var err error
+		if settings.TLS, err = kafkaconn.LoadTLSFiles(caCertPath, tlsCertPath, tlsKeyPath); err != nil {
+			return nil, fmt.Errorf("failed to load TLS certificates: %w", err)
+		}
 	}
-	return !info.IsDir()
+	return kafkagoconn.NewClient(settings)
 }
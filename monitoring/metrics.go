@@ -0,0 +1,208 @@
+//Copyright 2024-2025 NetCracker Technology Corporation
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	kafka "github.com/segmentio/kafka-go"
+)
+
+const (
+	clusterMeasurement = "kafka_cluster"
+	brokerMeasurement  = "kafka"
+)
+
+// Label is a tag of InfluxDB point or a label of Prometheus sample
+type Label struct {
+	Name  string
+	Value string
+}
+
+// Metric is a single collected value which is encoded to InfluxDB line protocol or Prometheus exposition format.
+// Metrics with the same measurement and labels are written as fields of one InfluxDB point,
+// and each of them is a separate Prometheus metric named as <measurement>_<field>.
+type Metric struct {
+	Measurement string
+	Field       string
+	Labels      []Label
+	Value       int64
+	// Integer shows that value is written to InfluxDB as integer field
+	Integer bool
+	Help    string
+}
+
+// ClusterSnapshot contains metadata and configs of Kafka cluster which metrics are calculated from
+type ClusterSnapshot struct {
+	Metadata      kafka.MetadataResponse
+	TopicConfigs  kafka.DescribeConfigsResponse
+	BrokerConfigs kafka.DescribeConfigsResponse
+	// ConsumerGroups contains committed offsets of consumer groups by topic and partition
+	ConsumerGroups map[string]map[string]map[int]int64
+	// EndOffsets contains last offsets of partitions which consumer groups have committed offsets for
+	EndOffsets map[string]map[int]int64
+	// LogDirs contains log directories of each broker
+	LogDirs     map[int][]LogDir
+	CollectedAt time.Time
+}
+
+func CollectMetrics(snapshot ClusterSnapshot) []Metric {
+	topicsHealth, brokersHealth := CalcPartitionHealth(snapshot)
+	metrics := CalcBrokerMetrics(snapshot.Metadata, snapshot.BrokerConfigs, brokersHealth)
+	metrics = append(metrics, calcUncleanElectionLeaderTopics(snapshot.TopicConfigs)...)
+	metrics = append(metrics, CalcTopicsWithoutLeader(snapshot.Metadata.Topics)...)
+	metrics = append(metrics, CalcTopicPartitionHealthMetrics(snapshot.Metadata.Topics, topicsHealth)...)
+	return metrics
+}
+
+func calcUncleanElectionLeaderTopics(configs kafka.DescribeConfigsResponse) []Metric {
+	metrics := make([]Metric, 0)
+	for _, res := range configs.Resources {
+		for _, entry := range res.ConfigEntries {
+			if entry.ConfigName == "unclean.leader.election.enable" && entry.ConfigValue == "true" {
+				metrics = append(metrics, Metric{
+					Measurement: clusterMeasurement,
+					Field:       "unclean_election_topics",
+					Labels:      []Label{{Name: "topic", Value: res.ResourceName}},
+					Value:       1,
+					Help:        "Topic has unclean leader election enabled.",
+				})
+				break
+			}
+		}
+	}
+	return metrics
+}
+
+func CalcTopicsWithoutLeader(topics []kafka.Topic) []Metric {
+	metrics := make([]Metric, 0)
+	for _, topic := range topics {
+		for _, partition := range topic.Partitions {
+			if partition.Leader.ID == 0 {
+				metrics = append(metrics, Metric{
+					Measurement: clusterMeasurement,
+					Field:       "topics_without_leader",
+					Labels:      []Label{{Name: "topic", Value: topic.Name}},
+					Value:       1,
+					Help:        "Topic has partitions without leader.",
+				})
+				break
+			}
+		}
+	}
+	return metrics
+}
+
+// CalcBrokerMetrics returns cleaner and replica fetcher threads, partitions and leaders skew
+// and partition health for each broker
+func CalcBrokerMetrics(metadata kafka.MetadataResponse, brokerConfigs kafka.DescribeConfigsResponse,
+	brokersHealth map[int]*PartitionHealth) []Metric {
+	threads := CalcCleanerNReplicaThreads(brokerConfigs)
+	partitionsSkew, leadersSkew := CalcSkew(metadata.Topics)
+
+	brokerIds := make([]int, 0, len(metadata.Brokers))
+	for _, broker := range metadata.Brokers {
+		brokerIds = append(brokerIds, broker.ID)
+	}
+	sort.Ints(brokerIds)
+
+	metrics := make([]Metric, 0)
+	for _, brokerId := range brokerIds {
+		labels := []Label{{Name: "broker", Value: fmt.Sprintf("%s-%d", serviceName, brokerId)}}
+		brokerMetric := func(field string, value int64, help string) Metric {
+			return Metric{Measurement: brokerMeasurement, Field: field, Labels: labels, Value: value, Integer: true, Help: help}
+		}
+		if values, ok := threads[brokerId]; ok {
+			metrics = append(metrics,
+				brokerMetric("log_cleaner_threads_count", values[0], "Number of log cleaner threads of broker."),
+				brokerMetric("replica_fetcher_threads_count", values[1], "Number of replica fetcher threads of broker."))
+		}
+		if skew, ok := partitionsSkew[brokerId]; ok {
+			metrics = append(metrics, brokerMetric("broker_skew", skew,
+				"Deviation of broker partitions count from the average in percent."))
+		}
+		if skew, ok := leadersSkew[brokerId]; ok {
+			metrics = append(metrics, brokerMetric("broker_leader_skew", skew,
+				"Deviation of broker leader partitions count from the average in percent."))
+		}
+		health := PartitionHealth{}
+		if brokerHealth, ok := brokersHealth[brokerId]; ok {
+			health = *brokerHealth
+		}
+		metrics = append(metrics, partitionHealthMetrics(brokerMeasurement, labels, health, false)...)
+	}
+	return metrics
+}
+
+// CalcSkew returns deviation of partitions and leaders count of each broker from the average in percent
+func CalcSkew(topics []kafka.Topic) (map[int]int64, map[int]int64) {
+	brokerPartitions := make(map[int]int)
+	brokerLeaders := make(map[int]int)
+	for _, topic := range topics {
+		for _, partition := range topic.Partitions {
+			brokerLeaders[partition.Leader.ID]++
+			for _, replica := range partition.Replicas {
+				brokerPartitions[replica.ID]++
+			}
+		}
+	}
+
+	var sumPartitions, sumLeaders float64
+	for _, count := range brokerPartitions {
+		sumPartitions += float64(count)
+	}
+	for _, count := range brokerLeaders {
+		sumLeaders += float64(count)
+	}
+
+	numBrokers := float64(len(brokerPartitions))
+	meanPartitions := sumPartitions / numBrokers
+	meanLeaders := sumLeaders / numBrokers
+
+	partitionsSkew := make(map[int]int64)
+	for brokerId, count := range brokerPartitions {
+		partitionsSkew[brokerId] = int64(((float64(count) - meanPartitions) / meanPartitions) * 100)
+	}
+	leadersSkew := make(map[int]int64)
+	for brokerId, count := range brokerLeaders {
+		leadersSkew[brokerId] = int64(((float64(count) - meanLeaders) / meanLeaders) * 100)
+	}
+	return partitionsSkew, leadersSkew
+}
+
+// CalcCleanerNReplicaThreads returns log cleaner and replica fetcher threads count for each broker
+func CalcCleanerNReplicaThreads(brokerConfigs kafka.DescribeConfigsResponse) map[int][2]int64 {
+	result := make(map[int][2]int64)
+	for _, resource := range brokerConfigs.Resources {
+		brokerId, err := strconv.Atoi(resource.ResourceName)
+		if err != nil {
+			continue
+		}
+		var cleanerVal, fetchersVal int64
+		for _, config := range resource.ConfigEntries {
+			if config.ConfigName == "log.cleaner.threads" {
+				cleanerVal, _ = strconv.ParseInt(config.ConfigValue, 10, 64)
+			}
+			if config.ConfigName == "num.replica.fetchers" {
+				fetchersVal, _ = strconv.ParseInt(config.ConfigValue, 10, 64)
+			}
+		}
+		result[brokerId] = [2]int64{cleanerVal, fetchersVal}
+	}
+	return result
+}
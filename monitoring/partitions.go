@@ -0,0 +1,144 @@
+//Copyright 2024-2025 NetCracker Technology Corporation
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package main
+
+import (
+	"strconv"
+
+	kafka "github.com/segmentio/kafka-go"
+)
+
+const (
+	minInsyncReplicasConfig  = "min.insync.replicas"
+	defaultMinInsyncReplicas = 1
+)
+
+// PartitionHealth contains counts of unhealthy partitions of topic or broker
+type PartitionHealth struct {
+	UnderReplicated    int64
+	UnderMinIsr        int64
+	Offline            int64
+	NonPreferredLeader int64
+}
+
+// CalcPartitionHealth returns partition health of each topic and of each broker.
+// Offline partition has no leader, it is not counted as under-replicated or under min ISR.
+// Under-replicated and under min ISR partitions are counted for their leader broker like Kafka does,
+// partition with non-preferred leader is counted for its preferred replica broker.
+func CalcPartitionHealth(snapshot ClusterSnapshot) (map[string]*PartitionHealth, map[int]*PartitionHealth) {
+	minIsr := getMinInsyncReplicas(snapshot.TopicConfigs, snapshot.BrokerConfigs)
+	topicsHealth := make(map[string]*PartitionHealth)
+	brokersHealth := make(map[int]*PartitionHealth)
+	brokerHealth := func(brokerId int) *PartitionHealth {
+		if _, found := brokersHealth[brokerId]; !found {
+			brokersHealth[brokerId] = &PartitionHealth{}
+		}
+		return brokersHealth[brokerId]
+	}
+	for _, topic := range snapshot.Metadata.Topics {
+		health := &PartitionHealth{}
+		topicsHealth[topic.Name] = health
+		topicMinIsr, found := minIsr[topic.Name]
+		if !found {
+			topicMinIsr = minIsr[""]
+		}
+		for _, partition := range topic.Partitions {
+			leaderId := partition.Leader.ID
+			if leaderId == 0 {
+				health.Offline++
+				continue
+			}
+			if len(partition.Isr) < len(partition.Replicas) {
+				health.UnderReplicated++
+				brokerHealth(leaderId).UnderReplicated++
+			}
+			if len(partition.Isr) < topicMinIsr {
+				health.UnderMinIsr++
+				brokerHealth(leaderId).UnderMinIsr++
+			}
+			if len(partition.Replicas) > 0 && partition.Replicas[0].ID != leaderId {
+				health.NonPreferredLeader++
+				if preferredId := partition.Replicas[0].ID; preferredId != 0 {
+					brokerHealth(preferredId).NonPreferredLeader++
+				}
+			}
+		}
+	}
+	return topicsHealth, brokersHealth
+}
+
+// getMinInsyncReplicas returns effective min.insync.replicas of each topic,
+// value for empty topic name is the broker default which is used when topic config is not described
+func getMinInsyncReplicas(topicConfigs kafka.DescribeConfigsResponse, brokerConfigs kafka.DescribeConfigsResponse) map[string]int {
+	result := map[string]int{"": defaultMinInsyncReplicas}
+	for _, resource := range brokerConfigs.Resources {
+		if value, found := getIntConfig(resource, minInsyncReplicasConfig); found {
+			result[""] = value
+			break
+		}
+	}
+	for _, resource := range topicConfigs.Resources {
+		if value, found := getIntConfig(resource, minInsyncReplicasConfig); found {
+			result[resource.ResourceName] = value
+		}
+	}
+	return result
+}
+
+func getIntConfig(resource kafka.DescribeConfigResponseResource, name string) (int, bool) {
+	for _, entry := range resource.ConfigEntries {
+		if entry.ConfigName == name {
+			value, err := strconv.Atoi(entry.ConfigValue)
+			return value, err == nil
+		}
+	}
+	return 0, false
+}
+
+// CalcTopicPartitionHealthMetrics returns partition health metrics of topics which have unhealthy partitions
+func CalcTopicPartitionHealthMetrics(topics []kafka.Topic, topicsHealth map[string]*PartitionHealth) []Metric {
+	metrics := make([]Metric, 0)
+	for _, topic := range topics {
+		health, found := topicsHealth[topic.Name]
+		if !found {
+			continue
+		}
+		labels := []Label{{Name: "topic", Value: topic.Name}}
+		for _, metric := range partitionHealthMetrics(clusterMeasurement, labels, *health, true) {
+			if metric.Value > 0 {
+				metrics = append(metrics, metric)
+			}
+		}
+	}
+	return metrics
+}
+
+func partitionHealthMetrics(measurement string, labels []Label, health PartitionHealth, withOffline bool) []Metric {
+	partitionMetric := func(field string, value int64, help string) Metric {
+		return Metric{Measurement: measurement, Field: field, Labels: labels, Value: value, Integer: true, Help: help}
+	}
+	metrics := []Metric{
+		partitionMetric("under_replicated_partitions", health.UnderReplicated,
+			"Number of partitions which have less in-sync replicas than replicas."),
+		partitionMetric("under_min_isr_partitions", health.UnderMinIsr,
+			"Number of partitions which have less in-sync replicas than effective min.insync.replicas."),
+	}
+	if withOffline {
+		metrics = append(metrics, partitionMetric("offline_partitions", health.Offline,
+			"Number of partitions without leader."))
+	}
+	return append(metrics, partitionMetric("preferred_leader_imbalance", health.NonPreferredLeader,
+		"Number of partitions whose leader is not the preferred replica."))
+}
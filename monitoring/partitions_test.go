@@ -0,0 +1,163 @@
+//Copyright 2024-2025 NetCracker Technology Corporation
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package main
+
+import (
+	"testing"
+
+	kafka "github.com/segmentio/kafka-go"
+)
+
+func brokers(ids ...int) []kafka.Broker {
+	result := make([]kafka.Broker, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, kafka.Broker{ID: id})
+	}
+	return result
+}
+
+// partition returns partition metadata, leader 0 means that partition has no leader
+func partition(id int, leader int, replicas []int, isr []int) kafka.Partition {
+	return kafka.Partition{ID: id, Leader: kafka.Broker{ID: leader}, Replicas: brokers(replicas...), Isr: brokers(isr...)}
+}
+
+func configs(name string, entries map[string]string) kafka.DescribeConfigResponseResource {
+	resource := kafka.DescribeConfigResponseResource{ResourceName: name}
+	for key, value := range entries {
+		resource.ConfigEntries = append(resource.ConfigEntries,
+			kafka.DescribeConfigResponseConfigEntry{ConfigName: key, ConfigValue: value})
+	}
+	return resource
+}
+
+func TestCalcPartitionHealth(t *testing.T) {
+	tests := []struct {
+		name         string
+		partitions   []kafka.Partition
+		topicConfigs map[string]string
+		topic        PartitionHealth
+		brokers      map[int]PartitionHealth
+	}{
+		{
+			name: "ok_topic",
+			partitions: []kafka.Partition{
+				partition(0, 2, []int{2, 1, 3}, []int{1, 2, 3}),
+				partition(1, 1, []int{1, 2, 3}, []int{1, 2, 3}),
+			},
+			topicConfigs: map[string]string{minInsyncReplicasConfig: "2"},
+			topic:        PartitionHealth{},
+			brokers:      map[int]PartitionHealth{},
+		},
+		{
+			name: "topic_without_leader",
+			partitions: []kafka.Partition{
+				partition(0, 1, []int{1, 2, 3}, []int{1, 2, 3}),
+				partition(1, 0, []int{1, 2, 3}, []int{1, 2, 3}),
+			},
+			topicConfigs: map[string]string{minInsyncReplicasConfig: "2"},
+			topic:        PartitionHealth{Offline: 1},
+			brokers:      map[int]PartitionHealth{},
+		},
+		{
+			name: "topic_under_replicated",
+			partitions: []kafka.Partition{
+				partition(0, 1, []int{1, 2, 3}, []int{1, 2, 3}),
+				partition(2, 2, []int{2, 1, 3}, []int{1, 2}),
+			},
+			topicConfigs: map[string]string{minInsyncReplicasConfig: "2"},
+			topic:        PartitionHealth{UnderReplicated: 1},
+			brokers:      map[int]PartitionHealth{2: {UnderReplicated: 1}},
+		},
+		{
+			name: "topic_under_min_isr",
+			partitions: []kafka.Partition{
+				partition(0, 3, []int{3, 1, 2}, []int{3}),
+			},
+			topicConfigs: map[string]string{minInsyncReplicasConfig: "2"},
+			topic:        PartitionHealth{UnderReplicated: 1, UnderMinIsr: 1},
+			brokers:      map[int]PartitionHealth{3: {UnderReplicated: 1, UnderMinIsr: 1}},
+		},
+		{
+			name: "topic_with_broker_default_min_isr",
+			partitions: []kafka.Partition{
+				partition(0, 3, []int{3, 1, 2}, []int{3, 1}),
+			},
+			topic:   PartitionHealth{UnderReplicated: 1, UnderMinIsr: 1},
+			brokers: map[int]PartitionHealth{3: {UnderReplicated: 1, UnderMinIsr: 1}},
+		},
+		{
+			name: "topic_with_non_preferred_leader",
+			partitions: []kafka.Partition{
+				partition(0, 2, []int{1, 2, 3}, []int{1, 2, 3}),
+				partition(1, 3, []int{0, 3}, []int{3}),
+			},
+			topicConfigs: map[string]string{minInsyncReplicasConfig: "1"},
+			topic:        PartitionHealth{UnderReplicated: 1, NonPreferredLeader: 2},
+			brokers:      map[int]PartitionHealth{1: {NonPreferredLeader: 1}, 3: {UnderReplicated: 1}},
+		},
+		{
+			name: "topic_without_leader_and_under_replicated",
+			partitions: []kafka.Partition{
+				partition(0, 0, []int{1, 2, 3}, []int{1, 2}),
+			},
+			topicConfigs: map[string]string{minInsyncReplicasConfig: "3"},
+			topic:        PartitionHealth{Offline: 1},
+			brokers:      map[int]PartitionHealth{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			snapshot := ClusterSnapshot{
+				Metadata: kafka.MetadataResponse{Topics: []kafka.Topic{{Name: test.name, Partitions: test.partitions}}},
+				BrokerConfigs: kafka.DescribeConfigsResponse{Resources: []kafka.DescribeConfigResponseResource{
+					configs("1", map[string]string{minInsyncReplicasConfig: "3"}),
+				}},
+			}
+			if test.topicConfigs != nil {
+				snapshot.TopicConfigs.Resources = []kafka.DescribeConfigResponseResource{configs(test.name, test.topicConfigs)}
+			}
+			topicsHealth, brokersHealth := CalcPartitionHealth(snapshot)
+			if *topicsHealth[test.name] != test.topic {
+				t.Errorf("unexpected topic health %+v, expected %+v", *topicsHealth[test.name], test.topic)
+			}
+			if len(brokersHealth) != len(test.brokers) {
+				t.Errorf("unexpected brokers with unhealthy partitions %d, expected %d", len(brokersHealth), len(test.brokers))
+			}
+			for brokerId, expected := range test.brokers {
+				if actual, found := brokersHealth[brokerId]; !found || *actual != expected {
+					t.Errorf("unexpected health of broker %d: %+v, expected %+v", brokerId, actual, expected)
+				}
+			}
+		})
+	}
+}
+
+func TestCalcTopicPartitionHealthMetrics(t *testing.T) {
+	topics := []kafka.Topic{{Name: "healthy"}, {Name: "unhealthy"}}
+	topicsHealth := map[string]*PartitionHealth{
+		"healthy":   {},
+		"unhealthy": {Offline: 2, NonPreferredLeader: 1},
+	}
+	metrics := CalcTopicPartitionHealthMetrics(topics, topicsHealth)
+	expected := map[string]int64{"offline_partitions": 2, "preferred_leader_imbalance": 1}
+	if len(metrics) != len(expected) {
+		t.Fatalf("unexpected metrics %+v", metrics)
+	}
+	for _, metric := range metrics {
+		if metric.Labels[0].Value != "unhealthy" || metric.Value != expected[metric.Field] {
+			t.Errorf("unexpected metric %+v", metric)
+		}
+	}
+}
@@ -0,0 +1,220 @@
+//Copyright 2024-2025 NetCracker Technology Corporation
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	kafka "github.com/segmentio/kafka-go"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	topicPolicyMeasurement = "kafka_topic_policy"
+	unlimitedConfigValue   = -1
+)
+
+// TopicPolicy is a set of rules which topic configs and metadata are audited with
+type TopicPolicy struct {
+	Rules []TopicPolicyRule `yaml:"rules"`
+}
+
+// TopicPolicyRule defines checks for topics which names match Topics and do not match ExcludeTopics expressions.
+// Internal topics are checked only if IncludeInternal is true. Zero values of checks are not checked.
+type TopicPolicyRule struct {
+	Name            string `yaml:"name"`
+	Topics          string `yaml:"topics,omitempty"`
+	ExcludeTopics   string `yaml:"excludeTopics,omitempty"`
+	IncludeInternal bool   `yaml:"includeInternal,omitempty"`
+
+	MinReplicationFactor int `yaml:"minReplicationFactor,omitempty"`
+	MaxPartitions        int `yaml:"maxPartitions,omitempty"`
+	MinInsyncReplicas    int `yaml:"minInsyncReplicas,omitempty"`
+	// Configs are required values of topic configs, list configs like cleanup.policy must contain the value
+	Configs map[string]string `yaml:"configs,omitempty"`
+	// Ranges are bounds of numeric topic configs, -1 value of config is treated as unlimited
+	Ranges map[string]ConfigRange `yaml:"ranges,omitempty"`
+
+	topics        *regexp.Regexp
+	excludeTopics *regexp.Regexp
+}
+
+// ConfigRange defines inclusive bounds of numeric config, nil bound is not checked
+type ConfigRange struct {
+	Min *int64 `yaml:"min,omitempty"`
+	Max *int64 `yaml:"max,omitempty"`
+}
+
+// PolicyViolation is a failed check of policy rule for topic
+type PolicyViolation struct {
+	Rule  string
+	Topic string
+	Check string
+}
+
+// LoadTopicPolicy reads policy from YAML file and compiles topic expressions of its rules
+func LoadTopicPolicy(path string) (*TopicPolicy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read topic policy: %w", err)
+	}
+	return ParseTopicPolicy(data)
+}
+
+// ParseTopicPolicy parses YAML policy, each rule must have unique name
+func ParseTopicPolicy(data []byte) (*TopicPolicy, error) {
+	policy := &TopicPolicy{}
+	if err := yaml.Unmarshal(data, policy); err != nil {
+		return nil, fmt.Errorf("failed to parse topic policy: %w", err)
+	}
+	names := make(map[string]bool)
+	for i := range policy.Rules {
+		rule := &policy.Rules[i]
+		if rule.Name == "" {
+			return nil, fmt.Errorf("topic policy rule %d has no name", i)
+		}
+		if names[rule.Name] {
+			return nil, fmt.Errorf("topic policy rule %s is duplicated", rule.Name)
+		}
+		names[rule.Name] = true
+		var err error
+		if rule.topics, err = regexp.Compile(rule.Topics); err != nil {
+			return nil, fmt.Errorf("incorrect topics expression of topic policy rule %s: %w", rule.Name, err)
+		}
+		if rule.ExcludeTopics != "" {
+			if rule.excludeTopics, err = regexp.Compile(rule.ExcludeTopics); err != nil {
+				return nil, fmt.Errorf("incorrect exclude topics expression of topic policy rule %s: %w", rule.Name, err)
+			}
+		}
+	}
+	return policy, nil
+}
+
+func (r TopicPolicyRule) matches(topic kafka.Topic) bool {
+	if topic.Internal && !r.IncludeInternal {
+		return false
+	}
+	return r.topics.MatchString(topic.Name) && (r.excludeTopics == nil || !r.excludeTopics.MatchString(topic.Name))
+}
+
+// Evaluate checks rules of policy for each topic from metadata with its effective configs
+func (p *TopicPolicy) Evaluate(metadata kafka.MetadataResponse, topicConfigs kafka.DescribeConfigsResponse) []PolicyViolation {
+	configs := make(map[string]map[string]string)
+	for _, resource := range topicConfigs.Resources {
+		configs[resource.ResourceName] = make(map[string]string)
+		for _, entry := range resource.ConfigEntries {
+			configs[resource.ResourceName][entry.ConfigName] = entry.ConfigValue
+		}
+	}
+	var violations []PolicyViolation
+	for _, rule := range p.Rules {
+		for _, topic := range metadata.Topics {
+			if !rule.matches(topic) {
+				continue
+			}
+			for _, check := range rule.check(topic, configs[topic.Name]) {
+				violations = append(violations, PolicyViolation{Rule: rule.Name, Topic: topic.Name, Check: check})
+			}
+		}
+	}
+	return violations
+}
+
+// check returns names of failed checks for topic
+func (r TopicPolicyRule) check(topic kafka.Topic, configs map[string]string) []string {
+	var failed []string
+	replicationFactor := 0
+	if len(topic.Partitions) > 0 {
+		replicationFactor = len(topic.Partitions[0].Replicas)
+	}
+	if r.MinReplicationFactor > 0 && replicationFactor < r.MinReplicationFactor {
+		failed = append(failed, "replication_factor")
+	}
+	if r.MaxPartitions > 0 && len(topic.Partitions) > r.MaxPartitions {
+		failed = append(failed, "partitions")
+	}
+	if r.MinInsyncReplicas > 0 {
+		if value, err := strconv.Atoi(configs[minInsyncReplicasConfig]); err != nil || value < r.MinInsyncReplicas {
+			failed = append(failed, minInsyncReplicasConfig)
+		}
+	}
+	for _, name := range sortedKeys(r.Configs) {
+		if !containsConfigValue(configs[name], r.Configs[name]) {
+			failed = append(failed, name)
+		}
+	}
+	for _, name := range sortedKeys(r.Ranges) {
+		if !r.Ranges[name].contains(configs[name]) {
+			failed = append(failed, name)
+		}
+	}
+	return failed
+}
+
+func containsConfigValue(actual string, required string) bool {
+	for _, value := range strings.Split(actual, ",") {
+		if strings.TrimSpace(value) == required {
+			return true
+		}
+	}
+	return false
+}
+
+func (r ConfigRange) contains(value string) bool {
+	number, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return false
+	}
+	if number == unlimitedConfigValue {
+		return r.Max == nil
+	}
+	return (r.Min == nil || number >= *r.Min) && (r.Max == nil || number <= *r.Max)
+}
+
+// CalcTopicPolicyMetrics returns violation of each check for topic and the number of violations for each rule
+func CalcTopicPolicyMetrics(policy *TopicPolicy, violations []PolicyViolation) []Metric {
+	metrics := make([]Metric, 0)
+	counts := make(map[string]int64)
+	for _, violation := range violations {
+		counts[violation.Rule]++
+		metrics = append(metrics, Metric{
+			Measurement: topicPolicyMeasurement,
+			Field:       "violation",
+			Labels: []Label{
+				{Name: "rule", Value: violation.Rule},
+				{Name: "topic", Value: violation.Topic},
+				{Name: "check", Value: violation.Check},
+			},
+			Value:   1,
+			Integer: true,
+			Help:    "Topic violates the check of topic policy rule.",
+		})
+	}
+	for _, rule := range policy.Rules {
+		metrics = append(metrics, Metric{
+			Measurement: topicPolicyMeasurement,
+			Field:       "violations",
+			Labels:      []Label{{Name: "rule", Value: rule.Name}},
+			Value:       counts[rule.Name],
+			Integer:     true,
+			Help:        "Number of failed checks of topic policy rule.",
+		})
+	}
+	return metrics
+}
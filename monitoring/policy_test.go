@@ -0,0 +1,183 @@
+//Copyright 2024-2025 NetCracker Technology Corporation
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	kafka "github.com/segmentio/kafka-go"
+)
+
+const testPolicy = `
+rules:
+  - name: replication
+    excludeTopics: ^test-
+    minReplicationFactor: 3
+    minInsyncReplicas: 2
+  - name: changelog
+    topics: _changelog$
+    configs:
+      cleanup.policy: compact
+  - name: retention
+    maxPartitions: 2
+    ranges:
+      retention.ms:
+        min: 3600000
+        max: 604800000
+`
+
+func TestTopicPolicyEvaluate(t *testing.T) {
+	policy, err := ParseTopicPolicy([]byte(testPolicy))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name       string
+		topic      kafka.Topic
+		configs    map[string]string
+		violations []PolicyViolation
+	}{
+		{
+			name: "compliant_topic",
+			topic: kafka.Topic{Name: "orders", Partitions: []kafka.Partition{
+				partition(0, 1, []int{1, 2, 3}, []int{1, 2, 3}),
+			}},
+			configs: map[string]string{minInsyncReplicasConfig: "2", "retention.ms": "86400000"},
+		},
+		{
+			name: "excluded_topic",
+			topic: kafka.Topic{Name: "test-orders", Partitions: []kafka.Partition{
+				partition(0, 1, []int{1}, []int{1}),
+			}},
+			configs: map[string]string{minInsyncReplicasConfig: "1", "retention.ms": "86400000"},
+		},
+		{
+			name:    "internal_topic",
+			topic:   kafka.Topic{Name: "__consumer_offsets", Internal: true},
+			configs: map[string]string{},
+		},
+		{
+			name: "low_replication",
+			topic: kafka.Topic{Name: "orders", Partitions: []kafka.Partition{
+				partition(0, 1, []int{1, 2}, []int{1, 2}),
+			}},
+			configs: map[string]string{minInsyncReplicasConfig: "1", "retention.ms": "86400000"},
+			violations: []PolicyViolation{
+				{Rule: "replication", Topic: "orders", Check: "replication_factor"},
+				{Rule: "replication", Topic: "orders", Check: minInsyncReplicasConfig},
+			},
+		},
+		{
+			name: "changelog_compact_and_delete",
+			topic: kafka.Topic{Name: "app_changelog", Partitions: []kafka.Partition{
+				partition(0, 1, []int{1, 2, 3}, []int{1, 2, 3}),
+			}},
+			configs: map[string]string{minInsyncReplicasConfig: "2", "cleanup.policy": "compact,delete", "retention.ms": "86400000"},
+		},
+		{
+			name: "changelog_not_compacted",
+			topic: kafka.Topic{Name: "app_changelog", Partitions: []kafka.Partition{
+				partition(0, 1, []int{1, 2, 3}, []int{1, 2, 3}),
+			}},
+			configs: map[string]string{minInsyncReplicasConfig: "2", "cleanup.policy": "delete", "retention.ms": "86400000"},
+			violations: []PolicyViolation{
+				{Rule: "changelog", Topic: "app_changelog", Check: "cleanup.policy"},
+			},
+		},
+		{
+			name: "unlimited_retention_and_too_many_partitions",
+			topic: kafka.Topic{Name: "orders", Partitions: []kafka.Partition{
+				partition(0, 1, []int{1, 2, 3}, []int{1, 2, 3}),
+				partition(1, 2, []int{2, 3, 1}, []int{1, 2, 3}),
+				partition(2, 3, []int{3, 1, 2}, []int{1, 2, 3}),
+			}},
+			configs: map[string]string{minInsyncReplicasConfig: "2", "retention.ms": "-1"},
+			violations: []PolicyViolation{
+				{Rule: "retention", Topic: "orders", Check: "partitions"},
+				{Rule: "retention", Topic: "orders", Check: "retention.ms"},
+			},
+		},
+		{
+			name: "short_retention",
+			topic: kafka.Topic{Name: "orders", Partitions: []kafka.Partition{
+				partition(0, 1, []int{1, 2, 3}, []int{1, 2, 3}),
+			}},
+			configs: map[string]string{minInsyncReplicasConfig: "2", "retention.ms": "60000"},
+			violations: []PolicyViolation{
+				{Rule: "retention", Topic: "orders", Check: "retention.ms"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metadata := kafka.MetadataResponse{Topics: []kafka.Topic{tt.topic}}
+			topicConfigs := kafka.DescribeConfigsResponse{
+				Resources: []kafka.DescribeConfigResponseResource{configs(tt.topic.Name, tt.configs)},
+			}
+			violations := policy.Evaluate(metadata, topicConfigs)
+			if !reflect.DeepEqual(violations, tt.violations) {
+				t.Errorf("expected violations %v, got %v", tt.violations, violations)
+			}
+		})
+	}
+}
+
+func TestParseTopicPolicyErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy string
+	}{
+		{name: "no_name", policy: "rules:\n  - minReplicationFactor: 3\n"},
+		{name: "duplicated_name", policy: "rules:\n  - name: rule\n  - name: rule\n"},
+		{name: "incorrect_expression", policy: "rules:\n  - name: rule\n    topics: '('\n"},
+		{name: "incorrect_yaml", policy: "rules: {"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseTopicPolicy([]byte(tt.policy)); err == nil {
+				t.Errorf("expected error for policy %q", tt.policy)
+			}
+		})
+	}
+}
+
+func TestCalcTopicPolicyMetrics(t *testing.T) {
+	policy, err := ParseTopicPolicy([]byte(testPolicy))
+	if err != nil {
+		t.Fatal(err)
+	}
+	violations := []PolicyViolation{
+		{Rule: "replication", Topic: "orders", Check: "replication_factor"},
+		{Rule: "replication", Topic: "payments", Check: "replication_factor"},
+	}
+	counts := make(map[string]int64)
+	violationMetrics := 0
+	for _, metric := range CalcTopicPolicyMetrics(policy, violations) {
+		switch metric.Field {
+		case "violation":
+			violationMetrics++
+		case "violations":
+			counts[metric.Labels[0].Value] = metric.Value
+		}
+	}
+	if violationMetrics != 2 {
+		t.Errorf("expected 2 violation metrics, got %d", violationMetrics)
+	}
+	expected := map[string]int64{"replication": 2, "changelog": 0, "retention": 0}
+	if !reflect.DeepEqual(counts, expected) {
+		t.Errorf("expected violation counts %v, got %v", expected, counts)
+	}
+}
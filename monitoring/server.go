@@ -0,0 +1,70 @@
+//Copyright 2024-2025 NetCracker Technology Corporation
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const scrapeTimeout = 30 * time.Second
+
+// NewMetricsHandler returns HTTP handler which serves metrics in Prometheus or OpenMetrics format
+// depending on Accept header of scrape request
+func NewMetricsHandler(collector *Collector) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), scrapeTimeout)
+		defer cancel()
+		metrics, err := collector.Collect(ctx)
+		if err != nil {
+			log.Printf("Failed to collect metrics: %s", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		openMetrics := strings.Contains(r.Header.Get("Accept"), "application/openmetrics-text")
+		body := new(bytes.Buffer)
+		if err = EncodePrometheus(body, metrics, openMetrics); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		contentType := prometheusContentType
+		if openMetrics {
+			contentType = openMetricsContentType
+		}
+		w.Header().Set("Content-Type", contentType)
+		_, _ = w.Write(body.Bytes())
+	})
+}
+
+// serveMetrics runs HTTP server with /metrics endpoint until it fails
+func serveMetrics(collector *Collector, address string) error {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", NewMetricsHandler(collector))
+	server := &http.Server{
+		Addr:              address,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	log.Printf("Serving metrics on %s/metrics", address)
+	if err := server.ListenAndServe(); err != nil {
+		return fmt.Errorf("metrics server is stopped: %w", err)
+	}
+	return nil
+}
@@ -19,20 +19,40 @@ import (
 )
 
 type Config struct {
-	TopicRegex           string `json:"topic-regex"`
+	TopicRegex string `json:"topic-regex"`
+	// Format - Format of messages in topics which match the regular expression. The default format is protobuf.
+	// +kubebuilder:validation:Enum=protobuf;avro;json-schema
+	Format               string `json:"format,omitempty"`
 	KeyType              string `json:"key-type,omitempty"`
-	MessageType          string `json:"message-type"`
-	DescriptorFileBase64 string `json:"descriptor-file-base64"`
+	MessageType          string `json:"message-type,omitempty"`
+	DescriptorFileBase64 string `json:"descriptor-file-base64,omitempty"`
+	// KeySchemaFileBase64 - Avro or JSON schema of message keys encoded to Base64 format.
+	KeySchemaFileBase64 string `json:"key-schema-file-base64,omitempty"`
+	// ValueSchemaFileBase64 - Avro or JSON schema of message values encoded to Base64 format.
+	ValueSchemaFileBase64 string `json:"value-schema-file-base64,omitempty"`
+}
+
+// Access - Binding of LDAP group to AKHQ role which is limited to topics and consumer groups
+// with the namespace of the custom resource as a prefix.
+type Access struct {
+	LdapGroup string `json:"ldap-group"`
+	// Role - AKHQ role of the LDAP group. Reader can only view topics, their data and consumer groups,
+	// admin can also create, update and delete them.
+	// +kubebuilder:validation:Enum=reader;admin
+	Role string `json:"role"`
 }
 
 type AkhqConfigSpec struct {
-	Configs []Config `json:"configs"`
+	Configs []Config `json:"configs,omitempty"`
+	Access  []Access `json:"access,omitempty"`
 }
 
 type AkhqConfigStatus struct {
-	IsInvalid   bool   `json:"isInvalid"`
-	IsProcessed bool   `json:"isProcessed,omitempty"`
-	Problems    string `json:"problemConfigs,omitempty"`
+	IsInvalid   bool `json:"isInvalid"`
+	IsProcessed bool `json:"isProcessed,omitempty"`
+	// Problems - All validation problems of the CR if it is invalid, otherwise warnings about
+	// regular expressions which overlap with other CRs. The items are separated by "; ".
+	Problems string `json:"problemConfigs,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -50,6 +50,7 @@ type KafkaSpec struct {
 	ZookeeperSetACL         *bool                   `json:"zookeeperSetACL,omitempty"`
 	ExternalHostNames       []string                `json:"externalHostNames,omitempty"`
 	ExternalPorts           []int                   `json:"externalPorts,omitempty"`
+	ExternalAccess          *ExternalAccess         `json:"externalAccess,omitempty"`
 	EnvironmentVariables    []string                `json:"environmentVariables,omitempty"`
 	RollbackTimeout         *int32                  `json:"rollbackTimeout,omitempty"`
 	HealthCheckTimeout      *int32                  `json:"healthCheckTimeout,omitempty"`
@@ -71,6 +72,7 @@ type KafkaSpec struct {
 	MigrationController     MigrationController     `json:"migrationController,omitempty"`
 	LivenessProbe           *ProbeTimingConfig      `json:"livenessProbe,omitempty"`
 	ReadinessProbe          *ProbeTimingConfig      `json:"readinessProbe,omitempty"`
+	Config                  map[string]string       `json:"config,omitempty"`
 }
 
 // ProbeTimingConfig defines reusable timing/threshold fields for health probes.
@@ -124,11 +126,33 @@ type OAuth struct {
 
 // Ssl defines Ssl Kafka settings
 type Ssl struct {
-	Enabled                 bool     `json:"enabled"`
-	SecretName              string   `json:"secretName,omitempty"`
-	CipherSuites            []string `json:"cipherSuites,omitempty"`
-	EnableTwoWaySsl         bool     `json:"enableTwoWaySsl,omitempty"`
-	AllowNonencryptedAccess bool     `json:"allowNonencryptedAccess,omitempty"`
+	Enabled                 bool         `json:"enabled"`
+	SecretName              string       `json:"secretName,omitempty"`
+	CipherSuites            []string     `json:"cipherSuites,omitempty"`
+	EnableTwoWaySsl         bool         `json:"enableTwoWaySsl,omitempty"`
+	AllowNonencryptedAccess bool         `json:"allowNonencryptedAccess,omitempty"`
+	CertManager             *CertManager `json:"certManager,omitempty"`
+}
+
+// CertManager defines cert-manager settings for certificates which are issued by operator
+type CertManager struct {
+	Enabled               bool     `json:"enabled"`
+	IssuerName            string   `json:"issuerName,omitempty"`
+	IssuerKind            string   `json:"issuerKind,omitempty"`
+	DurationDays          int      `json:"durationDays,omitempty"`
+	RenewBeforeDays       int      `json:"renewBeforeDays,omitempty"`
+	AdditionalDnsNames    []string `json:"additionalDnsNames,omitempty"`
+	AdditionalIpAddresses []string `json:"additionalIpAddresses,omitempty"`
+}
+
+// ExternalAccess defines per-broker Services or Ingresses which operator creates for access from outside Kubernetes
+type ExternalAccess struct {
+	// Type - Can be "LoadBalancer", "NodePort" or "Ingress".
+	Type                  string            `json:"type"`
+	Annotations           map[string]string `json:"annotations,omitempty"`
+	ExternalTrafficPolicy string            `json:"externalTrafficPolicy,omitempty"`
+	IngressClassName      string            `json:"ingressClassName,omitempty"`
+	BootstrapHostName     string            `json:"bootstrapHostName,omitempty"`
 }
 
 // Storage defines volumes of Kafka
@@ -144,6 +168,29 @@ type KafkaBrokerStatus struct {
 	Brokers []string `json:"brokers,omitempty"`
 }
 
+// ExternalAccessStatus defines addresses which brokers advertise for external clients
+type ExternalAccessStatus struct {
+	BootstrapServers string                  `json:"bootstrapServers,omitempty"`
+	Brokers          []ExternalBrokerAddress `json:"brokers,omitempty"`
+}
+
+// ExternalBrokerAddress defines host and port which broker advertises for external clients
+type ExternalBrokerAddress struct {
+	Id   int    `json:"id"`
+	Host string `json:"host"`
+	Port int32  `json:"port"`
+}
+
+// BrokerConfigStatus defines how broker configs from spec are applied
+type BrokerConfigStatus struct {
+	// DynamicConfigs - Configs which are applied to running brokers without restart.
+	DynamicConfigs []string `json:"dynamicConfigs,omitempty"`
+	// StaticConfigs - Configs which are applied to brokers by restart.
+	StaticConfigs []string `json:"staticConfigs,omitempty"`
+	// RestartRequiredConfigs - Static configs which are changed, but brokers are not restarted with them yet.
+	RestartRequiredConfigs []string `json:"restartRequiredConfigs,omitempty"`
+}
+
 type PartitionsReassignmentStatus struct {
 	Status string `json:"status,omitempty"`
 }
@@ -158,6 +205,8 @@ type KafkaStatus struct {
 	PartitionsReassignmentStatus PartitionsReassignmentStatus `json:"partitionsReassignmentStatus,omitempty"`
 	Conditions                   []StatusCondition            `json:"conditions,omitempty"`
 	KraftMigrationStatus         KraftMigrationStatus         `json:"kraftMigrationStatus,omitempty"`
+	ExternalAccessStatus         *ExternalAccessStatus        `json:"externalAccessStatus,omitempty"`
+	BrokerConfigStatus           *BrokerConfigStatus          `json:"brokerConfigStatus,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -0,0 +1,85 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// KafkaBackupSpec defines the desired state of KafkaBackup
+type KafkaBackupSpec struct {
+	// KafkaServiceName - Name of KafkaService custom resource from the same namespace which Backup Daemon performs backup.
+	KafkaServiceName string `json:"kafkaServiceName"`
+	// Topics - List of topics to back up. All topics are backed up if neither topics nor topic regex is specified.
+	Topics []string `json:"topics,omitempty"`
+	// TopicRegex - Regular expression of topic names to back up.
+	TopicRegex string `json:"topicRegex,omitempty"`
+	// IncludeAcl - Whether ACLs are stored in addition to topic configurations.
+	IncludeAcl bool `json:"includeAcl,omitempty"`
+	// AllowEviction - Whether the backup can be removed by Backup Daemon eviction policy.
+	AllowEviction *bool `json:"allowEviction,omitempty"`
+	// TimeoutSeconds - Time to wait for Backup Daemon to complete the backup before marking it failed.
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+	// Mode - What is backed up. Backup Daemon backs up topics in `data` mode. Operator stores metadata of Kafka cluster
+	// (topics, ACLs, SCRAM users and consumer group offsets) to config map in `metadata` mode,
+	// topics, topic regex, ACLs inclusion and eviction settings are not used in this mode.
+	// +kubebuilder:validation:Enum=data;metadata
+	Mode string `json:"mode,omitempty"`
+}
+
+// KafkaBackupStatus defines the observed state of KafkaBackup
+type KafkaBackupStatus struct {
+	// +kubebuilder:validation:Enum=running;successful;failed
+	Phase string `json:"phase,omitempty"`
+	// VaultId - Identifier of the vault where Backup Daemon stores the backup.
+	VaultId string `json:"vaultId,omitempty"`
+	// RequestTime - Time when backup was requested from Backup Daemon. The backup is not requested again
+	// if the vault is not known after the request, because Backup Daemon may have already started it.
+	RequestTime string `json:"requestTime,omitempty"`
+	// MetadataConfigMap - Name of the first config map which contains metadata archive of `metadata` mode backup.
+	// Other parts of the archive are stored to config maps with `-<part>` suffix.
+	MetadataConfigMap  string `json:"metadataConfigMap,omitempty"`
+	Message            string `json:"message,omitempty"`
+	StartTime          string `json:"startTime,omitempty"`
+	CompletionTime     string `json:"completionTime,omitempty"`
+	Duration           string `json:"duration,omitempty"`
+	ObservedGeneration int64  `json:"observedGeneration,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+
+// KafkaBackup is the Schema for the kafkabackups API
+type KafkaBackup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   KafkaBackupSpec   `json:"spec,omitempty"`
+	Status KafkaBackupStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// KafkaBackupList contains a list of KafkaBackup
+type KafkaBackupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KafkaBackup `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KafkaBackup{}, &KafkaBackupList{})
+}
@@ -0,0 +1,97 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// KafkaBackupScheduleSpec defines the desired state of KafkaBackupSchedule
+type KafkaBackupScheduleSpec struct {
+	// KafkaServiceName - Name of KafkaService custom resource from the same namespace which Backup Daemon performs backups.
+	KafkaServiceName string `json:"kafkaServiceName"`
+	// Schedule - Cron expression of backup runs, for example, "0 0 * * *" or "@daily".
+	Schedule string `json:"schedule"`
+	// Suspend - Whether new backups are not started by the schedule.
+	Suspend bool `json:"suspend,omitempty"`
+	// Topics - List of topics to back up. All topics are backed up if neither topics nor topic regex is specified.
+	Topics []string `json:"topics,omitempty"`
+	// TopicRegex - Regular expression of topic names to back up.
+	TopicRegex string `json:"topicRegex,omitempty"`
+	// IncludeAcl - Whether ACLs are stored in addition to topic configurations.
+	IncludeAcl bool `json:"includeAcl,omitempty"`
+	// Retention - Rules of scheduled backups eviction.
+	Retention KafkaBackupRetention `json:"retention,omitempty"`
+	// TimeoutSeconds - Time to wait for Backup Daemon to complete each backup before marking it failed.
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+}
+
+// KafkaBackupRetention defines which scheduled backups are kept, the last successful backup is never evicted
+type KafkaBackupRetention struct {
+	// MaxCount - Number of the most recent successful backups to keep.
+	MaxCount *int32 `json:"maxCount,omitempty"`
+	// MaxAge - Age after which backups are evicted, for example, "7d" or "12h".
+	MaxAge string `json:"maxAge,omitempty"`
+}
+
+// KafkaBackupScheduleStatus defines the observed state of KafkaBackupSchedule
+type KafkaBackupScheduleStatus struct {
+	Message          string `json:"message,omitempty"`
+	LastScheduleTime string `json:"lastScheduleTime,omitempty"`
+	// RequestTime - Time when scheduled backup was requested from Backup Daemon. The backup is not requested again
+	// if its vault is not known after the request, because Backup Daemon may have already started it.
+	RequestTime          string `json:"requestTime,omitempty"`
+	NextScheduleTime     string `json:"nextScheduleTime,omitempty"`
+	LastSuccessfulBackup string `json:"lastSuccessfulBackup,omitempty"`
+	LastSuccessfulTime   string `json:"lastSuccessfulTime,omitempty"`
+	// Backups - Scheduled backups which are not evicted yet.
+	Backups            []KafkaScheduledBackup `json:"backups,omitempty"`
+	ObservedGeneration int64                  `json:"observedGeneration,omitempty"`
+}
+
+// KafkaScheduledBackup contains description of one backup started by schedule
+type KafkaScheduledBackup struct {
+	VaultId string `json:"vaultId"`
+	// +kubebuilder:validation:Enum=running;successful;failed
+	Phase          string `json:"phase"`
+	StartTime      string `json:"startTime"`
+	CompletionTime string `json:"completionTime,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+
+// KafkaBackupSchedule is the Schema for the kafkabackupschedules API
+type KafkaBackupSchedule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   KafkaBackupScheduleSpec   `json:"spec,omitempty"`
+	Status KafkaBackupScheduleStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// KafkaBackupScheduleList contains a list of KafkaBackupSchedule
+type KafkaBackupScheduleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KafkaBackupSchedule `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KafkaBackupSchedule{}, &KafkaBackupScheduleList{})
+}
@@ -0,0 +1,81 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// KafkaDisasterRecoverySpec defines the desired state of KafkaDisasterRecovery
+type KafkaDisasterRecoverySpec struct {
+	// KafkaServiceName - Name of KafkaService custom resource from the same namespace which switchover is managed.
+	KafkaServiceName string `json:"kafkaServiceName"`
+	// +kubebuilder:validation:Enum=active;standby;disable
+	Mode   string `json:"mode"`
+	NoWait bool   `json:"noWait,omitempty"`
+	// ReplicationCheckTimeoutSeconds - Time to wait for Kafka Mirror Maker to replicate all messages before switching to active mode.
+	ReplicationCheckTimeoutSeconds *int32 `json:"replicationCheckTimeoutSeconds,omitempty"`
+	// BackupTimeoutSeconds - Time to wait for each Backup Daemon step (scaling, backup and restore) during switchover.
+	BackupTimeoutSeconds *int32 `json:"backupTimeoutSeconds,omitempty"`
+	// HistoryLimit - Number of finished switchovers kept in the status.
+	HistoryLimit *int32 `json:"historyLimit,omitempty"`
+}
+
+// KafkaDisasterRecoveryStatus defines the observed state of KafkaDisasterRecovery
+type KafkaDisasterRecoveryStatus struct {
+	Mode string `json:"mode,omitempty"`
+	// +kubebuilder:validation:Enum=running;done;failed
+	Status             string                            `json:"status,omitempty"`
+	Message            string                            `json:"message,omitempty"`
+	ObservedGeneration int64                             `json:"observedGeneration,omitempty"`
+	History            []KafkaDisasterRecoverySwitchover `json:"history,omitempty"`
+}
+
+// KafkaDisasterRecoverySwitchover contains description of one switchover operation
+type KafkaDisasterRecoverySwitchover struct {
+	FromMode       string `json:"fromMode,omitempty"`
+	ToMode         string `json:"toMode"`
+	NoWait         bool   `json:"noWait,omitempty"`
+	Status         string `json:"status"`
+	Message        string `json:"message,omitempty"`
+	StartTime      string `json:"startTime"`
+	CompletionTime string `json:"completionTime,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+
+// KafkaDisasterRecovery is the Schema for the kafkadisasterrecoveries API
+type KafkaDisasterRecovery struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   KafkaDisasterRecoverySpec   `json:"spec,omitempty"`
+	Status KafkaDisasterRecoveryStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// KafkaDisasterRecoveryList contains a list of KafkaDisasterRecovery
+type KafkaDisasterRecoveryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KafkaDisasterRecovery `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KafkaDisasterRecovery{}, &KafkaDisasterRecoveryList{})
+}
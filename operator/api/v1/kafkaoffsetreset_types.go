@@ -0,0 +1,80 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// KafkaOffsetResetSpec defines the desired state of KafkaOffsetReset
+type KafkaOffsetResetSpec struct {
+	// Group - Name of consumer group which offsets are reset. The group name must start with the namespace
+	// of the resource unless the resource is created in the operator namespace.
+	Group string `json:"group"`
+	// Topics - List of topics which offsets are reset for all partitions.
+	// +kubebuilder:validation:MinItems=1
+	Topics []string `json:"topics"`
+	// ResetTo - Position to reset offsets to: the earliest or the latest offset, or the offset of the first
+	// message with timestamp equal to or greater than the specified timestamp.
+	// +kubebuilder:validation:Enum=earliest;latest;timestamp
+	ResetTo string `json:"resetTo"`
+	// Timestamp - Time in RFC3339 format to reset offsets to, required if resetTo is timestamp.
+	Timestamp string `json:"timestamp,omitempty"`
+}
+
+// KafkaOffsetResetStatus defines the observed state of KafkaOffsetReset
+type KafkaOffsetResetStatus struct {
+	// +kubebuilder:validation:Enum=successful;failed
+	Phase              string                 `json:"phase,omitempty"`
+	Message            string                 `json:"message,omitempty"`
+	Offsets            []KafkaPartitionOffset `json:"offsets,omitempty"`
+	CompletionTime     string                 `json:"completionTime,omitempty"`
+	ObservedGeneration int64                  `json:"observedGeneration,omitempty"`
+}
+
+// KafkaPartitionOffset describes committed offset of consumer group for topic partition before and after reset
+type KafkaPartitionOffset struct {
+	Topic     string `json:"topic"`
+	Partition int32  `json:"partition"`
+	// Before - Committed offset before reset, it is absent if the group has not committed offset for the partition.
+	Before *int64 `json:"before,omitempty"`
+	After  int64  `json:"after"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+
+// KafkaOffsetReset is the Schema for the kafkaoffsetresets API
+type KafkaOffsetReset struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   KafkaOffsetResetSpec   `json:"spec,omitempty"`
+	Status KafkaOffsetResetStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// KafkaOffsetResetList contains a list of KafkaOffsetReset
+type KafkaOffsetResetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KafkaOffsetReset `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KafkaOffsetReset{}, &KafkaOffsetResetList{})
+}
@@ -0,0 +1,86 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// KafkaRestoreSpec defines the desired state of KafkaRestore
+type KafkaRestoreSpec struct {
+	// KafkaServiceName - Name of KafkaService custom resource from the same namespace which Backup Daemon performs restore.
+	KafkaServiceName string `json:"kafkaServiceName"`
+	// Vault - Identifier of the vault to restore. Either vault or backup name must be specified.
+	Vault string `json:"vault,omitempty"`
+	// BackupName - Name of successful KafkaBackup custom resource from the same namespace which vault is restored.
+	BackupName string `json:"backupName,omitempty"`
+	// Topics - List of topics to restore. All topics from the vault are restored if neither topics nor topic regex is specified.
+	Topics []string `json:"topics,omitempty"`
+	// TopicRegex - Regular expression of topic names to restore.
+	TopicRegex string `json:"topicRegex,omitempty"`
+	// IncludeAcl - Whether ACLs are restored in addition to topic configurations.
+	IncludeAcl bool `json:"includeAcl,omitempty"`
+	// TimeoutSeconds - Time to wait for Backup Daemon to complete the restore before marking it failed.
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+	// Mode - What is restored. Backup Daemon restores the vault in `data` mode. Operator restores metadata
+	// from `metadata` mode KafkaBackup specified by backup name in `metadata` mode.
+	// +kubebuilder:validation:Enum=data;metadata
+	Mode string `json:"mode,omitempty"`
+	// DryRun - Whether the differences between metadata backup and Kafka cluster are only reported in `metadata` mode.
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// KafkaRestoreStatus defines the observed state of KafkaRestore
+type KafkaRestoreStatus struct {
+	// +kubebuilder:validation:Enum=running;successful;failed
+	Phase string `json:"phase,omitempty"`
+	// VaultId - Identifier of the restored vault.
+	VaultId string `json:"vaultId,omitempty"`
+	// TaskId - Identifier of Backup Daemon restore task.
+	TaskId string `json:"taskId,omitempty"`
+	// Changes - Differences between metadata backup and Kafka cluster found by `metadata` mode restore.
+	Changes            []string `json:"changes,omitempty"`
+	Message            string   `json:"message,omitempty"`
+	StartTime          string   `json:"startTime,omitempty"`
+	CompletionTime     string   `json:"completionTime,omitempty"`
+	Duration           string   `json:"duration,omitempty"`
+	ObservedGeneration int64    `json:"observedGeneration,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+
+// KafkaRestore is the Schema for the kafkarestores API
+type KafkaRestore struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   KafkaRestoreSpec   `json:"spec,omitempty"`
+	Status KafkaRestoreStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// KafkaRestoreList contains a list of KafkaRestore
+type KafkaRestoreList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KafkaRestore `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KafkaRestore{}, &KafkaRestoreList{})
+}
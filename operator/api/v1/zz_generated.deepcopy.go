@@ -25,27 +25,622 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Access) DeepCopyInto(out *Access) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Access.
+func (in *Access) DeepCopy() *Access {
+	if in == nil {
+		return nil
+	}
+	out := new(Access)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AkhqConfig) DeepCopyInto(out *AkhqConfig) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AkhqConfig.
+func (in *AkhqConfig) DeepCopy() *AkhqConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AkhqConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AkhqConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AkhqConfigList) DeepCopyInto(out *AkhqConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AkhqConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AkhqConfigList.
+func (in *AkhqConfigList) DeepCopy() *AkhqConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(AkhqConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AkhqConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AkhqConfigSpec) DeepCopyInto(out *AkhqConfigSpec) {
+	*out = *in
+	if in.Configs != nil {
+		in, out := &in.Configs, &out.Configs
+		*out = make([]Config, len(*in))
+		copy(*out, *in)
+	}
+	if in.Access != nil {
+		in, out := &in.Access, &out.Access
+		*out = make([]Access, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AkhqConfigSpec.
+func (in *AkhqConfigSpec) DeepCopy() *AkhqConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AkhqConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AkhqConfigStatus) DeepCopyInto(out *AkhqConfigStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AkhqConfigStatus.
+func (in *AkhqConfigStatus) DeepCopy() *AkhqConfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AkhqConfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Authentication) DeepCopyInto(out *Authentication) {
+	*out = *in
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(Secret)
+		**out = **in
+	}
+	if in.WatchSecret != nil {
+		in, out := &in.WatchSecret, &out.WatchSecret
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Authentication.
+func (in *Authentication) DeepCopy() *Authentication {
+	if in == nil {
+		return nil
+	}
+	out := new(Authentication)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthenticationStatus) DeepCopyInto(out *AuthenticationStatus) {
+	*out = *in
+	out.Password = in.Password
+	out.Username = in.Username
+	out.ConnectionUri = in.ConnectionUri
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthenticationStatus.
+func (in *AuthenticationStatus) DeepCopy() *AuthenticationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthenticationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Authorization) DeepCopyInto(out *Authorization) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Authorization.
+func (in *Authorization) DeepCopy() *Authorization {
+	if in == nil {
+		return nil
+	}
+	out := new(Authorization)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthorizationStatus) DeepCopyInto(out *AuthorizationStatus) {
+	*out = *in
+	if in.Acls != nil {
+		in, out := &in.Acls, &out.Acls
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthorizationStatus.
+func (in *AuthorizationStatus) DeepCopy() *AuthorizationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthorizationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BrokerConfigStatus) DeepCopyInto(out *BrokerConfigStatus) {
+	*out = *in
+	if in.DynamicConfigs != nil {
+		in, out := &in.DynamicConfigs, &out.DynamicConfigs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.StaticConfigs != nil {
+		in, out := &in.StaticConfigs, &out.StaticConfigs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RestartRequiredConfigs != nil {
+		in, out := &in.RestartRequiredConfigs, &out.RestartRequiredConfigs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrokerConfigStatus.
+func (in *BrokerConfigStatus) DeepCopy() *BrokerConfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BrokerConfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertManager) DeepCopyInto(out *CertManager) {
+	*out = *in
+	if in.AdditionalDnsNames != nil {
+		in, out := &in.AdditionalDnsNames, &out.AdditionalDnsNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AdditionalIpAddresses != nil {
+		in, out := &in.AdditionalIpAddresses, &out.AdditionalIpAddresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertManager.
+func (in *CertManager) DeepCopy() *CertManager {
+	if in == nil {
+		return nil
+	}
+	out := new(CertManager)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Config) DeepCopyInto(out *Config) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Config.
+func (in *Config) DeepCopy() *Config {
+	if in == nil {
+		return nil
+	}
+	out := new(Config)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalAccess) DeepCopyInto(out *ExternalAccess) {
+	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalAccess.
+func (in *ExternalAccess) DeepCopy() *ExternalAccess {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalAccess)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalAccessStatus) DeepCopyInto(out *ExternalAccessStatus) {
+	*out = *in
+	if in.Brokers != nil {
+		in, out := &in.Brokers, &out.Brokers
+		*out = make([]ExternalBrokerAddress, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalAccessStatus.
+func (in *ExternalAccessStatus) DeepCopy() *ExternalAccessStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalAccessStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalBrokerAddress) DeepCopyInto(out *ExternalBrokerAddress) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalBrokerAddress.
+func (in *ExternalBrokerAddress) DeepCopy() *ExternalBrokerAddress {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalBrokerAddress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Kafka) DeepCopyInto(out *Kafka) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Kafka.
+func (in *Kafka) DeepCopy() *Kafka {
+	if in == nil {
+		return nil
+	}
+	out := new(Kafka)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Kafka) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaBackup) DeepCopyInto(out *KafkaBackup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaBackup.
+func (in *KafkaBackup) DeepCopy() *KafkaBackup {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaBackup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KafkaBackup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaBackupList) DeepCopyInto(out *KafkaBackupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KafkaBackup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaBackupList.
+func (in *KafkaBackupList) DeepCopy() *KafkaBackupList {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaBackupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KafkaBackupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaBackupRetention) DeepCopyInto(out *KafkaBackupRetention) {
+	*out = *in
+	if in.MaxCount != nil {
+		in, out := &in.MaxCount, &out.MaxCount
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaBackupRetention.
+func (in *KafkaBackupRetention) DeepCopy() *KafkaBackupRetention {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaBackupRetention)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaBackupSchedule) DeepCopyInto(out *KafkaBackupSchedule) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaBackupSchedule.
+func (in *KafkaBackupSchedule) DeepCopy() *KafkaBackupSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaBackupSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KafkaBackupSchedule) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaBackupScheduleList) DeepCopyInto(out *KafkaBackupScheduleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KafkaBackupSchedule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaBackupScheduleList.
+func (in *KafkaBackupScheduleList) DeepCopy() *KafkaBackupScheduleList {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaBackupScheduleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KafkaBackupScheduleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaBackupScheduleSpec) DeepCopyInto(out *KafkaBackupScheduleSpec) {
+	*out = *in
+	if in.Topics != nil {
+		in, out := &in.Topics, &out.Topics
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Retention.DeepCopyInto(&out.Retention)
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaBackupScheduleSpec.
+func (in *KafkaBackupScheduleSpec) DeepCopy() *KafkaBackupScheduleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaBackupScheduleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaBackupScheduleStatus) DeepCopyInto(out *KafkaBackupScheduleStatus) {
+	*out = *in
+	if in.Backups != nil {
+		in, out := &in.Backups, &out.Backups
+		*out = make([]KafkaScheduledBackup, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaBackupScheduleStatus.
+func (in *KafkaBackupScheduleStatus) DeepCopy() *KafkaBackupScheduleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaBackupScheduleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaBackupSpec) DeepCopyInto(out *KafkaBackupSpec) {
+	*out = *in
+	if in.Topics != nil {
+		in, out := &in.Topics, &out.Topics
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowEviction != nil {
+		in, out := &in.AllowEviction, &out.AllowEviction
+		*out = new(bool)
+		**out = **in
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaBackupSpec.
+func (in *KafkaBackupSpec) DeepCopy() *KafkaBackupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaBackupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaBackupStatus) DeepCopyInto(out *KafkaBackupStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaBackupStatus.
+func (in *KafkaBackupStatus) DeepCopy() *KafkaBackupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaBackupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaBrokerStatus) DeepCopyInto(out *KafkaBrokerStatus) {
+	*out = *in
+	if in.Brokers != nil {
+		in, out := &in.Brokers, &out.Brokers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaBrokerStatus.
+func (in *KafkaBrokerStatus) DeepCopy() *KafkaBrokerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaBrokerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaDisasterRecovery) DeepCopyInto(out *KafkaDisasterRecovery) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AkhqConfig.
-func (in *AkhqConfig) DeepCopy() *AkhqConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaDisasterRecovery.
+func (in *KafkaDisasterRecovery) DeepCopy() *KafkaDisasterRecovery {
 	if in == nil {
 		return nil
 	}
-	out := new(AkhqConfig)
+	out := new(KafkaDisasterRecovery)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *AkhqConfig) DeepCopyObject() runtime.Object {
+func (in *KafkaDisasterRecovery) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -53,31 +648,31 @@ func (in *AkhqConfig) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AkhqConfigList) DeepCopyInto(out *AkhqConfigList) {
+func (in *KafkaDisasterRecoveryList) DeepCopyInto(out *KafkaDisasterRecoveryList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]AkhqConfig, len(*in))
+		*out = make([]KafkaDisasterRecovery, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AkhqConfigList.
-func (in *AkhqConfigList) DeepCopy() *AkhqConfigList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaDisasterRecoveryList.
+func (in *KafkaDisasterRecoveryList) DeepCopy() *KafkaDisasterRecoveryList {
 	if in == nil {
 		return nil
 	}
-	out := new(AkhqConfigList)
+	out := new(KafkaDisasterRecoveryList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *AkhqConfigList) DeepCopyObject() runtime.Object {
+func (in *KafkaDisasterRecoveryList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -85,135 +680,225 @@ func (in *AkhqConfigList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AkhqConfigSpec) DeepCopyInto(out *AkhqConfigSpec) {
+func (in *KafkaDisasterRecoverySpec) DeepCopyInto(out *KafkaDisasterRecoverySpec) {
 	*out = *in
-	if in.Configs != nil {
-		in, out := &in.Configs, &out.Configs
-		*out = make([]Config, len(*in))
-		copy(*out, *in)
+	if in.ReplicationCheckTimeoutSeconds != nil {
+		in, out := &in.ReplicationCheckTimeoutSeconds, &out.ReplicationCheckTimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.BackupTimeoutSeconds != nil {
+		in, out := &in.BackupTimeoutSeconds, &out.BackupTimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.HistoryLimit != nil {
+		in, out := &in.HistoryLimit, &out.HistoryLimit
+		*out = new(int32)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AkhqConfigSpec.
-func (in *AkhqConfigSpec) DeepCopy() *AkhqConfigSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaDisasterRecoverySpec.
+func (in *KafkaDisasterRecoverySpec) DeepCopy() *KafkaDisasterRecoverySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(AkhqConfigSpec)
+	out := new(KafkaDisasterRecoverySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AkhqConfigStatus) DeepCopyInto(out *AkhqConfigStatus) {
+func (in *KafkaDisasterRecoveryStatus) DeepCopyInto(out *KafkaDisasterRecoveryStatus) {
 	*out = *in
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]KafkaDisasterRecoverySwitchover, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AkhqConfigStatus.
-func (in *AkhqConfigStatus) DeepCopy() *AkhqConfigStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaDisasterRecoveryStatus.
+func (in *KafkaDisasterRecoveryStatus) DeepCopy() *KafkaDisasterRecoveryStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(AkhqConfigStatus)
+	out := new(KafkaDisasterRecoveryStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Authentication) DeepCopyInto(out *Authentication) {
+func (in *KafkaDisasterRecoverySwitchover) DeepCopyInto(out *KafkaDisasterRecoverySwitchover) {
 	*out = *in
-	if in.Secret != nil {
-		in, out := &in.Secret, &out.Secret
-		*out = new(Secret)
-		**out = **in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaDisasterRecoverySwitchover.
+func (in *KafkaDisasterRecoverySwitchover) DeepCopy() *KafkaDisasterRecoverySwitchover {
+	if in == nil {
+		return nil
 	}
-	if in.WatchSecret != nil {
-		in, out := &in.WatchSecret, &out.WatchSecret
-		*out = new(bool)
-		**out = **in
+	out := new(KafkaDisasterRecoverySwitchover)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaList) DeepCopyInto(out *KafkaList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Kafka, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Authentication.
-func (in *Authentication) DeepCopy() *Authentication {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaList.
+func (in *KafkaList) DeepCopy() *KafkaList {
 	if in == nil {
 		return nil
 	}
-	out := new(Authentication)
+	out := new(KafkaList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KafkaList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AuthenticationStatus) DeepCopyInto(out *AuthenticationStatus) {
+func (in *KafkaOffsetReset) DeepCopyInto(out *KafkaOffsetReset) {
 	*out = *in
-	out.Password = in.Password
-	out.Username = in.Username
-	out.ConnectionUri = in.ConnectionUri
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthenticationStatus.
-func (in *AuthenticationStatus) DeepCopy() *AuthenticationStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaOffsetReset.
+func (in *KafkaOffsetReset) DeepCopy() *KafkaOffsetReset {
 	if in == nil {
 		return nil
 	}
-	out := new(AuthenticationStatus)
+	out := new(KafkaOffsetReset)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KafkaOffsetReset) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Authorization) DeepCopyInto(out *Authorization) {
+func (in *KafkaOffsetResetList) DeepCopyInto(out *KafkaOffsetResetList) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KafkaOffsetReset, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Authorization.
-func (in *Authorization) DeepCopy() *Authorization {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaOffsetResetList.
+func (in *KafkaOffsetResetList) DeepCopy() *KafkaOffsetResetList {
 	if in == nil {
 		return nil
 	}
-	out := new(Authorization)
+	out := new(KafkaOffsetResetList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KafkaOffsetResetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AuthorizationStatus) DeepCopyInto(out *AuthorizationStatus) {
+func (in *KafkaOffsetResetSpec) DeepCopyInto(out *KafkaOffsetResetSpec) {
 	*out = *in
-	if in.Acls != nil {
-		in, out := &in.Acls, &out.Acls
+	if in.Topics != nil {
+		in, out := &in.Topics, &out.Topics
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthorizationStatus.
-func (in *AuthorizationStatus) DeepCopy() *AuthorizationStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaOffsetResetSpec.
+func (in *KafkaOffsetResetSpec) DeepCopy() *KafkaOffsetResetSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(AuthorizationStatus)
+	out := new(KafkaOffsetResetSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Config) DeepCopyInto(out *Config) {
+func (in *KafkaOffsetResetStatus) DeepCopyInto(out *KafkaOffsetResetStatus) {
 	*out = *in
+	if in.Offsets != nil {
+		in, out := &in.Offsets, &out.Offsets
+		*out = make([]KafkaPartitionOffset, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Config.
-func (in *Config) DeepCopy() *Config {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaOffsetResetStatus.
+func (in *KafkaOffsetResetStatus) DeepCopy() *KafkaOffsetResetStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(Config)
+	out := new(KafkaOffsetResetStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Kafka) DeepCopyInto(out *Kafka) {
+func (in *KafkaPartitionOffset) DeepCopyInto(out *KafkaPartitionOffset) {
+	*out = *in
+	if in.Before != nil {
+		in, out := &in.Before, &out.Before
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaPartitionOffset.
+func (in *KafkaPartitionOffset) DeepCopy() *KafkaPartitionOffset {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaPartitionOffset)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaRestore) DeepCopyInto(out *KafkaRestore) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -221,18 +906,18 @@ func (in *Kafka) DeepCopyInto(out *Kafka) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Kafka.
-func (in *Kafka) DeepCopy() *Kafka {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaRestore.
+func (in *KafkaRestore) DeepCopy() *KafkaRestore {
 	if in == nil {
 		return nil
 	}
-	out := new(Kafka)
+	out := new(KafkaRestore)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Kafka) DeepCopyObject() runtime.Object {
+func (in *KafkaRestore) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -240,55 +925,95 @@ func (in *Kafka) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KafkaBrokerStatus) DeepCopyInto(out *KafkaBrokerStatus) {
+func (in *KafkaRestoreList) DeepCopyInto(out *KafkaRestoreList) {
 	*out = *in
-	if in.Brokers != nil {
-		in, out := &in.Brokers, &out.Brokers
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KafkaRestore, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaRestoreList.
+func (in *KafkaRestoreList) DeepCopy() *KafkaRestoreList {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaRestoreList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KafkaRestoreList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaRestoreSpec) DeepCopyInto(out *KafkaRestoreSpec) {
+	*out = *in
+	if in.Topics != nil {
+		in, out := &in.Topics, &out.Topics
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaBrokerStatus.
-func (in *KafkaBrokerStatus) DeepCopy() *KafkaBrokerStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaRestoreSpec.
+func (in *KafkaRestoreSpec) DeepCopy() *KafkaRestoreSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(KafkaBrokerStatus)
+	out := new(KafkaRestoreSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KafkaList) DeepCopyInto(out *KafkaList) {
+func (in *KafkaRestoreStatus) DeepCopyInto(out *KafkaRestoreStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]Kafka, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Changes != nil {
+		in, out := &in.Changes, &out.Changes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaList.
-func (in *KafkaList) DeepCopy() *KafkaList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaRestoreStatus.
+func (in *KafkaRestoreStatus) DeepCopy() *KafkaRestoreStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(KafkaList)
+	out := new(KafkaRestoreStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KafkaList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaScheduledBackup) DeepCopyInto(out *KafkaScheduledBackup) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaScheduledBackup.
+func (in *KafkaScheduledBackup) DeepCopy() *KafkaScheduledBackup {
+	if in == nil {
+		return nil
 	}
-	return nil
+	out := new(KafkaScheduledBackup)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -343,6 +1068,11 @@ func (in *KafkaSpec) DeepCopyInto(out *KafkaSpec) {
 		*out = make([]int, len(*in))
 		copy(*out, *in)
 	}
+	if in.ExternalAccess != nil {
+		in, out := &in.ExternalAccess, &out.ExternalAccess
+		*out = new(ExternalAccess)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.EnvironmentVariables != nil {
 		in, out := &in.EnvironmentVariables, &out.EnvironmentVariables
 		*out = make([]string, len(*in))
@@ -384,6 +1114,13 @@ func (in *KafkaSpec) DeepCopyInto(out *KafkaSpec) {
 	}
 	out.Kraft = in.Kraft
 	in.MigrationController.DeepCopyInto(&out.MigrationController)
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaSpec.
@@ -407,6 +1144,16 @@ func (in *KafkaStatus) DeepCopyInto(out *KafkaStatus) {
 		copy(*out, *in)
 	}
 	out.KraftMigrationStatus = in.KraftMigrationStatus
+	if in.ExternalAccessStatus != nil {
+		in, out := &in.ExternalAccessStatus, &out.ExternalAccessStatus
+		*out = new(ExternalAccessStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BrokerConfigStatus != nil {
+		in, out := &in.BrokerConfigStatus, &out.BrokerConfigStatus
+		*out = new(BrokerConfigStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaStatus.
@@ -814,6 +1561,11 @@ func (in *Ssl) DeepCopyInto(out *Ssl) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.CertManager != nil {
+		in, out := &in.CertManager, &out.CertManager
+		*out = new(CertManager)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Ssl.
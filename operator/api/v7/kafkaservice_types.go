@@ -29,6 +29,16 @@ type Global struct {
 	KafkaSaslMechanism string            `json:"kafkaSaslMechanism,omitempty"`
 	KafkaSsl           KafkaSsl          `json:"kafkaSsl,omitempty"`
 	Kraft              Kraft             `json:"kraft,omitempty"`
+	CertManager        *CertManager      `json:"certManager,omitempty"`
+}
+
+// CertManager defines cert-manager settings for client certificates which are issued by operator
+type CertManager struct {
+	Enabled         bool   `json:"enabled"`
+	IssuerName      string `json:"issuerName,omitempty"`
+	IssuerKind      string `json:"issuerKind,omitempty"`
+	DurationDays    int    `json:"durationDays,omitempty"`
+	RenewBeforeDays int    `json:"renewBeforeDays,omitempty"`
 }
 
 // Kraft defines Kafka parameters for Kraft
@@ -113,6 +123,7 @@ type Akhq struct {
 	SchemaRegistryUrl    string                  `json:"schemaRegistryUrl,omitempty"`
 	SchemaRegistryType   string                  `json:"schemaRegistryType,omitempty"`
 	Ldap                 *LdapConfig             `json:"ldap,omitempty"`
+	Oidc                 *OidcConfig             `json:"oidc,omitempty"`
 }
 
 // Monitoring shows Kafka Monitoring configuration
@@ -135,6 +146,12 @@ type Monitoring struct {
 	BootstrapServers           string                  `json:"bootstrapServers,omitempty"`
 	KafkaEnableSsl             bool                    `json:"kafkaEnableSsl,omitempty"` // for backwards compatibility, may be removed in the future
 	KafkaTotalBrokerCount      int                     `json:"kafkaTotalBrokerCount"`
+	KafkaStorageSize           string                  `json:"kafkaStorageSize,omitempty"`
+	TopicPolicyConfigMap       string                  `json:"topicPolicyConfigMap,omitempty"`
+	AdditionalMetricsMode      string                  `json:"additionalMetricsMode,omitempty"`
+	ConsumerLagEnabled         bool                    `json:"consumerLagEnabled,omitempty"`
+	ConsumerGroupsInclude      string                  `json:"consumerGroupsInclude,omitempty"`
+	ConsumerGroupsExclude      string                  `json:"consumerGroupsExclude,omitempty"`
 	LagExporter                *LagExporter            `json:"lagExporter,omitempty"`
 	CustomLabels               map[string]string       `json:"customLabels,omitempty"`
 }
@@ -166,10 +183,62 @@ type MirrorMaker struct {
 	Transformation               *kmm.Transformation     `json:"transformation,omitempty"`
 	TasksMax                     *int32                  `json:"tasksMax,omitempty"`
 	InternalRestEnabled          *bool                   `json:"internalRestEnabled,omitempty"`
+	// RestartFailedTasks - Whether to restart failed replication tasks of Kafka Mirror Maker.
+	RestartFailedTasks bool          `json:"restartFailedTasks,omitempty"`
+	ActiveActive       *ActiveActive `json:"activeActive,omitempty"`
+	ClusterSync        *ClusterSync  `json:"clusterSync,omitempty"`
+	// Flows - Replication flows which run in their own deployments. If it is empty, one deployment per cluster is created.
+	Flows []ReplicationFlow `json:"flows,omitempty"`
 	// Deprecated: it is kept for backward compatibility.
 	JolokiaPort *int32 `json:"jolokiaPort,omitempty"`
 }
 
+// ActiveActive shows configuration of bidirectional replication where all clusters accept writes
+type ActiveActive struct {
+	Enabled bool `json:"enabled"`
+	// OriginHeader - Name of record header which contains the cluster where the record was produced.
+	// Records with this header are not replicated again, so they do not return to the origin cluster.
+	OriginHeader string `json:"originHeader,omitempty"`
+	// Flows - Topic filters for specific replication directions.
+	Flows []ActiveActiveFlow `json:"flows,omitempty"`
+}
+
+// ActiveActiveFlow shows topic filters for one source->target replication direction
+type ActiveActiveFlow struct {
+	Source        string `json:"source"`
+	Target        string `json:"target"`
+	Topics        string `json:"topics,omitempty"`
+	TopicsExclude string `json:"topicsExclude,omitempty"`
+}
+
+// ClusterSync shows configuration of topic configs and ACLs synchronization from source to target cluster of replication
+type ClusterSync struct {
+	TopicConfigsEnabled bool `json:"topicConfigsEnabled,omitempty"`
+	AclsEnabled         bool `json:"aclsEnabled,omitempty"`
+	// IncludeTopics - Regular expressions of topics which configs and ACLs are synchronized. All topics are synchronized if it is empty.
+	IncludeTopics []string `json:"includeTopics,omitempty"`
+	// ExcludeTopics - Regular expressions of topics which configs and ACLs are not synchronized.
+	ExcludeTopics []string `json:"excludeTopics,omitempty"`
+	// ExcludeConfigs - Names of topic configs which are not synchronized.
+	ExcludeConfigs []string `json:"excludeConfigs,omitempty"`
+	// DryRun - Whether to only report divergences without applying them to target cluster.
+	DryRun bool `json:"dryRun,omitempty"`
+	// BlockSwitchover - Whether to fail switchover to active mode if divergences remain.
+	BlockSwitchover bool `json:"blockSwitchover,omitempty"`
+	// IntervalSeconds - Period of synchronization. If it is not set, synchronization runs on each reconciliation only.
+	IntervalSeconds *int32 `json:"intervalSeconds,omitempty"`
+}
+
+// ReplicationFlow shows configuration of source->target replication flow which is scaled independently
+type ReplicationFlow struct {
+	Source    string                   `json:"source"`
+	Target    string                   `json:"target"`
+	Topics    string                   `json:"topics,omitempty"`
+	TasksMax  *int32                   `json:"tasksMax,omitempty"`
+	Replicas  *int32                   `json:"replicas,omitempty"`
+	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+}
+
 type Cluster struct {
 	Name             string `json:"name"`
 	BootstrapServers string `json:"bootstrapServers"`
@@ -231,6 +300,9 @@ type IntegrationTests struct {
 	WaitForResult    bool   `json:"waitForResult"`
 	Timeout          int    `json:"timeout"`
 	RandomRunTrigger string `json:"randomRunTrigger,omitempty"`
+	// RunAsJob - Whether operator runs integration tests as a Job built from integration tests Deployment
+	// and publishes the results to status. New run is started when RandomRunTrigger is changed.
+	RunAsJob bool `json:"runAsJob,omitempty"`
 }
 
 // BackupDaemon defines the specific Kafka Backup Daemon configuration
@@ -255,7 +327,72 @@ type MonitoringStatus struct {
 }
 
 type MirrorMakerStatus struct {
-	Nodes []string `json:"nodes,omitempty"`
+	Nodes       []string                `json:"nodes,omitempty"`
+	Flows       []ReplicationFlowStatus `json:"flows,omitempty"`
+	ClusterSync *ClusterSyncStatus      `json:"clusterSync,omitempty"`
+}
+
+// IntegrationTestsStatus shows the result of the last integration tests run performed by Job
+type IntegrationTestsStatus struct {
+	RunTrigger     string                        `json:"runTrigger,omitempty"`
+	JobName        string                        `json:"jobName,omitempty"`
+	Result         string                        `json:"result,omitempty"`
+	Message        string                        `json:"message,omitempty"`
+	StartTime      string                        `json:"startTime,omitempty"`
+	CompletionTime string                        `json:"completionTime,omitempty"`
+	Passed         int                           `json:"passed,omitempty"`
+	Failed         int                           `json:"failed,omitempty"`
+	Skipped        int                           `json:"skipped,omitempty"`
+	Suites         []IntegrationTestsSuiteStatus `json:"suites,omitempty"`
+}
+
+// IntegrationTestsSuiteStatus shows test counts and failures of Robot Framework suite
+type IntegrationTestsSuiteStatus struct {
+	Name     string                    `json:"name"`
+	Passed   int                       `json:"passed"`
+	Failed   int                       `json:"failed"`
+	Skipped  int                       `json:"skipped"`
+	Failures []IntegrationTestsFailure `json:"failures,omitempty"`
+}
+
+// IntegrationTestsFailure shows the failed test and its message
+type IntegrationTestsFailure struct {
+	Test    string `json:"test"`
+	Message string `json:"message,omitempty"`
+}
+
+// ClusterSyncStatus shows the result of the last topic configs and ACLs synchronization
+type ClusterSyncStatus struct {
+	SourceCluster string              `json:"sourceCluster,omitempty"`
+	TargetCluster string              `json:"targetCluster,omitempty"`
+	LastSyncTime  string              `json:"lastSyncTime,omitempty"`
+	Message       string              `json:"message,omitempty"`
+	Divergences   []ClusterDivergence `json:"divergences,omitempty"`
+}
+
+// ClusterDivergence shows the difference between source and target clusters which is not synchronized
+type ClusterDivergence struct {
+	// +kubebuilder:validation:Enum=topicConfig;acl
+	Kind     string `json:"kind"`
+	Resource string `json:"resource"`
+	Message  string `json:"message"`
+}
+
+// ReplicationFlowStatus shows the health of one source->target replication flow
+type ReplicationFlowStatus struct {
+	Name    string                  `json:"name"`
+	Status  string                  `json:"status"`
+	Message string                  `json:"message,omitempty"`
+	Tasks   []ReplicationTaskStatus `json:"tasks,omitempty"`
+}
+
+// ReplicationTaskStatus shows the state of one Kafka Mirror Maker connector task
+type ReplicationTaskStatus struct {
+	Connector string `json:"connector"`
+	Id        int32  `json:"id"`
+	State     string `json:"state"`
+	WorkerId  string `json:"workerId,omitempty"`
+	Message   string `json:"message,omitempty"`
 }
 
 type DisasterRecoveryStatus struct {
@@ -304,6 +441,7 @@ type KafkaServiceStatus struct {
 	// Deprecated: no longer used. Retained for backward compatibility.
 	VaultSecretManagementStatus  VaultSecretManagementStatus  `json:"vaultSecretManagementStatus,omitempty"`
 	DisasterRecoveryStatus       DisasterRecoveryStatus       `json:"disasterRecoveryStatus,omitempty"`
+	IntegrationTestsStatus       IntegrationTestsStatus       `json:"integrationTestsStatus,omitempty"`
 	Conditions                   []StatusCondition            `json:"conditions,omitempty"`
 }
 
@@ -374,6 +512,37 @@ type KafkaServiceList struct {
 	Items           []KafkaService `json:"items"`
 }
 
+// OidcConfig shows AKHQ OpenID Connect authentication configuration
+type OidcConfig struct {
+	Enabled bool `json:"enabled"`
+	// Issuer - URL of OpenID Connect provider, its discovery document must be available by
+	// <issuer>/.well-known/openid-configuration path.
+	Issuer   string `json:"issuer"`
+	ClientId string `json:"clientId"`
+	// TrustedCert - Base64 encoded PEM certificate of CA which signs TLS certificate of the issuer.
+	// It is used to get discovery document and added to AKHQ trusted certificates.
+	TrustedCert string `json:"trustedCert,omitempty"`
+	// ClientSecretName - Name of the secret with client secret. The default value is akhq-oidc-secret.
+	ClientSecretName string `json:"clientSecretName,omitempty"`
+	// ClientSecretKey - Key of client secret in the secret. The default value is client-secret.
+	ClientSecretKey string `json:"clientSecretKey,omitempty"`
+	// Label - Label of the login button in AKHQ UI.
+	Label string `json:"label,omitempty"`
+	// UsernameField - Claim of ID token with username. The default value is preferred_username.
+	UsernameField string `json:"usernameField,omitempty"`
+	// GroupsField - Claim of ID token with groups or roles of the user. The default value is roles.
+	GroupsField string `json:"groupsField,omitempty"`
+	// DefaultGroup - AKHQ group of users which groups are not mapped to AKHQ groups.
+	DefaultGroup string `json:"defaultGroup,omitempty"`
+	// Groups - Mapping of OpenID Connect groups or roles to AKHQ groups.
+	Groups []OidcGroup `json:"groups,omitempty"`
+}
+
+type OidcGroup struct {
+	Name   string   `json:"name"`
+	Groups []string `json:"groups"`
+}
+
 type LdapConfig struct {
 	Enabled      bool              `json:"enabled"`
 	EnableSsl    bool              `json:"enableSsl"`
@@ -25,6 +25,41 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActiveActive) DeepCopyInto(out *ActiveActive) {
+	*out = *in
+	if in.Flows != nil {
+		in, out := &in.Flows, &out.Flows
+		*out = make([]ActiveActiveFlow, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActiveActive.
+func (in *ActiveActive) DeepCopy() *ActiveActive {
+	if in == nil {
+		return nil
+	}
+	out := new(ActiveActive)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActiveActiveFlow) DeepCopyInto(out *ActiveActiveFlow) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActiveActiveFlow.
+func (in *ActiveActiveFlow) DeepCopy() *ActiveActiveFlow {
+	if in == nil {
+		return nil
+	}
+	out := new(ActiveActiveFlow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Akhq) DeepCopyInto(out *Akhq) {
 	*out = *in
@@ -65,6 +100,11 @@ func (in *Akhq) DeepCopyInto(out *Akhq) {
 		*out = new(int)
 		**out = **in
 	}
+	if in.Oidc != nil {
+		in, out := &in.Oidc, &out.Oidc
+		*out = new(OidcConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Akhq.
@@ -112,6 +152,21 @@ func (in *BackupDaemon) DeepCopy() *BackupDaemon {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertManager) DeepCopyInto(out *CertManager) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertManager.
+func (in *CertManager) DeepCopy() *CertManager {
+	if in == nil {
+		return nil
+	}
+	out := new(CertManager)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Cluster) DeepCopyInto(out *Cluster) {
 	*out = *in
@@ -127,6 +182,76 @@ func (in *Cluster) DeepCopy() *Cluster {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDivergence) DeepCopyInto(out *ClusterDivergence) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDivergence.
+func (in *ClusterDivergence) DeepCopy() *ClusterDivergence {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDivergence)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterSync) DeepCopyInto(out *ClusterSync) {
+	*out = *in
+	if in.IncludeTopics != nil {
+		in, out := &in.IncludeTopics, &out.IncludeTopics
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludeTopics != nil {
+		in, out := &in.ExcludeTopics, &out.ExcludeTopics
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludeConfigs != nil {
+		in, out := &in.ExcludeConfigs, &out.ExcludeConfigs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IntervalSeconds != nil {
+		in, out := &in.IntervalSeconds, &out.IntervalSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSync.
+func (in *ClusterSync) DeepCopy() *ClusterSync {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterSync)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterSyncStatus) DeepCopyInto(out *ClusterSyncStatus) {
+	*out = *in
+	if in.Divergences != nil {
+		in, out := &in.Divergences, &out.Divergences
+		*out = make([]ClusterDivergence, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSyncStatus.
+func (in *ClusterSyncStatus) DeepCopy() *ClusterSyncStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterSyncStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DisasterRecovery) DeepCopyInto(out *DisasterRecovery) {
 	*out = *in
@@ -178,6 +303,11 @@ func (in *Global) DeepCopyInto(out *Global) {
 	}
 	out.KafkaSsl = in.KafkaSsl
 	out.Kraft = in.Kraft
+	if in.CertManager != nil {
+		in, out := &in.CertManager, &out.CertManager
+		*out = new(CertManager)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Global.
@@ -205,6 +335,63 @@ func (in *IntegrationTests) DeepCopy() *IntegrationTests {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrationTestsFailure) DeepCopyInto(out *IntegrationTestsFailure) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationTestsFailure.
+func (in *IntegrationTestsFailure) DeepCopy() *IntegrationTestsFailure {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrationTestsFailure)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrationTestsStatus) DeepCopyInto(out *IntegrationTestsStatus) {
+	*out = *in
+	if in.Suites != nil {
+		in, out := &in.Suites, &out.Suites
+		*out = make([]IntegrationTestsSuiteStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationTestsStatus.
+func (in *IntegrationTestsStatus) DeepCopy() *IntegrationTestsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrationTestsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrationTestsSuiteStatus) DeepCopyInto(out *IntegrationTestsSuiteStatus) {
+	*out = *in
+	if in.Failures != nil {
+		in, out := &in.Failures, &out.Failures
+		*out = make([]IntegrationTestsFailure, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationTestsSuiteStatus.
+func (in *IntegrationTestsSuiteStatus) DeepCopy() *IntegrationTestsSuiteStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrationTestsSuiteStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Kafka) DeepCopyInto(out *Kafka) {
 	*out = *in
@@ -429,6 +616,7 @@ func (in *KafkaServiceStatus) DeepCopyInto(out *KafkaServiceStatus) {
 	in.MonitoringStatus.DeepCopyInto(&out.MonitoringStatus)
 	in.MirrorMakerStatus.DeepCopyInto(&out.MirrorMakerStatus)
 	out.DisasterRecoveryStatus = in.DisasterRecoveryStatus
+	in.IntegrationTestsStatus.DeepCopyInto(&out.IntegrationTestsStatus)
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]StatusCondition, len(*in))
@@ -571,6 +759,23 @@ func (in *MirrorMaker) DeepCopyInto(out *MirrorMaker) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.ActiveActive != nil {
+		in, out := &in.ActiveActive, &out.ActiveActive
+		*out = new(ActiveActive)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClusterSync != nil {
+		in, out := &in.ClusterSync, &out.ClusterSync
+		*out = new(ClusterSync)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Flows != nil {
+		in, out := &in.Flows, &out.Flows
+		*out = make([]ReplicationFlow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.JolokiaPort != nil {
 		in, out := &in.JolokiaPort, &out.JolokiaPort
 		*out = new(int32)
@@ -648,6 +853,18 @@ func (in *MirrorMakerStatus) DeepCopyInto(out *MirrorMakerStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Flows != nil {
+		in, out := &in.Flows, &out.Flows
+		*out = make([]ReplicationFlowStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ClusterSync != nil {
+		in, out := &in.ClusterSync, &out.ClusterSync
+		*out = new(ClusterSyncStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MirrorMakerStatus.
@@ -757,6 +974,48 @@ func (in *OAuth) DeepCopy() *OAuth {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OidcConfig) DeepCopyInto(out *OidcConfig) {
+	*out = *in
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]OidcGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OidcConfig.
+func (in *OidcConfig) DeepCopy() *OidcConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OidcConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OidcGroup) DeepCopyInto(out *OidcGroup) {
+	*out = *in
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OidcGroup.
+func (in *OidcGroup) DeepCopy() *OidcGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(OidcGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PartitionsReassignmentStatus) DeepCopyInto(out *PartitionsReassignmentStatus) {
 	*out = *in
@@ -772,6 +1031,71 @@ func (in *PartitionsReassignmentStatus) DeepCopy() *PartitionsReassignmentStatus
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicationFlow) DeepCopyInto(out *ReplicationFlow) {
+	*out = *in
+	if in.TasksMax != nil {
+		in, out := &in.TasksMax, &out.TasksMax
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationFlow.
+func (in *ReplicationFlow) DeepCopy() *ReplicationFlow {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicationFlow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicationFlowStatus) DeepCopyInto(out *ReplicationFlowStatus) {
+	*out = *in
+	if in.Tasks != nil {
+		in, out := &in.Tasks, &out.Tasks
+		*out = make([]ReplicationTaskStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationFlowStatus.
+func (in *ReplicationFlowStatus) DeepCopy() *ReplicationFlowStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicationFlowStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicationTaskStatus) DeepCopyInto(out *ReplicationTaskStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationTaskStatus.
+func (in *ReplicationTaskStatus) DeepCopy() *ReplicationTaskStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicationTaskStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Scaling) DeepCopyInto(out *Scaling) {
 	*out = *in
@@ -15,10 +15,13 @@ type Cfg struct {
 	SecondaryApiGroup                        string  `long:"secondary-api-group" description:"The additional API group" optional:"true" env:"SECONDARY_API_GROUP"`
 	KmmEnabled                               bool    `long:"kmm-enabled" description:"Enable kmm manager" env:"KMM_ENABLED"`
 	KmmConfigurationReconcilePeriodSecs      int     `long:"kmm-configuration-reconcile-period-seconds" description:"Reconcilation period for Kafka KMM configuration" env:"KMM_CONFIG_RECONCILE_PERIOD_SECONDS" default:"60"`
+	DisasterRecoveryResourceEnabled          bool    `long:"disaster-recovery-resource-enabled" description:"Enable switchover management by KafkaDisasterRecovery resources" env:"DISASTER_RECOVERY_RESOURCE_ENABLED"`
+	BackupResourcesEnabled                   bool    `long:"backup-resources-enabled" description:"Enable backup and restore management by KafkaBackup, KafkaRestore and KafkaBackupSchedule resources" env:"BACKUP_RESOURCES_ENABLED"`
 	WatchAkhqCollectNamespace                *string `long:"watch-akhq-collect-namespace" description:"Namespace to watch for Akhq collect" env:"WATCH_AKHQ_COLLECT_NAMESPACE"`
 	WatchKafkaUsersCollectNamespace          *string `long:"watch-kafka-users-collect-namespace" description:"Namespace to watch for Kafka Users collect" env:"WATCH_KAFKA_USERS_COLLECT_NAMESPACE"`
 	KafkaUserSecretCreatingEnabled           bool    `long:"kafka-user-secret-creating-enabled" description:"Enable Kafka User secret creation" env:"KAFKA_USER_SECRET_CREATING_ENABLED"`
 	KafkaUserConfiguratorReconcilePeriodSecs int     `long:"kafka-user-configurator-reconcile-period-seconds" description:"Reconciliation period for Kafka User Configurator in seconds" default:"60" env:"KAFKA_USER_CONFIGURATOR_RECONCILE_PERIOD_SECONDS"`
+	KafkaOffsetResetEnabled                  bool    `long:"kafka-offset-reset-enabled" description:"Enable consumer group offsets reset by KafkaOffsetReset resources" env:"KAFKA_OFFSET_RESET_ENABLED"`
 	KafkaBootstrapServers                    string  `long:"kafka-bootstrap-servers" description:"Kafka bootstrap servers" env:"BOOTSTRAP_SERVERS" optional:"true"`
 	KafkaSecret                              string  `long:"kafka-secret" description:"Kafka secret" env:"KAFKA_SECRET"`
 	KafkaSaslMechanism                       string  `long:"kafka-sasl-mechanism" description:"Kafka SASL mechanism" env:"KAFKA_SASL_MECHANISM"`
@@ -184,6 +184,26 @@ Provider used to generate TLS certificates
   {{- default "helm" .Values.global.tls.generateCerts.certProvider -}}
 {{- end -}}
 
+{{/*
+Whether TLS certificates are issued by cert-manager Certificates which operator creates
+*/}}
+{{- define "services.operatorCerts" -}}
+  {{- and .Values.global.tls.enabled .Values.global.tls.generateCerts.enabled (eq (include "services.certProvider" .) "operator") -}}
+{{- end -}}
+
+{{/*
+Issuer of TLS certificates which are issued by cert-manager
+*/}}
+{{- define "services.certIssuer" -}}
+{{- if .Values.global.tls.generateCerts.clusterIssuerName -}}
+issuerName: {{ .Values.global.tls.generateCerts.clusterIssuerName }}
+issuerKind: ClusterIssuer
+{{- else -}}
+issuerName: {{ template "kafka.name" . }}-services-tls-issuer
+issuerKind: Issuer
+{{- end -}}
+{{- end -}}
+
 {{/*
 Configure Kafka monitoring type
 */}}
@@ -191,6 +211,17 @@ Configure Kafka monitoring type
 {{- coalesce .Values.monitoring.monitoringType .Values.global.monitoringType "prometheus" -}}
 {{- end -}}
 
+{{/*
+Configure mode of Kafka additional metrics, they can be served for Prometheus only if Prometheus monitoring is used
+*/}}
+{{- define "monitoring.additionalMetricsMode" -}}
+{{- if and (eq (include "monitoring.type" .) "prometheus") (eq (.Values.monitoring.additionalMetricsMode | default "influx") "prometheus") -}}
+prometheus
+{{- else -}}
+influx
+{{- end -}}
+{{- end -}}
+
 {{/*
 Configure Kafka Mirror Maker monitoring type
 */}}
@@ -852,7 +883,7 @@ Usage example:
     service: "{{ .service }}"
     {{ if eq .enableTls "true" }}
     secret: "{{ .secret }}"
-    {{ if eq .certProvider "cert-manager" }}
+    {{ if ne .certProvider "helm" }}
     certificate: "{{ .certificate }}"
     {{ end }}
     {{ end }}
@@ -1111,6 +1142,15 @@ Find an CRD Init job Docker image in various places.
   {{- end -}}
   {{- if .Values.operator.kafkaUserConfigurator.enabled -}}
     {{- $names = printf "%s,%s" $names "kafkauser_crd.yaml" -}}
+    {{- if .Values.operator.kafkaUserConfigurator.offsetResetEnabled -}}
+      {{- $names = printf "%s,%s" $names "kafka_offset_reset_crd.yaml" -}}
+    {{- end -}}
+  {{- end -}}
+  {{- if .Values.operator.disasterRecoveryResourceEnabled -}}
+    {{- $names = printf "%s,%s" $names "kafka_disaster_recovery_crd.yaml" -}}
+  {{- end -}}
+  {{- if .Values.operator.backupResourcesEnabled -}}
+    {{- $names = printf "%s,%s" $names "kafka_backup_crd.yaml,kafka_restore_crd.yaml,kafka_backup_schedule_crd.yaml" -}}
   {{- end -}}
   {{- printf "%s" $names | trimPrefix "," -}}
 {{- end -}}
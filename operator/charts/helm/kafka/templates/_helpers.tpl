@@ -179,6 +179,26 @@ Provider used to generate TLS certificates
   {{- default "helm" .Values.global.tls.generateCerts.certProvider -}}
 {{- end -}}
 
+{{/*
+Whether TLS certificates are issued by cert-manager Certificates which operator creates
+*/}}
+{{- define "services.operatorCerts" -}}
+  {{- and .Values.global.tls.enabled .Values.global.tls.generateCerts.enabled (eq (include "services.certProvider" .) "operator") -}}
+{{- end -}}
+
+{{/*
+Issuer of TLS certificates which are issued by cert-manager
+*/}}
+{{- define "services.certIssuer" -}}
+{{- if .Values.global.tls.generateCerts.clusterIssuerName -}}
+issuerName: {{ .Values.global.tls.generateCerts.clusterIssuerName }}
+issuerKind: ClusterIssuer
+{{- else -}}
+issuerName: {{ template "kafka.name" . }}-services-tls-issuer
+issuerKind: Issuer
+{{- end -}}
+{{- end -}}
+
 
 {{/*
 Create list of Kafka brokers separated by ",".
@@ -0,0 +1,97 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package akhqconfig
+
+import (
+	"context"
+	"fmt"
+
+	akhqconfigv1 "github.com/Netcracker/qubership-kafka/operator/api/v1"
+	akhqproto "github.com/Netcracker/qubership-kafka/operator/controllers/akhqprotobuf"
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/yaml"
+)
+
+const duplicateAccessValidationError = "LDAP group [%s] occurs twice in access section"
+
+// applyAccessRules replaces access rules of the CR in AKHQ access config.
+// The AKHQ reconciler of Kafka service renders them to AKHQ roles and groups.
+func (r *AkhqConfigReconciler) applyAccessRules(instance *akhqconfigv1.AkhqConfig, crFullName string,
+	reqLogger logr.Logger) error {
+	accessRules := make([]akhqproto.AccessRule, 0, len(instance.Spec.Access))
+	for _, access := range instance.Spec.Access {
+		accessRules = append(accessRules, akhqproto.AccessRule{
+			Name:      crFullName,
+			Namespace: instance.Namespace,
+			LdapGroup: access.LdapGroup,
+			Role:      access.Role,
+		})
+	}
+	return r.updateAccessConfigMap(crFullName, accessRules, reqLogger)
+}
+
+// deleteAccessRules removes access rules of the CR from AKHQ access config
+func (r *AkhqConfigReconciler) deleteAccessRules(crFullName string, reqLogger logr.Logger) error {
+	return r.updateAccessConfigMap(crFullName, nil, reqLogger)
+}
+
+func (r *AkhqConfigReconciler) updateAccessConfigMap(crFullName string, accessRules []akhqproto.AccessRule,
+	reqLogger logr.Logger) error {
+	accessCM, err := akhqproto.CreateAccessConfigMapIfNotExist(r.Namespace, r.Client, make(map[string]string), nil, nil)
+	if err != nil {
+		return err
+	}
+	accessConfig, err := akhqproto.ParseAccessConfig(accessCM)
+	if err != nil {
+		return err
+	}
+	accessConfig.Access = mergeAccessRules(accessConfig.Access, crFullName, accessRules)
+	data, err := yaml.Marshal(accessConfig)
+	if err != nil {
+		return err
+	}
+	if accessCM.Data["config"] == string(data) {
+		return nil
+	}
+	reqLogger.Info(fmt.Sprintf("Updating AKHQ access rules of [%s] config", crFullName))
+	accessCM.Data["config"] = string(data)
+	return r.Client.Update(context.TODO(), accessCM)
+}
+
+// mergeAccessRules replaces the rules of the CR with the new ones and keeps the rules of other CRs in their order
+func mergeAccessRules(existingRules []akhqproto.AccessRule, crFullName string,
+	crRules []akhqproto.AccessRule) []akhqproto.AccessRule {
+	result := make([]akhqproto.AccessRule, 0, len(existingRules)+len(crRules))
+	for _, rule := range existingRules {
+		if rule.Name != crFullName {
+			result = append(result, rule)
+		}
+	}
+	return append(result, crRules...)
+}
+
+// validateAccess checks that each LDAP group is bound to only one role
+func validateAccess(accessList []akhqconfigv1.Access) []string {
+	ldapGroups := make(map[string]bool)
+	var problems []string
+	for _, access := range accessList {
+		if ldapGroups[access.LdapGroup] {
+			problems = append(problems, fmt.Sprintf(duplicateAccessValidationError, access.LdapGroup))
+			continue
+		}
+		ldapGroups[access.LdapGroup] = true
+	}
+	return problems
+}
@@ -0,0 +1,54 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package akhqconfig
+
+import (
+	"fmt"
+	"testing"
+
+	akhqconfigv1 "github.com/Netcracker/qubership-kafka/operator/api/v1"
+	akhqproto "github.com/Netcracker/qubership-kafka/operator/controllers/akhqprotobuf"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_mergeAccessRules(t *testing.T) {
+	existingRules := []akhqproto.AccessRule{
+		{Name: "orders-team-a", Namespace: "team-a", LdapGroup: "team-a-developers", Role: "admin"},
+		{Name: "payments-team-b", Namespace: "team-b", LdapGroup: "team-b-developers", Role: "admin"},
+		{Name: "orders-team-a", Namespace: "team-a", LdapGroup: "support", Role: "reader"},
+	}
+	crRules := []akhqproto.AccessRule{
+		{Name: "orders-team-a", Namespace: "team-a", LdapGroup: "team-a-developers", Role: "reader"},
+	}
+
+	assert.Equal(t, []akhqproto.AccessRule{
+		{Name: "payments-team-b", Namespace: "team-b", LdapGroup: "team-b-developers", Role: "admin"},
+		{Name: "orders-team-a", Namespace: "team-a", LdapGroup: "team-a-developers", Role: "reader"},
+	}, mergeAccessRules(existingRules, "orders-team-a", crRules))
+	assert.Equal(t, []akhqproto.AccessRule{
+		{Name: "payments-team-b", Namespace: "team-b", LdapGroup: "team-b-developers", Role: "admin"},
+	}, mergeAccessRules(existingRules, "orders-team-a", nil))
+}
+
+func Test_validateAccess(t *testing.T) {
+	assert.Empty(t, validateAccess([]akhqconfigv1.Access{
+		{LdapGroup: "team-a-developers", Role: "admin"},
+		{LdapGroup: "support", Role: "reader"},
+	}))
+	assert.Equal(t, []string{fmt.Sprintf(duplicateAccessValidationError, "support")}, validateAccess([]akhqconfigv1.Access{
+		{LdapGroup: "support", Role: "admin"},
+		{LdapGroup: "support", Role: "reader"},
+	}))
+}
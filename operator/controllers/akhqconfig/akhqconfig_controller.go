@@ -17,8 +17,11 @@ package akhqconfig
 import (
 	"context"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"github.com/Netcracker/qubership-kafka/operator/controllers"
+	"regexp"
+	"slices"
 	"strings"
 
 	akhqconfigv1 "github.com/Netcracker/qubership-kafka/operator/api/v1"
@@ -45,24 +48,53 @@ const (
 	protobufConfigurationCMName = "akhq-protobuf-configuration"
 	decodingValidationError     = "can not decode descriptor-file-base64 config which is associated with [%s] regular expression"
 	duplicateValidationError    = "this regular expression occurs twice - [%s]"
+	requiredValidationError     = "%s config is required for %s format, it is associated with [%s] regular expression"
+	schemaValidationError       = "%s config which is associated with [%s] regular expression is not a valid JSON document"
+	jsonSchemaValidationError   = "%s config which is associated with [%s] regular expression is not a valid JSON schema: %v"
+	regexValidationError        = "[%s] is not a valid regular expression: %v"
+	descriptorValidationError   = "can not parse descriptor-file-base64 config which is associated with [%s] regular expression: %v"
+	messageTypeValidationError  = "%s [%s] is not found in descriptor which is associated with [%s] regular expression"
+	overlappingRegexWarning     = "[%s] regular expression overlaps with [%s] regular expression of [%s] config, AKHQ applies the first matching one"
+	protobufFormat              = "protobuf"
+	avroFormat                  = "avro"
+	jsonSchemaFormat            = "json-schema"
+	descriptorsFolder           = "/app/config"
 )
 
+// schemaFormats are the formats which are deserialized with schema files instead of protobuf descriptors
+var schemaFormats = []string{avroFormat, jsonSchemaFormat}
+
 type TopicMapping struct {
-	TopicRegex           string `yaml:"topic-regex"`
-	Name                 string `yaml:"name"`
-	DescriptorFile       string `yaml:"descriptor-file"`
-	DescriptorFileBase64 string `yaml:"descriptor-file-base64,omitempty"`
-	KeyMessageType       string `yaml:"key-message-type,omitempty"`
-	ValueMessageType     string `yaml:"value-message-type"`
+	TopicRegex           string `json:"topic-regex" yaml:"topic-regex"`
+	Name                 string `json:"name" yaml:"name"`
+	DescriptorFile       string `json:"descriptor-file" yaml:"descriptor-file"`
+	DescriptorFileBase64 string `json:"descriptor-file-base64,omitempty" yaml:"descriptor-file-base64,omitempty"`
+	KeyMessageType       string `json:"key-message-type,omitempty" yaml:"key-message-type,omitempty"`
+	ValueMessageType     string `json:"value-message-type" yaml:"value-message-type"`
+}
+
+// SchemaTopicMapping binds topics to Avro or JSON schema files of their keys and values
+type SchemaTopicMapping struct {
+	TopicRegex      string `json:"topic-regex" yaml:"topic-regex"`
+	Name            string `json:"name" yaml:"name"`
+	KeySchemaFile   string `json:"key-schema-file,omitempty" yaml:"key-schema-file,omitempty"`
+	ValueSchemaFile string `json:"value-schema-file,omitempty" yaml:"value-schema-file,omitempty"`
+}
+
+type SchemaDeserialization struct {
+	SchemasFolder string               `json:"schemas-folder" yaml:"schemas-folder"`
+	TopicsMapping []SchemaTopicMapping `json:"topics-mapping" yaml:"topics-mapping"`
 }
 
 type DeserializationConfig struct {
 	Deserialization struct {
 		Protobuf struct {
-			DescriptorsFolder string         `yaml:"descriptors-folder"`
-			TopicsMapping     []TopicMapping `yaml:"topics-mapping"`
-		} `yaml:"protobuf"`
-	} `yaml:"deserialization"`
+			DescriptorsFolder string         `json:"descriptors-folder" yaml:"descriptors-folder"`
+			TopicsMapping     []TopicMapping `json:"topics-mapping" yaml:"topics-mapping"`
+		} `json:"protobuf" yaml:"protobuf"`
+		AvroRaw    *SchemaDeserialization `json:"avro-raw,omitempty" yaml:"avro-raw,omitempty"`
+		JsonSchema *SchemaDeserialization `json:"json-schema,omitempty" yaml:"json-schema,omitempty"`
+	} `json:"deserialization" yaml:"deserialization"`
 }
 
 type AkhqConfigReconciler struct {
@@ -97,8 +129,8 @@ func (r *AkhqConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	}
 
 	if instance.DeletionTimestamp.IsZero() {
-		isValid, description := r.validate(instance)
-		if !isValid {
+		if problems := r.validate(instance); len(problems) > 0 {
+			description := strings.Join(problems, "; ")
 			reqLogger.Info(fmt.Sprintf("Validation was failed with the following description - %s", description))
 			if err = r.updateCrStatus(instance, true, false, description); err != nil {
 				return reconcile.Result{}, err
@@ -123,7 +155,8 @@ func (r *AkhqConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		}
 		return reconcile.Result{}, nil
 	}
-	if err = r.applyConfig(instance, reqLogger); err != nil {
+	warnings, err := r.applyConfig(instance, reqLogger)
+	if err != nil {
 		reqLogger.Error(err, fmt.Sprintf("Can not apply config for current AkhqConfig CR, name - [%s], namespace - [%s]", instance.Name, instance.Namespace))
 		if err := r.updateCrStatus(instance, false, true, internalServerError); err != nil {
 			return reconcile.Result{}, err
@@ -131,32 +164,163 @@ func (r *AkhqConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return reconcile.Result{}, err
 	}
 
-	if err = r.updateCrStatus(instance, false, true, ""); err != nil {
+	// warnings do not prevent applying of configs, but they are shown in the status
+	if err = r.updateCrStatus(instance, false, true, strings.Join(warnings, "; ")); err != nil {
 		return reconcile.Result{}, err
 	}
 	return reconcile.Result{}, nil
 }
 
-func (r *AkhqConfigReconciler) validate(instance *akhqconfigv1.AkhqConfig) (bool, string) {
-	crConfigs := instance.Spec.Configs
-	return r.validateCrConfig(crConfigs)
+// validate returns all problems of configs and access rules of the CR
+func (r *AkhqConfigReconciler) validate(instance *akhqconfigv1.AkhqConfig) []string {
+	problems := r.validateCrConfig(instance.Spec.Configs)
+	return append(problems, validateAccess(instance.Spec.Access)...)
 }
 
-func (r *AkhqConfigReconciler) validateCrConfig(crConfigs []akhqconfigv1.Config) (bool, string) {
+func (r *AkhqConfigReconciler) validateCrConfig(crConfigs []akhqconfigv1.Config) []string {
+	var problems []string
 	regexSet := make(map[string]bool)
 	for _, config := range crConfigs {
 		regex := strings.ReplaceAll(config.TopicRegex, ",", "|")
-		if _, ok := regexSet[regex]; !ok {
-			regexSet[regex] = true
-			_, err := base64.StdEncoding.DecodeString(config.DescriptorFileBase64)
-			if err != nil {
-				return false, fmt.Sprintf(decodingValidationError, config.TopicRegex)
-			}
+		if regexSet[regex] {
+			problems = append(problems, fmt.Sprintf(duplicateValidationError, config.TopicRegex))
+			continue
+		}
+		regexSet[regex] = true
+		if _, err := regexp.Compile(regex); err != nil {
+			problems = append(problems, fmt.Sprintf(regexValidationError, config.TopicRegex, err))
+		}
+		if getConfigFormat(config) == protobufFormat {
+			problems = append(problems, validateProtobufConfig(config)...)
 		} else {
-			return false, fmt.Sprintf(duplicateValidationError, config.TopicRegex)
+			problems = append(problems, validateSchemaConfig(config)...)
+		}
+	}
+	return problems
+}
+
+// validateProtobufConfig checks that descriptor of the config is a valid FileDescriptorSet
+// which contains key and value message types
+func validateProtobufConfig(config akhqconfigv1.Config) []string {
+	var problems []string
+	if config.MessageType == "" {
+		problems = append(problems, fmt.Sprintf(requiredValidationError, "message-type", protobufFormat, config.TopicRegex))
+	}
+	if config.DescriptorFileBase64 == "" {
+		return append(problems,
+			fmt.Sprintf(requiredValidationError, "descriptor-file-base64", protobufFormat, config.TopicRegex))
+	}
+	content, err := base64.StdEncoding.DecodeString(config.DescriptorFileBase64)
+	if err != nil {
+		return append(problems, fmt.Sprintf(decodingValidationError, config.TopicRegex))
+	}
+	files, err := parseFileDescriptorSet(content)
+	if err != nil {
+		return append(problems, fmt.Sprintf(descriptorValidationError, config.TopicRegex, err))
+	}
+	if config.MessageType != "" && !hasMessageType(files, config.MessageType) {
+		problems = append(problems,
+			fmt.Sprintf(messageTypeValidationError, "message-type", config.MessageType, config.TopicRegex))
+	}
+	if config.KeyType != "" && !hasMessageType(files, config.KeyType) {
+		problems = append(problems,
+			fmt.Sprintf(messageTypeValidationError, "key-type", config.KeyType, config.TopicRegex))
+	}
+	return problems
+}
+
+// validateSchemaConfig checks that Avro or JSON schemas of the config are encoded to base64 and contain JSON documents
+func validateSchemaConfig(config akhqconfigv1.Config) []string {
+	if config.KeySchemaFileBase64 == "" && config.ValueSchemaFileBase64 == "" {
+		return []string{fmt.Sprintf(requiredValidationError, "key-schema-file-base64 or value-schema-file-base64",
+			config.Format, config.TopicRegex)}
+	}
+	schemas := map[string]string{
+		"key-schema-file-base64":   config.KeySchemaFileBase64,
+		"value-schema-file-base64": config.ValueSchemaFileBase64,
+	}
+	var problems []string
+	for _, name := range []string{"key-schema-file-base64", "value-schema-file-base64"} {
+		schema := schemas[name]
+		if schema == "" {
+			continue
+		}
+		content, err := base64.StdEncoding.DecodeString(schema)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("can not decode %s config which is associated with [%s] regular expression",
+				name, config.TopicRegex))
+			continue
+		}
+		if !json.Valid(content) {
+			problems = append(problems, fmt.Sprintf(schemaValidationError, name, config.TopicRegex))
+			continue
+		}
+		if config.Format == jsonSchemaFormat {
+			if err = validateJsonSchema(content); err != nil {
+				problems = append(problems, fmt.Sprintf(jsonSchemaValidationError, name, config.TopicRegex, err))
+			}
+		}
+	}
+	return problems
+}
+
+// jsonSchemaTypes are the values of "type" keyword of JSON schema
+var jsonSchemaTypes = []string{"null", "boolean", "object", "array", "number", "string", "integer"}
+
+// validateJsonSchema checks that the document is a boolean schema or an object with valid "type", "properties"
+// and "items" keywords, so AKHQ can deserialize messages with it
+func validateJsonSchema(content []byte) error {
+	var schema any
+	if err := json.Unmarshal(content, &schema); err != nil {
+		return err
+	}
+	return validateJsonSchemaValue(schema, "schema")
+}
+
+func validateJsonSchemaValue(schema any, location string) error {
+	if _, isBool := schema.(bool); isBool {
+		return nil
+	}
+	object, isObject := schema.(map[string]any)
+	if !isObject {
+		return fmt.Errorf("%s must be an object or a boolean", location)
+	}
+	if schemaType, found := object["type"]; found {
+		types, isArray := schemaType.([]any)
+		if !isArray {
+			types = []any{schemaType}
+		}
+		for _, value := range types {
+			name, isString := value.(string)
+			if !isString || !slices.Contains(jsonSchemaTypes, name) {
+				return fmt.Errorf("%s has unknown type %v", location, value)
+			}
+		}
+	}
+	if properties, found := object["properties"]; found {
+		propertiesObject, isPropertiesObject := properties.(map[string]any)
+		if !isPropertiesObject {
+			return fmt.Errorf("properties of %s must be an object", location)
+		}
+		for name, property := range propertiesObject {
+			if err := validateJsonSchemaValue(property, fmt.Sprintf("property %s", name)); err != nil {
+				return err
+			}
 		}
 	}
-	return true, ""
+	if items, found := object["items"]; found {
+		if err := validateJsonSchemaValue(items, fmt.Sprintf("items of %s", location)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func getConfigFormat(config akhqconfigv1.Config) string {
+	if config.Format == "" {
+		return protobufFormat
+	}
+	return config.Format
 }
 
 func (r *AkhqConfigReconciler) deleteConfig(instance *akhqconfigv1.AkhqConfig, reqLogger logr.Logger) error {
@@ -176,57 +340,96 @@ func (r *AkhqConfigReconciler) deleteConfig(instance *akhqconfigv1.AkhqConfig, r
 	crFullName := fmt.Sprintf("%s-%s", instance.Name, instance.Namespace)
 	existingConfigs := dc.Deserialization.Protobuf.TopicsMapping
 
-	existingConfigs, mapToDelete := r.deleteDeserializationConfig(existingConfigs, crFullName)
-	if err := r.deleteBinaryConfigMap(r.Namespace, mapToDelete, reqLogger); err != nil {
-		return err
+	existingConfigs, _ = r.deleteDeserializationConfig(existingConfigs, crFullName)
+	for _, format := range schemaFormats {
+		schemaDeserialization := dc.getSchemaDeserialization(format)
+		schemaDeserialization.TopicsMapping, _ = r.deleteSchemaDeserializationConfig(schemaDeserialization.TopicsMapping, crFullName)
+		dc.setSchemaDeserialization(format, schemaDeserialization)
 	}
 
 	dc.Deserialization.Protobuf.TopicsMapping = existingConfigs
 	if err = r.updateDeserializationConfigMap(deserializationCM, *dc); err != nil {
 		return err
 	}
+	if err = r.deleteOrphanConfigMaps(r.Namespace, dc, crFullName, nil, reqLogger); err != nil {
+		return err
+	}
+	if err = r.deleteAccessRules(crFullName, reqLogger); err != nil {
+		return err
+	}
 
 	reqLogger.Info("Deleting has finished successfully")
 	return nil
 }
 
+// applyConfig adds configs of the CR to AKHQ deserialization config and returns warnings
+// about regular expressions which overlap with the ones of other CRs
 func (r *AkhqConfigReconciler) applyConfig(instance *akhqconfigv1.AkhqConfig,
-	reqLogger logr.Logger) error {
+	reqLogger logr.Logger) ([]string, error) {
 	reqLogger.Info("Start applying configs")
 
 	crConfigs := instance.Spec.Configs
 	crConfigsMap := make(map[string]akhqconfigv1.Config)
+	schemaConfigsMaps := make(map[string]map[string]akhqconfigv1.Config)
+	for _, format := range schemaFormats {
+		schemaConfigsMaps[format] = make(map[string]akhqconfigv1.Config)
+	}
 	for _, value := range crConfigs {
 		convertedString := strings.ReplaceAll(value.TopicRegex, ",", "|")
 		value.TopicRegex = convertedString
-		crConfigsMap[value.TopicRegex] = value
+		if format := getConfigFormat(value); format != protobufFormat {
+			schemaConfigsMaps[format][value.TopicRegex] = value
+		} else {
+			crConfigsMap[value.TopicRegex] = value
+		}
 	}
 	deserializationCM, err := akhqproto.CreateProtobufConfigMapIfNotExist(r.Namespace, r.Client, make(map[string]string), nil, nil)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	dc, err := r.getDeserializationConfig(deserializationCM)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	existingConfigs := dc.Deserialization.Protobuf.TopicsMapping
 	crFullName := fmt.Sprintf("%s-%s", instance.Name, instance.Namespace)
+	warnings := findOverlappingRegexes(dc, crFullName, crConfigs)
+	for _, warning := range warnings {
+		reqLogger.Info(warning)
+	}
 
 	existingConfigs, mapToUpdate := r.fullUpdateDeserializationConfig(existingConfigs, crConfigsMap, crFullName)
+	for _, format := range schemaFormats {
+		schemaDeserialization := dc.getSchemaDeserialization(format)
+		schemaConfigs, schemaFilesToUpdate := r.fullUpdateSchemaDeserializationConfig(
+			schemaDeserialization.TopicsMapping, schemaConfigsMaps[format], crFullName)
+		schemaDeserialization.TopicsMapping = schemaConfigs
+		dc.setSchemaDeserialization(format, schemaDeserialization)
+		for fileName, content := range schemaFilesToUpdate {
+			mapToUpdate[fileName] = content
+		}
+	}
 
-	if err = r.applyBinaryConfigMap(r.Namespace, mapToUpdate, deserializationCM.Labels, reqLogger); err != nil {
-		return err
+	shardNames, err := r.applyShardedConfigMaps(r.Namespace, crFullName, mapToUpdate, deserializationCM.Labels, reqLogger)
+	if err != nil {
+		return nil, err
 	}
 
 	dc.Deserialization.Protobuf.TopicsMapping = existingConfigs
 	if err = r.updateDeserializationConfigMap(deserializationCM, *dc); err != nil {
-		return err
+		return nil, err
+	}
+	if err = r.deleteOrphanConfigMaps(r.Namespace, dc, crFullName, shardNames, reqLogger); err != nil {
+		return nil, err
+	}
+	if err = r.applyAccessRules(instance, crFullName, reqLogger); err != nil {
+		return nil, err
 	}
 	reqLogger.Info("Applying has finished successfully")
 
-	return nil
+	return warnings, nil
 }
 
 func (r *AkhqConfigReconciler) updateCrStatus(instance *akhqconfigv1.AkhqConfig, isInvalid, isProcessed bool, problems string) error {
@@ -15,12 +15,14 @@
 package akhqconfig
 
 import (
+	"bytes"
 	"compress/gzip"
 	"context"
 	"encoding/base64"
 	"fmt"
-	"io"
-	"os"
+	"path"
+	"slices"
+	"sort"
 	"strings"
 
 	akhqconfigv1 "github.com/Netcracker/qubership-kafka/operator/api/v1"
@@ -29,13 +31,15 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/yaml"
 )
 
 const (
-	tmpFileName                 = "/tmp/protobuf"
-	tmpCompressedFileName       = "/tmp/compressed-proto.gz"
 	akhqDeserializationCMPrefix = "akhqdcm-"
+	// deserializationCMSizeBudget is the size of files in one config map, it leaves the room for metadata
+	// within 1 MiB limit of Kubernetes objects
+	deserializationCMSizeBudget = 900 * 1024
 )
 
 func (r *AkhqConfigReconciler) getConfigMap(configMapName, namespace string) (*corev1.ConfigMap, error) {
@@ -68,148 +72,165 @@ func (r *AkhqConfigReconciler) updateDeserializationConfigMap(deserializationCM
 	return nil
 }
 
-func (r *AkhqConfigReconciler) applyBinaryConfigMap(namespace string, mapToApply map[string]string, labels map[string]string, logger logr.Logger) error {
-	logger.Info(fmt.Sprintf("Applying %d AKHQ deserialization key binary config maps...", len(mapToApply)))
-
-	for configName, rawConfig := range mapToApply {
-		namespacedName := types.NamespacedName{Name: akhqDeserializationCMPrefix + configName, Namespace: namespace}
-		configAlreadyPresented := true
-		descKeysCM := &corev1.ConfigMap{}
-
-		// Try to load config map from the cluster
-		if err := r.Client.Get(context.TODO(), namespacedName, descKeysCM); err != nil {
-			if !k8sErrors.IsNotFound(err) {
-				return err
-			}
-			logger.Info(fmt.Sprintf("Config map %s was not found in cluster", namespacedName.Name))
-			configAlreadyPresented = false
+// applyShardedConfigMaps compresses deserialization files of the CR and stores them to config maps.
+// Files are distributed across several config maps if their total size exceeds the size budget of one config map.
+// It returns the names of config maps which contain the files of the CR.
+func (r *AkhqConfigReconciler) applyShardedConfigMaps(namespace string, crFullName string, files map[string]string,
+	labels map[string]string, logger logr.Logger) ([]string, error) {
+	compressedFiles := make(map[string][]byte, len(files))
+	for fileName, rawConfig := range files {
+		if rawConfig == "" {
+			// the file is removed from deserialization config
+			continue
 		}
-
-		// Compress raw config
 		content, err := base64.StdEncoding.DecodeString(rawConfig)
 		if err != nil {
+			logger.Info(fmt.Sprintf("Can not decode %s file, skipping it", fileName))
 			continue
 		}
-		compressedBytes, err := compressBytes(&content)
+		compressedBytes, err := compressBytes(content)
 		if err != nil {
-			return err
+			return nil, err
 		}
-
 		// Store with ".gz" suffix because the AKHQ decomposes it using `gzip` tool, it requires to have `.gz` suffix.
-		logger.Info(fmt.Sprintf("Storing %s file (compressed)", configName))
-		if descKeysCM.BinaryData == nil {
-			descKeysCM.BinaryData = make(map[string][]byte, 1)
+		compressedFiles[fileName+".gz"] = compressedBytes
+	}
+	shards, err := shardFiles(compressedFiles, deserializationCMSizeBudget)
+	if err != nil {
+		return nil, err
+	}
+	logger.Info(fmt.Sprintf("Applying %d AKHQ deserialization config maps with %d files...", len(shards), len(compressedFiles)))
+
+	shardNames := make([]string, 0, len(shards))
+	for i, shard := range shards {
+		namespacedName := types.NamespacedName{Name: getShardName(crFullName, i), Namespace: namespace}
+		descKeysCM := &corev1.ConfigMap{}
+		configAlreadyPresented := true
+		if err = r.Client.Get(context.TODO(), namespacedName, descKeysCM); err != nil {
+			if !k8sErrors.IsNotFound(err) {
+				return nil, err
+			}
+			configAlreadyPresented = false
+			descKeysCM.Name = namespacedName.Name
+			descKeysCM.Namespace = namespace
 		}
-		descKeysCM.BinaryData[configName+".gz"] = *compressedBytes
+		descKeysCM.BinaryData = shard
 		descKeysCM.Labels = labels
 
-		// Save the result
 		if !configAlreadyPresented {
 			logger.Info(fmt.Sprintf("Creating %s config map in %s namespace", namespacedName.Name, namespacedName.Namespace))
-			descKeysCM.Name = namespacedName.Name
-			descKeysCM.Namespace = namespace
-
-			if err := r.Client.Create(context.TODO(), descKeysCM); err != nil {
+			if err = r.Client.Create(context.TODO(), descKeysCM); err != nil {
 				logger.Error(err, fmt.Sprintf("Error while %s config map creation", namespacedName.Name))
-				return err
+				return nil, err
+			}
+		} else {
+			logger.Info(fmt.Sprintf("Updating %s config map in %s namespace", namespacedName.Name, namespacedName.Namespace))
+			if err = r.Client.Update(context.TODO(), descKeysCM); err != nil {
+				return nil, err
 			}
-
-			continue
-		}
-
-		logger.Info(fmt.Sprintf("Updating %s config map in %s namespace.", namespacedName.Name, namespacedName.Namespace))
-		if err := r.Client.Update(context.TODO(), descKeysCM); err != nil {
-			return err
 		}
-
+		shardNames = append(shardNames, namespacedName.Name)
 	}
-
-	return nil
+	return shardNames, nil
 }
 
-func (r *AkhqConfigReconciler) deleteBinaryConfigMap(namespace string, mapToDelete map[string]string, logger logr.Logger) error {
-	logger.Info(fmt.Sprintf("Deleting %d AKHQ deserialization key binary config maps...", len(mapToDelete)))
-
-	for configName := range mapToDelete {
-		logger.Info(fmt.Sprintf("Checking Existence of [%s] config map", configName))
-
-		foundConfigMap := &corev1.ConfigMap{}
-		configMapName := akhqDeserializationCMPrefix + configName
-		namespacedName := types.NamespacedName{Name: configMapName, Namespace: namespace}
-
-		err := r.Client.Get(context.TODO(), namespacedName, foundConfigMap)
-		if err != nil {
-			if k8sErrors.IsNotFound(err) {
-				logger.Info(fmt.Sprintf("Deserialization config map %s not found, so nothing to delete", namespacedName.Name))
-				continue
-			}
-			return err
+// deleteOrphanConfigMaps removes deserialization config maps which files are not used in deserialization config
+// anymore, as well as previous config maps of the CR which are replaced with the shards from shardNames
+func (r *AkhqConfigReconciler) deleteOrphanConfigMaps(namespace string, dc *DeserializationConfig, crFullName string,
+	shardNames []string, logger logr.Logger) error {
+	configMaps := &corev1.ConfigMapList{}
+	if err := r.Client.List(context.TODO(), configMaps, client.InNamespace(namespace)); err != nil {
+		return err
+	}
+	referencedFiles := dc.getReferencedFiles()
+	for _, configMap := range configMaps.Items {
+		if !strings.HasPrefix(configMap.Name, akhqDeserializationCMPrefix) || slices.Contains(shardNames, configMap.Name) {
+			continue
 		}
-
-		if err := r.Client.Delete(context.TODO(), foundConfigMap); err != nil {
-			logger.Error(err, fmt.Sprintf("Error while %s config map deletion", namespacedName.Name))
+		if !isOrphanConfigMap(&configMap, referencedFiles, crFullName) {
+			continue
+		}
+		logger.Info(fmt.Sprintf("Deleting orphan deserialization config map %s", configMap.Name))
+		if err := r.Client.Delete(context.TODO(), &configMap); err != nil && !k8sErrors.IsNotFound(err) {
+			logger.Error(err, fmt.Sprintf("Error while %s config map deletion", configMap.Name))
 			return err
 		}
 	}
-
 	return nil
 }
 
-func compressBytes(bytes *[]byte) (*[]byte, error) {
-
-	if err := storeBytesToTmpFile(bytes); err != nil {
-		return nil, err
+// isOrphanConfigMap checks that config map does not contain files which are used by other CRs.
+// Files of the current CR are stored in its shards, so other config maps with such files are orphans too.
+func isOrphanConfigMap(configMap *corev1.ConfigMap, referencedFiles map[string]string, crFullName string) bool {
+	for key := range configMap.BinaryData {
+		owner, found := referencedFiles[strings.TrimSuffix(key, ".gz")]
+		if found && owner != crFullName {
+			return false
+		}
 	}
+	return true
+}
 
-	if err := compressBytesFromTmpFile(); err != nil {
-		return nil, err
+// getReferencedFiles returns deserialization files used in the config with the names of CRs which they belong to
+func (dc *DeserializationConfig) getReferencedFiles() map[string]string {
+	referencedFiles := make(map[string]string)
+	for _, mapping := range dc.Deserialization.Protobuf.TopicsMapping {
+		if mapping.DescriptorFile != "" {
+			referencedFiles[path.Base(mapping.DescriptorFile)] = mapping.Name
+		}
 	}
-	defer func() { _ = os.Remove(tmpFileName) }()
-	defer func() { _ = os.Remove(tmpCompressedFileName) }()
-
-	fileBytes, err := os.ReadFile(tmpCompressedFileName)
-	if err != nil {
-		return nil, err
+	for _, format := range schemaFormats {
+		for _, mapping := range dc.getSchemaDeserialization(format).TopicsMapping {
+			for _, schemaFile := range []string{mapping.KeySchemaFile, mapping.ValueSchemaFile} {
+				if schemaFile != "" {
+					referencedFiles[path.Base(schemaFile)] = mapping.Name
+				}
+			}
+		}
 	}
-
-	return &fileBytes, nil
+	return referencedFiles
 }
 
-func compressBytesFromTmpFile() error {
-	tmpFile, err := os.Open(tmpFileName)
-	if err != nil {
-		return err
+// shardFiles distributes files across shards which total size does not exceed the budget.
+// Files are processed in the order of names, so the same files are always placed to the same shards.
+func shardFiles(files map[string][]byte, budget int) ([]map[string][]byte, error) {
+	fileNames := make([]string, 0, len(files))
+	for fileName := range files {
+		fileNames = append(fileNames, fileName)
 	}
-	defer func() { _ = tmpFile.Close() }()
-
-	compressedTmp, err := os.Create(tmpCompressedFileName)
-	if err != nil {
-		return err
-	}
-	defer func() { _ = compressedTmp.Close() }()
-
-	writer := gzip.NewWriter(compressedTmp)
-	defer func() { _ = writer.Close() }()
-
-	if _, err = io.Copy(writer, tmpFile); err != nil {
-		return err
+	sort.Strings(fileNames)
+	var shards []map[string][]byte
+	shardSize := 0
+	for _, fileName := range fileNames {
+		fileSize := len(fileName) + len(files[fileName])
+		if fileSize > budget {
+			return nil, fmt.Errorf("compressed file %s has size %d bytes which exceeds the config map size budget of %d bytes",
+				fileName, fileSize, budget)
+		}
+		if len(shards) == 0 || shardSize+fileSize > budget {
+			shards = append(shards, make(map[string][]byte))
+			shardSize = 0
+		}
+		shards[len(shards)-1][fileName] = files[fileName]
+		shardSize += fileSize
 	}
+	return shards, nil
+}
 
-	return nil
+func getShardName(crFullName string, index int) string {
+	return fmt.Sprintf("%s%s-shard-%d", akhqDeserializationCMPrefix, crFullName, index)
 }
 
-func storeBytesToTmpFile(bytes *[]byte) error {
-	tmpFile, err := os.Create(tmpFileName)
-	if err != nil {
-		return err
+func compressBytes(content []byte) ([]byte, error) {
+	var buffer bytes.Buffer
+	writer := gzip.NewWriter(&buffer)
+	if _, err := writer.Write(content); err != nil {
+		return nil, err
 	}
-	defer func() { _ = tmpFile.Close() }()
-
-	if _, err = tmpFile.Write(*bytes); err != nil {
-		return err
+	if err := writer.Close(); err != nil {
+		return nil, err
 	}
-
-	return nil
+	return buffer.Bytes(), nil
 }
 
 func (r *AkhqConfigReconciler) fullUpdateDeserializationConfig(existingConfigs []TopicMapping,
@@ -277,3 +298,83 @@ func (r *AkhqConfigReconciler) deleteDeserializationConfig(existingConfigs []Top
 	}
 	return existingConfigs, mapToDelete
 }
+
+// fullUpdateSchemaDeserializationConfig replaces Avro or JSON schema mappings of the CR with the ones from crConfigsMap.
+// New mappings are placed instead of the first existing mapping of the CR to keep the order of regular expressions.
+func (r *AkhqConfigReconciler) fullUpdateSchemaDeserializationConfig(existingConfigs []SchemaTopicMapping,
+	crConfigsMap map[string]akhqconfigv1.Config, crFullName string) ([]SchemaTopicMapping, map[string]string) {
+	insertPosition := slices.IndexFunc(existingConfigs, func(mapping SchemaTopicMapping) bool {
+		return mapping.Name == crFullName
+	})
+	existingConfigs, mapToUpdate := r.deleteSchemaDeserializationConfig(existingConfigs, crFullName)
+	if insertPosition < 0 {
+		insertPosition = len(existingConfigs)
+	}
+	regexes := make([]string, 0, len(crConfigsMap))
+	for regex := range crConfigsMap {
+		regexes = append(regexes, regex)
+	}
+	sort.Strings(regexes)
+	toInsert := make([]SchemaTopicMapping, 0, len(crConfigsMap))
+	for _, regex := range regexes {
+		value := crConfigsMap[regex]
+		fileName := fmt.Sprintf("%s-%s", crFullName, util.StringHash(value.TopicRegex))
+		topicMapping := SchemaTopicMapping{
+			TopicRegex: value.TopicRegex,
+			Name:       crFullName,
+		}
+		if value.KeySchemaFileBase64 != "" {
+			topicMapping.KeySchemaFile = fmt.Sprintf("descs/%s-key", fileName)
+			mapToUpdate[fileName+"-key"] = value.KeySchemaFileBase64
+		}
+		if value.ValueSchemaFileBase64 != "" {
+			topicMapping.ValueSchemaFile = fmt.Sprintf("descs/%s-value", fileName)
+			mapToUpdate[fileName+"-value"] = value.ValueSchemaFileBase64
+		}
+		toInsert = append(toInsert, topicMapping)
+	}
+	return slices.Insert(existingConfigs, insertPosition, toInsert...), mapToUpdate
+}
+
+func (r *AkhqConfigReconciler) deleteSchemaDeserializationConfig(existingConfigs []SchemaTopicMapping,
+	crFullName string) ([]SchemaTopicMapping, map[string]string) {
+	mapToDelete := make(map[string]string)
+	remainingConfigs := make([]SchemaTopicMapping, 0, len(existingConfigs))
+	for _, existedConfig := range existingConfigs {
+		if existedConfig.Name != crFullName {
+			remainingConfigs = append(remainingConfigs, existedConfig)
+			continue
+		}
+		for _, schemaFile := range []string{existedConfig.KeySchemaFile, existedConfig.ValueSchemaFile} {
+			if schemaFile != "" {
+				mapToDelete[path.Base(schemaFile)] = ""
+			}
+		}
+	}
+	return remainingConfigs, mapToDelete
+}
+
+// getSchemaDeserialization returns AKHQ deserialization section for specified schema format
+func (dc *DeserializationConfig) getSchemaDeserialization(format string) *SchemaDeserialization {
+	schemaDeserialization := dc.Deserialization.AvroRaw
+	if format == jsonSchemaFormat {
+		schemaDeserialization = dc.Deserialization.JsonSchema
+	}
+	if schemaDeserialization == nil {
+		return &SchemaDeserialization{SchemasFolder: descriptorsFolder}
+	}
+	return schemaDeserialization
+}
+
+// setSchemaDeserialization stores AKHQ deserialization section for specified schema format,
+// the section is removed when it does not contain topic mappings
+func (dc *DeserializationConfig) setSchemaDeserialization(format string, schemaDeserialization *SchemaDeserialization) {
+	if len(schemaDeserialization.TopicsMapping) == 0 {
+		schemaDeserialization = nil
+	}
+	if format == jsonSchemaFormat {
+		dc.Deserialization.JsonSchema = schemaDeserialization
+	} else {
+		dc.Deserialization.AvroRaw = schemaDeserialization
+	}
+}
@@ -15,10 +15,18 @@
 package akhqconfig
 
 import (
+	"bytes"
+	"compress/gzip"
 	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
 	akhqconfigv1 "github.com/Netcracker/qubership-kafka/operator/api/v1"
 	"github.com/Netcracker/qubership-kafka/operator/util"
-	"testing"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
 )
 
 var r = &AkhqConfigReconciler{}
@@ -516,7 +524,7 @@ func Test_validate(t *testing.T) {
 		{Configs: []akhqconfigv1.Config{
 			{
 				TopicRegex:           "topic1",
-				MessageType:          "org.qubership.Message",
+				MessageType:          "tutorial.AddressBook",
 				DescriptorFileBase64: "CuMCCghteS5wcm90bxIIdHV0b3JpYWwi/AEKBlBlcnNvbhISCgRuYW1lGAEgASgJUgRuYW1lEg4KAmlkGAIgASgFUgJpZBIUCgVlbWFpbBgDIAEoCVIFZW1haWwSNAoGcGhvbmVzGAQgAygLMhwudHV0b3JpYWwuUGVyc29uLlBob25lTnVtYmVyUgZwaG9uZXMaVQoLUGhvbmVOdW1iZXISFgoGbnVtYmVyGAEgASgJUgZudW1iZXISLgoEdHlwZRgCIAEoDjIaLnR1dG9yaWFsLlBlcnNvbi5QaG9uZVR5cGVSBHR5cGUiKwoJUGhvbmVUeXBlEgoKBk1PQklMRRAAEggKBEhPTUUQARIICgRXT1JLEAIiNwoLQWRkcmVzc0Jvb2sSKAoGcGVvcGxlGAEgAygLMhAudHV0b3JpYWwuUGVyc29uUgZwZW9wbGVCDVoLLi9yZXNvdXJjZXNiBnByb3RvMw==",
 			},
 		},
@@ -527,7 +535,7 @@ func Test_validate(t *testing.T) {
 		{Configs: []akhqconfigv1.Config{
 			{
 				TopicRegex:           "topic1",
-				MessageType:          "org.qubership.Message",
+				MessageType:          "tutorial.AddressBook",
 				DescriptorFileBase64: "jfhdghgrger",
 			},
 		},
@@ -538,25 +546,293 @@ func Test_validate(t *testing.T) {
 		{Configs: []akhqconfigv1.Config{
 			{
 				TopicRegex:           "topic1",
-				MessageType:          "org.qubership.Message",
+				MessageType:          "tutorial.AddressBook",
 				DescriptorFileBase64: "CuMCCghteS5wcm90bxIIdHV0b3JpYWwi/AEKBlBlcnNvbhISCgRuYW1lGAEgASgJUgRuYW1lEg4KAmlkGAIgASgFUgJpZBIUCgVlbWFpbBgDIAEoCVIFZW1haWwSNAoGcGhvbmVzGAQgAygLMhwudHV0b3JpYWwuUGVyc29uLlBob25lTnVtYmVyUgZwaG9uZXMaVQoLUGhvbmVOdW1iZXISFgoGbnVtYmVyGAEgASgJUgZudW1iZXISLgoEdHlwZRgCIAEoDjIaLnR1dG9yaWFsLlBlcnNvbi5QaG9uZVR5cGVSBHR5cGUiKwoJUGhvbmVUeXBlEgoKBk1PQklMRRAAEggKBEhPTUUQARIICgRXT1JLEAIiNwoLQWRkcmVzc0Jvb2sSKAoGcGVvcGxlGAEgAygLMhAudHV0b3JpYWwuUGVyc29uUgZwZW9wbGVCDVoLLi9yZXNvdXJjZXNiBnByb3RvMw==",
 			},
 			{
 				TopicRegex:           "topic1",
-				MessageType:          "org.qubership.Employee",
+				MessageType:          "tutorial.Person",
 				DescriptorFileBase64: "CuMCCghteS5wcm90bxIIdHV0b3JpYWwi/AEKBlBlcnNvbhISCgRuYW1lGAEgASgJUgRuYW1lEg4KAmlkGAIgASgFUgJpZBIUCgVlbWFpbBgDIAEoCVIFZW1haWwSNAoGcGhvbmVzGAQgAygLMhwudHV0b3JpYWwuUGVyc29uLlBob25lTnVtYmVyUgZwaG9uZXMaVQoLUGhvbmVOdW1iZXISFgoGbnVtYmVyGAEgASgJUgZudW1iZXISLgoEdHlwZRgCIAEoDjIaLnR1dG9yaWFsLlBlcnNvbi5QaG9uZVR5cGVSBHR5cGUiKwoJUGhvbmVUeXBlEgoKBk1PQklMRRAAEggKBEhPTUUQARIICgRXT1JLEAIiNwoLQWRkcmVzc0Jvb2sSKAoGcGVvcGxlGAEgAygLMhAudHV0b3JpYWwuUGVyc29uUgZwZW9wbGVCDVoLLi9yZXNvdXJjZXNiBnByb3RvMw==",
 			},
 		},
 			ExpectedBool:        false,
 			ExpectedDescription: fmt.Sprintf(duplicateValidationError, "topic1"),
 		},
+
+		{Configs: []akhqconfigv1.Config{
+			{
+				TopicRegex:            "topic1",
+				Format:                "avro",
+				ValueSchemaFileBase64: "eyJ0eXBlIjoicmVjb3JkIiwibmFtZSI6IkVtcGxveWVlIiwiZmllbGRzIjpbeyJuYW1lIjoibmFtZSIsInR5cGUiOiJzdHJpbmcifV19",
+			},
+		},
+			ExpectedBool:        true,
+			ExpectedDescription: "",
+		},
+
+		{Configs: []akhqconfigv1.Config{
+			{
+				TopicRegex:          "topic1",
+				Format:              "json-schema",
+				KeySchemaFileBase64: "dHlwZTogb2JqZWN0",
+			},
+		},
+			ExpectedBool:        false,
+			ExpectedDescription: fmt.Sprintf(schemaValidationError, "key-schema-file-base64", "topic1"),
+		},
+
+		{Configs: []akhqconfigv1.Config{
+			{
+				TopicRegex:            "topic1",
+				Format:                "json-schema",
+				ValueSchemaFileBase64: "eyJ0eXBlIjoib2JqZWN0IiwicHJvcGVydGllcyI6eyJpZCI6eyJ0eXBlIjoic3RyaW5nIn0sInRhZ3MiOnsidHlwZSI6ImFycmF5IiwiaXRlbXMiOnsidHlwZSI6InN0cmluZyJ9fX19",
+			},
+		},
+			ExpectedBool:        true,
+			ExpectedDescription: "",
+		},
+
+		{Configs: []akhqconfigv1.Config{
+			{
+				TopicRegex:            "topic1",
+				Format:                "json-schema",
+				ValueSchemaFileBase64: "eyJ0eXBlIjoib2JqZWN0IiwicHJvcGVydGllcyI6eyJpZCI6eyJ0eXBlIjoidGV4dCJ9fX0=",
+			},
+		},
+			ExpectedBool: false,
+			ExpectedDescription: fmt.Sprintf(jsonSchemaValidationError, "value-schema-file-base64", "topic1",
+				"property id has unknown type text"),
+		},
+
+		{Configs: []akhqconfigv1.Config{
+			{
+				TopicRegex:          "topic1",
+				Format:              "json-schema",
+				KeySchemaFileBase64: "WyJzdHJpbmciXQ==",
+			},
+		},
+			ExpectedBool: false,
+			ExpectedDescription: fmt.Sprintf(jsonSchemaValidationError, "key-schema-file-base64", "topic1",
+				"schema must be an object or a boolean"),
+		},
+
+		{Configs: []akhqconfigv1.Config{
+			{
+				TopicRegex: "topic1",
+				Format:     "avro",
+			},
+		},
+			ExpectedBool: false,
+			ExpectedDescription: fmt.Sprintf(requiredValidationError,
+				"key-schema-file-base64 or value-schema-file-base64", "avro", "topic1"),
+		},
+
+		{Configs: []akhqconfigv1.Config{
+			{
+				TopicRegex: "topic1",
+			},
+		},
+			ExpectedBool: false,
+			ExpectedDescription: fmt.Sprintf(requiredValidationError, "message-type", "protobuf", "topic1") + "; " +
+				fmt.Sprintf(requiredValidationError, "descriptor-file-base64", "protobuf", "topic1"),
+		},
 	}
 
 	for _, test := range tests {
-		boolRes, description := r.validateCrConfig(test.Configs)
+		problems := r.validateCrConfig(test.Configs)
+		boolRes, description := len(problems) == 0, strings.Join(problems, "; ")
 		if test.ExpectedBool != boolRes || test.ExpectedDescription != description {
 			t.Errorf("given boolRes - [%v], given description - [%v]; expected boolRes - [%v], expected description - [%v]",
 				boolRes, description, test.ExpectedBool, test.ExpectedDescription)
 		}
 	}
 }
+
+func Test_validateCollectsAllProblems(t *testing.T) {
+	r := &AkhqConfigReconciler{}
+	instance := &akhqconfigv1.AkhqConfig{Spec: akhqconfigv1.AkhqConfigSpec{
+		Configs: []akhqconfigv1.Config{
+			{TopicRegex: "topic1"},
+			{TopicRegex: "topic1"},
+			{TopicRegex: "topic2", Format: "avro"},
+		},
+		Access: []akhqconfigv1.Access{
+			{LdapGroup: "support", Role: "reader"},
+			{LdapGroup: "support", Role: "admin"},
+		},
+	}}
+	problems := r.validate(instance)
+	expected := []string{
+		fmt.Sprintf(requiredValidationError, "message-type", "protobuf", "topic1"),
+		fmt.Sprintf(requiredValidationError, "descriptor-file-base64", "protobuf", "topic1"),
+		fmt.Sprintf(duplicateValidationError, "topic1"),
+		fmt.Sprintf(requiredValidationError, "key-schema-file-base64 or value-schema-file-base64", "avro", "topic2"),
+	}
+	if len(problems) != len(expected)+1 {
+		t.Fatalf("given problems - %v, expected %d problems", problems, len(expected)+1)
+	}
+	for i, problem := range expected {
+		if problems[i] != problem {
+			t.Errorf("given problem - [%s], expected problem - [%s]", problems[i], problem)
+		}
+	}
+}
+
+func Test_schemaDeserializationConfigFullUpdate(t *testing.T) {
+	existingConfigs := []SchemaTopicMapping{
+		{
+			TopicRegex:    "topic1",
+			Name:          "akhq-kafka-service-1",
+			KeySchemaFile: fmt.Sprintf("descs/akhq-kafka-service-1-%s-key", suffixTopic1),
+		},
+		{
+			TopicRegex:      "topic2",
+			Name:            "akhq-kafka-service",
+			ValueSchemaFile: fmt.Sprintf("descs/akhq-kafka-service-%s-value", suffixTopic2),
+		},
+		{
+			TopicRegex:      "topic4",
+			Name:            "akhq-kafka-service-1",
+			ValueSchemaFile: fmt.Sprintf("descs/akhq-kafka-service-1-%s-value", suffixTopic4),
+		},
+	}
+
+	crConfigs := map[string]akhqconfigv1.Config{
+		"topic3": {
+			TopicRegex:            "topic3",
+			Format:                "avro",
+			KeySchemaFileBase64:   "eyJ0eXBlIjoic3RyaW5nIn0=",
+			ValueSchemaFileBase64: "eyJ0eXBlIjoibG9uZyJ9",
+		},
+	}
+
+	actualConfigs, actualMapToUpdate := r.fullUpdateSchemaDeserializationConfig(existingConfigs, crConfigs, "akhq-kafka-service")
+
+	expectedConfigs := []SchemaTopicMapping{
+		{
+			TopicRegex:    "topic1",
+			Name:          "akhq-kafka-service-1",
+			KeySchemaFile: fmt.Sprintf("descs/akhq-kafka-service-1-%s-key", suffixTopic1),
+		},
+		{
+			TopicRegex:      "topic3",
+			Name:            "akhq-kafka-service",
+			KeySchemaFile:   fmt.Sprintf("descs/akhq-kafka-service-%s-key", suffixTopic3),
+			ValueSchemaFile: fmt.Sprintf("descs/akhq-kafka-service-%s-value", suffixTopic3),
+		},
+		{
+			TopicRegex:      "topic4",
+			Name:            "akhq-kafka-service-1",
+			ValueSchemaFile: fmt.Sprintf("descs/akhq-kafka-service-1-%s-value", suffixTopic4),
+		},
+	}
+
+	expectedMapToUpdate := map[string]string{
+		fmt.Sprintf("akhq-kafka-service-%s-value", suffixTopic2): "",
+		fmt.Sprintf("akhq-kafka-service-%s-key", suffixTopic3):   "eyJ0eXBlIjoic3RyaW5nIn0=",
+		fmt.Sprintf("akhq-kafka-service-%s-value", suffixTopic3): "eyJ0eXBlIjoibG9uZyJ9",
+	}
+
+	if !slices.Equal(actualConfigs, expectedConfigs) {
+		t.Errorf("actualConfigs - %v are not equal expectedConfigs - %v", actualConfigs, expectedConfigs)
+	}
+
+	if !areMapsEqual(actualMapToUpdate, expectedMapToUpdate) {
+		t.Errorf("actualMapToUpdate - %v is not equal expectedMapToUpdate - %v", actualMapToUpdate, expectedMapToUpdate)
+	}
+}
+
+func Test_schemaDeserializationSection(t *testing.T) {
+	dc := &DeserializationConfig{}
+	avro := dc.getSchemaDeserialization(avroFormat)
+	if avro.SchemasFolder != descriptorsFolder {
+		t.Errorf("schemas folder - %s is not equal expected - %s", avro.SchemasFolder, descriptorsFolder)
+	}
+	avro.TopicsMapping = []SchemaTopicMapping{{TopicRegex: "topic1", Name: "akhq-kafka-service"}}
+	dc.setSchemaDeserialization(avroFormat, avro)
+	dc.setSchemaDeserialization(jsonSchemaFormat, dc.getSchemaDeserialization(jsonSchemaFormat))
+	if dc.Deserialization.AvroRaw == nil || dc.Deserialization.JsonSchema != nil {
+		t.Errorf("avro section - %v must be present and json schema section - %v must be absent",
+			dc.Deserialization.AvroRaw, dc.Deserialization.JsonSchema)
+	}
+
+	binDc, err := yaml.Marshal(dc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(binDc), "avro-raw:") || strings.Contains(string(binDc), "json-schema:") {
+		t.Errorf("unexpected deserialization config - %s", string(binDc))
+	}
+}
+
+func Test_shardFiles(t *testing.T) {
+	files := map[string][]byte{
+		"c.gz": make([]byte, 40),
+		"a.gz": make([]byte, 50),
+		"b.gz": make([]byte, 40),
+	}
+
+	shards, err := shardFiles(files, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(shards) != 2 {
+		t.Fatalf("shards count - %d is not equal expected - 2", len(shards))
+	}
+	if _, ok := shards[0]["a.gz"]; !ok || len(shards[0]) != 2 {
+		t.Errorf("first shard - %v must contain a.gz and b.gz files", shards[0])
+	}
+	if _, ok := shards[1]["c.gz"]; !ok || len(shards[1]) != 1 {
+		t.Errorf("second shard - %v must contain c.gz file only", shards[1])
+	}
+
+	if _, err = shardFiles(map[string][]byte{"a.gz": make([]byte, 100)}, 100); err == nil {
+		t.Errorf("file which exceeds the budget must not be sharded")
+	}
+}
+
+func Test_compressBytes(t *testing.T) {
+	content := []byte(strings.Repeat("descriptor", 100))
+	compressed, err := compressBytes(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reader, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	decompressed, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(content, decompressed) {
+		t.Errorf("decompressed content is not equal to the original one")
+	}
+}
+
+func Test_isOrphanConfigMap(t *testing.T) {
+	dc := &DeserializationConfig{}
+	dc.Deserialization.Protobuf.TopicsMapping = []TopicMapping{
+		{TopicRegex: "topic1", Name: "akhq-config-1", DescriptorFile: "descs/akhq-config-1-" + suffixTopic1},
+		{TopicRegex: "topic2", Name: "akhq-config-2", DescriptorFile: "descs/akhq-config-2-" + suffixTopic2},
+	}
+	referencedFiles := dc.getReferencedFiles()
+
+	tests := []struct {
+		binaryData     map[string][]byte
+		expectedOrphan bool
+	}{
+		{map[string][]byte{"akhq-config-2-" + suffixTopic2 + ".gz": nil}, false},
+		// legacy config map of the current CR which files are moved to shards
+		{map[string][]byte{"akhq-config-1-" + suffixTopic1 + ".gz": nil}, true},
+		{map[string][]byte{"akhq-config-3-" + suffixTopic3 + ".gz": nil}, true},
+		{map[string][]byte{}, true},
+	}
+	for _, test := range tests {
+		configMap := &corev1.ConfigMap{BinaryData: test.binaryData}
+		if isOrphanConfigMap(configMap, referencedFiles, "akhq-config-1") != test.expectedOrphan {
+			t.Errorf("config map with files %v must have orphan state %v", test.binaryData, test.expectedOrphan)
+		}
+	}
+}
@@ -0,0 +1,242 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package akhqconfig
+
+import (
+	"fmt"
+	"regexp/syntax"
+	"sort"
+	"strings"
+	"unicode"
+
+	akhqconfigv1 "github.com/Netcracker/qubership-kafka/operator/api/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// parseFileDescriptorSet builds registry of the files from descriptor set generated by protoc.
+// Imports which are not included to the set are allowed because AKHQ does not need them to find message types.
+func parseFileDescriptorSet(content []byte) (*protoregistry.Files, error) {
+	descriptorSet := &descriptorpb.FileDescriptorSet{}
+	if err := proto.Unmarshal(content, descriptorSet); err != nil {
+		return nil, err
+	}
+	if len(descriptorSet.File) == 0 {
+		return nil, fmt.Errorf("descriptor set does not contain files")
+	}
+	return protodesc.FileOptions{AllowUnresolvable: true}.NewFiles(descriptorSet)
+}
+
+// hasMessageType checks whether files contain message with specified fully-qualified name
+// or top-level message with specified short name
+func hasMessageType(files *protoregistry.Files, messageType string) bool {
+	if descriptor, err := files.FindDescriptorByName(protoreflect.FullName(messageType)); err == nil {
+		_, isMessage := descriptor.(protoreflect.MessageDescriptor)
+		return isMessage
+	}
+	found := false
+	files.RangeFiles(func(file protoreflect.FileDescriptor) bool {
+		messages := file.Messages()
+		for i := 0; i < messages.Len(); i++ {
+			if string(messages.Get(i).Name()) == messageType {
+				found = true
+				return false
+			}
+		}
+		return true
+	})
+	return found
+}
+
+// findOverlappingRegexes compares regular expressions of the CR with the ones
+// which other CRs have already added to AKHQ deserialization config
+func findOverlappingRegexes(dc *DeserializationConfig, crFullName string, crConfigs []akhqconfigv1.Config) []string {
+	otherRegexes := make(map[string]string)
+	for _, mapping := range dc.Deserialization.Protobuf.TopicsMapping {
+		if mapping.Name != crFullName {
+			otherRegexes[mapping.TopicRegex] = mapping.Name
+		}
+	}
+	for _, format := range schemaFormats {
+		for _, mapping := range dc.getSchemaDeserialization(format).TopicsMapping {
+			if mapping.Name != crFullName {
+				otherRegexes[mapping.TopicRegex] = mapping.Name
+			}
+		}
+	}
+	sortedRegexes := make([]string, 0, len(otherRegexes))
+	for regex := range otherRegexes {
+		sortedRegexes = append(sortedRegexes, regex)
+	}
+	sort.Strings(sortedRegexes)
+
+	var problems []string
+	for _, config := range crConfigs {
+		regex := strings.ReplaceAll(config.TopicRegex, ",", "|")
+		for _, otherRegex := range sortedRegexes {
+			if areRegexesOverlapping(regex, otherRegex) {
+				problems = append(problems,
+					fmt.Sprintf(overlappingRegexWarning, config.TopicRegex, otherRegex, otherRegexes[otherRegex]))
+			}
+		}
+	}
+	return problems
+}
+
+// areRegexesOverlapping checks whether there is a topic name which matches both regular expressions.
+// The regular expressions are compiled to automatons, and their product is traversed to find a path
+// to the match state which consists of characters allowed in topic names.
+func areRegexesOverlapping(first string, second string) bool {
+	firstProg, err := compileTopicRegex(first)
+	if err != nil {
+		return false
+	}
+	secondProg, err := compileTopicRegex(second)
+	if err != nil {
+		return false
+	}
+	type state struct {
+		first  uint32
+		second uint32
+	}
+	visited := make(map[state]bool)
+	queue := []state{{first: uint32(firstProg.Start), second: uint32(secondProg.Start)}}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if visited[current] {
+			continue
+		}
+		visited[current] = true
+		firstInsts, firstMatched := instClosure(firstProg, current.first)
+		secondInsts, secondMatched := instClosure(secondProg, current.second)
+		// the empty string is not a topic name, so the match state is checked after the first character only
+		isStart := current.first == uint32(firstProg.Start) && current.second == uint32(secondProg.Start)
+		if firstMatched && secondMatched && !isStart {
+			return true
+		}
+		for _, firstInst := range firstInsts {
+			for _, secondInst := range secondInsts {
+				if areRangesIntersecting(instRanges(&firstProg.Inst[firstInst]), instRanges(&secondProg.Inst[secondInst]),
+					topicNameRanges) {
+					queue = append(queue, state{first: firstProg.Inst[firstInst].Out, second: secondProg.Inst[secondInst].Out})
+				}
+			}
+		}
+	}
+	return false
+}
+
+// topicNameRanges are the characters allowed in Kafka topic names
+var topicNameRanges = [][2]rune{{'-', '.'}, {'0', '9'}, {'A', 'Z'}, {'_', '_'}, {'a', 'z'}}
+
+// compileTopicRegex compiles the regular expression to automaton which matches the whole topic name
+// as AKHQ does
+func compileTopicRegex(regex string) (*syntax.Prog, error) {
+	parsed, err := syntax.Parse(fmt.Sprintf("^(?:%s)$", regex), syntax.Perl)
+	if err != nil {
+		return nil, err
+	}
+	return syntax.Compile(parsed.Simplify())
+}
+
+// instClosure returns the instructions which consume characters and are reachable from pc without consuming,
+// and whether the match state is reachable. Assertions of text beginning can not be satisfied after
+// the first character, and no character can be consumed after assertions of text end.
+// Word boundaries are not checked, so the overlapping can be reported for such regular expressions in excess.
+func instClosure(prog *syntax.Prog, pc uint32) ([]uint32, bool) {
+	var insts []uint32
+	matched := false
+	type position struct {
+		pc      uint32
+		textEnd bool
+	}
+	visited := make(map[position]bool)
+	stack := []position{{pc: pc}}
+	atStart := pc == uint32(prog.Start)
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if visited[current] {
+			continue
+		}
+		visited[current] = true
+		inst := &prog.Inst[current.pc]
+		switch inst.Op {
+		case syntax.InstMatch:
+			matched = true
+		case syntax.InstFail:
+		case syntax.InstAlt, syntax.InstAltMatch:
+			stack = append(stack, position{inst.Out, current.textEnd}, position{inst.Arg, current.textEnd})
+		case syntax.InstCapture, syntax.InstNop:
+			stack = append(stack, position{inst.Out, current.textEnd})
+		case syntax.InstEmptyWidth:
+			op := syntax.EmptyOp(inst.Arg)
+			if op&(syntax.EmptyBeginLine|syntax.EmptyBeginText) != 0 && !atStart {
+				continue
+			}
+			textEnd := current.textEnd || op&(syntax.EmptyEndLine|syntax.EmptyEndText) != 0
+			stack = append(stack, position{inst.Out, textEnd})
+		default:
+			if !current.textEnd {
+				insts = append(insts, current.pc)
+			}
+		}
+	}
+	return insts, matched
+}
+
+// instRanges returns the ranges of characters which the instruction consumes
+func instRanges(inst *syntax.Inst) [][2]rune {
+	switch inst.Op {
+	case syntax.InstRune1:
+		return [][2]rune{{inst.Rune[0], inst.Rune[0]}}
+	case syntax.InstRuneAny:
+		return [][2]rune{{0, unicode.MaxRune}}
+	case syntax.InstRuneAnyNotNL:
+		return [][2]rune{{0, '\n' - 1}, {'\n' + 1, unicode.MaxRune}}
+	}
+	if len(inst.Rune) == 1 {
+		ranges := [][2]rune{{inst.Rune[0], inst.Rune[0]}}
+		if syntax.Flags(inst.Arg)&syntax.FoldCase != 0 {
+			for r := unicode.SimpleFold(inst.Rune[0]); r != inst.Rune[0]; r = unicode.SimpleFold(r) {
+				ranges = append(ranges, [2]rune{r, r})
+			}
+		}
+		return ranges
+	}
+	ranges := make([][2]rune, 0, len(inst.Rune)/2)
+	for i := 0; i+1 < len(inst.Rune); i += 2 {
+		ranges = append(ranges, [2]rune{inst.Rune[i], inst.Rune[i+1]})
+	}
+	return ranges
+}
+
+// areRangesIntersecting checks whether there is a character which belongs to all the sets of ranges
+func areRangesIntersecting(first [][2]rune, second [][2]rune, third [][2]rune) bool {
+	for _, a := range first {
+		for _, b := range second {
+			for _, c := range third {
+				if max(a[0], b[0], c[0]) <= min(a[1], b[1], c[1]) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,133 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package akhqconfig
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	akhqconfigv1 "github.com/Netcracker/qubership-kafka/operator/api/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+// employeeDescriptor is a descriptor set with com.netcracker.Employee message
+const employeeDescriptor = "Cp8BCg5lbXBsb3llZS5wcm90bxIOY29tLm5ldGNyYWNrZXIiZgoIRW1wbG95ZWUSEgoEbmFtZRgBIAEoCVIEbmFtZRIOCgJpZBgCIAEoBVICaWQSFgoGc2FsYXJ5GAMgASgFUgZzYWxhcnkSHgoKZXhwZXJpZW5jZRgEIAEoBVIKZXhwZXJpZW5jZUINWgsuL2VtcGxveWVlc2IGcHJvdG8z"
+
+func Test_validateProtobufConfig(t *testing.T) {
+	tests := []struct {
+		config              akhqconfigv1.Config
+		expectedDescription string
+	}{
+		{
+			config: akhqconfigv1.Config{TopicRegex: "topic1", MessageType: "com.netcracker.Employee",
+				DescriptorFileBase64: employeeDescriptor},
+		},
+		{
+			config: akhqconfigv1.Config{TopicRegex: "topic1", MessageType: "Employee", KeyType: "Employee",
+				DescriptorFileBase64: employeeDescriptor},
+		},
+		{
+			config: akhqconfigv1.Config{TopicRegex: "topic1", MessageType: "com.netcracker.Person",
+				KeyType: "com.netcracker.Employee", DescriptorFileBase64: employeeDescriptor},
+			expectedDescription: fmt.Sprintf(messageTypeValidationError, "message-type", "com.netcracker.Person", "topic1"),
+		},
+		{
+			config: akhqconfigv1.Config{TopicRegex: "topic1", MessageType: "com.netcracker.Employee",
+				KeyType: "com.netcracker.Key", DescriptorFileBase64: employeeDescriptor},
+			expectedDescription: fmt.Sprintf(messageTypeValidationError, "key-type", "com.netcracker.Key", "topic1"),
+		},
+		{
+			config: akhqconfigv1.Config{TopicRegex: "topic1", MessageType: "com.netcracker.name",
+				DescriptorFileBase64: employeeDescriptor},
+			expectedDescription: fmt.Sprintf(messageTypeValidationError, "message-type", "com.netcracker.name", "topic1"),
+		},
+	}
+	for _, test := range tests {
+		problems := validateProtobufConfig(test.config)
+		assert.Equal(t, test.expectedDescription, strings.Join(problems, "; "), "config is %v", test.config)
+	}
+}
+
+func Test_validateProtobufConfig_corruptDescriptor(t *testing.T) {
+	// valid base64, but truncated descriptor set
+	problems := validateProtobufConfig(akhqconfigv1.Config{TopicRegex: "topic1", MessageType: "Employee",
+		DescriptorFileBase64: employeeDescriptor[:40]})
+	assert.Len(t, problems, 1)
+	assert.Contains(t, problems[0], "can not parse descriptor-file-base64 config which is associated with [topic1]")
+}
+
+func Test_validateCrConfig_invalidRegex(t *testing.T) {
+	problems := r.validateCrConfig([]akhqconfigv1.Config{
+		{TopicRegex: "topic(1", MessageType: "Employee", DescriptorFileBase64: employeeDescriptor},
+	})
+	assert.Len(t, problems, 1)
+	assert.Contains(t, problems[0], "[topic(1] is not a valid regular expression")
+}
+
+func Test_findOverlappingRegexes(t *testing.T) {
+	dc := &DeserializationConfig{}
+	dc.Deserialization.Protobuf.TopicsMapping = []TopicMapping{
+		{TopicRegex: "orders.*", Name: "orders-config-team-a"},
+		{TopicRegex: "payments", Name: "payments-config-team-b"},
+		{TopicRegex: "employees", Name: "employees-config-team-c"},
+	}
+	dc.Deserialization.AvroRaw = &SchemaDeserialization{TopicsMapping: []SchemaTopicMapping{
+		{TopicRegex: "audit-.*", Name: "audit-config-team-d"},
+	}}
+	crConfigs := []akhqconfigv1.Config{
+		{TopicRegex: "orders-eu,orders-us"},
+		{TopicRegex: "pay.*"},
+		{TopicRegex: "employees"},
+		{TopicRegex: "audit-(eu|us)"},
+		{TopicRegex: "users"},
+	}
+
+	problems := findOverlappingRegexes(dc, "employees-config-team-c", crConfigs)
+
+	assert.Equal(t, []string{
+		fmt.Sprintf(overlappingRegexWarning, "orders-eu,orders-us", "orders.*", "orders-config-team-a"),
+		fmt.Sprintf(overlappingRegexWarning, "pay.*", "payments", "payments-config-team-b"),
+		fmt.Sprintf(overlappingRegexWarning, "audit-(eu|us)", "audit-.*", "audit-config-team-d"),
+	}, problems)
+}
+
+func Test_areRegexesOverlapping(t *testing.T) {
+	tests := []struct {
+		first       string
+		second      string
+		overlapping bool
+	}{
+		{first: "topic-.*", second: "topic-.*", overlapping: true},
+		{first: "topic-1|topic-2", second: "topic-[0-9]", overlapping: true},
+		{first: "topic-.*", second: "topic-[0-9]+", overlapping: true},
+		{first: "orders\\..*", second: ".*\\.eu", overlapping: true},
+		{first: "(?i)ORDERS", second: "orders", overlapping: true},
+		{first: "^orders$", second: "orders|payments", overlapping: true},
+		{first: "topic-[a-z]", second: "topic-1", overlapping: false},
+		{first: "topic-[a-z]+", second: "topic-[0-9]+", overlapping: false},
+		{first: "orders.*", second: "payments.*", overlapping: false},
+		// topic names can not be empty or contain other characters
+		{first: "a*", second: "b*", overlapping: false},
+		{first: "orders/.*", second: "orders.*", overlapping: false},
+		{first: "topic(", second: "topic", overlapping: false},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.overlapping, areRegexesOverlapping(test.first, test.second),
+			"regular expressions are %s and %s", test.first, test.second)
+		assert.Equal(t, test.overlapping, areRegexesOverlapping(test.second, test.first),
+			"regular expressions are %s and %s", test.second, test.first)
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package akhqprotobuf
+
+import (
+	kafkaservice "github.com/Netcracker/qubership-kafka/operator/api/v7"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	AccessConfigurationCMName = "akhq-access-configuration"
+	accessConfig              = `access: []
+`
+)
+
+// AccessRule binds LDAP group to AKHQ role in the namespace of AkhqConfig custom resource
+type AccessRule struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	LdapGroup string `json:"ldap-group"`
+	Role      string `json:"role"`
+}
+
+// AccessConfig is a content of the config map with access rules collected from all AkhqConfig custom resources
+type AccessConfig struct {
+	Access []AccessRule `json:"access"`
+}
+
+func CreateAccessConfigMapIfNotExist(namespace string, client client.Client, labels map[string]string,
+	controller *kafkaservice.KafkaService, scheme *runtime.Scheme) (*corev1.ConfigMap, error) {
+	return createConfigMapIfNotExist(AccessConfigurationCMName, accessConfig, namespace, client, labels, controller, scheme)
+}
+
+// ParseAccessConfig reads access rules from the config map
+func ParseAccessConfig(configMap *corev1.ConfigMap) (*AccessConfig, error) {
+	config := &AccessConfig{}
+	if err := yaml.Unmarshal([]byte(configMap.Data["config"]), config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
@@ -49,50 +49,55 @@ func getConfigMap(configMapName, namespace string, client client.Client) (*corev
 
 func CreateProtobufConfigMapIfNotExist(namespace string, client client.Client, labels map[string]string,
 	controller *kafkaservice.KafkaService, scheme *runtime.Scheme) (*corev1.ConfigMap, error) {
+	return createConfigMapIfNotExist(protobufConfigurationCMName, protobufConfig, namespace, client, labels, controller, scheme)
+}
+
+func createConfigMapIfNotExist(configMapName, defaultConfig, namespace string, client client.Client,
+	labels map[string]string, controller *kafkaservice.KafkaService, scheme *runtime.Scheme) (*corev1.ConfigMap, error) {
 
 	needToCreate := false
 	needToUpdate := false
 
-	protobufConfigMap, err := getConfigMap(protobufConfigurationCMName, namespace, client)
+	configMap, err := getConfigMap(configMapName, namespace, client)
 	if err != nil {
 		if !errors.IsNotFound(err) {
 			return nil, err
 		}
-		protobufConfigMap = &corev1.ConfigMap{
+		configMap = &corev1.ConfigMap{
 			ObjectMeta: metav1.ObjectMeta{
-				Name:      protobufConfigurationCMName,
+				Name:      configMapName,
 				Namespace: namespace,
 			},
-			Data: map[string]string{"config": protobufConfig},
+			Data: map[string]string{"config": defaultConfig},
 		}
 		needToCreate = true
 	}
 
-	if !reflect.DeepEqual(protobufConfigMap.Labels, labels) {
-		protobufConfigMap.Labels = labels
+	if !reflect.DeepEqual(configMap.Labels, labels) {
+		configMap.Labels = labels
 		needToUpdate = true
 	}
 
 	if controller != nil && scheme != nil {
-		if existing := metav1.GetControllerOf(protobufConfigMap); existing != nil && !controllers.ReferSameObject(existing.Name, existing.APIVersion, existing.Kind, controller.Name, controller.APIVersion, controller.Kind) {
-			protobufConfigMap.SetOwnerReferences(nil)
+		if existing := metav1.GetControllerOf(configMap); existing != nil && !controllers.ReferSameObject(existing.Name, existing.APIVersion, existing.Kind, controller.Name, controller.APIVersion, controller.Kind) {
+			configMap.SetOwnerReferences(nil)
 		}
-		if err = controllerutil.SetControllerReference(controller, protobufConfigMap, scheme); err != nil {
+		if err = controllerutil.SetControllerReference(controller, configMap, scheme); err != nil {
 			return &corev1.ConfigMap{}, err
 		}
 		needToUpdate = true
 	}
 
 	if needToCreate {
-		if err = client.Create(context.TODO(), protobufConfigMap); err != nil {
+		if err = client.Create(context.TODO(), configMap); err != nil {
 			return nil, err
 		}
 	} else if needToUpdate {
-		if err = client.Update(context.TODO(), protobufConfigMap); err != nil {
+		if err = client.Update(context.TODO(), configMap); err != nil {
 			return nil, err
 		}
 	}
 
-	return protobufConfigMap, nil
+	return configMap, nil
 
 }
@@ -0,0 +1,243 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	DefaultCertificateIssuerKind = "Issuer"
+	ClusterCertificateIssuerKind = "ClusterIssuer"
+	defaultCertificateDays       = 365
+	// CertificateCheckInterval is the interval of requeue while cert-manager issues certificate
+	CertificateCheckInterval = 10 * time.Second
+)
+
+var CertificateGVK = schema.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "Certificate"}
+
+// ErrCertificateNotIssued means that cert-manager has not issued certificate yet,
+// reconciliation is requeued in this case instead of waiting for the certificate
+var ErrCertificateNotIssued = stderrors.New("certificate is not issued by cert-manager yet")
+
+// CertificateParameters defines cert-manager Certificate which is issued by operator
+type CertificateParameters struct {
+	Name            string
+	Namespace       string
+	SecretName      string
+	CommonName      string
+	DnsNames        []string
+	IpAddresses     []string
+	Usages          []string
+	IssuerName      string
+	IssuerKind      string
+	DurationDays    int
+	RenewBeforeDays int
+	Labels          map[string]string
+}
+
+// NewCertificate returns cert-manager Certificate with specified parameters.
+// Secret with certificates is annotated for automatic restart of workloads, so that renewed certificates are applied.
+func NewCertificate(parameters CertificateParameters) (*unstructured.Unstructured, error) {
+	if parameters.IssuerName == "" {
+		return nil, fmt.Errorf("issuer name must be specified for [%s] certificate", parameters.Name)
+	}
+	issuerKind := parameters.IssuerKind
+	if issuerKind == "" {
+		issuerKind = DefaultCertificateIssuerKind
+	}
+	if issuerKind != DefaultCertificateIssuerKind && issuerKind != ClusterCertificateIssuerKind {
+		return nil, fmt.Errorf("issuer kind of [%s] certificate must be %s or %s, but it is %s",
+			parameters.Name, DefaultCertificateIssuerKind, ClusterCertificateIssuerKind, issuerKind)
+	}
+	durationDays := parameters.DurationDays
+	if durationDays <= 0 {
+		durationDays = defaultCertificateDays
+	}
+	if parameters.RenewBeforeDays >= durationDays {
+		return nil, fmt.Errorf("renewal of [%s] certificate must start before it expires in %d days",
+			parameters.Name, durationDays)
+	}
+
+	spec := map[string]interface{}{
+		"secretName": parameters.SecretName,
+		"secretTemplate": map[string]interface{}{
+			"annotations": map[string]interface{}{AutoRestartAnnotation: "true"},
+		},
+		"duration": formatDays(durationDays),
+		"privateKey": map[string]interface{}{
+			"rotationPolicy": "Always",
+		},
+		"issuerRef": map[string]interface{}{
+			"group": CertificateGVK.Group,
+			"kind":  issuerKind,
+			"name":  parameters.IssuerName,
+		},
+	}
+	if parameters.CommonName != "" {
+		spec["commonName"] = parameters.CommonName
+	}
+	if parameters.RenewBeforeDays > 0 {
+		spec["renewBefore"] = formatDays(parameters.RenewBeforeDays)
+	}
+	if len(parameters.DnsNames) > 0 {
+		spec["dnsNames"] = toInterfaceSlice(parameters.DnsNames)
+	}
+	if len(parameters.IpAddresses) > 0 {
+		spec["ipAddresses"] = toInterfaceSlice(parameters.IpAddresses)
+	}
+	if len(parameters.Usages) > 0 {
+		spec["usages"] = toInterfaceSlice(parameters.Usages)
+	}
+
+	certificate := &unstructured.Unstructured{}
+	certificate.SetGroupVersionKind(CertificateGVK)
+	certificate.SetName(parameters.Name)
+	certificate.SetNamespace(parameters.Namespace)
+	certificate.SetLabels(parameters.Labels)
+	certificate.Object["spec"] = spec
+	return certificate, nil
+}
+
+// SplitSubjectAlternativeNames separates host names into DNS names and IP addresses
+// as cert-manager requires them in different fields
+func SplitSubjectAlternativeNames(hostNames []string) ([]string, []string) {
+	var dnsNames []string
+	var ipAddresses []string
+	for _, hostName := range hostNames {
+		if net.ParseIP(hostName) != nil {
+			ipAddresses = appendUnique(ipAddresses, hostName)
+		} else if hostName != "" {
+			dnsNames = appendUnique(dnsNames, hostName)
+		}
+	}
+	return dnsNames, ipAddresses
+}
+
+// IsCertificateReady checks that cert-manager Certificate has issued certificates which match its current spec
+func IsCertificateReady(certificate *unstructured.Unstructured) bool {
+	conditions, _, _ := unstructured.NestedSlice(certificate.Object, "status", "conditions")
+	for _, item := range conditions {
+		condition, ok := item.(map[string]interface{})
+		if !ok || condition["type"] != "Ready" {
+			continue
+		}
+		observedGeneration, found, _ := unstructured.NestedInt64(condition, "observedGeneration")
+		if found && observedGeneration != certificate.GetGeneration() {
+			return false
+		}
+		return condition["status"] == "True"
+	}
+	return false
+}
+
+// CreateOrUpdateCertificate creates cert-manager Certificate or updates its spec and labels
+func (r *Reconciler) CreateOrUpdateCertificate(certificate *unstructured.Unstructured, logger logr.Logger) error {
+	found, err := r.FindCertificate(certificate.GetName(), certificate.GetNamespace())
+	if err != nil && errors.IsNotFound(err) {
+		logger.Info("Creating a new certificate",
+			"Certificate.Namespace", certificate.GetNamespace(), "Certificate.Name", certificate.GetName())
+		return r.Client.Create(context.TODO(), certificate)
+	} else if err != nil {
+		return err
+	}
+	if equality.Semantic.DeepEqual(found.Object["spec"], certificate.Object["spec"]) &&
+		equality.Semantic.DeepEqual(found.GetLabels(), certificate.GetLabels()) &&
+		equality.Semantic.DeepEqual(found.GetOwnerReferences(), certificate.GetOwnerReferences()) {
+		return nil
+	}
+	logger.Info("Updating the found certificate",
+		"Certificate.Namespace", certificate.GetNamespace(), "Certificate.Name", certificate.GetName())
+	found.Object["spec"] = certificate.Object["spec"]
+	found.SetLabels(certificate.GetLabels())
+	found.SetOwnerReferences(certificate.GetOwnerReferences())
+	return r.Client.Update(context.TODO(), found)
+}
+
+// FindCertificate finds cert-manager Certificate by name
+func (r *Reconciler) FindCertificate(name string, namespace string) (*unstructured.Unstructured, error) {
+	certificate := &unstructured.Unstructured{}
+	certificate.SetGroupVersionKind(CertificateGVK)
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, certificate)
+	return certificate, err
+}
+
+// DeleteCertificate deletes cert-manager Certificate if it exists.
+// Nothing is deleted when cert-manager is not installed to Kubernetes.
+func (r *Reconciler) DeleteCertificate(name string, namespace string, logger logr.Logger) error {
+	certificate, err := r.FindCertificate(name, namespace)
+	if err != nil {
+		if errors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return nil
+		}
+		return err
+	}
+	logger.Info("Deleting the found certificate", "Certificate.Namespace", namespace, "Certificate.Name", name)
+	return r.Client.Delete(context.TODO(), certificate)
+}
+
+// CheckCertificateIssued checks that cert-manager has issued certificate and stored it to secret.
+// ErrCertificateNotIssued is returned otherwise, so reconciliation can be requeued without blocking.
+func (r *Reconciler) CheckCertificateIssued(name string, namespace string, logger logr.Logger) error {
+	certificate, err := r.FindCertificate(name, namespace)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return fmt.Errorf("[%s] certificate is not found: %w", name, ErrCertificateNotIssued)
+		}
+		return err
+	}
+	if !IsCertificateReady(certificate) {
+		return fmt.Errorf("[%s] certificate is not ready: %w", name, ErrCertificateNotIssued)
+	}
+	secretName, _, _ := unstructured.NestedString(certificate.Object, "spec", "secretName")
+	if _, err = r.FindSecret(secretName, namespace, logger); err != nil {
+		if errors.IsNotFound(err) {
+			return fmt.Errorf("[%s] secret of [%s] certificate is not found: %w", secretName, name, ErrCertificateNotIssued)
+		}
+		return err
+	}
+	return nil
+}
+
+func formatDays(days int) string {
+	return (time.Duration(days) * 24 * time.Hour).String()
+}
+
+func toInterfaceSlice(values []string) []interface{} {
+	result := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		result = append(result, value)
+	}
+	return result
+}
+
+func appendUnique(values []string, value string) []string {
+	if containsString(values, value) {
+		return values
+	}
+	return append(values, value)
+}
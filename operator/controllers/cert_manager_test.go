@@ -0,0 +1,191 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newCertManagerScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	scheme.AddKnownTypeWithName(CertificateGVK, &unstructured.Unstructured{})
+	listGVK := CertificateGVK.GroupVersion().WithKind(CertificateGVK.Kind + "List")
+	scheme.AddKnownTypeWithName(listGVK, &unstructured.UnstructuredList{})
+	return scheme
+}
+
+func newCertManagerReconciler(objects ...client.Object) *Reconciler {
+	scheme := newCertManagerScheme()
+	return &Reconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build(),
+		Scheme: scheme,
+	}
+}
+
+func newReadyCertificate(name string, secretName string) *unstructured.Unstructured {
+	certificate := &unstructured.Unstructured{}
+	certificate.SetGroupVersionKind(CertificateGVK)
+	certificate.SetName(name)
+	certificate.SetNamespace("kafka")
+	certificate.Object["spec"] = map[string]interface{}{"secretName": secretName}
+	certificate.Object["status"] = map[string]interface{}{
+		"conditions": []interface{}{
+			map[string]interface{}{"type": "Ready", "status": "True"},
+		},
+	}
+	return certificate
+}
+
+func TestNewCertificate(t *testing.T) {
+	certificate, err := NewCertificate(CertificateParameters{
+		Name:            "kafka-tls-certificate",
+		Namespace:       "kafka",
+		SecretName:      "kafka-tls-secret",
+		CommonName:      "kafka",
+		DnsNames:        []string{"kafka", "kafka.kafka"},
+		IpAddresses:     []string{"127.0.0.1"},
+		Usages:          []string{"server auth", "client auth"},
+		IssuerName:      "kafka-issuer",
+		IssuerKind:      ClusterCertificateIssuerKind,
+		DurationDays:    90,
+		RenewBeforeDays: 30,
+		Labels:          map[string]string{"name": "kafka"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, CertificateGVK, certificate.GroupVersionKind())
+	assert.Equal(t, "kafka-tls-certificate", certificate.GetName())
+	assert.Equal(t, map[string]string{"name": "kafka"}, certificate.GetLabels())
+	spec := certificate.Object["spec"].(map[string]interface{})
+	assert.Equal(t, "kafka-tls-secret", spec["secretName"])
+	assert.Equal(t, "2160h0m0s", spec["duration"])
+	assert.Equal(t, "720h0m0s", spec["renewBefore"])
+	assert.Equal(t, []interface{}{"kafka", "kafka.kafka"}, spec["dnsNames"])
+	assert.Equal(t, []interface{}{"127.0.0.1"}, spec["ipAddresses"])
+	assert.Equal(t, map[string]interface{}{"group": "cert-manager.io", "kind": "ClusterIssuer", "name": "kafka-issuer"},
+		spec["issuerRef"])
+	annotations, _, _ := unstructured.NestedStringMap(certificate.Object, "spec", "secretTemplate", "annotations")
+	assert.Equal(t, "true", annotations[AutoRestartAnnotation])
+}
+
+func TestNewCertificate_defaults(t *testing.T) {
+	certificate, err := NewCertificate(CertificateParameters{Name: "akhq", SecretName: "akhq-tls", IssuerName: "issuer"})
+	require.NoError(t, err)
+	spec := certificate.Object["spec"].(map[string]interface{})
+	assert.Equal(t, "8760h0m0s", spec["duration"])
+	assert.NotContains(t, spec, "renewBefore")
+	assert.NotContains(t, spec, "dnsNames")
+	assert.Equal(t, DefaultCertificateIssuerKind, spec["issuerRef"].(map[string]interface{})["kind"])
+}
+
+func TestNewCertificate_invalidParameters(t *testing.T) {
+	_, err := NewCertificate(CertificateParameters{Name: "akhq"})
+	assert.Error(t, err)
+	_, err = NewCertificate(CertificateParameters{Name: "akhq", IssuerName: "issuer", IssuerKind: "Vault"})
+	assert.Error(t, err)
+	_, err = NewCertificate(CertificateParameters{Name: "akhq", IssuerName: "issuer", DurationDays: 30, RenewBeforeDays: 30})
+	assert.Error(t, err)
+}
+
+func TestSplitSubjectAlternativeNames(t *testing.T) {
+	dnsNames, ipAddresses := SplitSubjectAlternativeNames(
+		[]string{"localhost", "127.0.0.1", "kafka.example.com", "", "10.0.0.1", "localhost", "::1"})
+	assert.Equal(t, []string{"localhost", "kafka.example.com"}, dnsNames)
+	assert.Equal(t, []string{"127.0.0.1", "10.0.0.1", "::1"}, ipAddresses)
+}
+
+func TestIsCertificateReady(t *testing.T) {
+	certificate := newReadyCertificate("kafka-tls-certificate", "kafka-tls-secret")
+	assert.True(t, IsCertificateReady(certificate))
+
+	certificate.SetGeneration(2)
+	certificate.Object["status"] = map[string]interface{}{
+		"conditions": []interface{}{
+			map[string]interface{}{"type": "Ready", "status": "True", "observedGeneration": int64(1)},
+		},
+	}
+	assert.False(t, IsCertificateReady(certificate))
+
+	certificate.Object["status"] = map[string]interface{}{
+		"conditions": []interface{}{
+			map[string]interface{}{"type": "Issuing", "status": "True"},
+			map[string]interface{}{"type": "Ready", "status": "False"},
+		},
+	}
+	assert.False(t, IsCertificateReady(certificate))
+	assert.False(t, IsCertificateReady(&unstructured.Unstructured{Object: map[string]interface{}{}}))
+}
+
+func TestCreateOrUpdateCertificate(t *testing.T) {
+	r := newCertManagerReconciler()
+	parameters := CertificateParameters{
+		Name:       "kafka-tls-certificate",
+		Namespace:  "kafka",
+		SecretName: "kafka-tls-secret",
+		DnsNames:   []string{"kafka"},
+		IssuerName: "issuer",
+	}
+	certificate, err := NewCertificate(parameters)
+	require.NoError(t, err)
+	require.NoError(t, r.CreateOrUpdateCertificate(certificate, logr.Discard()))
+
+	found, err := r.FindCertificate("kafka-tls-certificate", "kafka")
+	require.NoError(t, err)
+	dnsNames, _, _ := unstructured.NestedStringSlice(found.Object, "spec", "dnsNames")
+	assert.Equal(t, []string{"kafka"}, dnsNames)
+	version := found.GetResourceVersion()
+
+	certificate, err = NewCertificate(parameters)
+	require.NoError(t, err)
+	require.NoError(t, r.CreateOrUpdateCertificate(certificate, logr.Discard()))
+	found, err = r.FindCertificate("kafka-tls-certificate", "kafka")
+	require.NoError(t, err)
+	assert.Equal(t, version, found.GetResourceVersion(), "unchanged certificate must not be updated")
+
+	parameters.DnsNames = []string{"kafka", "kafka-1"}
+	certificate, err = NewCertificate(parameters)
+	require.NoError(t, err)
+	require.NoError(t, r.CreateOrUpdateCertificate(certificate, logr.Discard()))
+	found, err = r.FindCertificate("kafka-tls-certificate", "kafka")
+	require.NoError(t, err)
+	dnsNames, _, _ = unstructured.NestedStringSlice(found.Object, "spec", "dnsNames")
+	assert.Equal(t, []string{"kafka", "kafka-1"}, dnsNames)
+
+	require.NoError(t, r.DeleteCertificate("kafka-tls-certificate", "kafka", logr.Discard()))
+	require.NoError(t, r.DeleteCertificate("kafka-tls-certificate", "kafka", logr.Discard()))
+}
+
+func TestCheckCertificateIssued(t *testing.T) {
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "kafka-tls-secret", Namespace: "kafka"}}
+	r := newCertManagerReconciler(newReadyCertificate("kafka-tls-certificate", "kafka-tls-secret"), secret)
+	assert.NoError(t, r.CheckCertificateIssued("kafka-tls-certificate", "kafka", logr.Discard()))
+
+	r = newCertManagerReconciler(newReadyCertificate("kafka-tls-certificate", "kafka-tls-secret"))
+	assert.ErrorIs(t, r.CheckCertificateIssued("kafka-tls-certificate", "kafka", logr.Discard()), ErrCertificateNotIssued)
+
+	r = newCertManagerReconciler(secret)
+	assert.ErrorIs(t, r.CheckCertificateIssued("kafka-tls-certificate", "kafka", logr.Discard()), ErrCertificateNotIssued)
+}
@@ -0,0 +1,53 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"fmt"
+
+	"github.com/IBM/sarama"
+)
+
+// CountConsumerGroupMembers returns the number of active members of consumer group,
+// the group which does not exist has no members
+func CountConsumerGroupMembers(adminClient sarama.ClusterAdmin, group string) (int, error) {
+	descriptions, err := adminClient.DescribeConsumerGroups([]string{group})
+	if err != nil {
+		return 0, fmt.Errorf("cannot describe consumer group %s: %w", group, err)
+	}
+	members := 0
+	for _, description := range descriptions {
+		if description.Err != sarama.ErrNoError && description.Err != sarama.ErrGroupIDNotFound {
+			return 0, fmt.Errorf("cannot describe consumer group %s: %w", group, description.Err)
+		}
+		members += len(description.Members)
+	}
+	return members, nil
+}
+
+// OffsetCommitError returns the error of AlterConsumerGroupOffsets request or the first error of partition commit
+func OffsetCommitError(response *sarama.OffsetCommitResponse, err error) error {
+	if err != nil || response == nil {
+		return err
+	}
+	for topic, partitions := range response.Errors {
+		for partition, kerr := range partitions {
+			if kerr != sarama.ErrNoError {
+				return fmt.Errorf("offset of partition %s-%d is not committed: %w", topic, partition, kerr)
+			}
+		}
+	}
+	return nil
+}
@@ -12,7 +12,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package kafkaservice
+package controllers
 
 import (
 	"context"
@@ -27,16 +27,19 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-// applyAutoRestartSecretAnnotations sets pod-template annotations from Secret resourceVersion when the
+const (
+	// AutoRestartAnnotation marks secrets which changes restart the pods that use them
+	AutoRestartAnnotation             = "kafkaservice.netcracker.com/auto-restart"
+	resourceVersionAnnotationTemplate = "%s/resource-version"
+)
+
+// ApplyAutoRestartSecretAnnotations sets pod-template annotations from Secret resourceVersion when the
 // Secret has kafkaservice.netcracker.com/auto-restart: "true". Returns true if annotations changed
 // (a pod-template change triggers a rolling restart for startup-only entrypoints).
-func applyAutoRestartSecretAnnotations(deployment *appsv1.Deployment, logger logr.Logger, secrets ...*corev1.Secret) bool {
+func ApplyAutoRestartSecretAnnotations(deployment *appsv1.Deployment, logger logr.Logger, secrets ...*corev1.Secret) bool {
 	modified := false
 	for _, secret := range secrets {
-		if secret == nil || secret.Name == "" {
-			continue
-		}
-		if secret.Annotations == nil || secret.Annotations[autoRestartAnnotation] != "true" {
+		if !isAutoRestartSecret(secret) {
 			continue
 		}
 		annotationName := fmt.Sprintf(resourceVersionAnnotationTemplate, secret.Name)
@@ -54,11 +57,30 @@ func applyAutoRestartSecretAnnotations(deployment *appsv1.Deployment, logger log
 	return modified
 }
 
-// updateDeploymentSecretRestartAnnotations patches an existing Deployment in the cluster.
+// IsSecretRestartRequired checks whether pod-template annotations of an existing Deployment refer to other
+// resourceVersions of auto-restart Secrets, so applying the current ones restarts the Deployment pods.
+func IsSecretRestartRequired(deployment *appsv1.Deployment, secrets ...*corev1.Secret) bool {
+	for _, secret := range secrets {
+		if !isAutoRestartSecret(secret) {
+			continue
+		}
+		annotationName := fmt.Sprintf(resourceVersionAnnotationTemplate, secret.Name)
+		if deployment.Spec.Template.Annotations[annotationName] != secret.ResourceVersion {
+			return true
+		}
+	}
+	return false
+}
+
+func isAutoRestartSecret(secret *corev1.Secret) bool {
+	return secret != nil && secret.Name != "" && secret.Annotations[AutoRestartAnnotation] == "true"
+}
+
+// UpdateDeploymentSecretRestartAnnotations patches an existing Deployment in the cluster.
 // This matches the backup-daemon flow: load live object (with resourceVersion), set pod-template
 // annotations, then Update — so secret rotation triggers a rollout even when the reconciler
 // skips rebuilding the full Deployment spec.
-func updateDeploymentSecretRestartAnnotations(
+func UpdateDeploymentSecretRestartAnnotations(
 	c client.Client,
 	namespace, deploymentName string,
 	logger logr.Logger,
@@ -73,7 +95,7 @@ func updateDeploymentSecretRestartAnnotations(
 		if err != nil {
 			return err
 		}
-		if !applyAutoRestartSecretAnnotations(deployment, logger, secrets...) {
+		if !ApplyAutoRestartSecretAnnotations(deployment, logger, secrets...) {
 			return nil
 		}
 		return c.Update(context.TODO(), deployment)
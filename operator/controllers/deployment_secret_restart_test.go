@@ -0,0 +1,58 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newRestartSecret(name string, resourceVersion string, autoRestart bool) *corev1.Secret {
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, ResourceVersion: resourceVersion}}
+	if autoRestart {
+		secret.Annotations = map[string]string{AutoRestartAnnotation: "true"}
+	}
+	return secret
+}
+
+func TestApplyAutoRestartSecretAnnotations(t *testing.T) {
+	deployment := &appsv1.Deployment{}
+	kafkaSecret := newRestartSecret("kafka-secret", "10", true)
+	tlsSecret := newRestartSecret("kafka-tls-secret", "20", true)
+	manualSecret := newRestartSecret("manual-secret", "30", false)
+
+	assert.True(t, ApplyAutoRestartSecretAnnotations(deployment, logr.Discard(), kafkaSecret, tlsSecret, manualSecret, nil))
+	assert.Equal(t, map[string]string{
+		"kafka-secret/resource-version":     "10",
+		"kafka-tls-secret/resource-version": "20",
+	}, deployment.Spec.Template.Annotations)
+	assert.False(t, ApplyAutoRestartSecretAnnotations(deployment, logr.Discard(), kafkaSecret, tlsSecret))
+}
+
+func TestIsSecretRestartRequired(t *testing.T) {
+	deployment := &appsv1.Deployment{}
+	deployment.Spec.Template.Annotations = map[string]string{"kafka-secret/resource-version": "10"}
+
+	assert.False(t, IsSecretRestartRequired(deployment, newRestartSecret("kafka-secret", "10", true), nil))
+	assert.False(t, IsSecretRestartRequired(deployment, newRestartSecret("kafka-tls-secret", "20", false)))
+	assert.True(t, IsSecretRestartRequired(deployment, newRestartSecret("kafka-secret", "11", true)))
+	assert.True(t, IsSecretRestartRequired(deployment, newRestartSecret("kafka-tls-secret", "20", true)))
+	assert.True(t, IsSecretRestartRequired(&appsv1.Deployment{}, newRestartSecret("kafka-secret", "10", true)))
+}
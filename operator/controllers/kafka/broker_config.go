@@ -0,0 +1,136 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/IBM/sarama"
+	kafka "github.com/Netcracker/qubership-kafka/operator/api/v1"
+	"github.com/Netcracker/qubership-kafka/operator/controllers"
+	"github.com/Netcracker/qubership-kafka/operator/controllers/provider"
+	appsv1 "k8s.io/api/apps/v1"
+)
+
+// updateRestartRequiredConfigs shows in status static broker configs which are changed,
+// but not applied yet, because brokers are not restarted with them
+func (r ReconcileKafka) updateRestartRequiredConfigs() error {
+	brokerConfigStatus, err := r.getBrokerConfigStatus()
+	if err != nil || brokerConfigStatus == nil || len(brokerConfigStatus.RestartRequiredConfigs) == 0 {
+		return err
+	}
+	r.logger.Info(fmt.Sprintf("Broker configs %v require restart of brokers", brokerConfigStatus.RestartRequiredConfigs))
+	return r.reconciler.StatusUpdater.UpdateStatusWithRetry(func(instance *kafka.Kafka) {
+		instance.Status.BrokerConfigStatus = brokerConfigStatus
+	})
+}
+
+// applyDynamicConfigs updates dynamic broker configs cluster-wide on running brokers
+// and removes dynamic configs which are deleted from spec
+func (r ReconcileKafka) applyDynamicConfigs() error {
+	dynamicConfig, _ := provider.SplitBrokerConfig(r.cr.Spec.Config)
+	entries := make(map[string]sarama.IncrementalAlterConfigsEntry)
+	for key, value := range dynamicConfig {
+		entries[key] = sarama.IncrementalAlterConfigsEntry{Operation: sarama.IncrementalAlterConfigsOperationSet, Value: &value}
+	}
+	if status := r.cr.Status.BrokerConfigStatus; status != nil {
+		for _, key := range status.DynamicConfigs {
+			if _, found := dynamicConfig[key]; !found {
+				entries[key] = sarama.IncrementalAlterConfigsEntry{Operation: sarama.IncrementalAlterConfigsOperationDelete}
+			}
+		}
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+	adminClient, err := r.newKafkaAdminClient()
+	if err != nil {
+		return err
+	}
+	defer adminClient.Close()
+	r.logger.Info(fmt.Sprintf("Applying %d dynamic broker configs", len(entries)))
+	if err = adminClient.IncrementalAlterConfig(sarama.BrokerResource, "", entries, false); err != nil {
+		return fmt.Errorf("dynamic broker configs are not applied: %w", err)
+	}
+	return nil
+}
+
+// getBrokerConfigStatus returns broker configs which are applied dynamically and by restart of brokers.
+// Static config requires restart while its value differs from the value in any broker deployment.
+func (r ReconcileKafka) getBrokerConfigStatus() (*kafka.BrokerConfigStatus, error) {
+	dynamicConfig, staticConfig := provider.SplitBrokerConfig(r.cr.Spec.Config)
+	staticKeys := map[string]bool{}
+	for key := range staticConfig {
+		staticKeys[key] = true
+	}
+	if status := r.cr.Status.BrokerConfigStatus; status != nil {
+		for _, key := range status.StaticConfigs {
+			staticKeys[key] = true
+		}
+	}
+	var restartRequired []string
+	if len(staticKeys) > 0 {
+		deployments, err := r.reconciler.FindKafkaDeployments(r.cr)
+		if err != nil {
+			return nil, err
+		}
+		for key := range staticKeys {
+			if r.isRestartRequired(deployments.Items, key, staticConfig[key]) {
+				restartRequired = append(restartRequired, key)
+			}
+		}
+		sort.Strings(restartRequired)
+	}
+	if len(r.cr.Spec.Config) == 0 && len(restartRequired) == 0 {
+		return nil, nil
+	}
+	return &kafka.BrokerConfigStatus{
+		DynamicConfigs:         provider.SortedKeys(dynamicConfig),
+		StaticConfigs:          provider.SortedKeys(staticConfig),
+		RestartRequiredConfigs: restartRequired,
+	}, nil
+}
+
+func (r ReconcileKafka) isRestartRequired(deployments []appsv1.Deployment, key string, value string) bool {
+	envName := provider.GetBrokerConfigEnvName(key)
+	for _, deployment := range deployments {
+		if deployment.Spec.Replicas != nil && *deployment.Spec.Replicas == 0 {
+			continue
+		}
+		if r.reconciler.GetDeploymentParameter(deployment, envName) != value {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *ReconcileKafka) newKafkaAdminClient() (sarama.ClusterAdmin, error) {
+	username, password, err := r.getKafkaCredentials()
+	if err != nil {
+		return nil, err
+	}
+	sslCertificates, err := r.getKafkaCertificates()
+	if err != nil {
+		return nil, err
+	}
+	saslSettings := &controllers.SaslSettings{
+		Mechanism: sarama.SASLTypeSCRAMSHA512,
+		Username:  username,
+		Password:  password,
+	}
+	return controllers.NewKafkaAdminClient(fmt.Sprintf("%s:9092", r.kafkaProvider.GetServiceName()),
+		saslSettings, r.cr.Spec.Ssl.Enabled, sslCertificates)
+}
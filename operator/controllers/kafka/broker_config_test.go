@@ -0,0 +1,49 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+)
+
+func newBrokerDeployment(replicas int32, envs ...corev1.EnvVar) appsv1.Deployment {
+	return appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To(replicas),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "kafka", Env: envs}}},
+			},
+		},
+	}
+}
+
+func TestIsRestartRequired(t *testing.T) {
+	r := ReconcileKafka{reconciler: &KafkaReconciler{}}
+	applied := corev1.EnvVar{Name: "CONF_KAFKA_REPLICA_FETCH_MAX_BYTES", Value: "2097152"}
+	deployments := []appsv1.Deployment{newBrokerDeployment(1, applied), newBrokerDeployment(1, applied)}
+	assert.False(t, r.isRestartRequired(deployments, "replica.fetch.max.bytes", "2097152"))
+	assert.True(t, r.isRestartRequired(deployments, "replica.fetch.max.bytes", "4194304"))
+	assert.True(t, r.isRestartRequired(deployments, "replica.fetch.max.bytes", ""))
+
+	deployments = append(deployments, newBrokerDeployment(1))
+	assert.True(t, r.isRestartRequired(deployments, "replica.fetch.max.bytes", "2097152"))
+	deployments[2] = newBrokerDeployment(0)
+	assert.False(t, r.isRestartRequired(deployments, "replica.fetch.max.bytes", "2097152"))
+}
@@ -0,0 +1,200 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"strings"
+	"time"
+
+	kafka "github.com/Netcracker/qubership-kafka/operator/api/v1"
+	"github.com/Netcracker/qubership-kafka/operator/controllers/provider"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+const (
+	externalAccessHashName = "externalAccess"
+	// externalAddressCheckInterval is the interval of requeue while Kubernetes assigns external addresses to brokers
+	externalAddressCheckInterval = 10 * time.Second
+)
+
+// errExternalAddressNotAssigned means that Kubernetes has not assigned address to external Service of broker yet,
+// reconciliation is requeued in this case instead of waiting for the address
+var errExternalAddressNotAssigned = stderrors.New("external address is not assigned yet")
+
+// reconcileExternalAccess creates external Services and Ingresses for brokers and returns addresses
+// which brokers advertise for external clients. Services and Ingresses which are not needed anymore are removed.
+func (r ReconcileKafka) reconcileExternalAccess() ([]kafka.ExternalBrokerAddress, error) {
+	services := map[string]bool{}
+	ingresses := map[string]bool{}
+	var addresses []kafka.ExternalBrokerAddress
+	var pendingServices []string
+	externalAccess := r.cr.Spec.ExternalAccess
+	if externalAccess != nil {
+		if err := checkParamsForExternalAccess(r.cr, r.cr.Spec.Replicas); err != nil {
+			return nil, err
+		}
+		for brokerId := 1; brokerId <= r.cr.Spec.Replicas; brokerId++ {
+			service := r.kafkaProvider.NewKafkaExternalServiceForCR(brokerId)
+			if err := r.reconciler.SetControllerReference(r.cr, service, r.reconciler.Scheme); err != nil {
+				return nil, err
+			}
+			if err := r.reconciler.CreateOrUpdateService(service, r.logger); err != nil {
+				return nil, err
+			}
+			services[service.Name] = true
+			if externalAccess.Type == provider.ExternalAccessIngress {
+				ingress := r.kafkaProvider.NewKafkaExternalIngressForCR(brokerId)
+				if err := r.reconciler.SetControllerReference(r.cr, ingress, r.reconciler.Scheme); err != nil {
+					return nil, err
+				}
+				if err := r.reconciler.CreateOrUpdateIngress(ingress, r.logger); err != nil {
+					return nil, err
+				}
+				ingresses[ingress.Name] = true
+			}
+			address, found, err := r.discoverExternalAddress(brokerId, service.Name)
+			if err != nil {
+				return nil, err
+			}
+			if !found {
+				pendingServices = append(pendingServices, service.Name)
+				continue
+			}
+			addresses = append(addresses, address)
+		}
+		if externalAccess.Type == provider.ExternalAccessIngress && externalAccess.BootstrapHostName != "" {
+			ingress := r.kafkaProvider.NewKafkaBootstrapIngressForCR()
+			if err := r.reconciler.SetControllerReference(r.cr, ingress, r.reconciler.Scheme); err != nil {
+				return nil, err
+			}
+			if err := r.reconciler.CreateOrUpdateIngress(ingress, r.logger); err != nil {
+				return nil, err
+			}
+			ingresses[ingress.Name] = true
+		}
+	}
+	if err := r.deleteUnusedExternalAccessEntities(services, ingresses); err != nil {
+		return nil, err
+	}
+	if len(pendingServices) > 0 {
+		return nil, fmt.Errorf("external address of [%s] services: %w",
+			strings.Join(pendingServices, ", "), errExternalAddressNotAssigned)
+	}
+	return addresses, nil
+}
+
+// discoverExternalAddress checks whether external Service of broker has got address assigned by Kubernetes.
+// Host from external host names takes precedence over discovered one.
+func (r ReconcileKafka) discoverExternalAddress(brokerId int, serviceName string) (kafka.ExternalBrokerAddress, bool, error) {
+	address := kafka.ExternalBrokerAddress{Id: brokerId}
+	if len(r.cr.Spec.ExternalHostNames) > 0 {
+		address.Host = r.cr.Spec.ExternalHostNames[brokerId-1]
+	}
+	accessType := r.cr.Spec.ExternalAccess.Type
+	if accessType == provider.ExternalAccessIngress {
+		address.Port = provider.IngressExternalPort
+		return address, true, nil
+	}
+	service, err := r.reconciler.FindService(serviceName, r.cr.Namespace)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return address, false, nil
+		}
+		return address, false, err
+	}
+	host, port, found := getExternalServiceAddress(service, accessType, address.Host != "")
+	if !found {
+		return address, false, nil
+	}
+	if address.Host == "" {
+		address.Host = host
+	}
+	address.Port = port
+	return address, true, nil
+}
+
+// getExternalServiceAddress returns host and port which are assigned to external Service.
+// Load balancer address is not required when broker host is already known.
+func getExternalServiceAddress(service *corev1.Service, accessType string, hostKnown bool) (string, int32, bool) {
+	if len(service.Spec.Ports) == 0 {
+		return "", 0, false
+	}
+	if accessType == provider.ExternalAccessNodePort {
+		nodePort := service.Spec.Ports[0].NodePort
+		return "", nodePort, nodePort > 0
+	}
+	port := service.Spec.Ports[0].Port
+	if hostKnown {
+		return "", port, true
+	}
+	for _, ingress := range service.Status.LoadBalancer.Ingress {
+		if ingress.Hostname != "" {
+			return ingress.Hostname, port, true
+		}
+		if ingress.IP != "" {
+			return ingress.IP, port, true
+		}
+	}
+	return "", 0, false
+}
+
+// deleteUnusedExternalAccessEntities removes external Services and Ingresses which are not in specified sets,
+// for example, after scaling in brokers, changing external access type or disabling it
+func (r ReconcileKafka) deleteUnusedExternalAccessEntities(services map[string]bool, ingresses map[string]bool) error {
+	labels := r.kafkaProvider.GetExternalAccessLabels()
+	serviceList, err := r.reconciler.FindServiceList(r.cr.Namespace, labels)
+	if err != nil {
+		return err
+	}
+	for i := range serviceList.Items {
+		service := &serviceList.Items[i]
+		if services[service.Name] {
+			continue
+		}
+		if err = r.reconciler.DeleteService(service, r.logger); err != nil {
+			return err
+		}
+	}
+	ingressList, err := r.reconciler.FindIngressList(r.cr.Namespace, labels)
+	if err != nil {
+		return err
+	}
+	for i := range ingressList.Items {
+		ingress := &ingressList.Items[i]
+		if ingresses[ingress.Name] {
+			continue
+		}
+		r.logger.Info("Deleting the unused ingress", "Ingress.Namespace", ingress.Namespace, "Ingress.Name", ingress.Name)
+		if err = r.reconciler.Client.Delete(context.TODO(), ingress); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// getExternalAccessStatus returns external addresses of brokers for Kafka status
+func (r ReconcileKafka) getExternalAccessStatus() *kafka.ExternalAccessStatus {
+	if r.cr.Spec.ExternalAccess == nil {
+		return nil
+	}
+	return &kafka.ExternalAccessStatus{
+		BootstrapServers: r.kafkaProvider.GetExternalBootstrapServers(),
+		Brokers:          r.kafkaProvider.GetExternalAddresses(),
+	}
+}
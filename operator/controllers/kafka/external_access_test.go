@@ -0,0 +1,117 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"testing"
+
+	kafka "github.com/Netcracker/qubership-kafka/operator/api/v1"
+	"github.com/Netcracker/qubership-kafka/operator/controllers"
+	"github.com/Netcracker/qubership-kafka/operator/controllers/provider"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestCheckParamsForExternalAccess(t *testing.T) {
+	cr := &kafka.Kafka{Spec: kafka.KafkaSpec{ExternalHostNames: []string{"host-1", "host-2"}}}
+	assert.NoError(t, checkParamsForExternalAccess(cr, 2))
+	assert.Error(t, checkParamsForExternalAccess(cr, 3))
+
+	cr = &kafka.Kafka{Spec: kafka.KafkaSpec{
+		ExternalPorts:  []int{9094},
+		ExternalAccess: &kafka.ExternalAccess{Type: provider.ExternalAccessLoadBalancer},
+	}}
+	assert.Error(t, checkParamsForExternalAccess(cr, 2))
+	cr.Spec.ExternalPorts = nil
+	assert.NoError(t, checkParamsForExternalAccess(cr, 2))
+
+	cr.Spec.ExternalAccess.Type = provider.ExternalAccessNodePort
+	assert.Error(t, checkParamsForExternalAccess(cr, 2))
+	cr.Spec.ExternalHostNames = []string{"node-1", "node-2"}
+	assert.NoError(t, checkParamsForExternalAccess(cr, 2))
+
+	cr.Spec.ExternalAccess.Type = provider.ExternalAccessIngress
+	assert.Error(t, checkParamsForExternalAccess(cr, 2))
+	cr.Spec.Ssl.Enabled = true
+	assert.NoError(t, checkParamsForExternalAccess(cr, 2))
+
+	cr.Spec.ExternalAccess.Type = "ClusterIP"
+	assert.Error(t, checkParamsForExternalAccess(cr, 2))
+}
+
+func TestGetExternalServiceAddress(t *testing.T) {
+	service := &corev1.Service{Spec: corev1.ServiceSpec{Ports: []corev1.ServicePort{{Port: 9094}}}}
+	_, _, found := getExternalServiceAddress(service, provider.ExternalAccessLoadBalancer, false)
+	assert.False(t, found)
+
+	host, port, found := getExternalServiceAddress(service, provider.ExternalAccessLoadBalancer, true)
+	assert.True(t, found)
+	assert.Empty(t, host)
+	assert.Equal(t, int32(9094), port)
+
+	service.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{Hostname: "lb.example.com"}}
+	host, _, found = getExternalServiceAddress(service, provider.ExternalAccessLoadBalancer, false)
+	assert.True(t, found)
+	assert.Equal(t, "lb.example.com", host)
+
+	service.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{IP: "10.0.0.1"}}
+	host, _, _ = getExternalServiceAddress(service, provider.ExternalAccessLoadBalancer, false)
+	assert.Equal(t, "10.0.0.1", host)
+
+	_, _, found = getExternalServiceAddress(service, provider.ExternalAccessNodePort, true)
+	assert.False(t, found)
+	service.Spec.Ports[0].NodePort = 31001
+	_, port, found = getExternalServiceAddress(service, provider.ExternalAccessNodePort, true)
+	assert.True(t, found)
+	assert.Equal(t, int32(31001), port)
+}
+
+func TestDiscoverExternalAddress(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "kafka-1-external", Namespace: "kafka-service"},
+		Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Port: 9094}}},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(service).Build()
+	r := ReconcileKafka{
+		cr: &kafka.Kafka{
+			ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka-service"},
+			Spec:       kafka.KafkaSpec{ExternalAccess: &kafka.ExternalAccess{Type: provider.ExternalAccessLoadBalancer}},
+		},
+		reconciler: &KafkaReconciler{Reconciler: controllers.Reconciler{Client: fakeClient, Scheme: scheme}},
+		logger:     logr.Discard(),
+	}
+
+	_, found, err := r.discoverExternalAddress(1, "kafka-2-external")
+	assert.NoError(t, err)
+	assert.False(t, found)
+
+	_, found, err = r.discoverExternalAddress(1, service.Name)
+	assert.NoError(t, err)
+	assert.False(t, found)
+
+	service.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{IP: "10.0.0.1"}}
+	assert.NoError(t, fakeClient.Status().Update(context.Background(), service))
+	address, found, err := r.discoverExternalAddress(1, service.Name)
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, kafka.ExternalBrokerAddress{Id: 1, Host: "10.0.0.1", Port: 9094}, address)
+}
@@ -16,17 +16,22 @@ package kafka
 
 import (
 	"context"
+	stderrors "errors"
 	"fmt"
 	kafka "github.com/Netcracker/qubership-kafka/operator/api/v1"
 	"github.com/Netcracker/qubership-kafka/operator/controllers"
+	"github.com/Netcracker/qubership-kafka/operator/controllers/provider"
 	"github.com/Netcracker/qubership-kafka/operator/util"
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
 	"os"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -54,6 +59,8 @@ type KafkaReconciler struct {
 //+kubebuilder:rbac:groups=netcracker.com,resources=kafkas,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=netcracker.com,resources=kafkas/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=netcracker.com,resources=kafkas/finalizers,verbs=update
+//+kubebuilder:rbac:groups=cert-manager.io,resources=certificates,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -115,6 +122,14 @@ func (r *KafkaReconciler) Reconcile(ctx context.Context, request ctrl.Request) (
 
 	for _, reconciler := range reconcilers {
 		if err = reconciler.Reconcile(); err != nil {
+			if stderrors.Is(err, controllers.ErrCertificateNotIssued) {
+				reqLogger.Info(fmt.Sprintf("Reconciliation is postponed: %v", err))
+				return reconcile.Result{RequeueAfter: controllers.CertificateCheckInterval}, nil
+			}
+			if stderrors.Is(err, errExternalAddressNotAssigned) {
+				reqLogger.Info(fmt.Sprintf("Reconciliation is postponed: %v", err))
+				return reconcile.Result{RequeueAfter: externalAddressCheckInterval}, nil
+			}
 			reqLogger.Error(err, "Error during reconciliation")
 			r.writeFailedStatus(fmt.Sprintf("Reconciliation cycle failed for %T due to: %v", reconciler, err))
 			return reconcile.Result{}, err
@@ -191,9 +206,31 @@ func (r *KafkaReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&kafka.Kafka{}).
 		Owns(&corev1.Secret{}, builder.WithPredicates(namespacePredicate, dummyPredicate)).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.sslSecretRequests),
+			builder.WithPredicates(namespacePredicate, dummyPredicate)).
 		Complete(r)
 }
 
+// sslSecretRequests returns reconcile requests for Kafka custom resources which use the secret as TLS secret.
+// Such secrets are watched without owner reference, so they are not deleted together with the custom resource.
+func (r *KafkaReconciler) sslSecretRequests(ctx context.Context, object client.Object) []reconcile.Request {
+	kafkaList := &kafka.KafkaList{}
+	if err := r.Client.List(ctx, kafkaList, client.InNamespace(object.GetNamespace())); err != nil {
+		log.Error(err, "Cannot list Kafka custom resources")
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range kafkaList.Items {
+		cr := &kafkaList.Items[i]
+		if cr.Spec.Ssl.Enabled && provider.NewKafkaResourceProvider(cr, log).GetSslSecretName() == object.GetName() {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace},
+			})
+		}
+	}
+	return requests
+}
+
 func (r *KafkaReconciler) writeFailedStatus(errorMessage string) {
 	if err := r.updateConditions(NewCondition(statusFalse, typeFailed, kafkaServiceConditionReason, errorMessage)); err != nil {
 		log.Error(err, "An error occurred while updating the status condition")
@@ -0,0 +1,67 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"testing"
+
+	kafka "github.com/Netcracker/qubership-kafka/operator/api/v1"
+	"github.com/Netcracker/qubership-kafka/operator/controllers"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestKafkaReconciler_sslSecretRequests(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = kafka.AddToScheme(scheme)
+	objects := []runtime.Object{
+		&kafka.Kafka{
+			ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka-service"},
+			Spec:       kafka.KafkaSpec{Ssl: kafka.Ssl{Enabled: true, SecretName: "kafka-tls-secret"}},
+		},
+		&kafka.Kafka{
+			ObjectMeta: metav1.ObjectMeta{Name: "kafka-generated", Namespace: "kafka-service"},
+			Spec: kafka.KafkaSpec{Ssl: kafka.Ssl{Enabled: true,
+				CertManager: &kafka.CertManager{Enabled: true}}},
+		},
+		&kafka.Kafka{
+			ObjectMeta: metav1.ObjectMeta{Name: "kafka-plain", Namespace: "kafka-service"},
+			Spec:       kafka.KafkaSpec{Ssl: kafka.Ssl{SecretName: "kafka-tls-secret"}},
+		},
+	}
+	r := &KafkaReconciler{Reconciler: controllers.Reconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(objects...).Build(),
+		Scheme: scheme,
+	}}
+
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "kafka-tls-secret", Namespace: "kafka-service"}}
+	assert.Equal(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Name: "kafka", Namespace: "kafka-service"}},
+	}, r.sslSecretRequests(context.Background(), secret))
+
+	secret.Name = "kafka-generated-tls-secret"
+	assert.Equal(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Name: "kafka-generated", Namespace: "kafka-service"}},
+	}, r.sslSecretRequests(context.Background(), secret))
+
+	secret.Name = "kafka-secret"
+	assert.Empty(t, r.sslSecretRequests(context.Background(), secret))
+}
@@ -43,10 +43,8 @@ import (
 var ErrNoKafkaPods = stderrors.New("no Kafka pods found")
 
 const (
-	kafkaConditionReason              = "KafkaReadinessStatus"
-	kafkaHashName                     = "spec"
-	autoRestartAnnotation             = "kafkaservice.netcracker.com/auto-restart"
-	resourceVersionAnnotationTemplate = "%s/resource-version"
+	kafkaConditionReason = "KafkaReadinessStatus"
+	kafkaHashName        = "spec"
 )
 
 type ReconcileKafka struct {
@@ -54,6 +52,8 @@ type ReconcileKafka struct {
 	reconciler    *KafkaReconciler
 	logger        logr.Logger
 	kafkaProvider provider.KafkaResourceProvider
+	// restartSecrets are credentials and TLS secrets which rotation restarts brokers and KRaft controller
+	restartSecrets []*corev1.Secret
 }
 
 func NewReconcileKafka(r *KafkaReconciler, cr *kafka.Kafka, logger logr.Logger) ReconcileKafka {
@@ -66,24 +66,62 @@ func NewReconcileKafka(r *KafkaReconciler, cr *kafka.Kafka, logger logr.Logger)
 }
 
 func (r ReconcileKafka) Reconcile() error {
+	if err := provider.ValidateBrokerConfig(r.cr.Spec.Config); err != nil {
+		return err
+	}
 	kafkaSecret, err := r.reconciler.WatchSecret(r.cr.Spec.SecretName, r.cr, r.logger)
 	if err != nil {
 		return err
 	}
+	externalAddresses, err := r.reconcileExternalAccess()
+	if err != nil {
+		return err
+	}
+	r.kafkaProvider = r.kafkaProvider.WithExternalAddresses(externalAddresses)
+	if r.kafkaProvider.IsCertManagerEnabled() {
+		if err = r.reconcileCertificate(); err != nil {
+			return err
+		}
+	} else if err = r.reconciler.DeleteCertificate(r.kafkaProvider.GetCertificateName(), r.cr.Namespace, r.logger); err != nil {
+		return err
+	}
+	var sslSecret *corev1.Secret
+	if r.cr.Spec.Ssl.Enabled && r.kafkaProvider.GetSslSecretName() != "" {
+		// TLS secret is not owned by the custom resource, because it can be kept on uninstallation,
+		// so its rotation is watched by sslSecretRequests
+		sslSecret, err = r.reconciler.FindSecret(r.kafkaProvider.GetSslSecretName(), r.cr.Namespace, r.logger)
+		if err != nil {
+			return err
+		}
+	}
+	r.restartSecrets = []*corev1.Secret{kafkaSecret, sslSecret}
 
 	kafkaSpecHash, err := util.Hash(r.cr.Spec)
 	if err != nil {
 		return err
 	}
 
+	externalAccessHash, err := util.Hash(externalAddresses)
+	if err != nil {
+		return err
+	}
+
 	kafkaConfigurationChanged := r.reconciler.ResourceHashes[kafkaHashName] != kafkaSpecHash ||
-		(kafkaSecret.Name != "" && r.reconciler.ResourceVersions[kafkaSecret.Name] != kafkaSecret.ResourceVersion)
+		r.reconciler.ResourceHashes[externalAccessHashName] != externalAccessHash ||
+		(kafkaSecret.Name != "" && r.reconciler.ResourceVersions[kafkaSecret.Name] != kafkaSecret.ResourceVersion) ||
+		(sslSecret != nil && r.reconciler.ResourceVersions[sslSecret.Name] != sslSecret.ResourceVersion)
 
 	if !kafkaConfigurationChanged {
 		r.logger.Info("Kafka configuration didn't change, skipping reconcile loop")
 	} else {
 		if r.cr.Spec.Replicas > 0 {
-			if err = r.processKafkaReplicas(kafkaSecret); err != nil {
+			if err = r.updateRestartRequiredConfigs(); err != nil {
+				return err
+			}
+			if err = r.processKafkaReplicas(); err != nil {
+				return err
+			}
+			if err = r.applyDynamicConfigs(); err != nil {
 				return err
 			}
 		}
@@ -114,11 +152,47 @@ func (r ReconcileKafka) Reconcile() error {
 	}
 
 	r.reconciler.ResourceVersions[kafkaSecret.Name] = kafkaSecret.ResourceVersion
+	if sslSecret != nil {
+		r.reconciler.ResourceVersions[sslSecret.Name] = sslSecret.ResourceVersion
+	}
 	r.reconciler.ResourceHashes[kafkaHashName] = kafkaSpecHash
+	r.reconciler.ResourceHashes[externalAccessHashName] = externalAccessHash
 	return nil
 }
 
-func (r ReconcileKafka) processKafkaReplicas(kafkaSecret *corev1.Secret) error {
+// reconcileCertificate creates cert-manager Certificate for brokers and checks that it is issued,
+// ErrCertificateNotIssued is returned until then. Renewed certificate changes TLS secret,
+// so brokers are restarted one by one with the other secret changes.
+func (r ReconcileKafka) reconcileCertificate() error {
+	certManager := r.cr.Spec.Ssl.CertManager
+	dnsNames, ipAddresses := controllers.SplitSubjectAlternativeNames(r.kafkaProvider.GetCertificateHostNames())
+	certificate, err := controllers.NewCertificate(controllers.CertificateParameters{
+		Name:            r.kafkaProvider.GetCertificateName(),
+		Namespace:       r.cr.Namespace,
+		SecretName:      r.kafkaProvider.GetSslSecretName(),
+		CommonName:      r.kafkaProvider.GetServiceName(),
+		DnsNames:        dnsNames,
+		IpAddresses:     ipAddresses,
+		Usages:          []string{"server auth", "client auth"},
+		IssuerName:      certManager.IssuerName,
+		IssuerKind:      certManager.IssuerKind,
+		DurationDays:    certManager.DurationDays,
+		RenewBeforeDays: certManager.RenewBeforeDays,
+		Labels:          r.kafkaProvider.GetKafkaLabels(),
+	})
+	if err != nil {
+		return err
+	}
+	if err = r.reconciler.SetControllerReference(r.cr, certificate, r.reconciler.Scheme); err != nil {
+		return err
+	}
+	if err = r.reconciler.CreateOrUpdateCertificate(certificate, r.logger); err != nil {
+		return err
+	}
+	return r.reconciler.CheckCertificateIssued(certificate.GetName(), r.cr.Namespace, r.logger)
+}
+
+func (r ReconcileKafka) processKafkaReplicas() error {
 	kafkaSpec := r.cr.Spec
 
 	err := checkParamsForExternalAccess(r.cr, kafkaSpec.Replicas)
@@ -279,7 +353,11 @@ func (r ReconcileKafka) processKafkaReplicas(kafkaSecret *corev1.Secret) error {
 		log.Info("ZooKeeper to Kraft migration finished succesfully or not needed")
 	}
 
-	if err := r.rolloutBrokers(kafkaSpec.Replicas, kraft, kafkaSecret); err != nil {
+	if err := r.rolloutKraftController(); err != nil {
+		return err
+	}
+
+	if err := r.rolloutBrokers(kafkaSpec.Replicas, kraft); err != nil {
 		return err
 	}
 
@@ -321,13 +399,16 @@ func (r *ReconcileKafka) isRollingUpdateApplicable(currentReplicas int) (bool, e
 	return true, nil
 }
 
-func (r ReconcileKafka) rolloutBrokers(replicas int, kraft bool, kafkaSecret *corev1.Secret) error {
+// rolloutBrokers updates brokers one by one. It waits for broker readiness before the next one
+// when rolling update is enabled or the broker is restarted because of secrets rotation.
+func (r ReconcileKafka) rolloutBrokers(replicas int, kraft bool) error {
 	r.logger.Info("Perform brokers rollout procedure")
 	for brokerId := 1; brokerId <= replicas; brokerId++ {
-		if err := r.rolloutBroker(brokerId, kraft, kafkaSecret); err != nil {
+		restarted, err := r.rolloutBroker(brokerId, kraft)
+		if err != nil {
 			return err
 		}
-		if r.cr.Spec.RollingUpdate {
+		if r.cr.Spec.RollingUpdate || restarted {
 			if err := r.waitUntilBrokerIsReady(brokerId, 300); err != nil {
 				return err
 			}
@@ -378,46 +459,78 @@ func (r ReconcileKafka) Status() error {
 	return r.reconciler.updateConditions(NewCondition(statusTrue, typeReady, kafkaConditionReason, "Kafka pods are ready"))
 }
 
-func (r *ReconcileKafka) rolloutBroker(brokerId int, kraft bool, kafkaSecret *corev1.Secret) error {
+// rolloutBroker creates or updates broker entities and returns whether the broker is restarted
+// because secrets with auto-restart annotation are rotated
+func (r *ReconcileKafka) rolloutBroker(brokerId int, kraft bool) (bool, error) {
 	brokerService := r.kafkaProvider.NewKafkaBrokerServiceForCR(brokerId)
 	if err := r.reconciler.SetControllerReference(r.cr, brokerService, r.reconciler.Scheme); err != nil {
-		return err
+		return false, err
 	}
 	if err := r.reconciler.CreateOrUpdateService(brokerService, r.logger); err != nil {
-		return err
+		return false, err
 	}
 
 	persistentVolumeClaim := r.kafkaProvider.NewKafkaPersistentVolumeClaimForCR(brokerId)
 	if persistentVolumeClaim != nil {
 		if err := r.reconciler.CreatePersistentVolumeClaim(persistentVolumeClaim, r.logger); err != nil {
-			return err
+			return false, err
 		}
 	}
 
 	rack, err := r.getRack(brokerId, r.logger)
 	if err != nil {
-		return err
+		return false, err
 	}
      
 	var clusterID string
     if kraft {
         clusterID, err = r.resolveClusterID()
         if err != nil && err != ErrNoKafkaPods {
-		   return err
+		   return false, err
 	    }
     }
 
 	brokerDeployment := r.kafkaProvider.NewKafkaBrokerDeploymentForCR(brokerId, rack, kraft, clusterID)
 	if err := r.reconciler.SetControllerReference(r.cr, brokerDeployment, r.reconciler.Scheme); err != nil {
-		return err
+		return false, err
 	}
-	if kafkaSecret.Annotations != nil && kafkaSecret.Annotations[autoRestartAnnotation] == "true" {
-		r.addDeploymentAnnotation(brokerDeployment, fmt.Sprintf(resourceVersionAnnotationTemplate, kafkaSecret.Name), kafkaSecret.ResourceVersion)
+	restarted, err := r.isRestartedBySecrets(brokerDeployment.Name)
+	if err != nil {
+		return false, err
 	}
+	controllers.ApplyAutoRestartSecretAnnotations(brokerDeployment, r.logger, r.restartSecrets...)
 	if err := r.reconciler.CreateOrUpdateDeployment(brokerDeployment, r.logger); err != nil {
+		return false, err
+	}
+	return restarted, nil
+}
+
+// isRestartedBySecrets checks whether existing deployment is restarted by rotation of secrets
+func (r *ReconcileKafka) isRestartedBySecrets(deploymentName string) (bool, error) {
+	deployment, err := r.reconciler.FindDeployment(deploymentName, r.cr.Namespace, r.logger)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return controllers.IsSecretRestartRequired(deployment, r.restartSecrets...), nil
+}
+
+// rolloutKraftController restarts KRaft migration controller before brokers when its secrets are rotated
+// and waits for its readiness
+func (r *ReconcileKafka) rolloutKraftController() error {
+	deploymentName := fmt.Sprintf("%s-%s", r.cr.Name, "kraft-controller")
+	restarted, err := r.isRestartedBySecrets(deploymentName)
+	if err != nil || !restarted {
 		return err
 	}
-	return nil
+	r.logger.Info("Restarting Kraft controller because of secrets rotation")
+	if err = controllers.UpdateDeploymentSecretRestartAnnotations(r.reconciler.Client, r.cr.Namespace, deploymentName,
+		r.logger, r.restartSecrets...); err != nil {
+		return err
+	}
+	return r.waitUntilControllerIsReady(r.cr.Spec.Kraft.MigrationTimeout)
 }
 
 func (r *ReconcileKafka) updateBrokerDeploymentForMigration(brokerId int, replicas int, zkClusterID string, migrated bool) error {
@@ -451,6 +564,7 @@ func (r *ReconcileKafka) updateBrokerDeploymentForMigration(brokerId int, replic
 	if err := r.reconciler.SetControllerReference(r.cr, brokerDeployment, r.reconciler.Scheme); err != nil {
 		return err
 	}
+	controllers.ApplyAutoRestartSecretAnnotations(brokerDeployment, r.logger, r.restartSecrets...)
 	if err := r.reconciler.CreateOrUpdateDeployment(brokerDeployment, r.logger); err != nil {
 		return err
 	}
@@ -476,6 +590,7 @@ func (r *ReconcileKafka) createMigrationControllerEntities(zkClusterID string) e
 	if err := r.reconciler.SetControllerReference(r.cr, controllerDeployment, r.reconciler.Scheme); err != nil {
 		return err
 	}
+	controllers.ApplyAutoRestartSecretAnnotations(controllerDeployment, r.logger, r.restartSecrets...)
 
 	if err := r.reconciler.CreateOrUpdateDeployment(controllerDeployment, r.logger); err != nil {
 		return err
@@ -514,6 +629,7 @@ func (r *ReconcileKafka) updateMigrationControllerWithoutZooKeeper(zkClusterID s
 	if err := r.reconciler.SetControllerReference(r.cr, controllerDeployment, r.reconciler.Scheme); err != nil {
 		return err
 	}
+	controllers.ApplyAutoRestartSecretAnnotations(controllerDeployment, r.logger, r.restartSecrets...)
 
 	if err := r.reconciler.CreateOrUpdateDeployment(controllerDeployment, r.logger); err != nil {
 		return err
@@ -568,6 +684,7 @@ func (r *ReconcileKafka) updateBrokersWithoutKraftMigrationController(zkClusterI
 		if err := r.reconciler.SetControllerReference(r.cr, brokerDeployment, r.reconciler.Scheme); err != nil {
 			return err
 		}
+		controllers.ApplyAutoRestartSecretAnnotations(brokerDeployment, r.logger, r.restartSecrets...)
 		if err := r.reconciler.CreateOrUpdateDeployment(brokerDeployment, r.logger); err != nil {
 			return err
 		}
@@ -748,9 +865,9 @@ func (r *ReconcileKafka) getKafkaCredentials() (string, string, error) {
 }
 
 func (r *ReconcileKafka) getKafkaCertificates() (*controllers.SslCertificates, error) {
-	if r.cr.Spec.Ssl.Enabled && r.cr.Spec.Ssl.SecretName != "" {
+	if r.cr.Spec.Ssl.Enabled && r.kafkaProvider.GetSslSecretName() != "" {
 		sslCertificates, err :=
-			r.reconciler.GetSslCertificates(r.cr.Spec.Ssl.SecretName, r.cr.Namespace, r.logger)
+			r.reconciler.GetSslCertificates(r.kafkaProvider.GetSslSecretName(), r.cr.Namespace, r.logger)
 		if err != nil {
 			return nil, err
 		}
@@ -778,14 +895,33 @@ func (r *ReconcileKafka) getCurrentDeploymentsCount() (int, error) {
 func checkParamsForExternalAccess(cr *kafka.Kafka, replicasCount int) error {
 	externalHostNamesCount := len(cr.Spec.ExternalHostNames)
 	externalPortsCount := len(cr.Spec.ExternalPorts)
+	externalAccess := cr.Spec.ExternalAccess
 
-	if externalHostNamesCount > 0 {
-		if externalHostNamesCount != replicasCount {
-			return fmt.Errorf("the number of external host names must be equal to replicas")
+	if externalHostNamesCount > 0 && externalHostNamesCount != replicasCount {
+		return fmt.Errorf("the number of external host names must be equal to replicas")
+	}
+	if (externalHostNamesCount > 0 || externalAccess != nil) && externalPortsCount > 0 && externalPortsCount != replicasCount {
+		return fmt.Errorf("external ports must be empty or their number must be equal to replicas")
+	}
+	if externalAccess == nil {
+		return nil
+	}
+	switch externalAccess.Type {
+	case provider.ExternalAccessLoadBalancer:
+	case provider.ExternalAccessNodePort:
+		if externalHostNamesCount == 0 {
+			return fmt.Errorf("external host names of nodes must be specified for NodePort external access")
+		}
+	case provider.ExternalAccessIngress:
+		if externalHostNamesCount == 0 {
+			return fmt.Errorf("external host names must be specified for Ingress external access")
 		}
-		if externalPortsCount > 0 && externalPortsCount != replicasCount {
-			return fmt.Errorf("external ports must be empty or their number must be equal to replicas")
+		if !cr.Spec.Ssl.Enabled {
+			return fmt.Errorf("TLS must be enabled for Ingress external access, because Ingress passes TLS traffic through")
 		}
+	default:
+		return fmt.Errorf("external access type must be %s, %s or %s, but it is [%s]", provider.ExternalAccessLoadBalancer,
+			provider.ExternalAccessNodePort, provider.ExternalAccessIngress, externalAccess.Type)
 	}
 	return nil
 }
@@ -834,8 +970,15 @@ func (r *ReconcileKafka) updateKafkaStatus() error {
 	}
 	podNames := controllers.GetPodNames(foundPodList.Items)
 	sort.Strings(podNames)
+	externalAccessStatus := r.getExternalAccessStatus()
+	brokerConfigStatus, err := r.getBrokerConfigStatus()
+	if err != nil {
+		return err
+	}
 	return r.reconciler.StatusUpdater.UpdateStatusWithRetry(func(instance *kafka.Kafka) {
 		instance.Status.KafkaBrokerStatus.Brokers = podNames
+		instance.Status.ExternalAccessStatus = externalAccessStatus
+		instance.Status.BrokerConfigStatus = brokerConfigStatus
 	})
 }
 
@@ -882,12 +1025,3 @@ func (r *ReconcileKafka) waitUntilMigrationCompleted(maxWaitingInterval int) err
 	}
 	return nil
 }
-
-func (r ReconcileKafka) addDeploymentAnnotation(deployment *appsv1.Deployment, annotationName string, annotationValue string) {
-	r.logger.Info(fmt.Sprintf("Add annotation '%s: %s' to deployment '%s'",
-		annotationName, annotationValue, deployment.Name))
-	if deployment.Spec.Template.Annotations == nil {
-		deployment.Spec.Template.Annotations = map[string]string{}
-	}
-	deployment.Spec.Template.Annotations[annotationName] = annotationValue
-}
@@ -15,11 +15,10 @@
 package controllers
 
 import (
-	"crypto/tls"
-	"crypto/x509"
 	"fmt"
 	"github.com/IBM/sarama"
-	"github.com/Netcracker/qubership-kafka/operator/util"
+	"github.com/Netcracker/qubership-kafka/kafkaconn"
+	"github.com/Netcracker/qubership-kafka/kafkaconn/saramaconn"
 	"math"
 	"sort"
 	"strings"
@@ -111,48 +110,35 @@ func NewKafkaAdminClient(
 	return adminClient, nil
 }
 
+// NewKafkaClientConfig builds sarama configuration with the shared Kafka connection settings,
+// supported SASL mechanisms are PLAIN, SCRAM-SHA-256, SCRAM-SHA-512 and OAUTHBEARER
 func NewKafkaClientConfig(saslSettings *SaslSettings, sslEnabled bool, sslCertificates *SslCertificates) (*sarama.Config, error) {
-	config := sarama.NewConfig()
-	if saslSettings.Username != "" && saslSettings.Password != "" {
-		log.Info("Configuring SASL...")
-
-		mechanism := util.DefaultIfEmpty(saslSettings.Mechanism, sarama.SASLTypeSCRAMSHA512)
-		if mechanism != sarama.SASLTypePlaintext && mechanism != sarama.SASLTypeSCRAMSHA512 {
-			return nil, fmt.Errorf("cannot use given SASL Mechanism: %s", mechanism)
-		}
-		config.Net.SASL.Enable = true
-		config.Net.SASL.Mechanism = sarama.SASLMechanism(mechanism)
-		config.Net.SASL.User = saslSettings.Username
-		config.Net.SASL.Password = saslSettings.Password
-
-		if mechanism == sarama.SASLTypeSCRAMSHA512 {
-			config.Net.SASL.SCRAMClientGeneratorFunc = func() sarama.SCRAMClient {
-				return &XDGSCRAMClient{HashGeneratorFcn: SHA512}
-			}
-		}
-		log.Info("SASL configuration is applied")
-	}
-	if sslEnabled {
-		log.Info("Configuring TLS...")
-		if len(sslCertificates.CaCert) != 0 {
-			caCertPool := x509.NewCertPool()
-			caCertPool.AppendCertsFromPEM(sslCertificates.CaCert)
-			tlsConfig := &tls.Config{
-				RootCAs: caCertPool,
-			}
-			if len(sslCertificates.TlsCert) != 0 && len(sslCertificates.TlsKey) != 0 {
-				log.Info("Configuring mTLS...")
-				cert, err := tls.X509KeyPair(sslCertificates.TlsCert, sslCertificates.TlsKey)
-				if err != nil {
-					return nil, err
-				}
-				tlsConfig.Certificates = []tls.Certificate{cert}
-				log.Info("mTLS configuration is applied")
-			}
-			config.Net.TLS.Config = tlsConfig
+	settings := kafkaconn.Settings{
+		SASL: kafkaconn.SASLSettings{
+			Mechanism: saslSettings.Mechanism,
+			Username:  saslSettings.Username,
+			Password:  saslSettings.Password,
+		},
+		TLS: kafkaconn.TLSSettings{
+			Enabled: sslEnabled,
+			CACert:  sslCertificates.CaCert,
+			Cert:    sslCertificates.TlsCert,
+			Key:     sslCertificates.TlsKey,
+		},
+	}
+	config, err := saramaconn.NewConfig(settings)
+	if err != nil {
+		return nil, err
+	}
+	if config.Net.SASL.Enable {
+		log.Info(fmt.Sprintf("SASL configuration is applied with mechanism %s", config.Net.SASL.Mechanism))
+	}
+	if config.Net.TLS.Enable {
+		if settings.TLS.MutualTLS() {
+			log.Info("mTLS configuration is applied")
+		} else {
+			log.Info("TLS configuration is applied")
 		}
-		config.Net.TLS.Enable = true
-		log.Info("TLS configuration is applied")
 	}
 	config.Version = sarama.V3_6_0_0
 	return config, nil
@@ -82,9 +82,23 @@ func TestNewKafkaClientConfigWhenSaslMechanismIsScramSha256(t *testing.T) {
 		Username:  username,
 		Password:  password,
 	}
+	config, err := NewKafkaClientConfig(saslSettings, false, &SslCertificates{})
+	assert.Nil(t, err)
+	assert.Equal(t, true, config.Net.SASL.Enable)
+	assert.Equal(t, sarama.SASLMechanism(sarama.SASLTypeSCRAMSHA256), config.Net.SASL.Mechanism)
+	assert.NotNil(t, config.Net.SASL.SCRAMClientGeneratorFunc)
+	assert.Equal(t, false, config.Net.TLS.Enable)
+}
+
+func TestNewKafkaClientConfigWhenSaslMechanismIsUnsupported(t *testing.T) {
+	saslSettings := &SaslSettings{
+		Mechanism: sarama.SASLTypeGSSAPI,
+		Username:  username,
+		Password:  password,
+	}
 	_, err := NewKafkaClientConfig(saslSettings, false, &SslCertificates{})
 	assert.NotNil(t, err)
-	assert.Equal(t, fmt.Sprintf("cannot use given SASL Mechanism: %s", sarama.SASLTypeSCRAMSHA256), err.Error())
+	assert.Equal(t, fmt.Sprintf("cannot use given SASL Mechanism: %s", sarama.SASLTypeGSSAPI), err.Error())
 }
 
 func TestNewKafkaClientConfigWhenSaslIsDisabledAndCaCertExists(t *testing.T) {
@@ -0,0 +1,133 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaservice
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	kafkaservice "github.com/Netcracker/qubership-kafka/operator/api/v7"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	akhqOidcConditionReason = "AkhqOidcDiscoveryStatus"
+	oidcDiscoveryPath       = "/.well-known/openid-configuration"
+	oidcDiscoveryTimeout    = 10 * time.Second
+)
+
+// oidcDiscoveryDocument contains the fields of OpenID Connect discovery document which AKHQ needs to log in users
+type oidcDiscoveryDocument struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JwksUri               string `json:"jwks_uri"`
+}
+
+// validateOidcConfig checks required parameters of OpenID Connect configuration, client secret
+// and trusted certificate of the issuer
+func validateOidcConfig(oidc *kafkaservice.OidcConfig, secret *corev1.Secret, secretKey string) error {
+	if oidc.ClientId == "" {
+		return fmt.Errorf("AKHQ OpenID Connect client ID is not specified")
+	}
+	issuerUrl, err := url.Parse(oidc.Issuer)
+	if err != nil || (issuerUrl.Scheme != "https" && issuerUrl.Scheme != "http") || issuerUrl.Host == "" {
+		return fmt.Errorf("AKHQ OpenID Connect issuer [%s] is not a valid URL", oidc.Issuer)
+	}
+	for _, group := range oidc.Groups {
+		if group.Name == "" {
+			return fmt.Errorf("AKHQ OpenID Connect groups mapping contains group without name")
+		}
+	}
+	if len(secret.Data[secretKey]) == 0 {
+		return fmt.Errorf("AKHQ OpenID Connect client secret is not found by [%s] key in [%s] secret",
+			secretKey, secret.Name)
+	}
+	if _, err = newOidcHttpClient(oidc); err != nil {
+		return err
+	}
+	return nil
+}
+
+// newOidcHttpClient returns HTTP client which trusts system certificates and trusted certificate of the issuer
+func newOidcHttpClient(oidc *kafkaservice.OidcConfig) (*http.Client, error) {
+	httpClient := &http.Client{Timeout: oidcDiscoveryTimeout}
+	if oidc.TrustedCert == "" {
+		return httpClient, nil
+	}
+	cert, err := base64.StdEncoding.DecodeString(oidc.TrustedCert)
+	if err != nil {
+		return nil, fmt.Errorf("AKHQ OpenID Connect trusted certificate is not base64 encoded: %w", err)
+	}
+	rootCAs, err := x509.SystemCertPool()
+	if err != nil {
+		rootCAs = x509.NewCertPool()
+	}
+	if !rootCAs.AppendCertsFromPEM(cert) {
+		return nil, fmt.Errorf("AKHQ OpenID Connect trusted certificate does not contain PEM encoded certificates")
+	}
+	httpClient.Transport = &http.Transport{
+		Proxy:           http.ProxyFromEnvironment,
+		TLSClientConfig: &tls.Config{RootCAs: rootCAs, MinVersion: tls.VersionTLS12},
+	}
+	return httpClient, nil
+}
+
+// checkOidcDiscovery checks that discovery document of the issuer is available and contains endpoints
+// which AKHQ needs to log in users
+func checkOidcDiscovery(oidc *kafkaservice.OidcConfig, httpClient *http.Client) error {
+	document, err := getOidcDiscoveryDocument(oidc.Issuer, httpClient)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSuffix(document.Issuer, "/") != strings.TrimSuffix(oidc.Issuer, "/") {
+		return fmt.Errorf("AKHQ OpenID Connect issuer [%s] differs from [%s] issuer of discovery document",
+			oidc.Issuer, document.Issuer)
+	}
+	if document.AuthorizationEndpoint == "" || document.TokenEndpoint == "" || document.JwksUri == "" {
+		return fmt.Errorf("discovery document of [%s] issuer does not contain authorization, token or JWKS endpoint",
+			oidc.Issuer)
+	}
+	return nil
+}
+
+func getOidcDiscoveryDocument(issuer string, httpClient *http.Client) (*oidcDiscoveryDocument, error) {
+	response, err := httpClient.Get(strings.TrimSuffix(issuer, "/") + oidcDiscoveryPath)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get discovery document of [%s] issuer: %w", issuer, err)
+	}
+	defer func() { _ = response.Body.Close() }()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("cannot get discovery document of [%s] issuer, response code is %d",
+			issuer, response.StatusCode)
+	}
+	document := &oidcDiscoveryDocument{}
+	if err = json.Unmarshal(body, document); err != nil {
+		return nil, fmt.Errorf("discovery document of [%s] issuer is not a valid JSON: %w", issuer, err)
+	}
+	return document, nil
+}
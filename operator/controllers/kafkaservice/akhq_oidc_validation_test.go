@@ -0,0 +1,183 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaservice
+
+import (
+	"encoding/base64"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	kafkaservice "github.com/Netcracker/qubership-kafka/operator/api/v7"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// discoveryDocument is a static discovery document of OpenID Connect provider, $SERVER is replaced with test server URL
+const discoveryDocument = `{
+  "issuer": "$SERVER/realms/kafka",
+  "authorization_endpoint": "$SERVER/realms/kafka/protocol/openid-connect/auth",
+  "token_endpoint": "$SERVER/realms/kafka/protocol/openid-connect/token",
+  "jwks_uri": "$SERVER/realms/kafka/protocol/openid-connect/certs",
+  "response_types_supported": ["code"]
+}`
+
+func newDiscoveryServer(t *testing.T, document string) *httptest.Server {
+	return startDiscoveryServer(t, document, false)
+}
+
+func startDiscoveryServer(t *testing.T, document string, tls bool) *httptest.Server {
+	var server *httptest.Server
+	server = httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/realms/kafka"+oidcDiscoveryPath {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(strings.ReplaceAll(document, "$SERVER", server.URL)))
+	}))
+	if tls {
+		server.StartTLS()
+	} else {
+		server.Start()
+	}
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newOidcSecret(data map[string][]byte) *corev1.Secret {
+	return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "akhq-oidc-secret"}, Data: data}
+}
+
+func newTrustedCert(server *httptest.Server) string {
+	cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	return base64.StdEncoding.EncodeToString(cert)
+}
+
+func TestAkhqOidc_validateOidcConfig(t *testing.T) {
+	secret := newOidcSecret(map[string][]byte{"client-secret": []byte("secret")})
+	oidc := &kafkaservice.OidcConfig{Enabled: true, Issuer: "https://sso.example.com/realms/kafka/", ClientId: "akhq"}
+
+	assert.NoError(t, validateOidcConfig(oidc, secret, "client-secret"))
+}
+
+func TestAkhqOidc_validateOidcConfig_invalidConfig(t *testing.T) {
+	secret := newOidcSecret(map[string][]byte{"client-secret": []byte("secret")})
+	issuer := "https://sso.example.com/realms/kafka"
+	tests := []struct {
+		oidc          *kafkaservice.OidcConfig
+		secretKey     string
+		expectedError string
+	}{
+		{
+			oidc:          &kafkaservice.OidcConfig{Issuer: issuer},
+			secretKey:     "client-secret",
+			expectedError: "client ID is not specified",
+		},
+		{
+			oidc:          &kafkaservice.OidcConfig{Issuer: "sso.example.com", ClientId: "akhq"},
+			secretKey:     "client-secret",
+			expectedError: "is not a valid URL",
+		},
+		{
+			oidc: &kafkaservice.OidcConfig{Issuer: issuer, ClientId: "akhq",
+				Groups: []kafkaservice.OidcGroup{{Groups: []string{"admin"}}}},
+			secretKey:     "client-secret",
+			expectedError: "contains group without name",
+		},
+		{
+			oidc:          &kafkaservice.OidcConfig{Issuer: issuer, ClientId: "akhq"},
+			secretKey:     "secret",
+			expectedError: "client secret is not found by [secret] key in [akhq-oidc-secret] secret",
+		},
+		{
+			oidc:          &kafkaservice.OidcConfig{Issuer: issuer, ClientId: "akhq", TrustedCert: "not-base64"},
+			secretKey:     "client-secret",
+			expectedError: "trusted certificate is not base64 encoded",
+		},
+		{
+			oidc: &kafkaservice.OidcConfig{Issuer: issuer, ClientId: "akhq",
+				TrustedCert: base64.StdEncoding.EncodeToString([]byte("ca"))},
+			secretKey:     "client-secret",
+			expectedError: "trusted certificate does not contain PEM encoded certificates",
+		},
+	}
+	for _, test := range tests {
+		err := validateOidcConfig(test.oidc, secret, test.secretKey)
+		if assert.Error(t, err, "issuer is %s", test.oidc.Issuer) {
+			assert.Contains(t, err.Error(), test.expectedError)
+		}
+	}
+}
+
+func TestAkhqOidc_checkOidcDiscovery(t *testing.T) {
+	server := newDiscoveryServer(t, discoveryDocument)
+	oidc := &kafkaservice.OidcConfig{Enabled: true, Issuer: server.URL + "/realms/kafka/", ClientId: "akhq"}
+
+	assert.NoError(t, checkOidcDiscovery(oidc, server.Client()))
+
+	oidc.Issuer = server.URL + "/realms/other"
+	err := checkOidcDiscovery(oidc, server.Client())
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "response code is 404")
+	}
+}
+
+func TestAkhqOidc_checkOidcDiscovery_trustedCert(t *testing.T) {
+	server := startDiscoveryServer(t, discoveryDocument, true)
+	oidc := &kafkaservice.OidcConfig{Enabled: true, Issuer: server.URL + "/realms/kafka", ClientId: "akhq"}
+
+	httpClient, err := newOidcHttpClient(oidc)
+	assert.NoError(t, err)
+	err = checkOidcDiscovery(oidc, httpClient)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "cannot get discovery document")
+	}
+
+	oidc.TrustedCert = newTrustedCert(server)
+	httpClient, err = newOidcHttpClient(oidc)
+	assert.NoError(t, err)
+	assert.NoError(t, checkOidcDiscovery(oidc, httpClient))
+}
+
+func TestAkhqOidc_checkOidcDiscovery_invalidDocument(t *testing.T) {
+	tests := []struct {
+		document      string
+		expectedError string
+	}{
+		{
+			document:      `{"issuer": "https://sso.example.com/realms/kafka"}`,
+			expectedError: "differs from [https://sso.example.com/realms/kafka] issuer of discovery document",
+		},
+		{
+			document:      `{"issuer": "$SERVER/realms/kafka", "token_endpoint": "$SERVER/token"}`,
+			expectedError: "does not contain authorization, token or JWKS endpoint",
+		},
+		{
+			document:      `<html>$SERVER</html>`,
+			expectedError: "is not a valid JSON",
+		},
+	}
+	for _, test := range tests {
+		server := newDiscoveryServer(t, test.document)
+		oidc := &kafkaservice.OidcConfig{Issuer: server.URL + "/realms/kafka", ClientId: "akhq"}
+		err := checkOidcDiscovery(oidc, server.Client())
+		if assert.Error(t, err, "document is %s", test.document) {
+			assert.Contains(t, err.Error(), test.expectedError)
+		}
+	}
+}
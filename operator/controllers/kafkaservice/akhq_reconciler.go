@@ -43,7 +43,9 @@ const (
 )
 
 var controllerReferenceSet = false
+var accessControllerReferenceSet = false
 var ldapSecret *corev1.Secret
+var oidcSecret *corev1.Secret
 var ldapConfigMap *corev1.ConfigMap
 
 type ReconcileAkhq struct {
@@ -59,6 +61,7 @@ func NewReconcileAkhq(r *KafkaServiceReconciler, cr *kafkaservice.KafkaService,
 
 func (r ReconcileAkhq) Reconcile() error {
 	ldapSecret = nil
+	oidcSecret = nil
 	ldapConfigMap = nil
 
 	akhqSecret, err := r.reconciler.WatchSecret("akhq-secret", r.cr, r.logger)
@@ -71,6 +74,14 @@ func (r ReconcileAkhq) Reconcile() error {
 		return err
 	}
 
+	var sslSecret *corev1.Secret
+	if provider.IsCertManagerEnabled(r.cr) {
+		sslSecret, err = r.reconciler.WatchSecret(r.akhqProvider.GetSslSecretName(), r.cr, r.logger)
+		if err != nil {
+			return err
+		}
+	}
+
 	if err := r.deleteLegacyConfigMap(); err != nil {
 		return err
 	}
@@ -80,6 +91,11 @@ func (r ReconcileAkhq) Reconcile() error {
 		return err
 	}
 
+	accessConfigMap, err := r.getAccessConfigMap()
+	if err != nil {
+		return err
+	}
+
 	if r.cr.Spec.Akhq.Ldap.Enabled {
 		ldapSecret, err = r.reconciler.WatchSecret("akhq-ldap-secret", r.cr, r.logger)
 		if err != nil {
@@ -93,6 +109,13 @@ func (r ReconcileAkhq) Reconcile() error {
 		}
 	}
 
+	if r.akhqProvider.IsOidcEnabled() {
+		oidcSecret, err = r.reconciler.WatchSecret(r.akhqProvider.GetOidcSecretName(), r.cr, r.logger)
+		if err != nil {
+			return err
+		}
+	}
+
 	deserealizationSourceConfigMaps, err := r.findDeserializationConfigMaps()
 	if err != nil {
 		r.logger.Error(err, "Cannot create or find deserialization source config maps")
@@ -107,11 +130,20 @@ func (r ReconcileAkhq) Reconcile() error {
 		r.reconciler.ResourceHashes[globalHashName] != globalSpecHash ||
 		(akhqSecret.Name != "" && r.reconciler.ResourceVersions[akhqSecret.Name] != akhqSecret.ResourceVersion) ||
 		(ldapSecret != nil && ldapSecret.Name != "" && r.reconciler.ResourceVersions[ldapSecret.Name] != ldapSecret.ResourceVersion) ||
+		(oidcSecret != nil && oidcSecret.Name != "" && r.reconciler.ResourceVersions[oidcSecret.Name] != oidcSecret.ResourceVersion) ||
 		(kafkaServicesSecret.Name != "" && r.reconciler.ResourceVersions[kafkaServicesSecret.Name] != kafkaServicesSecret.ResourceVersion) ||
 		(protobufConfigMap.Name != "" && r.reconciler.ResourceVersions[protobufConfigMap.Name] != protobufConfigMap.ResourceVersion) ||
+		(accessConfigMap.Name != "" && r.reconciler.ResourceVersions[accessConfigMap.Name] != accessConfigMap.ResourceVersion) ||
 		(ldapConfigMap != nil && ldapConfigMap.Name != "" && r.reconciler.ResourceVersions[ldapConfigMap.Name] != ldapConfigMap.ResourceVersion) {
 		akhqLabels := r.akhqProvider.GetAkhqSelectorLabels()
 
+		if r.akhqProvider.IsOidcEnabled() {
+			if err := validateOidcConfig(r.cr.Spec.Akhq.Oidc, oidcSecret, r.akhqProvider.GetOidcSecretKey()); err != nil {
+				r.logger.Error(err, "AKHQ OpenID Connect configuration is not valid")
+				return err
+			}
+		}
+
 		trustCertsSecret := r.akhqProvider.NewLdapTrustedSecret("akhq-trusted-certs")
 		if err := r.reconciler.CreateOrUpdateSecret(trustCertsSecret, r.logger); err != nil {
 			return err
@@ -133,18 +165,31 @@ func (r ReconcileAkhq) Reconcile() error {
 		r.logger.Info("Checking security configuration. " +
 			"Empty security configuration will be created if it does not exist. " +
 			"Otherwise, it will not be deleted during installation, to check and change it go to the <akhq-security-configuration> secret")
+		if r.akhqProvider.IsOidcEnabled() {
+			if err := r.updateOidcDiscoveryStatus(); err != nil {
+				return err
+			}
+		}
+
+		accessConfig, err := akhqproto.ParseAccessConfig(accessConfigMap)
+		if err != nil {
+			r.logger.Error(err, "Cannot parse AKHQ access configuration")
+			return err
+		}
 		securityConfiguration := r.akhqProvider.NewSecurityConfiguration()
 
 		errSec := r.reconciler.RestoreSpecFields(securityConfiguration, r.akhqProvider.ProtectedSecretsFields(), r.logger)
 		if errSec != nil {
 			return err
 		}
+		r.akhqProvider.AddAccessGroupsProperties(securityConfiguration, accessConfig.Access)
 
 		if errSec = r.reconciler.CreateOrUpdateSecret(securityConfiguration, r.logger); errSec != nil {
 			return errSec
 		}
 
-		deployment := r.akhqProvider.NewAkhqDeployment(protobufConfigMap.ResourceVersion, deserealizationSourceConfigMaps)
+		deployment := r.akhqProvider.NewAkhqDeployment(protobufConfigMap.ResourceVersion, accessConfigMap.ResourceVersion,
+			deserealizationSourceConfigMaps)
 		if err := r.reconciler.SetControllerReference(r.cr, deployment, r.reconciler.Scheme); err != nil {
 			return err
 		}
@@ -160,20 +205,24 @@ func (r ReconcileAkhq) Reconcile() error {
 		r.logger.Info("AKHQ configuration didn't change, skipping reconcile loop")
 	}
 
-	if err := updateDeploymentSecretRestartAnnotations(
+	if err := controllers.UpdateDeploymentSecretRestartAnnotations(
 		r.reconciler.Client, r.cr.Namespace, r.akhqProvider.GetServiceName(), r.logger,
-		akhqSecret, kafkaServicesSecret, ldapSecret); err != nil {
+		akhqSecret, kafkaServicesSecret, ldapSecret, oidcSecret, sslSecret); err != nil {
 		return err
 	}
 
 	r.reconciler.ResourceVersions[akhqSecret.Name] = akhqSecret.ResourceVersion
 	r.reconciler.ResourceVersions[kafkaServicesSecret.Name] = kafkaServicesSecret.ResourceVersion
 	r.reconciler.ResourceVersions[protobufConfigMap.Name] = protobufConfigMap.ResourceVersion
+	r.reconciler.ResourceVersions[accessConfigMap.Name] = accessConfigMap.ResourceVersion
 	r.reconciler.ResourceHashes[akhqHashName] = akhqSpecHash
 	if r.cr.Spec.Akhq.Ldap.Enabled {
 		r.reconciler.ResourceVersions[ldapSecret.Name] = ldapSecret.ResourceVersion
 		r.reconciler.ResourceVersions[ldapConfigMap.Name] = ldapConfigMap.ResourceVersion
 	}
+	if r.akhqProvider.IsOidcEnabled() {
+		r.reconciler.ResourceVersions[oidcSecret.Name] = oidcSecret.ResourceVersion
+	}
 	return nil
 }
 
@@ -191,6 +240,20 @@ func (r *ReconcileAkhq) getProtobufConfigMap() (*corev1.ConfigMap, error) {
 	return protobufConfigMap, err
 }
 
+func (r *ReconcileAkhq) getAccessConfigMap() (*corev1.ConfigMap, error) {
+	var accessConfigMap *corev1.ConfigMap
+	var err error
+	if !accessControllerReferenceSet {
+		accessConfigMap, err = akhqproto.CreateAccessConfigMapIfNotExist(r.cr.Namespace, r.reconciler.Client, r.akhqProvider.GetAkhqLabels(), r.cr, r.reconciler.Scheme)
+		if err == nil {
+			accessControllerReferenceSet = true
+		}
+	} else {
+		accessConfigMap, err = akhqproto.CreateAccessConfigMapIfNotExist(r.cr.Namespace, r.reconciler.Client, r.akhqProvider.GetAkhqLabels(), nil, nil)
+	}
+	return accessConfigMap, err
+}
+
 func (r ReconcileAkhq) getLdapConfigMap(configMapName string, logger logr.Logger) (*corev1.ConfigMap, error) {
 	ldapConfigMap := r.akhqProvider.NewConfigurationMapForLdap(configMapName)
 	err := r.reconciler.CreateOrUpdateConfigMap(ldapConfigMap, logger)
@@ -201,6 +264,22 @@ func (r ReconcileAkhq) getLdapConfigMap(configMapName string, logger logr.Logger
 	return ldapConfigMap, nil
 }
 
+// updateOidcDiscoveryStatus checks discovery document of OpenID Connect issuer and reports the result
+// in status conditions, unavailable issuer does not block the reconciliation
+func (r ReconcileAkhq) updateOidcDiscoveryStatus() error {
+	httpClient, err := newOidcHttpClient(r.cr.Spec.Akhq.Oidc)
+	if err != nil {
+		return err
+	}
+	if err = checkOidcDiscovery(r.cr.Spec.Akhq.Oidc, httpClient); err != nil {
+		r.logger.Error(err, "AKHQ OpenID Connect discovery check failed")
+		return r.reconciler.updateConditions(NewCondition(statusFalse, typeReady, akhqOidcConditionReason,
+			fmt.Sprintf("AKHQ OpenID Connect discovery check failed: %v", err)))
+	}
+	return r.reconciler.updateConditions(NewCondition(statusTrue, typeReady, akhqOidcConditionReason,
+		"AKHQ OpenID Connect discovery document is available"))
+}
+
 func (r ReconcileAkhq) Status() error {
 	if err := r.reconciler.updateConditions(NewCondition(statusFalse,
 		typeInProgress,
@@ -0,0 +1,233 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaservice
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	kafkaservice "github.com/Netcracker/qubership-kafka/operator/api/v7"
+	"github.com/Netcracker/qubership-kafka/operator/controllers"
+	"github.com/go-logr/logr"
+)
+
+const (
+	backupJobStatusSuccessful = "Successful"
+	backupJobStatusFailed     = "Failed"
+	backupModeAcl             = "acl"
+	// backupDaemonRequestTimeout limits requests to Backup Daemon, it only starts jobs and returns their statuses
+	backupDaemonRequestTimeout = 30 * time.Second
+)
+
+var errBackupDaemonNotFound = errors.New("response code is 404")
+
+type StatusResponse struct {
+	Status string `json:"status"`
+	Vault  string `json:"vault"`
+	Type   string `json:"type"`
+	Err    string `json:"err"`
+	TaskId string `json:"task_id"`
+}
+
+type BackupInfoResponse struct {
+	IsGranular bool              `json:"is_granular"`
+	DBList     string            `json:"db_list"`
+	Id         string            `json:"id"`
+	Failed     bool              `json:"failed"`
+	Locked     bool              `json:"locked"`
+	TS         int               `json:"ts"`
+	SpentTime  string            `json:"spent_time"`
+	Valid      bool              `json:"valid"`
+	Evictable  bool              `json:"evictable"`
+	CustomVars map[string]string `json:"custom_vars,omitempty"`
+}
+
+// BackupRequest contains custom variables of Backup Daemon backup, empty request performs full backup
+type BackupRequest struct {
+	Dbs           []string `json:"dbs,omitempty"`
+	TopicRegex    string   `json:"topic_regex,omitempty"`
+	Mode          string   `json:"mode,omitempty"`
+	AllowEviction string   `json:"allow_eviction,omitempty"`
+}
+
+// RestoreRequest contains vault and custom variables of Backup Daemon restore
+type RestoreRequest struct {
+	Vault      string   `json:"vault"`
+	Dbs        []string `json:"dbs,omitempty"`
+	TopicRegex string   `json:"topic_regex,omitempty"`
+	Mode       string   `json:"mode,omitempty"`
+}
+
+// BackupDaemonClient sends requests to Kafka Backup Daemon REST API
+type BackupDaemonClient struct {
+	url        string
+	username   string
+	password   string
+	httpClient *http.Client
+}
+
+// NewBackupDaemonClient creates client for Backup Daemon with specified URL,
+// the CA certificate is used to verify Backup Daemon if it is mounted to the operator
+func NewBackupDaemonClient(url string, username string, password string) BackupDaemonClient {
+	httpClient := &http.Client{Timeout: backupDaemonRequestTimeout}
+	caCert, err := os.ReadFile(certificateFilePath)
+	if err == nil {
+		caCertPool := x509.NewCertPool()
+		caCertPool.AppendCertsFromPEM(caCert)
+		httpClient.Transport = &http.Transport{
+			TLSClientConfig: &tls.Config{
+				RootCAs: caCertPool,
+			},
+		}
+	}
+	return BackupDaemonClient{
+		url:        strings.TrimSuffix(url, "/"),
+		username:   username,
+		password:   password,
+		httpClient: httpClient,
+	}
+}
+
+// newKafkaServiceBackupDaemonClient creates client for Backup Daemon deployed by specified KafkaService
+func newKafkaServiceBackupDaemonClient(r *controllers.Reconciler, cr *kafkaservice.KafkaService,
+	logger logr.Logger) BackupDaemonClient {
+	backupDaemonUrl := fmt.Sprintf("http://%s-backup-daemon.%s:8080", cr.Name, cr.Namespace)
+	if _, err := os.Stat(certificateFilePath); err == nil {
+		backupDaemonUrl = fmt.Sprintf("https://%s-backup-daemon.%s:8443", cr.Name, cr.Namespace)
+	}
+	var username, password string
+	foundSecret, err := r.FindSecret(fmt.Sprintf("%s-backup-daemon-secret", cr.Name), cr.Namespace, logger)
+	if err == nil {
+		username = string(foundSecret.Data["username"])
+		password = string(foundSecret.Data["password"])
+	}
+	return NewBackupDaemonClient(backupDaemonUrl, username, password)
+}
+
+// Backup starts backup and returns the vault identifier which is also the identifier of backup job
+func (c BackupDaemonClient) Backup(request BackupRequest) (string, error) {
+	var body []byte
+	if len(request.Dbs) != 0 || request.TopicRegex != "" || request.Mode != "" || request.AllowEviction != "" {
+		data, err := json.Marshal(request)
+		if err != nil {
+			return "", err
+		}
+		body = data
+	}
+	response, err := c.processRequest(http.MethodPost, "/backup", body)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(response)), nil
+}
+
+// Restore starts restore of the vault and returns the identifier of restore job
+func (c BackupDaemonClient) Restore(request RestoreRequest) (string, error) {
+	data, err := json.Marshal(request)
+	if err != nil {
+		return "", err
+	}
+	response, err := c.processRequest(http.MethodPost, "/restore", data)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(response)), nil
+}
+
+// JobStatus returns the status of backup or restore job
+func (c BackupDaemonClient) JobStatus(jobId string) (StatusResponse, error) {
+	var status StatusResponse
+	response, err := c.processRequest(http.MethodGet, "/jobstatus/"+url.PathEscape(jobId), nil)
+	if err != nil {
+		return status, err
+	}
+	err = json.Unmarshal(response, &status)
+	return status, err
+}
+
+// ListBackups returns vault identifiers of all stored backups
+func (c BackupDaemonClient) ListBackups() ([]string, error) {
+	var backups []string
+	response, err := c.processRequest(http.MethodGet, "/listbackups", nil)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(response, &backups)
+	return backups, err
+}
+
+// BackupInfo returns the description of stored backup
+func (c BackupDaemonClient) BackupInfo(vault string) (BackupInfoResponse, error) {
+	var info BackupInfoResponse
+	response, err := c.processRequest(http.MethodGet, "/listbackups/"+url.PathEscape(vault), nil)
+	if err != nil {
+		return info, err
+	}
+	err = json.Unmarshal(response, &info)
+	return info, err
+}
+
+// Evict removes stored backup, the backup which does not exist is considered as already removed
+func (c BackupDaemonClient) Evict(vault string) error {
+	_, err := c.processRequest(http.MethodPost, "/evict/"+url.PathEscape(vault), nil)
+	if errors.Is(err, errBackupDaemonNotFound) {
+		return nil
+	}
+	return err
+}
+
+func (c BackupDaemonClient) processRequest(method string, path string, body []byte) ([]byte, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequest(method, c.url+path, reader)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.username != "" && c.password != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+	response, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = response.Body.Close() }()
+	responseData, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%s %s request to Backup Daemon failed: %w", method, path, errBackupDaemonNotFound)
+	}
+	if response.StatusCode >= http.StatusBadRequest {
+		return nil, fmt.Errorf("%s %s request to Backup Daemon failed, response code is %d: %s",
+			method, path, response.StatusCode, strings.TrimSpace(string(responseData)))
+	}
+	return responseData, nil
+}
@@ -0,0 +1,203 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaservice
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// backupDaemonStub imitates Backup Daemon REST API and records received requests
+type backupDaemonStub struct {
+	*httptest.Server
+	mu        sync.Mutex
+	requests  map[string]string
+	jobStatus map[string]string
+	backups   map[string]BackupInfoResponse
+	// vaultIds are returned for backup requests in order, the default vault is returned when they run out
+	vaultIds []string
+}
+
+func newBackupDaemonStub(t *testing.T) *backupDaemonStub {
+	stub := &backupDaemonStub{
+		requests:  map[string]string{},
+		jobStatus: map[string]string{},
+		backups:   map[string]BackupInfoResponse{},
+	}
+	stub.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, ok := r.BasicAuth()
+		if !ok || username != "admin" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		stub.mu.Lock()
+		defer stub.mu.Unlock()
+		stub.requests[r.Method+" "+r.URL.Path] = string(body)
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/backup":
+			vaultId := "20250513T095536"
+			if len(stub.vaultIds) != 0 {
+				vaultId, stub.vaultIds = stub.vaultIds[0], stub.vaultIds[1:]
+			}
+			_, _ = w.Write([]byte(vaultId))
+		case r.Method == http.MethodPost && strings.HasPrefix(r.URL.Path, "/evict/"):
+			vaultId := strings.TrimPrefix(r.URL.Path, "/evict/")
+			if _, found := stub.backups[vaultId]; !found {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			delete(stub.backups, vaultId)
+			_, _ = w.Write([]byte(fmt.Sprintf("Backup %s successfully removed", vaultId)))
+		case r.Method == http.MethodPost && r.URL.Path == "/restore":
+			_, _ = w.Write([]byte("restore-task\n"))
+		case r.URL.Path == "/listbackups":
+			var vaults []string
+			for vault := range stub.backups {
+				vaults = append(vaults, vault)
+			}
+			writeJson(t, w, vaults)
+		case strings.HasPrefix(r.URL.Path, "/listbackups/"):
+			info, found := stub.backups[strings.TrimPrefix(r.URL.Path, "/listbackups/")]
+			if !found {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			writeJson(t, w, info)
+		case strings.HasPrefix(r.URL.Path, "/jobstatus/"):
+			jobId := strings.TrimPrefix(r.URL.Path, "/jobstatus/")
+			status, found := stub.jobStatus[jobId]
+			if !found {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			response := StatusResponse{Status: status, TaskId: jobId}
+			if status == backupJobStatusFailed {
+				response.Err = "Topic topic1 already exists"
+			}
+			writeJson(t, w, response)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(stub.Close)
+	return stub
+}
+
+func (s *backupDaemonStub) setJobStatus(jobId string, status string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobStatus[jobId] = status
+}
+
+func (s *backupDaemonStub) request(key string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	body, found := s.requests[key]
+	return body, found
+}
+
+func (s *backupDaemonStub) client() BackupDaemonClient {
+	return NewBackupDaemonClient(s.URL, "admin", "secret")
+}
+
+func writeJson(t *testing.T, w http.ResponseWriter, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	require.NoError(t, json.NewEncoder(w).Encode(value))
+}
+
+func TestBackupDaemonClient_FullBackupHasNoBody(t *testing.T) {
+	stub := newBackupDaemonStub(t)
+
+	vaultId, err := stub.client().Backup(BackupRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "20250513T095536", vaultId)
+	body, found := stub.request("POST /backup")
+	assert.True(t, found)
+	assert.Empty(t, body)
+}
+
+func TestBackupDaemonClient_GranularBackup(t *testing.T) {
+	stub := newBackupDaemonStub(t)
+
+	_, err := stub.client().Backup(BackupRequest{Dbs: []string{"topic1", "topic2"}, Mode: backupModeAcl, AllowEviction: "False"})
+	require.NoError(t, err)
+	body, _ := stub.request("POST /backup")
+	assert.JSONEq(t, `{"dbs":["topic1","topic2"],"mode":"acl","allow_eviction":"False"}`, body)
+}
+
+func TestBackupDaemonClient_Restore(t *testing.T) {
+	stub := newBackupDaemonStub(t)
+
+	taskId, err := stub.client().Restore(RestoreRequest{Vault: "20250513T095536", TopicRegex: "topic[1-9]"})
+	require.NoError(t, err)
+	assert.Equal(t, "restore-task", taskId)
+	body, _ := stub.request("POST /restore")
+	assert.JSONEq(t, `{"vault":"20250513T095536","topic_regex":"topic[1-9]"}`, body)
+}
+
+func TestBackupDaemonClient_JobStatus(t *testing.T) {
+	stub := newBackupDaemonStub(t)
+	stub.setJobStatus("20250513T095536", "Processing")
+
+	status, err := stub.client().JobStatus("20250513T095536")
+	require.NoError(t, err)
+	assert.Equal(t, "Processing", status.Status)
+
+	_, err = stub.client().JobStatus("unknown")
+	assert.ErrorContains(t, err, "response code is 404")
+}
+
+func TestBackupDaemonClient_ListBackups(t *testing.T) {
+	stub := newBackupDaemonStub(t)
+	stub.backups["20250513T095536"] = BackupInfoResponse{Id: "20250513T095536", DBList: "full backup",
+		CustomVars: map[string]string{"region": "dc1"}}
+
+	backups, err := stub.client().ListBackups()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"20250513T095536"}, backups)
+	info, err := stub.client().BackupInfo("20250513T095536")
+	require.NoError(t, err)
+	assert.Equal(t, "full backup", info.DBList)
+	assert.Equal(t, "dc1", info.CustomVars["region"])
+}
+
+func TestBackupDaemonClient_Evict(t *testing.T) {
+	stub := newBackupDaemonStub(t)
+	stub.backups["20250513T095536"] = BackupInfoResponse{Id: "20250513T095536"}
+
+	assert.NoError(t, stub.client().Evict("20250513T095536"))
+	assert.Empty(t, stub.backups)
+	// already removed backup is not an error
+	assert.NoError(t, stub.client().Evict("20250513T095536"))
+	assert.Error(t, NewBackupDaemonClient(stub.URL, "admin", "wrong").Evict("20250513T095536"))
+}
+
+func TestBackupDaemonClient_Unauthorized(t *testing.T) {
+	stub := newBackupDaemonStub(t)
+
+	_, err := NewBackupDaemonClient(stub.URL, "admin", "wrong").Backup(BackupRequest{})
+	assert.ErrorContains(t, err, "response code is 401")
+}
@@ -15,19 +15,13 @@
 package kafkaservice
 
 import (
-	"bytes"
 	"context"
-	"crypto/tls"
-	"crypto/x509"
-	"encoding/json"
 	"fmt"
 	kafkaservice "github.com/Netcracker/qubership-kafka/operator/api/v7"
+	"github.com/Netcracker/qubership-kafka/operator/controllers"
 	"github.com/go-logr/logr"
-	"io"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/utils/pointer"
-	"net/http"
-	"os"
 	"sort"
 	"strings"
 	"time"
@@ -42,40 +36,20 @@ const (
 )
 
 type ReconcileBackupDaemon struct {
-	cr              *kafkaservice.KafkaService
-	reconciler      *KafkaServiceReconciler
-	logger          logr.Logger
-	username        string
-	password        string
-	backupDaemonUrl string
+	cr         *kafkaservice.KafkaService
+	reconciler *KafkaServiceReconciler
+	logger     logr.Logger
+	client     BackupDaemonClient
+	drManaged  bool
 }
 
-type StatusResponse struct {
-	Status string `json:"status"`
-	Vault  string `json:"vault"`
-	Type   string `json:"type"`
-	Err    string `json:"err"`
-	TaskId string `json:"task_id"`
-}
-
-type BackupInfoResponse struct {
-	IsGranular bool              `json:"is_granular"`
-	DBList     string            `json:"db_list"`
-	Id         string            `json:"id"`
-	Failed     bool              `json:"failed"`
-	Locked     bool              `json:"locked"`
-	TS         int               `json:"ts"`
-	SpentTime  string            `json:"spent_time"`
-	Valid      bool              `json:"valid"`
-	Evictable  bool              `json:"evictable"`
-	CustomVars map[string]string `json:"custom_vars,omitempty"`
-}
-
-func NewReconcileBackupDaemon(r *KafkaServiceReconciler, cr *kafkaservice.KafkaService, logger logr.Logger) ReconcileBackupDaemon {
+func NewReconcileBackupDaemon(r *KafkaServiceReconciler, cr *kafkaservice.KafkaService, logger logr.Logger,
+	drManaged bool) ReconcileBackupDaemon {
 	return ReconcileBackupDaemon{
 		cr:         cr,
 		logger:     logger,
 		reconciler: r,
+		drManaged:  drManaged,
 	}
 }
 
@@ -88,7 +62,7 @@ func (r ReconcileBackupDaemon) Reconcile() error {
 	if _, err := r.reconciler.FindDeployment(backupDaemonName, r.cr.Namespace, r.logger); err != nil {
 		return err
 	}
-	if err := updateDeploymentSecretRestartAnnotations(
+	if err := controllers.UpdateDeploymentSecretRestartAnnotations(
 		r.reconciler.Client, r.cr.Namespace, backupDaemonName, r.logger, kafkaServicesSecret); err != nil {
 		return err
 	}
@@ -96,68 +70,74 @@ func (r ReconcileBackupDaemon) Reconcile() error {
 		r.logger.Info("TopicsBackup is not enabled, skipping disaster recovery operations.")
 		return nil
 	}
+	if r.drManaged {
+		r.logger.Info("Disaster recovery is managed by KafkaDisasterRecovery resource, skipping switchover operations.")
+		return nil
+	}
 	if r.cr.Status.DisasterRecoveryStatus.Mode != "" && r.cr.Status.DisasterRecoveryStatus.Mode != r.cr.Spec.DisasterRecovery.Mode || r.cr.Status.DisasterRecoveryStatus.Status == "failed" {
-		r.username, r.password = r.getBackupDaemonCredentials()
-		_, err := os.ReadFile(certificateFilePath)
-		if err == nil {
-			r.backupDaemonUrl = fmt.Sprintf("https://%s-backup-daemon.%s:8443", r.cr.Name, r.cr.Namespace)
-		} else {
-			r.backupDaemonUrl = fmt.Sprintf("http://%s-backup-daemon.%s:8080", r.cr.Name, r.cr.Namespace)
+		if err := r.switchover(r.cr.Status.DisasterRecoveryStatus.Mode, backupRestoreTimeout); err != nil {
+			return err
 		}
-		r.logger.Info(fmt.Sprintf("Start switchover with mode: %s and no-wait: %t, current status mode is: %s",
-			r.cr.Spec.DisasterRecovery.Mode,
-			r.cr.Spec.DisasterRecovery.NoWait,
-			r.cr.Status.DisasterRecoveryStatus.Mode))
-		if strings.ToLower(r.cr.Spec.DisasterRecovery.Mode) == "active" {
-			r.logger.Info("Scaling up backup daemon")
-			err := r.scaleDeploymentWithCheck(1, waitingInterval, scaleTimeout)
-			if err != nil {
-				return err
-			}
-			r.logger.Info("Backup Daemon started")
+		return r.reconciler.StatusUpdater.UpdateStatusWithRetry(func(instance *kafkaservice.KafkaService) {
+			instance.Status.DisasterRecoveryStatus.Status = "done"
+			instance.Status.DisasterRecoveryStatus.Message = ""
+		})
+	}
+	return nil
+}
 
-			r.logger.Info("Restoring last backup")
-			lastFullBackup, jobId, err := r.restoreLastBackup(waitingInterval, backupRestoreTimeout)
-			if err != nil {
-				return err
-			}
+// switchover performs Backup Daemon steps of switching from previous mode to the mode specified in custom resource
+func (r ReconcileBackupDaemon) switchover(previousMode string, timeout time.Duration) error {
+	r.client = newKafkaServiceBackupDaemonClient(&r.reconciler.Reconciler, r.cr, r.logger)
+	r.logger.Info(fmt.Sprintf("Start switchover with mode: %s and no-wait: %t, current status mode is: %s",
+		r.cr.Spec.DisasterRecovery.Mode,
+		r.cr.Spec.DisasterRecovery.NoWait,
+		previousMode))
+	if strings.ToLower(r.cr.Spec.DisasterRecovery.Mode) == "active" {
+		r.logger.Info("Scaling up backup daemon")
+		err := r.scaleDeploymentWithCheck(1, waitingInterval, scaleTimeout)
+		if err != nil {
+			return err
+		}
+		r.logger.Info("Backup Daemon started")
 
-			if lastFullBackup != "" {
-				if err = r.checkRestoreStatus(jobId, waitingInterval, backupRestoreTimeout); err != nil {
-					return err
-				}
-			}
-		} else if strings.ToLower(r.cr.Spec.DisasterRecovery.Mode) == "standby" &&
-			strings.ToLower(r.cr.Status.DisasterRecoveryStatus.Mode) != "disable" {
-			r.logger.Info("Backup started")
-			vaultId, err := r.performBackup(waitingInterval, backupRestoreTimeout)
-			if err != nil {
-				return err
-			}
+		r.logger.Info("Restoring last backup")
+		lastFullBackup, jobId, err := r.restoreLastBackup(waitingInterval, timeout)
+		if err != nil {
+			return err
+		}
 
-			r.logger.Info(fmt.Sprintf("Backup was performed: %s, check status", vaultId))
-			if err = r.checkBackupStatus(vaultId, waitingInterval, backupRestoreTimeout); err != nil {
+		if lastFullBackup != "" {
+			if err = r.checkRestoreStatus(jobId, waitingInterval, timeout); err != nil {
 				return err
 			}
+		}
+	} else if strings.ToLower(r.cr.Spec.DisasterRecovery.Mode) == "standby" &&
+		strings.ToLower(previousMode) != "disable" {
+		r.logger.Info("Backup started")
+		vaultId, err := r.performBackup(waitingInterval, timeout)
+		if err != nil {
+			return err
+		}
 
-			r.logger.Info("Backup Daemon scale-down started")
-			if err = r.scaleDeploymentWithCheck(0, waitingInterval, backupRestoreTimeout); err != nil {
-				return err
-			}
-			r.logger.Info("Backup Daemon scale-down completed")
-		} else if strings.ToLower(r.cr.Spec.DisasterRecovery.Mode) == "disable" {
-			r.logger.Info("Backup Daemon scale-down started for disable mode")
-			if err := r.scaleDeploymentWithCheck(0, waitingInterval, backupRestoreTimeout); err != nil {
-				return err
-			}
-			r.logger.Info("Backup Daemon scale-down for disable mode completed")
+		r.logger.Info(fmt.Sprintf("Backup was performed: %s, check status", vaultId))
+		if err = r.checkBackupStatus(vaultId, waitingInterval, timeout); err != nil {
+			return err
 		}
-		r.logger.Info("Switchover finished successfully")
-		return r.reconciler.StatusUpdater.UpdateStatusWithRetry(func(instance *kafkaservice.KafkaService) {
-			instance.Status.DisasterRecoveryStatus.Status = "done"
-			instance.Status.DisasterRecoveryStatus.Message = ""
-		})
+
+		r.logger.Info("Backup Daemon scale-down started")
+		if err = r.scaleDeploymentWithCheck(0, waitingInterval, timeout); err != nil {
+			return err
+		}
+		r.logger.Info("Backup Daemon scale-down completed")
+	} else if strings.ToLower(r.cr.Spec.DisasterRecovery.Mode) == "disable" {
+		r.logger.Info("Backup Daemon scale-down started for disable mode")
+		if err := r.scaleDeploymentWithCheck(0, waitingInterval, timeout); err != nil {
+			return err
+		}
+		r.logger.Info("Backup Daemon scale-down for disable mode completed")
 	}
+	r.logger.Info("Switchover finished successfully")
 	return nil
 }
 
@@ -165,13 +145,7 @@ func (r ReconcileBackupDaemon) restoreLastBackup(interval, timeout time.Duration
 	var lastFullBackup string
 	var jobId string
 	err := wait.PollImmediate(interval, timeout, func() (done bool, err error) {
-		response, err := r.processRequest("GET", fmt.Sprintf("%s/listbackups", r.backupDaemonUrl), nil)
-		if err != nil {
-			r.logger.Error(err, "Can't get a list of backups, attempt failed")
-			return false, nil
-		}
-
-		backupList, err := r.getBackupList(response)
+		backupList, err := r.client.ListBackups()
 		if err != nil {
 			r.logger.Error(err, "Can't get a list of backups, attempt failed")
 			return false, nil
@@ -192,20 +166,12 @@ func (r ReconcileBackupDaemon) restoreLastBackup(interval, timeout time.Duration
 			}
 		}
 
-		data := map[string]string{}
-		data["vault"] = lastFullBackup
-		byteData, err := json.Marshal(data)
-		if err != nil {
-			return false, nil
-		}
 		r.logger.Info(fmt.Sprintf("Trying to restore backup %s", lastFullBackup))
-		response, err = r.processRequest("POST", fmt.Sprintf("%s/restore", r.backupDaemonUrl), bytes.NewBuffer(byteData))
+		jobId, err = r.client.Restore(RestoreRequest{Vault: lastFullBackup})
 		if err != nil {
 			r.logger.Error(err, "Restore attempt failed")
 			return false, nil
 		}
-
-		jobId = string(response)
 		return true, nil
 	})
 	if err != nil {
@@ -219,18 +185,13 @@ func (r ReconcileBackupDaemon) checkRestoreStatus(jobId string, interval, timeou
 	err := wait.PollImmediate(interval, timeout, func() (done bool, err error) {
 		r.logger.Info(fmt.Sprintf("Check restore status of job %s", jobId))
 
-		response, err := r.processRequest("GET", fmt.Sprintf("%s/jobstatus/%s", r.backupDaemonUrl, jobId), nil)
+		restoreStatus, err := r.client.JobStatus(jobId)
 		if err != nil {
 			r.logger.Error(err, "Job status check failed")
 			return false, nil
 		}
 
-		restoreStatus, err := r.getBackupStatus(response)
-		if err != nil {
-			return false, nil
-		}
-
-		if restoreStatus != "Successful" {
+		if restoreStatus.Status != backupJobStatusSuccessful {
 			r.logger.Error(err, "Restore job is not successful")
 			return false, nil
 		}
@@ -247,11 +208,10 @@ func (r ReconcileBackupDaemon) checkRestoreStatus(jobId string, interval, timeou
 func (r ReconcileBackupDaemon) performBackup(interval, timeout time.Duration) (string, error) {
 	var vaultId string
 	err := wait.PollImmediate(interval, timeout, func() (done bool, err error) {
-		response, err := r.processRequest("POST", fmt.Sprintf("%s/backup", r.backupDaemonUrl), nil)
+		vaultId, err = r.client.Backup(BackupRequest{})
 		if err != nil {
 			return false, nil
 		}
-		vaultId = string(response)
 		return true, nil
 	})
 	if err != nil {
@@ -263,17 +223,11 @@ func (r ReconcileBackupDaemon) performBackup(interval, timeout time.Duration) (s
 
 func (r ReconcileBackupDaemon) checkBackupStatus(vaultId string, interval, timeout time.Duration) error {
 	err := wait.PollImmediate(interval, timeout, func() (done bool, err error) {
-		response, err :=
-			r.processRequest("GET", fmt.Sprintf("%s/jobstatus/%s", r.backupDaemonUrl, vaultId), nil)
+		backupStatus, err := r.client.JobStatus(vaultId)
 		if err != nil {
 			return false, nil
 		}
-
-		backupStatus, err := r.getBackupStatus(response)
-		if err != nil {
-			return false, nil
-		}
-		if backupStatus != "Successful" {
+		if backupStatus.Status != backupJobStatusSuccessful {
 			return false, nil
 		}
 		return true, nil
@@ -329,40 +283,6 @@ func (r ReconcileBackupDaemon) Status() error {
 	return r.reconciler.updateConditions(NewCondition(statusTrue, typeReady, backupDaemonConditionReason, "Kafka backupDaemonConditionReason pod is ready"))
 }
 
-func (r *ReconcileBackupDaemon) processRequest(method string, url string, body io.Reader) ([]byte, error) {
-	httpClient := &http.Client{}
-	req, err := http.NewRequest(method, url, body)
-	if err != nil {
-		return nil, err
-	}
-	caCert, err := os.ReadFile(certificateFilePath)
-	if err == nil {
-		caCertPool := x509.NewCertPool()
-		caCertPool.AppendCertsFromPEM(caCert)
-		httpClient.Transport = &http.Transport{
-			TLSClientConfig: &tls.Config{
-				RootCAs: caCertPool,
-			},
-		}
-	}
-	if body != nil {
-		req.Header.Set("Content-Type", "application/json")
-	}
-	if r.username != "" && r.password != "" {
-		req.SetBasicAuth(r.username, r.password)
-	}
-	response, err := httpClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer func() { _ = response.Body.Close() }()
-	responseData, err := io.ReadAll(response.Body)
-	if err != nil {
-		return nil, err
-	}
-	return responseData, nil
-}
-
 func (r *ReconcileBackupDaemon) scaleDeployment(replicas int32) error {
 	backupDaemonDeployment, err := r.reconciler.FindDeployment(fmt.Sprintf("%s-backup-daemon", r.cr.Name), r.cr.Namespace, r.logger)
 	if err == nil {
@@ -373,16 +293,6 @@ func (r *ReconcileBackupDaemon) scaleDeployment(replicas int32) error {
 	return err
 }
 
-func (r *ReconcileBackupDaemon) getBackupDaemonCredentials() (string, string) {
-	foundSecret, err := r.reconciler.FindSecret(fmt.Sprintf("%s-backup-daemon-secret", r.cr.Name), r.cr.Namespace, r.logger)
-	if err != nil {
-		return "", ""
-	}
-	username := string(foundSecret.Data["username"])
-	password := string(foundSecret.Data["password"])
-	return username, password
-}
-
 func (r ReconcileBackupDaemon) GetBackupDaemonLabels() map[string]string {
 	return map[string]string{
 		"component": "kafka-backup-daemon",
@@ -390,34 +300,11 @@ func (r ReconcileBackupDaemon) GetBackupDaemonLabels() map[string]string {
 	}
 }
 
-func (r *ReconcileBackupDaemon) getBackupStatus(response []byte) (status string, err error) {
-	var backupStatusResponse StatusResponse
-	err = json.Unmarshal(response, &backupStatusResponse)
-	if err != nil {
-		return "", err
-	}
-	return backupStatusResponse.Status, nil
-}
-
-func (r *ReconcileBackupDaemon) getBackupList(response []byte) (backupList []string, err error) {
-	var backupListResponse []string
-	err = json.Unmarshal(response, &backupListResponse)
-	if err != nil {
-		return []string{}, err
-	}
-	return backupListResponse, nil
-}
-
 func (r *ReconcileBackupDaemon) getLastFullBackup(backups []string) (vaultId string, err error) {
 	// Assuming that vault id of backup is close to timestamp and can be used to find the last full backup.
 	sort.Sort(sort.Reverse(sort.StringSlice(backups)))
 	for _, backup := range backups {
-		response, err := r.processRequest("GET", fmt.Sprintf("%s/listbackups/%s", r.backupDaemonUrl, backup), nil)
-		if err != nil {
-			return "", err
-		}
-		var backupInfoResponse BackupInfoResponse
-		err = json.Unmarshal(response, &backupInfoResponse)
+		backupInfoResponse, err := r.client.BackupInfo(backup)
 		if err != nil {
 			return "", err
 		}
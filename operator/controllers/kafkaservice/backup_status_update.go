@@ -0,0 +1,102 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaservice
+
+import (
+	"context"
+
+	kafkav1 "github.com/Netcracker/qubership-kafka/operator/api/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type BackupStatusUpdater struct {
+	client    client.Client
+	name      string
+	namespace string
+}
+
+func NewBackupStatusUpdater(client client.Client, cr *kafkav1.KafkaBackup) BackupStatusUpdater {
+	return BackupStatusUpdater{
+		client:    client,
+		name:      cr.Name,
+		namespace: cr.Namespace,
+	}
+}
+
+func (su BackupStatusUpdater) UpdateStatusWithRetry(statusUpdateFunc func(*kafkav1.KafkaBackup)) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		instance := &kafkav1.KafkaBackup{}
+		err := su.client.Get(context.TODO(), types.NamespacedName{Name: su.name, Namespace: su.namespace}, instance)
+		if err != nil {
+			return err
+		}
+		statusUpdateFunc(instance)
+		return su.client.Status().Update(context.TODO(), instance)
+	})
+}
+
+type RestoreStatusUpdater struct {
+	client    client.Client
+	name      string
+	namespace string
+}
+
+func NewRestoreStatusUpdater(client client.Client, cr *kafkav1.KafkaRestore) RestoreStatusUpdater {
+	return RestoreStatusUpdater{
+		client:    client,
+		name:      cr.Name,
+		namespace: cr.Namespace,
+	}
+}
+
+func (su RestoreStatusUpdater) UpdateStatusWithRetry(statusUpdateFunc func(*kafkav1.KafkaRestore)) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		instance := &kafkav1.KafkaRestore{}
+		err := su.client.Get(context.TODO(), types.NamespacedName{Name: su.name, Namespace: su.namespace}, instance)
+		if err != nil {
+			return err
+		}
+		statusUpdateFunc(instance)
+		return su.client.Status().Update(context.TODO(), instance)
+	})
+}
+
+type BackupScheduleStatusUpdater struct {
+	client    client.Client
+	name      string
+	namespace string
+}
+
+func NewBackupScheduleStatusUpdater(client client.Client, cr *kafkav1.KafkaBackupSchedule) BackupScheduleStatusUpdater {
+	return BackupScheduleStatusUpdater{
+		client:    client,
+		name:      cr.Name,
+		namespace: cr.Namespace,
+	}
+}
+
+func (su BackupScheduleStatusUpdater) UpdateStatusWithRetry(statusUpdateFunc func(*kafkav1.KafkaBackupSchedule)) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		instance := &kafkav1.KafkaBackupSchedule{}
+		err := su.client.Get(context.TODO(), types.NamespacedName{Name: su.name, Namespace: su.namespace}, instance)
+		if err != nil {
+			return err
+		}
+		statusUpdateFunc(instance)
+		return su.client.Status().Update(context.TODO(), instance)
+	})
+}
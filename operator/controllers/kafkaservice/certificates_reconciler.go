@@ -0,0 +1,110 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaservice
+
+import (
+	"fmt"
+	"slices"
+
+	kafkaservice "github.com/Netcracker/qubership-kafka/operator/api/v7"
+	"github.com/Netcracker/qubership-kafka/operator/controllers"
+	"github.com/Netcracker/qubership-kafka/operator/controllers/provider"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// ReconcileCertificates creates cert-manager Certificates for clients of Kafka, so that AKHQ, Kafka Monitoring
+// and Kafka Mirror Maker use their own certificates. Renewed certificates restart the components
+// as their secrets are annotated for automatic restart. Certificates are removed when cert-manager is disabled.
+type ReconcileCertificates struct {
+	cr         *kafkaservice.KafkaService
+	reconciler *KafkaServiceReconciler
+	logger     logr.Logger
+}
+
+func NewReconcileCertificates(r *KafkaServiceReconciler, cr *kafkaservice.KafkaService, logger logr.Logger) ReconcileCertificates {
+	return ReconcileCertificates{cr: cr, reconciler: r, logger: logger}
+}
+
+func (r ReconcileCertificates) Reconcile() error {
+	components := r.getCertificateComponents()
+	for _, component := range components {
+		certificate, err := r.newClientCertificate(component)
+		if err != nil {
+			return err
+		}
+		if err = r.reconciler.SetControllerReference(r.cr, certificate, r.reconciler.Scheme); err != nil {
+			return err
+		}
+		if err = r.reconciler.CreateOrUpdateCertificate(certificate, r.logger); err != nil {
+			return err
+		}
+	}
+	// certificates of components which do not use cert-manager anymore are removed
+	for _, component := range provider.CertificateComponents {
+		if slices.Contains(components, component) {
+			continue
+		}
+		if err := r.reconciler.DeleteCertificate(provider.GetClientCertificateName(r.cr, component), r.cr.Namespace,
+			r.logger); err != nil {
+			return err
+		}
+	}
+	for _, component := range components {
+		if err := r.reconciler.CheckCertificateIssued(provider.GetClientCertificateName(r.cr, component), r.cr.Namespace,
+			r.logger); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r ReconcileCertificates) Status() error {
+	return nil
+}
+
+// getCertificateComponents returns components which need client certificates to connect to Kafka
+func (r ReconcileCertificates) getCertificateComponents() []string {
+	var components []string
+	if !provider.IsCertManagerEnabled(r.cr) {
+		return components
+	}
+	if r.cr.Spec.Akhq != nil {
+		components = append(components, provider.AkhqCertificateComponent)
+	}
+	if r.cr.Spec.Monitoring != nil {
+		components = append(components, provider.MonitoringCertificateComponent)
+	}
+	if provider.IsMirrorMakerCertificateRequired(r.cr) {
+		components = append(components, provider.MirrorMakerCertificateComponent)
+	}
+	return components
+}
+
+func (r ReconcileCertificates) newClientCertificate(component string) (*unstructured.Unstructured, error) {
+	certManager := r.cr.Spec.Global.CertManager
+	return controllers.NewCertificate(controllers.CertificateParameters{
+		Name:            provider.GetClientCertificateName(r.cr, component),
+		Namespace:       r.cr.Namespace,
+		SecretName:      provider.GetClientSslSecretName(r.cr, component),
+		CommonName:      fmt.Sprintf("%s-%s", r.cr.Name, component),
+		Usages:          []string{"client auth"},
+		IssuerName:      certManager.IssuerName,
+		IssuerKind:      certManager.IssuerKind,
+		DurationDays:    certManager.DurationDays,
+		RenewBeforeDays: certManager.RenewBeforeDays,
+		Labels:          r.cr.Spec.Global.DefaultLabels,
+	})
+}
@@ -0,0 +1,160 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaservice
+
+import (
+	"testing"
+
+	kafkaservice "github.com/Netcracker/qubership-kafka/operator/api/v7"
+	"github.com/Netcracker/qubership-kafka/operator/controllers"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newCertificatesKafkaService() *kafkaservice.KafkaService {
+	return &kafkaservice.KafkaService{
+		TypeMeta:   metav1.TypeMeta{APIVersion: kafkaservice.GroupVersion.String(), Kind: "KafkaService"},
+		ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka-service", UID: "kafka-uid"},
+		Spec: kafkaservice.KafkaServiceSpec{
+			Global: &kafkaservice.Global{
+				KafkaSsl: kafkaservice.KafkaSsl{Enabled: true, SecretName: "kafka-tls-secret"},
+				CertManager: &kafkaservice.CertManager{
+					Enabled: true, IssuerName: "kafka-issuer", IssuerKind: "ClusterIssuer", DurationDays: 90,
+				},
+				DefaultLabels: map[string]string{"app.kubernetes.io/part-of": "kafka"},
+			},
+			Akhq:       &kafkaservice.Akhq{},
+			Monitoring: &kafkaservice.Monitoring{},
+			MirrorMaker: &kafkaservice.MirrorMaker{
+				Clusters: []kafkaservice.Cluster{
+					{Name: "dc1", EnableSsl: true},
+					{Name: "dc2", EnableSsl: true, SslSecretName: "dc2-tls-secret"},
+				},
+			},
+		},
+	}
+}
+
+// newIssuedCertificate returns certificate which cert-manager has already issued with empty spec,
+// so that reconciliation does not wait for cert-manager
+func newIssuedCertificate(name string, secretName string) []client.Object {
+	certificate := &unstructured.Unstructured{}
+	certificate.SetGroupVersionKind(controllers.CertificateGVK)
+	certificate.SetName(name)
+	certificate.SetNamespace("kafka-service")
+	certificate.Object["spec"] = map[string]interface{}{"secretName": secretName}
+	certificate.Object["status"] = map[string]interface{}{
+		"conditions": []interface{}{map[string]interface{}{"type": "Ready", "status": "True"}},
+	}
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: "kafka-service"}}
+	return []client.Object{certificate, secret}
+}
+
+func newCertificatesReconciler(objects ...client.Object) *KafkaServiceReconciler {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = kafkaservice.AddToScheme(scheme)
+	scheme.AddKnownTypeWithName(controllers.CertificateGVK, &unstructured.Unstructured{})
+	scheme.AddKnownTypeWithName(controllers.CertificateGVK.GroupVersion().WithKind("CertificateList"),
+		&unstructured.UnstructuredList{})
+	return &KafkaServiceReconciler{Reconciler: controllers.Reconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build(),
+		Scheme: scheme,
+	}}
+}
+
+func TestReconcileCertificates_getCertificateComponents(t *testing.T) {
+	cr := newCertificatesKafkaService()
+	r := NewReconcileCertificates(newCertificatesReconciler(), cr, logr.Discard())
+	assert.Equal(t, []string{"akhq", "monitoring", "mirror-maker"}, r.getCertificateComponents())
+
+	cr.Spec.Akhq = nil
+	cr.Spec.MirrorMaker.Clusters[0].SslSecretName = "dc1-tls-secret"
+	assert.Equal(t, []string{"monitoring"}, r.getCertificateComponents())
+}
+
+func TestReconcileCertificates_Reconcile(t *testing.T) {
+	var objects []client.Object
+	objects = append(objects, newIssuedCertificate("kafka-akhq-tls-certificate", "kafka-akhq-tls-secret")...)
+	objects = append(objects, newIssuedCertificate("kafka-monitoring-tls-certificate", "kafka-monitoring-tls-secret")...)
+	objects = append(objects, newIssuedCertificate("kafka-mirror-maker-tls-certificate", "kafka-mirror-maker-tls-secret")...)
+	reconciler := newCertificatesReconciler(objects...)
+	cr := newCertificatesKafkaService()
+
+	require.NoError(t, NewReconcileCertificates(reconciler, cr, logr.Discard()).Reconcile())
+
+	certificate, err := reconciler.FindCertificate("kafka-akhq-tls-certificate", "kafka-service")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"app.kubernetes.io/part-of": "kafka"}, certificate.GetLabels())
+	owner := metav1.GetControllerOf(certificate)
+	require.NotNil(t, owner)
+	assert.Equal(t, "kafka", owner.Name)
+	assert.Equal(t, "KafkaService", owner.Kind)
+	spec := certificate.Object["spec"].(map[string]interface{})
+	assert.Equal(t, "kafka-akhq-tls-secret", spec["secretName"])
+	assert.Equal(t, "kafka-akhq", spec["commonName"])
+	assert.Equal(t, "2160h0m0s", spec["duration"])
+	assert.Equal(t, []interface{}{"client auth"}, spec["usages"])
+	issuerName, _, _ := unstructured.NestedString(certificate.Object, "spec", "issuerRef", "name")
+	assert.Equal(t, "kafka-issuer", issuerName)
+	issuerKind, _, _ := unstructured.NestedString(certificate.Object, "spec", "issuerRef", "kind")
+	assert.Equal(t, "ClusterIssuer", issuerKind)
+	annotations, _, _ := unstructured.NestedStringMap(certificate.Object, "spec", "secretTemplate", "annotations")
+	assert.Equal(t, "true", annotations[controllers.AutoRestartAnnotation])
+
+	_, err = reconciler.FindCertificate("kafka-mirror-maker-tls-certificate", "kafka-service")
+	assert.NoError(t, err)
+}
+
+func TestReconcileCertificates_ReconcileWithoutIssuer(t *testing.T) {
+	cr := newCertificatesKafkaService()
+	cr.Spec.Global.CertManager.IssuerName = ""
+	assert.Error(t, NewReconcileCertificates(newCertificatesReconciler(), cr, logr.Discard()).Reconcile())
+}
+
+func TestReconcileCertificates_ReconcileNotIssued(t *testing.T) {
+	cr := newCertificatesKafkaService()
+	err := NewReconcileCertificates(newCertificatesReconciler(), cr, logr.Discard()).Reconcile()
+	assert.ErrorIs(t, err, controllers.ErrCertificateNotIssued)
+}
+
+func TestReconcileCertificates_ReconcileDeletesCertificates(t *testing.T) {
+	var objects []client.Object
+	objects = append(objects, newIssuedCertificate("kafka-akhq-tls-certificate", "kafka-akhq-tls-secret")...)
+	objects = append(objects, newIssuedCertificate("kafka-monitoring-tls-certificate", "kafka-monitoring-tls-secret")...)
+	reconciler := newCertificatesReconciler(objects...)
+	cr := newCertificatesKafkaService()
+	cr.Spec.Akhq = nil
+	cr.Spec.MirrorMaker = nil
+
+	require.NoError(t, NewReconcileCertificates(reconciler, cr, logr.Discard()).Reconcile())
+	_, err := reconciler.FindCertificate("kafka-akhq-tls-certificate", "kafka-service")
+	assert.True(t, errors.IsNotFound(err))
+	_, err = reconciler.FindCertificate("kafka-monitoring-tls-certificate", "kafka-service")
+	assert.NoError(t, err)
+
+	cr.Spec.Global.CertManager.Enabled = false
+	require.NoError(t, NewReconcileCertificates(reconciler, cr, logr.Discard()).Reconcile())
+	_, err = reconciler.FindCertificate("kafka-monitoring-tls-certificate", "kafka-service")
+	assert.True(t, errors.IsNotFound(err))
+}
@@ -0,0 +1,55 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaservice
+
+import (
+	"context"
+	kafkav1 "github.com/Netcracker/qubership-kafka/operator/api/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type DisasterRecoveryStatusUpdater struct {
+	client    client.Client
+	name      string
+	namespace string
+}
+
+func NewDisasterRecoveryStatusUpdater(client client.Client, cr *kafkav1.KafkaDisasterRecovery) DisasterRecoveryStatusUpdater {
+	return DisasterRecoveryStatusUpdater{
+		client:    client,
+		name:      cr.Name,
+		namespace: cr.Namespace,
+	}
+}
+
+func (su DisasterRecoveryStatusUpdater) UpdateStatusWithRetry(statusUpdateFunc func(*kafkav1.KafkaDisasterRecovery)) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		instance, err := su.reloadCR()
+		if err != nil {
+			return err
+		}
+		statusUpdateFunc(instance)
+		return su.client.Status().Update(context.TODO(), instance)
+	})
+}
+
+func (su DisasterRecoveryStatusUpdater) reloadCR() (*kafkav1.KafkaDisasterRecovery, error) {
+	instance := &kafkav1.KafkaDisasterRecovery{}
+	err := su.client.Get(context.TODO(),
+		types.NamespacedName{Name: su.name, Namespace: su.namespace}, instance)
+	return instance, err
+}
@@ -15,13 +15,34 @@
 package kafkaservice
 
 import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
 	kafkaservice "github.com/Netcracker/qubership-kafka/operator/api/v7"
+	"github.com/Netcracker/qubership-kafka/operator/util"
 	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
-	"time"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-const integrationTestsConditionReason = "KafkaIntegrationTestsStatus"
+const (
+	integrationTestsConditionReason = "KafkaIntegrationTestsStatus"
+	integrationTestsResultRunning   = "Running"
+	integrationTestsResultPassed    = "Passed"
+	integrationTestsResultFailed    = "Failed"
+	// integrationTestsJobCommand runs tests once and publishes results to config map instead of serving them
+	integrationTestsJobCommand = "/opt/robot/run_tests_job.py"
+	resultsRunTriggerKey       = "run-trigger"
+	resultsOutputKey           = "output.xml.gz"
+)
 
 type ReconcileIntegrationTests struct {
 	reconciler *KafkaServiceReconciler
@@ -38,6 +59,17 @@ func NewReconcileIntegrationTests(r *KafkaServiceReconciler, cr *kafkaservice.Ka
 }
 
 func (r ReconcileIntegrationTests) Reconcile() error {
+	if !r.cr.Spec.IntegrationTests.RunAsJob {
+		return nil
+	}
+	status := r.cr.Status.IntegrationTestsStatus
+	if status.JobName == "" || status.RunTrigger != r.cr.Spec.IntegrationTests.RandomRunTrigger {
+		return r.startRun()
+	}
+	if status.Result == integrationTestsResultRunning {
+		_, err := r.collectResults(status.JobName)
+		return err
+	}
 	return nil
 }
 
@@ -53,6 +85,9 @@ func (r ReconcileIntegrationTests) Status() error {
 		return err
 	}
 	r.logger.Info("Start checking Kafka Integration Tests")
+	if r.cr.Spec.IntegrationTests.RunAsJob {
+		return r.runStatus()
+	}
 	err := wait.PollImmediate(waitingInterval, time.Duration(r.cr.Spec.IntegrationTests.Timeout)*time.Second, func() (done bool, err error) {
 		labels := r.getKafkaIntegrationTestsLabels()
 		return r.reconciler.AreDeploymentsReady(labels, r.cr.Namespace, r.logger), nil
@@ -63,6 +98,228 @@ func (r ReconcileIntegrationTests) Status() error {
 	return r.reconciler.updateConditions(NewCondition(statusTrue, typeReady, integrationTestsConditionReason, "Kafka Integration Tests performed successfully"))
 }
 
+// runStatus waits for the Job of current run and sets condition in accordance with published results
+func (r ReconcileIntegrationTests) runStatus() error {
+	jobName := r.getJobName()
+	var status *kafkaservice.IntegrationTestsStatus
+	err := wait.PollImmediate(waitingInterval, time.Duration(r.cr.Spec.IntegrationTests.Timeout)*time.Second, func() (done bool, err error) {
+		status, err = r.collectResults(jobName)
+		if err != nil {
+			r.logger.Error(err, "Cannot collect Kafka Integration Tests results")
+			return false, nil
+		}
+		return status != nil, nil
+	})
+	if err != nil {
+		return r.reconciler.updateConditions(NewCondition(statusFalse, typeFailed, integrationTestsConditionReason,
+			fmt.Sprintf("Kafka Integration Tests [%s] job is not completed in time", jobName)))
+	}
+	if status.Result != integrationTestsResultPassed {
+		return r.reconciler.updateConditions(NewCondition(statusFalse, typeFailed, integrationTestsConditionReason,
+			fmt.Sprintf("Kafka Integration Tests failed: %s", status.Message)))
+	}
+	return r.reconciler.updateConditions(NewCondition(statusTrue, typeReady, integrationTestsConditionReason, "Kafka Integration Tests performed successfully"))
+}
+
+// startRun removes Jobs of previous runs and their results and creates Job for current run trigger
+func (r ReconcileIntegrationTests) startRun() error {
+	if err := r.deletePreviousJobs(); err != nil {
+		return err
+	}
+	if err := r.reconciler.DeleteConfigMapByName(r.getResultsConfigMapName(), r.cr.Namespace, r.logger); err != nil {
+		return err
+	}
+	deployment, err := r.reconciler.FindDeployment(r.cr.Spec.IntegrationTests.ServiceName, r.cr.Namespace, r.logger)
+	if err != nil {
+		return fmt.Errorf("cannot find [%s] integration tests deployment to build job from: %w",
+			r.cr.Spec.IntegrationTests.ServiceName, err)
+	}
+	job := r.newIntegrationTestsJob(deployment.Spec.Template)
+	if err = r.reconciler.SetControllerReference(r.cr, job, r.reconciler.Scheme); err != nil {
+		return err
+	}
+	r.logger.Info(fmt.Sprintf("Starting Kafka Integration Tests job [%s]", job.Name))
+	if err = r.reconciler.Client.Create(context.TODO(), job); err != nil && !errors.IsAlreadyExists(err) {
+		return err
+	}
+	runTrigger := r.cr.Spec.IntegrationTests.RandomRunTrigger
+	return r.reconciler.StatusUpdater.UpdateStatusWithRetry(func(instance *kafkaservice.KafkaService) {
+		instance.Status.IntegrationTestsStatus = kafkaservice.IntegrationTestsStatus{
+			RunTrigger: runTrigger,
+			JobName:    job.Name,
+			Result:     integrationTestsResultRunning,
+			StartTime:  metav1.Now().String(),
+		}
+	})
+}
+
+// collectResults checks the Job and publishes the results to status when the Job is finished.
+// It returns nil status while the Job is running and already published status when the results are collected.
+func (r ReconcileIntegrationTests) collectResults(jobName string) (*kafkaservice.IntegrationTestsStatus, error) {
+	currentStatus, err := r.reconciler.StatusUpdater.GetStatus()
+	if err != nil {
+		return nil, err
+	}
+	if isRunCompleted(currentStatus.IntegrationTestsStatus, jobName) {
+		return &currentStatus.IntegrationTestsStatus, nil
+	}
+	job := &batchv1.Job{}
+	err = r.reconciler.Client.Get(context.TODO(), types.NamespacedName{Name: jobName, Namespace: r.cr.Namespace}, job)
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, err
+	}
+	var status kafkaservice.IntegrationTestsStatus
+	if errors.IsNotFound(err) {
+		status = failedRunStatus(fmt.Sprintf("integration tests job [%s] is not found", jobName))
+	} else {
+		completed, failed := getJobResult(job)
+		if !completed && !failed {
+			return nil, nil
+		}
+		if failed {
+			status = failedRunStatus(fmt.Sprintf("integration tests job [%s] failed before publishing results, "+
+				"see more details in its logs", jobName))
+		} else {
+			status = r.readResults()
+		}
+	}
+	status.RunTrigger = r.cr.Spec.IntegrationTests.RandomRunTrigger
+	status.JobName = jobName
+	status.CompletionTime = metav1.Now().String()
+	r.logger.Info(fmt.Sprintf("Kafka Integration Tests job [%s] is finished with [%s] result", jobName, status.Result))
+	err = r.reconciler.StatusUpdater.UpdateStatusWithRetry(func(instance *kafkaservice.KafkaService) {
+		if isRunCompleted(instance.Status.IntegrationTestsStatus, jobName) {
+			// results are already published, completion time of the run is kept
+			status = instance.Status.IntegrationTestsStatus
+			return
+		}
+		status.StartTime = instance.Status.IntegrationTestsStatus.StartTime
+		instance.Status.IntegrationTestsStatus = status
+	})
+	return &status, err
+}
+
+// isRunCompleted returns whether the status contains published results of the run with given Job
+func isRunCompleted(status kafkaservice.IntegrationTestsStatus, jobName string) bool {
+	return status.JobName == jobName && status.Result != integrationTestsResultRunning && status.CompletionTime != ""
+}
+
+// readResults reads Robot Framework results which Job publishes to config map and summarizes them
+func (r ReconcileIntegrationTests) readResults() kafkaservice.IntegrationTestsStatus {
+	configMap, err := r.reconciler.FindConfigMap(r.getResultsConfigMapName(), r.cr.Namespace, r.logger)
+	if err != nil {
+		return failedRunStatus(fmt.Sprintf("cannot read integration tests results: %v", err))
+	}
+	if configMap.Data[resultsRunTriggerKey] != r.cr.Spec.IntegrationTests.RandomRunTrigger {
+		return failedRunStatus(fmt.Sprintf("[%s] config map contains results of another run",
+			r.getResultsConfigMapName()))
+	}
+	suites, err := parseRobotResults(configMap.BinaryData[resultsOutputKey])
+	if err != nil {
+		return failedRunStatus(err.Error())
+	}
+	return summarizeResults(suites)
+}
+
+func summarizeResults(suites []kafkaservice.IntegrationTestsSuiteStatus) kafkaservice.IntegrationTestsStatus {
+	status := kafkaservice.IntegrationTestsStatus{Suites: suites}
+	var failedSuites []string
+	for _, suite := range suites {
+		status.Passed += suite.Passed
+		status.Failed += suite.Failed
+		status.Skipped += suite.Skipped
+		if suite.Failed > 0 {
+			failedSuites = append(failedSuites, suite.Name)
+		}
+	}
+	status.Result = integrationTestsResultPassed
+	status.Message = fmt.Sprintf("%d tests passed, %d failed, %d skipped", status.Passed, status.Failed, status.Skipped)
+	if status.Failed > 0 {
+		status.Result = integrationTestsResultFailed
+		status.Message = fmt.Sprintf("%s, failed suites: %s", status.Message, strings.Join(failedSuites, ", "))
+	}
+	return status
+}
+
+func failedRunStatus(message string) kafkaservice.IntegrationTestsStatus {
+	return kafkaservice.IntegrationTestsStatus{Result: integrationTestsResultFailed, Message: message}
+}
+
+// getJobResult returns whether the Job is completed successfully or failed
+func getJobResult(job *batchv1.Job) (bool, bool) {
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobComplete:
+			return true, false
+		case batchv1.JobFailed:
+			return false, true
+		}
+	}
+	return false, false
+}
+
+func (r ReconcileIntegrationTests) deletePreviousJobs() error {
+	return r.reconciler.Client.DeleteAllOf(context.TODO(), &batchv1.Job{},
+		client.InNamespace(r.cr.Namespace),
+		client.MatchingLabels(r.getJobLabels()),
+		client.PropagationPolicy(metav1.DeletePropagationBackground))
+}
+
+// newIntegrationTestsJob builds Job from pod template of integration tests Deployment,
+// so the Job runs the same image with the same environment, secrets and volumes
+func (r ReconcileIntegrationTests) newIntegrationTestsJob(template corev1.PodTemplateSpec) *batchv1.Job {
+	podTemplate := *template.DeepCopy()
+	podTemplate.Labels = util.JoinMaps(podTemplate.Labels, r.getJobLabels())
+	podTemplate.Spec.RestartPolicy = corev1.RestartPolicyNever
+	for i := range podTemplate.Spec.Containers {
+		container := &podTemplate.Spec.Containers[i]
+		container.LivenessProbe = nil
+		container.ReadinessProbe = nil
+		if i == 0 {
+			container.Command = []string{"python3", integrationTestsJobCommand}
+			container.Args = nil
+			container.Env = append(container.Env,
+				corev1.EnvVar{Name: "RESULTS_CONFIG_MAP", Value: r.getResultsConfigMapName()},
+				corev1.EnvVar{Name: "RUN_TRIGGER", Value: r.cr.Spec.IntegrationTests.RandomRunTrigger})
+		}
+	}
+	var activeDeadlineSeconds *int64
+	if r.cr.Spec.IntegrationTests.Timeout > 0 {
+		activeDeadlineSeconds = ptr.To(int64(r.cr.Spec.IntegrationTests.Timeout))
+	}
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      r.getJobName(),
+			Namespace: r.cr.Namespace,
+			Labels:    r.getJobLabels(),
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:          ptr.To(int32(0)),
+			ActiveDeadlineSeconds: activeDeadlineSeconds,
+			Template:              podTemplate,
+		},
+	}
+}
+
+// getJobName returns name of the Job for current run trigger
+func (r ReconcileIntegrationTests) getJobName() string {
+	return fmt.Sprintf("%s-%s", r.cr.Spec.IntegrationTests.ServiceName,
+		util.StringHash(r.cr.Spec.IntegrationTests.RandomRunTrigger)[:8])
+}
+
+func (r ReconcileIntegrationTests) getResultsConfigMapName() string {
+	return fmt.Sprintf("%s-results", r.cr.Spec.IntegrationTests.ServiceName)
+}
+
+func (r ReconcileIntegrationTests) getJobLabels() map[string]string {
+	return map[string]string{
+		"name": fmt.Sprintf("%s-job", r.cr.Spec.IntegrationTests.ServiceName),
+	}
+}
+
 func (r ReconcileIntegrationTests) getKafkaIntegrationTestsLabels() map[string]string {
 	return map[string]string{
 		"name": r.cr.Spec.IntegrationTests.ServiceName,
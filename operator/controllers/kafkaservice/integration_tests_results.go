@@ -0,0 +1,122 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaservice
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	kafkaservice "github.com/Netcracker/qubership-kafka/operator/api/v7"
+)
+
+const (
+	robotStatusPass = "PASS"
+	robotStatusSkip = "SKIP"
+	// maxFailureMessageLength limits failure messages, so that status of custom resource stays small
+	maxFailureMessageLength = 512
+)
+
+type robotOutput struct {
+	Suites []robotSuite `xml:"suite"`
+}
+
+type robotSuite struct {
+	Name   string       `xml:"name,attr"`
+	Suites []robotSuite `xml:"suite"`
+	Tests  []robotTest  `xml:"test"`
+}
+
+type robotTest struct {
+	Name   string      `xml:"name,attr"`
+	Status robotStatus `xml:"status"`
+}
+
+type robotStatus struct {
+	Status  string `xml:"status,attr"`
+	Message string `xml:",chardata"`
+}
+
+// parseRobotResults reads gzipped Robot Framework output.xml and returns statuses of suites which contain tests.
+// Suite names are full names joined with dots as Robot Framework shows them in reports.
+func parseRobotResults(data []byte) ([]kafkaservice.IntegrationTestsSuiteStatus, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("integration tests results are not gzipped: %w", err)
+	}
+	defer func() { _ = reader.Close() }()
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read integration tests results: %w", err)
+	}
+	return parseRobotOutput(content)
+}
+
+// parseRobotOutput returns statuses of suites with tests from Robot Framework output.xml
+func parseRobotOutput(content []byte) ([]kafkaservice.IntegrationTestsSuiteStatus, error) {
+	output := robotOutput{}
+	if err := xml.Unmarshal(content, &output); err != nil {
+		return nil, fmt.Errorf("integration tests results are not a valid Robot Framework output: %w", err)
+	}
+	if len(output.Suites) == 0 {
+		return nil, fmt.Errorf("integration tests results do not contain suites")
+	}
+	var suites []kafkaservice.IntegrationTestsSuiteStatus
+	for _, suite := range output.Suites {
+		suites = appendSuiteStatuses(suites, suite, "")
+	}
+	return suites, nil
+}
+
+func appendSuiteStatuses(suites []kafkaservice.IntegrationTestsSuiteStatus, suite robotSuite,
+	parentName string) []kafkaservice.IntegrationTestsSuiteStatus {
+	name := suite.Name
+	if parentName != "" {
+		name = parentName + "." + suite.Name
+	}
+	if len(suite.Tests) > 0 {
+		status := kafkaservice.IntegrationTestsSuiteStatus{Name: name}
+		for _, test := range suite.Tests {
+			switch test.Status.Status {
+			case robotStatusPass:
+				status.Passed++
+			case robotStatusSkip:
+				status.Skipped++
+			default:
+				status.Failed++
+				status.Failures = append(status.Failures, kafkaservice.IntegrationTestsFailure{
+					Test:    test.Name,
+					Message: truncateMessage(strings.TrimSpace(test.Status.Message)),
+				})
+			}
+		}
+		suites = append(suites, status)
+	}
+	for _, child := range suite.Suites {
+		suites = appendSuiteStatuses(suites, child, name)
+	}
+	return suites
+}
+
+func truncateMessage(message string) string {
+	runes := []rune(message)
+	if len(runes) <= maxFailureMessageLength {
+		return message
+	}
+	return string(runes[:maxFailureMessageLength]) + "..."
+}
@@ -0,0 +1,208 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaservice
+
+import (
+	"bytes"
+	"compress/gzip"
+	"strings"
+	"testing"
+
+	kafkaservice "github.com/Netcracker/qubership-kafka/operator/api/v7"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const robotOutputXml = `<?xml version="1.0" encoding="UTF-8"?>
+<robot generator="Robot 7.0" generated="2025-01-01T10:00:00.000000" rpa="false" schemaversion="5">
+<suite id="s1" name="Tests" source="/opt/robot/tests">
+<suite id="s1-s1" name="Kafka" source="/opt/robot/tests/kafka">
+<suite id="s1-s1-s1" name="Topic Tests" source="/opt/robot/tests/kafka/crud/topic_tests.robot">
+<test id="s1-s1-s1-t1" name="Test Create Topic" line="10">
+<kw name="Create Topic">
+<status status="FAIL" start="2025-01-01T10:00:01.000000" elapsed="0.100">Keyword message</status>
+</kw>
+<status status="PASS" start="2025-01-01T10:00:01.000000" elapsed="1.000"/>
+</test>
+<test id="s1-s1-s1-t2" name="Test Delete Topic" line="20">
+<status status="FAIL" start="2025-01-01T10:00:02.000000" elapsed="1.000">Topic 'kafka_crud_topic' is still present</status>
+</test>
+<test id="s1-s1-s1-t3" name="Test Change Partitions" line="30">
+<status status="SKIP" start="2025-01-01T10:00:03.000000" elapsed="0.000">Skipped with --skip option.</status>
+</test>
+<status status="FAIL" start="2025-01-01T10:00:00.000000" elapsed="3.000"/>
+</suite>
+<suite id="s1-s1-s2" name="Consumer Producer Tests" source="/opt/robot/tests/kafka/cp_tests/consumer_producer_tests.robot">
+<test id="s1-s1-s2-t1" name="Test Produce And Consume Message" line="10">
+<status status="PASS" start="2025-01-01T10:00:04.000000" elapsed="1.000"/>
+</test>
+<status status="PASS" start="2025-01-01T10:00:04.000000" elapsed="1.000"/>
+</suite>
+<status status="FAIL" start="2025-01-01T10:00:00.000000" elapsed="4.000"/>
+</suite>
+<status status="FAIL" start="2025-01-01T10:00:00.000000" elapsed="4.000"/>
+</suite>
+<statistics>
+<total>
+<stat pass="2" fail="1" skip="1">All Tests</stat>
+</total>
+</statistics>
+<errors>
+</errors>
+</robot>
+`
+
+func gzipContent(t *testing.T, content string) []byte {
+	buffer := &bytes.Buffer{}
+	writer := gzip.NewWriter(buffer)
+	_, err := writer.Write([]byte(content))
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Close())
+	return buffer.Bytes()
+}
+
+func TestIntegrationTests_parseRobotResults(t *testing.T) {
+	suites, err := parseRobotResults(gzipContent(t, robotOutputXml))
+	assert.NoError(t, err)
+	assert.Equal(t, []kafkaservice.IntegrationTestsSuiteStatus{
+		{
+			Name:    "Tests.Kafka.Topic Tests",
+			Passed:  1,
+			Failed:  1,
+			Skipped: 1,
+			Failures: []kafkaservice.IntegrationTestsFailure{
+				{Test: "Test Delete Topic", Message: "Topic 'kafka_crud_topic' is still present"},
+			},
+		},
+		{Name: "Tests.Kafka.Consumer Producer Tests", Passed: 1},
+	}, suites)
+
+	status := summarizeResults(suites)
+	assert.Equal(t, integrationTestsResultFailed, status.Result)
+	assert.Equal(t, 2, status.Passed)
+	assert.Equal(t, 1, status.Failed)
+	assert.Equal(t, 1, status.Skipped)
+	assert.Equal(t, "2 tests passed, 1 failed, 1 skipped, failed suites: Tests.Kafka.Topic Tests", status.Message)
+	assert.Equal(t, integrationTestsResultPassed, summarizeResults(suites[1:]).Result)
+}
+
+func TestIntegrationTests_parseRobotResults_invalid(t *testing.T) {
+	_, err := parseRobotResults([]byte(robotOutputXml))
+	assert.Error(t, err)
+	_, err = parseRobotResults(gzipContent(t, "<robot></robot>"))
+	assert.Error(t, err)
+	_, err = parseRobotResults(gzipContent(t, "<robot><suite"))
+	assert.Error(t, err)
+}
+
+func TestIntegrationTests_truncateMessage(t *testing.T) {
+	message := strings.Repeat("ы", maxFailureMessageLength+1)
+	assert.Equal(t, strings.Repeat("ы", maxFailureMessageLength)+"...", truncateMessage(message))
+	assert.Equal(t, "short", truncateMessage("short"))
+}
+
+func TestIntegrationTests_getJobResult(t *testing.T) {
+	completed, failed := getJobResult(&batchv1.Job{})
+	assert.False(t, completed)
+	assert.False(t, failed)
+	completed, failed = getJobResult(&batchv1.Job{Status: batchv1.JobStatus{Conditions: []batchv1.JobCondition{
+		{Type: batchv1.JobFailed, Status: corev1.ConditionTrue},
+	}}})
+	assert.False(t, completed)
+	assert.True(t, failed)
+	completed, failed = getJobResult(&batchv1.Job{Status: batchv1.JobStatus{Conditions: []batchv1.JobCondition{
+		{Type: batchv1.JobFailed, Status: corev1.ConditionFalse},
+		{Type: batchv1.JobComplete, Status: corev1.ConditionTrue},
+	}}})
+	assert.True(t, completed)
+	assert.False(t, failed)
+}
+
+func TestIntegrationTests_newIntegrationTestsJob(t *testing.T) {
+	cr := &kafkaservice.KafkaService{
+		ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka-service"},
+		Spec: kafkaservice.KafkaServiceSpec{IntegrationTests: &kafkaservice.IntegrationTests{
+			ServiceName:      "kafka-integration-tests-runner",
+			Timeout:          1200,
+			RandomRunTrigger: "Xk2PqL9aZt",
+			RunAsJob:         true,
+		}},
+	}
+	r := NewReconcileIntegrationTests(&KafkaServiceReconciler{}, cr, logr.Discard())
+	deployment := appsv1.Deployment{Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{
+			"name":                   "kafka-integration-tests-runner",
+			"app.kubernetes.io/name": "kafka-integration-tests-runner",
+		}},
+		Spec: corev1.PodSpec{
+			ServiceAccountName: "kafka-integration-tests-runner",
+			Containers: []corev1.Container{{
+				Name:           "kafka-integration-tests-runner",
+				Env:            []corev1.EnvVar{{Name: "TAGS", Value: "kafka_crud"}},
+				ReadinessProbe: &corev1.Probe{},
+				LivenessProbe:  &corev1.Probe{},
+			}},
+		},
+	}}}
+
+	job := r.newIntegrationTestsJob(deployment.Spec.Template)
+
+	assert.Equal(t, r.getJobName(), job.Name)
+	assert.True(t, strings.HasPrefix(job.Name, "kafka-integration-tests-runner-"))
+	assert.Equal(t, "kafka-service", job.Namespace)
+	assert.Equal(t, int32(0), *job.Spec.BackoffLimit)
+	assert.Equal(t, int64(1200), *job.Spec.ActiveDeadlineSeconds)
+	podSpec := job.Spec.Template.Spec
+	assert.Equal(t, corev1.RestartPolicyNever, podSpec.RestartPolicy)
+	assert.Equal(t, "kafka-integration-tests-runner-job", job.Spec.Template.Labels["name"])
+	assert.Equal(t, "kafka-integration-tests-runner", job.Spec.Template.Labels["app.kubernetes.io/name"])
+	assert.Equal(t, "kafka-integration-tests-runner", podSpec.ServiceAccountName)
+	container := podSpec.Containers[0]
+	assert.Equal(t, []string{"python3", integrationTestsJobCommand}, container.Command)
+	assert.Nil(t, container.ReadinessProbe)
+	assert.Nil(t, container.LivenessProbe)
+	assert.Contains(t, container.Env, corev1.EnvVar{Name: "TAGS", Value: "kafka_crud"})
+	assert.Contains(t, container.Env, corev1.EnvVar{Name: "RESULTS_CONFIG_MAP", Value: "kafka-integration-tests-runner-results"})
+	assert.Contains(t, container.Env, corev1.EnvVar{Name: "RUN_TRIGGER", Value: "Xk2PqL9aZt"})
+	assert.Nil(t, deployment.Spec.Template.Spec.Containers[0].Command)
+	assert.NotNil(t, deployment.Spec.Template.Spec.Containers[0].ReadinessProbe)
+}
+
+func TestIntegrationTests_collectResults_keepsCompletionTime(t *testing.T) {
+	cr := &kafkaservice.KafkaService{
+		ObjectMeta: metav1.ObjectMeta{Name: "kafka-services", Namespace: "kafka"},
+		Status: kafkaservice.KafkaServiceStatus{IntegrationTestsStatus: kafkaservice.IntegrationTestsStatus{
+			JobName:        "kafka-integration-tests-run",
+			Result:         integrationTestsResultPassed,
+			CompletionTime: "2025-01-01 10:05:00 +0000 UTC",
+		}},
+	}
+	reconciler := newDisasterRecoveryReconciler(cr)
+	reconciler.StatusUpdater = NewStatusUpdater(reconciler.Client, cr)
+	r := NewReconcileIntegrationTests(reconciler, cr, logr.Discard())
+
+	status, err := r.collectResults("kafka-integration-tests-run")
+	assert.NoError(t, err)
+	assert.Equal(t, "2025-01-01 10:05:00 +0000 UTC", status.CompletionTime)
+	assert.Equal(t, integrationTestsResultPassed, status.Result)
+
+	currentStatus, err := reconciler.StatusUpdater.GetStatus()
+	assert.NoError(t, err)
+	assert.Equal(t, "2025-01-01 10:05:00 +0000 UTC", currentStatus.IntegrationTestsStatus.CompletionTime)
+}
@@ -0,0 +1,315 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaservice
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	kafkav1 "github.com/Netcracker/qubership-kafka/operator/api/v1"
+	kafkaservice "github.com/Netcracker/qubership-kafka/operator/api/v7"
+	"github.com/Netcracker/qubership-kafka/operator/controllers"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	backupPhaseRunning      = "running"
+	backupPhaseSuccessful   = "successful"
+	backupPhaseFailed       = "failed"
+	defaultBackupJobTimeout = 30 * time.Minute
+)
+
+var backupLog = logf.Log.WithName("controller_kafka_backup")
+
+// KafkaBackupReconciler reconciles a KafkaBackup object
+type KafkaBackupReconciler struct {
+	controllers.Reconciler
+	// KafkaConnection - Settings of connection to Kafka for `metadata` mode backups
+	KafkaConnection KafkaConnection
+}
+
+//+kubebuilder:rbac:groups=netcracker.com,resources=kafkabackups,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=netcracker.com,resources=kafkabackups/status,verbs=get;update;patch
+
+func (r *KafkaBackupReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	reqLogger := backupLog.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	reqLogger.Info("Reconciling KafkaBackup")
+
+	instance := &kafkav1.KafkaBackup{}
+	if err := r.Client.Get(ctx, request.NamespacedName, instance); err != nil {
+		if errors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	if isBackupJobFinished(instance.Status.Phase) {
+		reqLogger.Info(fmt.Sprintf("Backup is already %s, skipping", instance.Status.Phase))
+		return reconcile.Result{}, nil
+	}
+	statusUpdater := NewBackupStatusUpdater(r.Client, instance)
+
+	if instance.Spec.Mode == backupModeMetadata {
+		status := r.progressMetadataBackup(ctx, instance, time.Now(), reqLogger)
+		reqLogger.Info(fmt.Sprintf("Metadata backup is %s: %s", status.Phase, status.Message))
+		if err := statusUpdater.UpdateStatusWithRetry(func(backup *kafkav1.KafkaBackup) {
+			backup.Status = status
+		}); err != nil {
+			return reconcile.Result{}, err
+		}
+		if status.Phase == backupPhaseRunning {
+			return reconcile.Result{RequeueAfter: waitingInterval}, nil
+		}
+		return reconcile.Result{}, nil
+	}
+
+	backupDaemon, message, err :=
+		findBackupDaemonClient(ctx, &r.Reconciler, instance.Spec.KafkaServiceName, instance.Namespace, reqLogger)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if message != "" {
+		reqLogger.Info(message)
+		return reconcile.Result{}, statusUpdater.UpdateStatusWithRetry(func(backup *kafkav1.KafkaBackup) {
+			backup.Status.Phase = backupPhaseFailed
+			backup.Status.Message = message
+			backup.Status.ObservedGeneration = instance.Generation
+		})
+	}
+
+	status, err := progressBackup(backupDaemon, instance, time.Now(), func(status kafkav1.KafkaBackupStatus) error {
+		return statusUpdater.UpdateStatusWithRetry(func(backup *kafkav1.KafkaBackup) {
+			backup.Status = status
+		})
+	})
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	reqLogger.Info(fmt.Sprintf("Backup %s is %s: %s", status.VaultId, status.Phase, status.Message))
+	if err = statusUpdater.UpdateStatusWithRetry(func(backup *kafkav1.KafkaBackup) {
+		backup.Status = status
+	}); err != nil {
+		return reconcile.Result{}, err
+	}
+	if status.Phase == backupPhaseRunning {
+		return reconcile.Result{RequeueAfter: waitingInterval}, nil
+	}
+	return reconcile.Result{}, nil
+}
+
+// progressBackup starts the backup in Backup Daemon or checks the status of started backup job
+// and returns the new status of KafkaBackup resource. The status with request time is saved by markRequested
+// before the backup is requested, so the backup is not requested twice if the vault is not saved after the request.
+func progressBackup(backupDaemon BackupDaemonClient, backup *kafkav1.KafkaBackup, now time.Time,
+	markRequested func(kafkav1.KafkaBackupStatus) error) (kafkav1.KafkaBackupStatus, error) {
+	status := backup.Status
+	status.ObservedGeneration = backup.Generation
+	if status.StartTime == "" {
+		status.Phase = backupPhaseRunning
+		status.StartTime = now.Format(time.RFC3339)
+	}
+	timeout := secondsOrDefault(backup.Spec.TimeoutSeconds, defaultBackupJobTimeout)
+	if status.VaultId == "" {
+		if status.RequestTime != "" {
+			status.Phase = backupPhaseFailed
+			status.Message = fmt.Sprintf("Backup was requested at %s, but its vault is unknown. "+
+				"Check backups of Backup Daemon before the backup is repeated", status.RequestTime)
+			status.CompletionTime, status.Duration = completeBackupJob(status.StartTime, now)
+			return status, nil
+		}
+		status.RequestTime = now.Format(time.RFC3339)
+		status.Message = "Backup is being requested"
+		if err := markRequested(status); err != nil {
+			return backup.Status, err
+		}
+		vaultId, err := backupDaemon.Backup(newBackupRequest(backup.Spec))
+		if err != nil {
+			status.Message = fmt.Sprintf("Backup request failed: %v", err)
+			if !isRequestTimeoutError(err) {
+				// Backup Daemon has not accepted the request, so it can be repeated
+				status.RequestTime = ""
+			}
+			if isBackupJobTimedOut(status.StartTime, timeout, now) {
+				status.Phase = backupPhaseFailed
+				status.CompletionTime, status.Duration = completeBackupJob(status.StartTime, now)
+			}
+			return status, nil
+		}
+		status.VaultId = vaultId
+		status.Message = "Backup has been started"
+		return status, nil
+	}
+	status.Phase, status.Message = checkBackupJob(backupDaemon, status.VaultId, status.StartTime, timeout, now)
+	if status.Phase != backupPhaseRunning {
+		status.CompletionTime, status.Duration = completeBackupJob(status.StartTime, now)
+	}
+	return status, nil
+}
+
+// progressMetadataBackup exports metadata of Kafka cluster to config map and returns the new status
+// of KafkaBackup resource. Failed export is repeated until the backup timeout is exceeded.
+func (r *KafkaBackupReconciler) progressMetadataBackup(ctx context.Context, backup *kafkav1.KafkaBackup,
+	now time.Time, logger logr.Logger) kafkav1.KafkaBackupStatus {
+	status := backup.Status
+	status.ObservedGeneration = backup.Generation
+	if status.StartTime == "" {
+		status.Phase = backupPhaseRunning
+		status.StartTime = now.Format(time.RFC3339)
+	}
+	configMapName, err := r.exportMetadata(ctx, backup, now, logger)
+	if err != nil {
+		status.Message = fmt.Sprintf("Metadata backup failed: %v", err)
+		timeout := secondsOrDefault(backup.Spec.TimeoutSeconds, defaultBackupJobTimeout)
+		if isBackupJobTimedOut(status.StartTime, timeout, now) {
+			status.Phase = backupPhaseFailed
+			status.CompletionTime, status.Duration = completeBackupJob(status.StartTime, now)
+		}
+		return status
+	}
+	status.Phase = backupPhaseSuccessful
+	status.MetadataConfigMap = configMapName
+	status.Message = fmt.Sprintf("Metadata has been stored to config map %s", configMapName)
+	status.CompletionTime, status.Duration = completeBackupJob(status.StartTime, now)
+	return status
+}
+
+func (r *KafkaBackupReconciler) exportMetadata(ctx context.Context, backup *kafkav1.KafkaBackup, now time.Time,
+	logger logr.Logger) (string, error) {
+	admin, err := r.KafkaConnection.adminClient(&r.Reconciler, backup.Namespace, logger)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = admin.Close() }()
+	archive, err := ExportKafkaMetadata(admin, now)
+	if err != nil {
+		return "", err
+	}
+	return saveMetadataArchive(ctx, &r.Reconciler, backup, archive, logger)
+}
+
+func newBackupRequest(spec kafkav1.KafkaBackupSpec) BackupRequest {
+	request := BackupRequest{
+		Dbs:        spec.Topics,
+		TopicRegex: spec.TopicRegex,
+	}
+	if spec.IncludeAcl {
+		request.Mode = backupModeAcl
+	}
+	if spec.AllowEviction != nil && !*spec.AllowEviction {
+		request.AllowEviction = "False"
+	}
+	return request
+}
+
+// checkBackupJob returns the phase and the message of Backup Daemon job
+func checkBackupJob(backupDaemon BackupDaemonClient, jobId string, startTime string,
+	timeout time.Duration, now time.Time) (string, string) {
+	jobStatus, err := backupDaemon.JobStatus(jobId)
+	var message string
+	switch {
+	case err != nil:
+		message = fmt.Sprintf("Job status check failed: %v", err)
+	case jobStatus.Status == backupJobStatusSuccessful:
+		return backupPhaseSuccessful, fmt.Sprintf("Job %s has completed successfully", jobId)
+	case jobStatus.Status == backupJobStatusFailed:
+		return backupPhaseFailed, fmt.Sprintf("Job %s has failed: %s", jobId, jobStatus.Err)
+	default:
+		message = fmt.Sprintf("Job %s is %s", jobId, jobStatus.Status)
+	}
+	if isBackupJobTimedOut(startTime, timeout, now) {
+		return backupPhaseFailed, fmt.Sprintf("Timeout occurred while waiting for job %s. %s", jobId, message)
+	}
+	return backupPhaseRunning, message
+}
+
+// isRequestTimeoutError checks if the request has timed out, Backup Daemon may have processed it in this case
+func isRequestTimeoutError(err error) bool {
+	var netErr net.Error
+	return stderrors.As(err, &netErr) && netErr.Timeout()
+}
+
+func isBackupJobFinished(phase string) bool {
+	return phase == backupPhaseSuccessful || phase == backupPhaseFailed
+}
+
+func isBackupJobTimedOut(startTime string, timeout time.Duration, now time.Time) bool {
+	start, err := time.Parse(time.RFC3339, startTime)
+	return err == nil && now.Sub(start) > timeout
+}
+
+// completeBackupJob returns the completion time and the duration of finished job
+func completeBackupJob(startTime string, now time.Time) (string, string) {
+	completionTime := now.Format(time.RFC3339)
+	start, err := time.Parse(time.RFC3339, startTime)
+	if err != nil {
+		return completionTime, ""
+	}
+	return completionTime, now.Sub(start).Round(time.Second).String()
+}
+
+// findBackupDaemonClient returns client for Backup Daemon of specified KafkaService
+// or the message why the KafkaService cannot be used for backup and restore
+func findBackupDaemonClient(ctx context.Context, r *controllers.Reconciler, name string, namespace string,
+	logger logr.Logger) (BackupDaemonClient, string, error) {
+	kafkaService := &kafkaservice.KafkaService{}
+	err := r.Client.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, kafkaService)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return BackupDaemonClient{}, fmt.Sprintf("KafkaService [%s] is not found", name), nil
+		}
+		return BackupDaemonClient{}, "", err
+	}
+	if kafkaService.Spec.BackupDaemon == nil {
+		return BackupDaemonClient{}, fmt.Sprintf("KafkaService [%s] does not have Backup Daemon configuration", name), nil
+	}
+	return newKafkaServiceBackupDaemonClient(r, kafkaService, logger), "", nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *KafkaBackupReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&kafkav1.KafkaBackup{}, builder.WithPredicates(backupJobPredicates()...)).
+		Complete(r)
+}
+
+// backupJobPredicates filter status updates and deletions of backup and restore resources
+// and the resources from other namespaces
+func backupJobPredicates() []predicate.Predicate {
+	statusPredicate := predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			// Ignore updates to CR status in which case metadata.Generation does not change
+			return e.ObjectOld.GetGeneration() != e.ObjectNew.GetGeneration()
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return false
+		},
+	}
+	namespacePredicate := predicate.NewPredicateFuncs(func(object client.Object) bool {
+		return object.GetNamespace() == os.Getenv("OPERATOR_NAMESPACE")
+	})
+	return []predicate.Predicate{statusPredicate, namespacePredicate}
+}
@@ -0,0 +1,200 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaservice
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	kafkav1 "github.com/Netcracker/qubership-kafka/operator/api/v1"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+)
+
+// progressBackupStub progresses the backup and checks that the request marker is saved before backup is requested
+func progressBackupStub(t *testing.T, backupDaemon BackupDaemonClient, backup *kafkav1.KafkaBackup,
+	now time.Time) kafkav1.KafkaBackupStatus {
+	status, err := progressBackup(backupDaemon, backup, now, func(status kafkav1.KafkaBackupStatus) error {
+		assert.Empty(t, status.VaultId)
+		assert.Equal(t, now.Format(time.RFC3339), status.RequestTime)
+		backup.Status = status
+		return nil
+	})
+	assert.NoError(t, err)
+	return status
+}
+
+func TestKafkaBackup_progressBackup(t *testing.T) {
+	stub := newBackupDaemonStub(t)
+	start := time.Date(2025, 5, 13, 9, 55, 36, 0, time.UTC)
+	backup := &kafkav1.KafkaBackup{Spec: kafkav1.KafkaBackupSpec{
+		KafkaServiceName: "kafka",
+		TopicRegex:       "topic[1-9]",
+		IncludeAcl:       true,
+	}}
+
+	backup.Status = progressBackupStub(t, stub.client(), backup, start)
+	assert.Equal(t, backupPhaseRunning, backup.Status.Phase)
+	assert.Equal(t, "20250513T095536", backup.Status.VaultId)
+	assert.Equal(t, "2025-05-13T09:55:36Z", backup.Status.StartTime)
+	assert.Equal(t, "2025-05-13T09:55:36Z", backup.Status.RequestTime)
+	body, _ := stub.request("POST /backup")
+	assert.JSONEq(t, `{"topic_regex":"topic[1-9]","mode":"acl"}`, body)
+
+	stub.setJobStatus("20250513T095536", "Processing")
+	backup.Status = progressBackupStub(t, stub.client(), backup, start.Add(10*time.Second))
+	assert.Equal(t, backupPhaseRunning, backup.Status.Phase)
+	assert.Equal(t, "Job 20250513T095536 is Processing", backup.Status.Message)
+
+	stub.setJobStatus("20250513T095536", backupJobStatusSuccessful)
+	backup.Status = progressBackupStub(t, stub.client(), backup, start.Add(90*time.Second))
+	assert.Equal(t, backupPhaseSuccessful, backup.Status.Phase)
+	assert.Equal(t, "2025-05-13T09:57:06Z", backup.Status.CompletionTime)
+	assert.Equal(t, "1m30s", backup.Status.Duration)
+}
+
+func TestKafkaBackup_progressFailedBackup(t *testing.T) {
+	stub := newBackupDaemonStub(t)
+	start := time.Date(2025, 5, 13, 9, 55, 36, 0, time.UTC)
+	backup := &kafkav1.KafkaBackup{Status: kafkav1.KafkaBackupStatus{
+		Phase:     backupPhaseRunning,
+		VaultId:   "20250513T095536",
+		StartTime: start.Format(time.RFC3339),
+	}}
+	stub.setJobStatus("20250513T095536", backupJobStatusFailed)
+
+	status := progressBackupStub(t, stub.client(), backup, start.Add(time.Minute))
+	assert.Equal(t, backupPhaseFailed, status.Phase)
+	assert.Equal(t, "Job 20250513T095536 has failed: Topic topic1 already exists", status.Message)
+	assert.Equal(t, "1m0s", status.Duration)
+}
+
+func TestKafkaBackup_progressBackupTimeout(t *testing.T) {
+	stub := newBackupDaemonStub(t)
+	start := time.Date(2025, 5, 13, 9, 55, 36, 0, time.UTC)
+	backup := &kafkav1.KafkaBackup{Spec: kafkav1.KafkaBackupSpec{TimeoutSeconds: ptr.To[int32](60)}}
+	unavailable := NewBackupDaemonClient(stub.URL, "admin", "wrong")
+
+	backup.Status = progressBackupStub(t, unavailable, backup, start)
+	assert.Equal(t, backupPhaseRunning, backup.Status.Phase)
+	assert.Empty(t, backup.Status.VaultId)
+	assert.Empty(t, backup.Status.RequestTime)
+	assert.Contains(t, backup.Status.Message, "Backup request failed")
+
+	backup.Status = progressBackupStub(t, unavailable, backup, start.Add(2*time.Minute))
+	assert.Equal(t, backupPhaseFailed, backup.Status.Phase)
+	assert.NotEmpty(t, backup.Status.CompletionTime)
+}
+
+func TestKafkaBackup_progressBackupRequestTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer server.Close()
+	slow := NewBackupDaemonClient(server.URL, "admin", "secret")
+	slow.httpClient.Timeout = 50 * time.Millisecond
+	start := time.Date(2025, 5, 13, 9, 55, 36, 0, time.UTC)
+	backup := &kafkav1.KafkaBackup{}
+
+	status := progressBackupStub(t, slow, backup, start)
+	assert.Equal(t, backupPhaseRunning, status.Phase)
+	assert.Empty(t, status.VaultId)
+	assert.Equal(t, "2025-05-13T09:55:36Z", status.RequestTime)
+}
+
+func TestKafkaBackup_progressBackupWithUnknownVault(t *testing.T) {
+	stub := newBackupDaemonStub(t)
+	start := time.Date(2025, 5, 13, 9, 55, 36, 0, time.UTC)
+	backup := &kafkav1.KafkaBackup{Status: kafkav1.KafkaBackupStatus{
+		Phase:       backupPhaseRunning,
+		StartTime:   start.Format(time.RFC3339),
+		RequestTime: start.Format(time.RFC3339),
+	}}
+
+	status, err := progressBackup(stub.client(), backup, start.Add(10*time.Second),
+		func(kafkav1.KafkaBackupStatus) error {
+			t.Error("backup is requested again")
+			return nil
+		})
+	assert.NoError(t, err)
+	assert.Equal(t, backupPhaseFailed, status.Phase)
+	assert.Contains(t, status.Message, "Backup was requested at 2025-05-13T09:55:36Z, but its vault is unknown")
+	_, requested := stub.request("POST /backup")
+	assert.False(t, requested)
+}
+
+func TestKafkaBackup_progressBackupNotMarked(t *testing.T) {
+	stub := newBackupDaemonStub(t)
+	backup := &kafkav1.KafkaBackup{}
+
+	_, err := progressBackup(stub.client(), backup, time.Now(), func(kafkav1.KafkaBackupStatus) error {
+		return errors.New("conflict")
+	})
+	assert.Error(t, err)
+	_, requested := stub.request("POST /backup")
+	assert.False(t, requested)
+}
+
+func TestKafkaBackup_newBackupRequest(t *testing.T) {
+	request := newBackupRequest(kafkav1.KafkaBackupSpec{
+		Topics:        []string{"topic1"},
+		AllowEviction: ptr.To(false),
+	})
+	assert.Equal(t, BackupRequest{Dbs: []string{"topic1"}, AllowEviction: "False"}, request)
+	assert.Equal(t, BackupRequest{}, newBackupRequest(kafkav1.KafkaBackupSpec{AllowEviction: ptr.To(true)}))
+}
+
+func TestKafkaRestore_progressRestore(t *testing.T) {
+	stub := newBackupDaemonStub(t)
+	start := time.Date(2025, 5, 13, 10, 0, 0, 0, time.UTC)
+	restore := &kafkav1.KafkaRestore{Spec: kafkav1.KafkaRestoreSpec{
+		KafkaServiceName: "kafka",
+		BackupName:       "daily",
+		Topics:           []string{"topic1", "topic2"},
+	}}
+
+	restore.Status = progressRestore(stub.client(), restore, "20250513T095536", start)
+	assert.Equal(t, backupPhaseRunning, restore.Status.Phase)
+	assert.Equal(t, "restore-task", restore.Status.TaskId)
+	assert.Equal(t, "20250513T095536", restore.Status.VaultId)
+	body, _ := stub.request("POST /restore")
+	assert.JSONEq(t, `{"vault":"20250513T095536","dbs":["topic1","topic2"]}`, body)
+
+	stub.setJobStatus("restore-task", backupJobStatusSuccessful)
+	restore.Status = progressRestore(stub.client(), restore, "20250513T095536", start.Add(5*time.Second))
+	assert.Equal(t, backupPhaseSuccessful, restore.Status.Phase)
+	assert.Equal(t, "5s", restore.Status.Duration)
+}
+
+func TestKafkaRestore_progressRestoreTimeout(t *testing.T) {
+	stub := newBackupDaemonStub(t)
+	start := time.Date(2025, 5, 13, 10, 0, 0, 0, time.UTC)
+	restore := &kafkav1.KafkaRestore{
+		Spec: kafkav1.KafkaRestoreSpec{TimeoutSeconds: ptr.To[int32](30)},
+		Status: kafkav1.KafkaRestoreStatus{
+			Phase:     backupPhaseRunning,
+			TaskId:    "restore-task",
+			StartTime: start.Format(time.RFC3339),
+		},
+	}
+	stub.setJobStatus("restore-task", "Queued")
+
+	status := progressRestore(stub.client(), restore, "20250513T095536", start.Add(time.Minute))
+	assert.Equal(t, backupPhaseFailed, status.Phase)
+	assert.Equal(t, "Timeout occurred while waiting for job restore-task. Job restore-task is Queued", status.Message)
+}
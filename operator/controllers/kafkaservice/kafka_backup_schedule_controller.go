@@ -0,0 +1,307 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaservice
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	kafkav1 "github.com/Netcracker/qubership-kafka/operator/api/v1"
+	"github.com/Netcracker/qubership-kafka/operator/controllers"
+	"github.com/Netcracker/qubership-kafka/operator/util"
+	"k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const defaultBackupRetentionCount = 7
+
+var backupScheduleLog = logf.Log.WithName("controller_kafka_backup_schedule")
+
+// KafkaBackupScheduleReconciler reconciles a KafkaBackupSchedule object
+type KafkaBackupScheduleReconciler struct {
+	controllers.Reconciler
+}
+
+//+kubebuilder:rbac:groups=netcracker.com,resources=kafkabackupschedules,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=netcracker.com,resources=kafkabackupschedules/status,verbs=get;update;patch
+
+func (r *KafkaBackupScheduleReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	reqLogger := backupScheduleLog.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	reqLogger.Info("Reconciling KafkaBackupSchedule")
+
+	instance := &kafkav1.KafkaBackupSchedule{}
+	if err := r.Client.Get(ctx, request.NamespacedName, instance); err != nil {
+		if errors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	statusUpdater := NewBackupScheduleStatusUpdater(r.Client, instance)
+
+	cronSchedule, err := util.ParseCronSchedule(instance.Spec.Schedule)
+	if err == nil {
+		_, err = parseRetentionAge(instance.Spec.Retention.MaxAge)
+	}
+	if err != nil {
+		message := fmt.Sprintf("Backup schedule is invalid: %v", err)
+		reqLogger.Info(message)
+		return reconcile.Result{}, statusUpdater.UpdateStatusWithRetry(func(schedule *kafkav1.KafkaBackupSchedule) {
+			schedule.Status.Message = message
+			schedule.Status.NextScheduleTime = ""
+			schedule.Status.ObservedGeneration = instance.Generation
+		})
+	}
+
+	backupDaemon, message, err :=
+		findBackupDaemonClient(ctx, &r.Reconciler, instance.Spec.KafkaServiceName, instance.Namespace, reqLogger)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if message != "" {
+		reqLogger.Info(message)
+		if err = statusUpdater.UpdateStatusWithRetry(func(schedule *kafkav1.KafkaBackupSchedule) {
+			schedule.Status.Message = message
+			schedule.Status.ObservedGeneration = instance.Generation
+		}); err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{RequeueAfter: time.Minute}, nil
+	}
+
+	now := time.Now()
+	status, requeueAfter, err := progressBackupSchedule(backupDaemon, instance, cronSchedule, now,
+		func(status kafkav1.KafkaBackupScheduleStatus) error {
+			return statusUpdater.UpdateStatusWithRetry(func(schedule *kafkav1.KafkaBackupSchedule) {
+				schedule.Status = status
+			})
+		})
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	reqLogger.Info(fmt.Sprintf("Next backup is scheduled at %s", status.NextScheduleTime))
+	if err = statusUpdater.UpdateStatusWithRetry(func(schedule *kafkav1.KafkaBackupSchedule) {
+		schedule.Status = status
+	}); err != nil {
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// progressBackupSchedule checks started backups, starts the backup if its scheduled time has come
+// and evicts expired backups. It returns the new status of KafkaBackupSchedule and the time to the next check.
+// The status with request time is saved by markRequested before the backup is requested,
+// so the scheduled backup is not requested twice if its vault is not saved after the request.
+func progressBackupSchedule(backupDaemon BackupDaemonClient, schedule *kafkav1.KafkaBackupSchedule,
+	cronSchedule *util.CronSchedule, now time.Time,
+	markRequested func(kafkav1.KafkaBackupScheduleStatus) error) (kafkav1.KafkaBackupScheduleStatus, time.Duration, error) {
+	status := *schedule.Status.DeepCopy()
+	status.ObservedGeneration = schedule.Generation
+	timeout := secondsOrDefault(schedule.Spec.TimeoutSeconds, defaultBackupJobTimeout)
+	var messages []string
+
+	running := false
+	for i, backup := range status.Backups {
+		if backup.Phase != backupPhaseRunning {
+			continue
+		}
+		phase, message := checkBackupJob(backupDaemon, backup.VaultId, backup.StartTime, timeout, now)
+		status.Backups[i].Phase = phase
+		switch phase {
+		case backupPhaseRunning:
+			running = true
+		case backupPhaseSuccessful:
+			status.Backups[i].CompletionTime, _ = completeBackupJob(backup.StartTime, now)
+			status.LastSuccessfulBackup = backup.VaultId
+			status.LastSuccessfulTime = status.Backups[i].CompletionTime
+		default:
+			status.Backups[i].CompletionTime, _ = completeBackupJob(backup.StartTime, now)
+			messages = append(messages, message)
+		}
+	}
+
+	retry := false
+	scheduledTime := lastScheduledRun(cronSchedule, scheduleBaseTime(schedule), now)
+	switch {
+	case status.RequestTime != "":
+		// Backup Daemon may have started the backup, so the activation is not repeated
+		messages = append(messages, fmt.Sprintf("Scheduled backup was requested at %s, but its vault is unknown. "+
+			"Check backups of Backup Daemon, the backup is not repeated", status.RequestTime))
+		status.RequestTime = ""
+		if !scheduledTime.IsZero() {
+			status.LastScheduleTime = scheduledTime.Format(time.RFC3339)
+		}
+	case scheduledTime.IsZero():
+	case schedule.Spec.Suspend:
+		// skipped activations are not caught up when the schedule is resumed
+		status.LastScheduleTime = scheduledTime.Format(time.RFC3339)
+	case running:
+		messages = append(messages, "Previous backup is still running, scheduled backup is postponed")
+	default:
+		status.RequestTime = now.Format(time.RFC3339)
+		if err := markRequested(status); err != nil {
+			return schedule.Status, 0, err
+		}
+		vaultId, err := backupDaemon.Backup(newScheduledBackupRequest(schedule.Spec))
+		if err != nil {
+			messages = append(messages, fmt.Sprintf("Backup request failed: %v", err))
+			if !isRequestTimeoutError(err) {
+				// Backup Daemon has not accepted the request, so it can be repeated
+				status.RequestTime = ""
+			}
+			retry = true
+			break
+		}
+		status.RequestTime = ""
+		status.LastScheduleTime = scheduledTime.Format(time.RFC3339)
+		status.Backups = append(status.Backups, kafkav1.KafkaScheduledBackup{
+			VaultId:   vaultId,
+			Phase:     backupPhaseRunning,
+			StartTime: now.Format(time.RFC3339),
+		})
+		running = true
+	}
+
+	var evictionMessages []string
+	status.Backups, evictionMessages = evictExpiredBackups(backupDaemon, status.Backups, schedule.Spec.Retention, now)
+	messages = append(messages, evictionMessages...)
+	status.Message = strings.Join(messages, "; ")
+
+	nextTime := cronSchedule.Next(now)
+	if nextTime.IsZero() {
+		status.NextScheduleTime = ""
+	} else {
+		status.NextScheduleTime = nextTime.Format(time.RFC3339)
+	}
+	if running || retry || len(evictionMessages) != 0 {
+		return status, waitingInterval, nil
+	}
+	if nextTime.IsZero() {
+		return status, 0, nil
+	}
+	return status, nextTime.Sub(now), nil
+}
+
+// lastScheduledRun returns the latest activation of schedule between base and current time
+// or zero time if there are no such activations, so missed activations result in one backup only
+func lastScheduledRun(cronSchedule *util.CronSchedule, base time.Time, now time.Time) time.Time {
+	var scheduledTime time.Time
+	for next := cronSchedule.Next(base); !next.IsZero() && !next.After(now); next = cronSchedule.Next(next) {
+		scheduledTime = next
+	}
+	return scheduledTime
+}
+
+func scheduleBaseTime(schedule *kafkav1.KafkaBackupSchedule) time.Time {
+	if lastScheduleTime, err := time.Parse(time.RFC3339, schedule.Status.LastScheduleTime); err == nil {
+		return lastScheduleTime
+	}
+	return schedule.CreationTimestamp.Time
+}
+
+// newScheduledBackupRequest disables eviction by Backup Daemon, because the operator evicts scheduled backups itself
+func newScheduledBackupRequest(spec kafkav1.KafkaBackupScheduleSpec) BackupRequest {
+	request := BackupRequest{
+		Dbs:           spec.Topics,
+		TopicRegex:    spec.TopicRegex,
+		AllowEviction: "False",
+	}
+	if spec.IncludeAcl {
+		request.Mode = backupModeAcl
+	}
+	return request
+}
+
+// evictExpiredBackups removes finished backups which exceed maximum count or age from Backup Daemon
+// and returns the backups which are kept. Only successful backups are counted, failed backups are removed
+// when there are maximum count of newer successful backups. The last successful backup is never evicted.
+func evictExpiredBackups(backupDaemon BackupDaemonClient, backups []kafkav1.KafkaScheduledBackup,
+	retention kafkav1.KafkaBackupRetention, now time.Time) ([]kafkav1.KafkaScheduledBackup, []string) {
+	maxCount := defaultBackupRetentionCount
+	if retention.MaxCount != nil && *retention.MaxCount > 0 {
+		maxCount = int(*retention.MaxCount)
+	}
+	maxAge, _ := parseRetentionAge(retention.MaxAge)
+
+	var kept []kafkav1.KafkaScheduledBackup
+	var messages []string
+	successful := 0
+	lastSuccessfulFound := false
+	// backups are stored in order of start, so the newest ones are checked first
+	for i := len(backups) - 1; i >= 0; i-- {
+		backup := backups[i]
+		if backup.Phase == backupPhaseRunning {
+			kept = append(kept, backup)
+			continue
+		}
+		var expired bool
+		if backup.Phase == backupPhaseSuccessful {
+			successful++
+			expired = successful > maxCount
+		} else {
+			expired = successful >= maxCount
+		}
+		if startTime, err := time.Parse(time.RFC3339, backup.StartTime); err == nil && maxAge > 0 {
+			expired = expired || now.Sub(startTime) > maxAge
+		}
+		if backup.Phase == backupPhaseSuccessful && !lastSuccessfulFound {
+			lastSuccessfulFound = true
+			expired = false
+		}
+		if !expired {
+			kept = append(kept, backup)
+			continue
+		}
+		if err := backupDaemon.Evict(backup.VaultId); err != nil {
+			messages = append(messages, fmt.Sprintf("Eviction of backup %s failed: %v", backup.VaultId, err))
+			kept = append(kept, backup)
+		}
+	}
+	for i, j := 0, len(kept)-1; i < j; i, j = i+1, j-1 {
+		kept[i], kept[j] = kept[j], kept[i]
+	}
+	return kept, messages
+}
+
+// parseRetentionAge parses Go duration with additional support of days, for example, "7d"
+func parseRetentionAge(age string) (time.Duration, error) {
+	if age == "" {
+		return 0, nil
+	}
+	if days, found := strings.CutSuffix(age, "d"); found {
+		value, err := strconv.Atoi(days)
+		if err != nil || value <= 0 {
+			return 0, fmt.Errorf("invalid retention age '%s'", age)
+		}
+		return time.Duration(value) * 24 * time.Hour, nil
+	}
+	duration, err := time.ParseDuration(age)
+	if err != nil || duration <= 0 {
+		return 0, fmt.Errorf("invalid retention age '%s'", age)
+	}
+	return duration, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *KafkaBackupScheduleReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&kafkav1.KafkaBackupSchedule{}, builder.WithPredicates(backupJobPredicates()...)).
+		Complete(r)
+}
@@ -0,0 +1,248 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaservice
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	kafkav1 "github.com/Netcracker/qubership-kafka/operator/api/v1"
+	"github.com/Netcracker/qubership-kafka/operator/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func newTestBackupSchedule(t *testing.T, expression string, created time.Time) (*kafkav1.KafkaBackupSchedule, *util.CronSchedule) {
+	cronSchedule, err := util.ParseCronSchedule(expression)
+	require.NoError(t, err)
+	return &kafkav1.KafkaBackupSchedule{
+		ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(created)},
+		Spec: kafkav1.KafkaBackupScheduleSpec{
+			KafkaServiceName: "kafka",
+			Schedule:         expression,
+			Topics:           []string{"orders"},
+		},
+	}, cronSchedule
+}
+
+func noopMarkRequested(kafkav1.KafkaBackupScheduleStatus) error {
+	return nil
+}
+
+func TestKafkaBackupSchedule_progressBackupSchedule(t *testing.T) {
+	stub := newBackupDaemonStub(t)
+	created := time.Date(2025, 5, 13, 9, 55, 36, 0, time.UTC)
+	schedule, cronSchedule := newTestBackupSchedule(t, "0 0 * * *", created)
+
+	status, requeueAfter, _ := progressBackupSchedule(stub.client(), schedule, cronSchedule, created, noopMarkRequested)
+	assert.Empty(t, status.Backups)
+	assert.Equal(t, "2025-05-14T00:00:00Z", status.NextScheduleTime)
+	assert.Equal(t, 14*time.Hour+4*time.Minute+24*time.Second, requeueAfter)
+	_, found := stub.request("POST /backup")
+	assert.False(t, found)
+
+	schedule.Status = status
+	midnight := time.Date(2025, 5, 14, 0, 0, 1, 0, time.UTC)
+	status, requeueAfter, _ = progressBackupSchedule(stub.client(), schedule, cronSchedule, midnight, noopMarkRequested)
+	require.Len(t, status.Backups, 1)
+	assert.Equal(t, backupPhaseRunning, status.Backups[0].Phase)
+	assert.Equal(t, "2025-05-14T00:00:00Z", status.LastScheduleTime)
+	assert.Equal(t, "2025-05-15T00:00:00Z", status.NextScheduleTime)
+	assert.Equal(t, waitingInterval, requeueAfter)
+	body, _ := stub.request("POST /backup")
+	assert.JSONEq(t, `{"dbs":["orders"],"allow_eviction":"False"}`, body)
+
+	schedule.Status = status
+	stub.setJobStatus("20250513T095536", backupJobStatusSuccessful)
+	status, _, _ = progressBackupSchedule(stub.client(), schedule, cronSchedule, midnight.Add(time.Minute), noopMarkRequested)
+	assert.Equal(t, backupPhaseSuccessful, status.Backups[0].Phase)
+	assert.Equal(t, "20250513T095536", status.LastSuccessfulBackup)
+	assert.Equal(t, "2025-05-14T00:01:01Z", status.LastSuccessfulTime)
+	assert.Empty(t, status.Message)
+}
+
+func TestKafkaBackupSchedule_missedRunsStartOneBackup(t *testing.T) {
+	stub := newBackupDaemonStub(t)
+	created := time.Date(2025, 5, 13, 9, 55, 36, 0, time.UTC)
+	schedule, cronSchedule := newTestBackupSchedule(t, "@hourly", created)
+
+	status, _, _ := progressBackupSchedule(stub.client(), schedule, cronSchedule, created.Add(5*time.Hour), noopMarkRequested)
+	require.Len(t, status.Backups, 1)
+	assert.Equal(t, "2025-05-13T14:00:00Z", status.LastScheduleTime)
+}
+
+func TestKafkaBackupSchedule_suspended(t *testing.T) {
+	stub := newBackupDaemonStub(t)
+	created := time.Date(2025, 5, 13, 9, 55, 36, 0, time.UTC)
+	schedule, cronSchedule := newTestBackupSchedule(t, "@hourly", created)
+	schedule.Spec.Suspend = true
+
+	status, _, _ := progressBackupSchedule(stub.client(), schedule, cronSchedule, created.Add(time.Hour), noopMarkRequested)
+	assert.Empty(t, status.Backups)
+	assert.Equal(t, "2025-05-13T10:00:00Z", status.LastScheduleTime)
+	assert.Equal(t, "2025-05-13T11:00:00Z", status.NextScheduleTime)
+
+	// skipped activation does not start backup when the schedule is resumed
+	schedule.Spec.Suspend = false
+	schedule.Status = status
+	status, _, _ = progressBackupSchedule(stub.client(), schedule, cronSchedule, created.Add(time.Hour+time.Minute), noopMarkRequested)
+	assert.Empty(t, status.Backups)
+	_, found := stub.request("POST /backup")
+	assert.False(t, found)
+}
+
+func TestKafkaBackupSchedule_postponedWhileRunning(t *testing.T) {
+	stub := newBackupDaemonStub(t)
+	created := time.Date(2025, 5, 13, 9, 55, 36, 0, time.UTC)
+	schedule, cronSchedule := newTestBackupSchedule(t, "@hourly", created)
+	schedule.Status.Backups = []kafkav1.KafkaScheduledBackup{
+		{VaultId: "20250513T095536", Phase: backupPhaseRunning, StartTime: "2025-05-13T09:59:00Z"},
+	}
+	stub.setJobStatus("20250513T095536", "Processing")
+
+	status, requeueAfter, _ := progressBackupSchedule(stub.client(), schedule, cronSchedule, created.Add(10*time.Minute), noopMarkRequested)
+	assert.Len(t, status.Backups, 1)
+	assert.Empty(t, status.LastScheduleTime)
+	assert.Equal(t, "Previous backup is still running, scheduled backup is postponed", status.Message)
+	assert.Equal(t, waitingInterval, requeueAfter)
+}
+
+func TestKafkaBackupSchedule_requestMarked(t *testing.T) {
+	stub := newBackupDaemonStub(t)
+	created := time.Date(2025, 5, 13, 9, 55, 36, 0, time.UTC)
+	schedule, cronSchedule := newTestBackupSchedule(t, "@hourly", created)
+	now := created.Add(10 * time.Minute)
+
+	var marked kafkav1.KafkaBackupScheduleStatus
+	status, _, err := progressBackupSchedule(stub.client(), schedule, cronSchedule, now,
+		func(status kafkav1.KafkaBackupScheduleStatus) error {
+			_, requested := stub.request("POST /backup")
+			assert.False(t, requested, "backup is requested before request time is saved")
+			marked = status
+			return nil
+		})
+	require.NoError(t, err)
+	assert.Equal(t, "2025-05-13T10:05:36Z", marked.RequestTime)
+	assert.Empty(t, status.RequestTime)
+	assert.Len(t, status.Backups, 1)
+
+	schedule, _ = newTestBackupSchedule(t, "@hourly", created)
+	_, _, err = progressBackupSchedule(stub.client(), schedule, cronSchedule, now,
+		func(kafkav1.KafkaBackupScheduleStatus) error {
+			return errors.New("conflict")
+		})
+	assert.Error(t, err)
+}
+
+func TestKafkaBackupSchedule_requestWithUnknownVault(t *testing.T) {
+	stub := newBackupDaemonStub(t)
+	created := time.Date(2025, 5, 13, 9, 55, 36, 0, time.UTC)
+	schedule, cronSchedule := newTestBackupSchedule(t, "@hourly", created)
+	schedule.Status.RequestTime = "2025-05-13T10:00:01Z"
+
+	status, _, err := progressBackupSchedule(stub.client(), schedule, cronSchedule, created.Add(10*time.Minute),
+		func(kafkav1.KafkaBackupScheduleStatus) error {
+			t.Error("backup is requested again")
+			return nil
+		})
+	require.NoError(t, err)
+	assert.Empty(t, status.Backups)
+	assert.Empty(t, status.RequestTime)
+	assert.Equal(t, "2025-05-13T10:00:00Z", status.LastScheduleTime)
+	assert.Contains(t, status.Message, "Scheduled backup was requested at 2025-05-13T10:00:01Z, but its vault is unknown")
+	_, requested := stub.request("POST /backup")
+	assert.False(t, requested)
+}
+
+func TestKafkaBackupSchedule_evictExpiredBackups(t *testing.T) {
+	stub := newBackupDaemonStub(t)
+	for _, vaultId := range []string{"20250510T000000", "20250511T000000", "20250512T000000", "20250513T000000"} {
+		stub.backups[vaultId] = BackupInfoResponse{Id: vaultId}
+	}
+	backups := []kafkav1.KafkaScheduledBackup{
+		{VaultId: "20250510T000000", Phase: backupPhaseSuccessful, StartTime: "2025-05-10T00:00:00Z"},
+		{VaultId: "20250511T000000", Phase: backupPhaseSuccessful, StartTime: "2025-05-11T00:00:00Z"},
+		{VaultId: "20250512T000000", Phase: backupPhaseFailed, StartTime: "2025-05-12T00:00:00Z"},
+		{VaultId: "20250513T000000", Phase: backupPhaseSuccessful, StartTime: "2025-05-13T00:00:00Z"},
+		{VaultId: "20250514T000000", Phase: backupPhaseRunning, StartTime: "2025-05-14T00:00:00Z"},
+	}
+	now := time.Date(2025, 5, 14, 0, 1, 0, 0, time.UTC)
+
+	kept, messages := evictExpiredBackups(stub.client(), backups, kafkav1.KafkaBackupRetention{MaxCount: ptr.To[int32](2)}, now)
+	assert.Empty(t, messages)
+	assert.Equal(t, []string{"20250511T000000", "20250512T000000", "20250513T000000", "20250514T000000"}, vaultIds(kept))
+	assert.NotContains(t, stub.backups, "20250510T000000")
+
+	kept, messages = evictExpiredBackups(stub.client(), kept, kafkav1.KafkaBackupRetention{MaxAge: "2d"}, now)
+	assert.Empty(t, messages)
+	assert.Equal(t, []string{"20250513T000000", "20250514T000000"}, vaultIds(kept))
+
+	// the last successful backup is kept even if it is expired
+	kept, _ = evictExpiredBackups(stub.client(), kept, kafkav1.KafkaBackupRetention{MaxAge: "1h"}, now)
+	assert.Equal(t, []string{"20250513T000000", "20250514T000000"}, vaultIds(kept))
+}
+
+func TestKafkaBackupSchedule_evictFailedBackups(t *testing.T) {
+	stub := newBackupDaemonStub(t)
+	for _, vaultId := range []string{"20250510T000000", "20250511T000000", "20250512T000000", "20250513T000000"} {
+		stub.backups[vaultId] = BackupInfoResponse{Id: vaultId}
+	}
+	backups := []kafkav1.KafkaScheduledBackup{
+		{VaultId: "20250510T000000", Phase: backupPhaseFailed, StartTime: "2025-05-10T00:00:00Z"},
+		{VaultId: "20250511T000000", Phase: backupPhaseSuccessful, StartTime: "2025-05-11T00:00:00Z"},
+		{VaultId: "20250512T000000", Phase: backupPhaseFailed, StartTime: "2025-05-12T00:00:00Z"},
+		{VaultId: "20250513T000000", Phase: backupPhaseFailed, StartTime: "2025-05-13T00:00:00Z"},
+	}
+	now := time.Date(2025, 5, 14, 0, 1, 0, 0, time.UTC)
+	retention := kafkav1.KafkaBackupRetention{MaxCount: ptr.To[int32](1)}
+
+	// failed backups do not displace successful ones
+	kept, messages := evictExpiredBackups(stub.client(), backups, retention, now)
+	assert.Empty(t, messages)
+	assert.Equal(t, []string{"20250511T000000", "20250512T000000", "20250513T000000"}, vaultIds(kept))
+	assert.NotContains(t, stub.backups, "20250510T000000")
+
+	kept = append(kept, kafkav1.KafkaScheduledBackup{
+		VaultId: "20250514T000000", Phase: backupPhaseSuccessful, StartTime: "2025-05-14T00:00:00Z"})
+	kept, _ = evictExpiredBackups(stub.client(), kept, retention, now)
+	assert.Equal(t, []string{"20250514T000000"}, vaultIds(kept))
+}
+
+func TestKafkaBackupSchedule_parseRetentionAge(t *testing.T) {
+	age, err := parseRetentionAge("7d")
+	require.NoError(t, err)
+	assert.Equal(t, 7*24*time.Hour, age)
+	age, err = parseRetentionAge("12h")
+	require.NoError(t, err)
+	assert.Equal(t, 12*time.Hour, age)
+	age, err = parseRetentionAge("")
+	require.NoError(t, err)
+	assert.Zero(t, age)
+	for _, invalid := range []string{"d", "-1d", "week", "-5m"} {
+		_, err = parseRetentionAge(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func vaultIds(backups []kafkav1.KafkaScheduledBackup) []string {
+	var ids []string
+	for _, backup := range backups {
+		ids = append(ids, backup.VaultId)
+	}
+	return ids
+}
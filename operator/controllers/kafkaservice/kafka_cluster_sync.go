@@ -0,0 +1,324 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaservice
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/IBM/sarama"
+	kafkaservice "github.com/Netcracker/qubership-kafka/operator/api/v7"
+	"github.com/Netcracker/qubership-kafka/operator/controllers/handlers"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	divergenceKindTopicConfig = "topicConfig"
+	divergenceKindAcl         = "acl"
+)
+
+// clusterSpecificTopicConfigs are topic configs which depend on brokers of the cluster and must not be copied
+var clusterSpecificTopicConfigs = []string{
+	"leader.replication.throttled.replicas",
+	"follower.replication.throttled.replicas",
+}
+
+var syncLogger = log.WithName("Kafka cluster sync")
+
+type topicConfigDiff struct {
+	topic   string
+	configs map[string]*string
+	message string
+}
+
+type aclEntry struct {
+	resource sarama.Resource
+	acl      sarama.Acl
+}
+
+// KafkaClusterSync copies topic configs and ACLs from source cluster of replication to target one
+type KafkaClusterSync struct {
+	cr         *kafkaservice.KafkaService
+	reconciler *KafkaServiceReconciler
+	spec       *kafkaservice.ClusterSync
+}
+
+func NewKafkaClusterSync(cr *kafkaservice.KafkaService, r *KafkaServiceReconciler) *KafkaClusterSync {
+	return &KafkaClusterSync{cr: cr, reconciler: r, spec: cr.Spec.MirrorMaker.ClusterSync}
+}
+
+// Sync compares topic configs and ACLs of source and target clusters, applies differences to target cluster
+// if dry run is disabled and returns the status with divergences which remain
+func (s *KafkaClusterSync) Sync() (*kafkaservice.ClusterSyncStatus, error) {
+	return s.synchronize(s.spec.DryRun, true)
+}
+
+// Check compares topic configs and ACLs of source and target clusters without changes of target cluster
+// and returns the status with divergences which block switchover. ACLs which exist only in target cluster,
+// for example ACLs of KafkaUser resources created on standby side, are not reported.
+func (s *KafkaClusterSync) Check() (*kafkaservice.ClusterSyncStatus, error) {
+	return s.synchronize(true, false)
+}
+
+func (s *KafkaClusterSync) synchronize(dryRun bool, reportExtraAcls bool) (*kafkaservice.ClusterSyncStatus, error) {
+	kmmConfigHandler, err := handlers.NewKmmConfigHandler(s.reconciler.Client)
+	if err != nil {
+		return nil, err
+	}
+	status := &kafkaservice.ClusterSyncStatus{
+		SourceCluster: kmmConfigHandler.GetSourceCluster(),
+		TargetCluster: kmmConfigHandler.GetTargetCluster(),
+		LastSyncTime:  metav1.Now().String(),
+	}
+	if !kmmConfigHandler.IsReplicationEnabled() {
+		status.Message = "Replication between Kafka clusters is disabled, synchronization is skipped"
+		return status, nil
+	}
+	targetClient, sourceClient, err := NewKafkaReplicationAuditor(s.cr, s.reconciler).getKafkaClients(kmmConfigHandler)
+	if err != nil {
+		return nil, err
+	}
+	targetAdmin, err := sarama.NewClusterAdminFromClient(targetClient)
+	if err != nil {
+		_ = targetClient.Close()
+		_ = sourceClient.Close()
+		return nil, err
+	}
+	defer func() { _ = targetAdmin.Close() }()
+	sourceAdmin, err := sarama.NewClusterAdminFromClient(sourceClient)
+	if err != nil {
+		_ = sourceClient.Close()
+		return nil, err
+	}
+	defer func() { _ = sourceAdmin.Close() }()
+
+	if s.spec.TopicConfigsEnabled {
+		divergences, err := s.syncTopicConfigs(sourceAdmin, targetAdmin, dryRun)
+		if err != nil {
+			return nil, err
+		}
+		status.Divergences = append(status.Divergences, divergences...)
+	}
+	if s.spec.AclsEnabled {
+		divergences, err := s.syncAcls(sourceAdmin, targetAdmin, dryRun, reportExtraAcls)
+		if err != nil {
+			return nil, err
+		}
+		status.Divergences = append(status.Divergences, divergences...)
+	}
+	status.Message = fmt.Sprintf("%d divergences found", len(status.Divergences))
+	return status, nil
+}
+
+func (s *KafkaClusterSync) syncTopicConfigs(source sarama.ClusterAdmin,
+	target sarama.ClusterAdmin, dryRun bool) ([]kafkaservice.ClusterDivergence, error) {
+	sourceTopics, err := source.ListTopics()
+	if err != nil {
+		return nil, err
+	}
+	targetTopics, err := target.ListTopics()
+	if err != nil {
+		return nil, err
+	}
+	var divergences []kafkaservice.ClusterDivergence
+	excludedConfigs := append(slices.Clone(clusterSpecificTopicConfigs), s.spec.ExcludeConfigs...)
+	for _, diff := range diffTopicConfigs(sourceTopics, targetTopics, s.isTopicSynchronized, excludedConfigs) {
+		message := diff.message
+		if diff.configs != nil && !dryRun {
+			syncLogger.Info(fmt.Sprintf("Updating configs of topic %s: %s", diff.topic, diff.message))
+			err = target.AlterConfig(sarama.TopicResource, diff.topic, diff.configs, false)
+			if err == nil {
+				continue
+			}
+			message = fmt.Sprintf("%s, update failed: %v", message, err)
+		}
+		divergences = append(divergences, kafkaservice.ClusterDivergence{
+			Kind:     divergenceKindTopicConfig,
+			Resource: diff.topic,
+			Message:  message,
+		})
+	}
+	return divergences, nil
+}
+
+// syncAcls creates ACLs of source cluster which are missing in target cluster. ACLs which exist only in target cluster
+// are never removed, they are reported as divergences if reportExtraAcls is set.
+func (s *KafkaClusterSync) syncAcls(source sarama.ClusterAdmin, target sarama.ClusterAdmin,
+	dryRun bool, reportExtraAcls bool) ([]kafkaservice.ClusterDivergence, error) {
+	filter := sarama.AclFilter{
+		ResourceType:              sarama.AclResourceAny,
+		ResourcePatternTypeFilter: sarama.AclPatternAny,
+		PermissionType:            sarama.AclPermissionAny,
+		Operation:                 sarama.AclOperationAny,
+	}
+	sourceAcls, err := source.ListAcls(filter)
+	if err != nil {
+		return nil, err
+	}
+	targetAcls, err := target.ListAcls(filter)
+	if err != nil {
+		return nil, err
+	}
+	missing, extra := diffAcls(sourceAcls, targetAcls, s.isAclSynchronized)
+	var divergences []kafkaservice.ClusterDivergence
+	for _, entry := range missing {
+		message := "ACL does not exist in target cluster"
+		if !dryRun {
+			syncLogger.Info(fmt.Sprintf("Creating ACL %s", entry))
+			acl := entry.acl
+			err = target.CreateACLs([]*sarama.ResourceAcls{{Resource: entry.resource, Acls: []*sarama.Acl{&acl}}})
+			if err == nil {
+				continue
+			}
+			message = fmt.Sprintf("%s, creation failed: %v", message, err)
+		}
+		divergences = append(divergences, kafkaservice.ClusterDivergence{
+			Kind:     divergenceKindAcl,
+			Resource: entry.String(),
+			Message:  message,
+		})
+	}
+	if !reportExtraAcls {
+		return divergences, nil
+	}
+	for _, entry := range extra {
+		divergences = append(divergences, kafkaservice.ClusterDivergence{
+			Kind:     divergenceKindAcl,
+			Resource: entry.String(),
+			Message:  "ACL exists only in target cluster, it is not removed",
+		})
+	}
+	return divergences, nil
+}
+
+func (s *KafkaClusterSync) isTopicSynchronized(topic string) bool {
+	var includeTopics []string
+	if len(s.spec.IncludeTopics) > 0 {
+		includeTopics = s.spec.IncludeTopics
+	}
+	return mustBeTopicReplicated(topic, includeTopics, s.spec.ExcludeTopics)
+}
+
+func (s *KafkaClusterSync) isAclSynchronized(resource sarama.Resource) bool {
+	if resource.ResourceType != sarama.AclResourceTopic {
+		return true
+	}
+	return s.isTopicSynchronized(resource.ResourceName)
+}
+
+// diffTopicConfigs finds topics which configs in target cluster differ from source cluster.
+// The result contains configs for target topic which keep excluded configs of target cluster.
+func diffTopicConfigs(sourceTopics map[string]sarama.TopicDetail, targetTopics map[string]sarama.TopicDetail,
+	isTopicSynchronized func(string) bool, excludedConfigs []string) []topicConfigDiff {
+	var topics []string
+	for topic := range sourceTopics {
+		if isTopicSynchronized(topic) {
+			topics = append(topics, topic)
+		}
+	}
+	sort.Strings(topics)
+	var diffs []topicConfigDiff
+	for _, topic := range topics {
+		targetTopic, found := targetTopics[topic]
+		if !found {
+			diffs = append(diffs, topicConfigDiff{topic: topic, message: "topic does not exist in target cluster"})
+			continue
+		}
+		sourceConfigs := sourceTopics[topic].ConfigEntries
+		configs := make(map[string]*string)
+		var differentConfigs []string
+		for name, value := range sourceConfigs {
+			if slices.Contains(excludedConfigs, name) {
+				continue
+			}
+			configs[name] = value
+			if !isConfigValueEqual(value, targetTopic.ConfigEntries[name]) {
+				differentConfigs = append(differentConfigs, name)
+			}
+		}
+		for name, value := range targetTopic.ConfigEntries {
+			if slices.Contains(excludedConfigs, name) {
+				configs[name] = value
+				continue
+			}
+			if _, found = sourceConfigs[name]; !found {
+				differentConfigs = append(differentConfigs, name)
+			}
+		}
+		if len(differentConfigs) > 0 {
+			sort.Strings(differentConfigs)
+			diffs = append(diffs, topicConfigDiff{
+				topic:   topic,
+				configs: configs,
+				message: fmt.Sprintf("configs differ: %s", strings.Join(differentConfigs, ", ")),
+			})
+		}
+	}
+	return diffs
+}
+
+func isConfigValueEqual(first *string, second *string) bool {
+	if first == nil || second == nil {
+		return first == second
+	}
+	return *first == *second
+}
+
+// diffAcls returns ACLs which exist only in source cluster and ACLs which exist only in target cluster
+func diffAcls(sourceAcls []sarama.ResourceAcls, targetAcls []sarama.ResourceAcls,
+	isAclSynchronized func(sarama.Resource) bool) ([]aclEntry, []aclEntry) {
+	sourceEntries := flattenAcls(sourceAcls, isAclSynchronized)
+	targetEntries := flattenAcls(targetAcls, isAclSynchronized)
+	var missing []aclEntry
+	for _, entry := range sourceEntries {
+		if !slices.Contains(targetEntries, entry) {
+			missing = append(missing, entry)
+		}
+	}
+	var extra []aclEntry
+	for _, entry := range targetEntries {
+		if !slices.Contains(sourceEntries, entry) {
+			extra = append(extra, entry)
+		}
+	}
+	return missing, extra
+}
+
+func flattenAcls(resourceAcls []sarama.ResourceAcls, isAclSynchronized func(sarama.Resource) bool) []aclEntry {
+	var entries []aclEntry
+	for _, resourceAcl := range resourceAcls {
+		if !isAclSynchronized(resourceAcl.Resource) {
+			continue
+		}
+		for _, acl := range resourceAcl.Acls {
+			entries = append(entries, aclEntry{resource: resourceAcl.Resource, acl: *acl})
+		}
+	}
+	return entries
+}
+
+func (e aclEntry) String() string {
+	return fmt.Sprintf("%s:%s:%s %s %s %s from %s",
+		e.resource.ResourceType.String(), e.resource.ResourcePatternType.String(), e.resource.ResourceName,
+		e.acl.Principal, e.acl.PermissionType.String(), e.acl.Operation.String(), e.acl.Host)
+}
+
+// isClusterSyncEnabled checks whether topic configs or ACLs are synchronized between replicated clusters
+func isClusterSyncEnabled(cr *kafkaservice.KafkaService) bool {
+	return cr.Spec.MirrorMaker != nil && cr.Spec.MirrorMaker.ClusterSync != nil &&
+		(cr.Spec.MirrorMaker.ClusterSync.TopicConfigsEnabled || cr.Spec.MirrorMaker.ClusterSync.AclsEnabled)
+}
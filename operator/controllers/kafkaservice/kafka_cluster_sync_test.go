@@ -0,0 +1,135 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaservice
+
+import (
+	"testing"
+
+	"github.com/IBM/sarama"
+	kafkaservice "github.com/Netcracker/qubership-kafka/operator/api/v7"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+)
+
+func TestClusterSync_diffTopicConfigs(t *testing.T) {
+	sourceTopics := map[string]sarama.TopicDetail{
+		"orders": {ConfigEntries: map[string]*string{
+			"retention.ms":   ptr.To("3600000"),
+			"cleanup.policy": ptr.To("compact"),
+		}},
+		"payments": {ConfigEntries: map[string]*string{"retention.ms": ptr.To("1000")}},
+		"audit":    {ConfigEntries: map[string]*string{}},
+		"local":    {ConfigEntries: map[string]*string{"retention.ms": ptr.To("1")}},
+	}
+	targetTopics := map[string]sarama.TopicDetail{
+		"orders": {ConfigEntries: map[string]*string{
+			"retention.ms":                          ptr.To("600000"),
+			"segment.bytes":                         ptr.To("1024"),
+			"leader.replication.throttled.replicas": ptr.To("0:1"),
+		}},
+		"payments": {ConfigEntries: map[string]*string{"retention.ms": ptr.To("1000")}},
+		"local":    {ConfigEntries: map[string]*string{}},
+	}
+	isTopicSynchronized := func(topic string) bool { return topic != "local" }
+
+	diffs := diffTopicConfigs(sourceTopics, targetTopics, isTopicSynchronized, clusterSpecificTopicConfigs)
+	assert.Len(t, diffs, 2)
+	assert.Equal(t, "audit", diffs[0].topic)
+	assert.Nil(t, diffs[0].configs)
+	assert.Equal(t, "orders", diffs[1].topic)
+	assert.Equal(t, "configs differ: cleanup.policy, retention.ms, segment.bytes", diffs[1].message)
+	assert.Equal(t, map[string]*string{
+		"retention.ms":                          ptr.To("3600000"),
+		"cleanup.policy":                        ptr.To("compact"),
+		"leader.replication.throttled.replicas": ptr.To("0:1"),
+	}, diffs[1].configs)
+}
+
+func TestClusterSync_diffAcls(t *testing.T) {
+	ordersTopic := sarama.Resource{
+		ResourceType:        sarama.AclResourceTopic,
+		ResourceName:        "orders",
+		ResourcePatternType: sarama.AclPatternLiteral,
+	}
+	localTopic := sarama.Resource{
+		ResourceType:        sarama.AclResourceTopic,
+		ResourceName:        "local",
+		ResourcePatternType: sarama.AclPatternLiteral,
+	}
+	readAcl := &sarama.Acl{Principal: "User:alice", Host: "*",
+		Operation: sarama.AclOperationRead, PermissionType: sarama.AclPermissionAllow}
+	writeAcl := &sarama.Acl{Principal: "User:bob", Host: "*",
+		Operation: sarama.AclOperationWrite, PermissionType: sarama.AclPermissionAllow}
+	sourceAcls := []sarama.ResourceAcls{
+		{Resource: ordersTopic, Acls: []*sarama.Acl{readAcl, writeAcl}},
+		{Resource: localTopic, Acls: []*sarama.Acl{readAcl}},
+	}
+	targetAcls := []sarama.ResourceAcls{
+		{Resource: ordersTopic, Acls: []*sarama.Acl{readAcl}},
+		{Resource: sarama.Resource{ResourceType: sarama.AclResourceGroup, ResourceName: "group",
+			ResourcePatternType: sarama.AclPatternLiteral}, Acls: []*sarama.Acl{readAcl}},
+	}
+	cs := &KafkaClusterSync{spec: &kafkaservice.ClusterSync{ExcludeTopics: []string{"local"}}}
+
+	missing, extra := diffAcls(sourceAcls, targetAcls, cs.isAclSynchronized)
+	assert.Len(t, missing, 1)
+	assert.Equal(t, "Topic:Literal:orders User:bob Allow Write from *", missing[0].String())
+	assert.Len(t, extra, 1)
+	assert.Equal(t, "group", extra[0].resource.ResourceName)
+}
+
+func TestClusterSync_isTopicSynchronized(t *testing.T) {
+	cs := &KafkaClusterSync{spec: &kafkaservice.ClusterSync{IncludeTopics: []string{}}}
+	assert.True(t, cs.isTopicSynchronized("orders"))
+	assert.False(t, cs.isTopicSynchronized("__consumer_offsets"))
+
+	cs.spec = &kafkaservice.ClusterSync{IncludeTopics: []string{"orders.*"}, ExcludeTopics: []string{"orders-local"}}
+	assert.True(t, cs.isTopicSynchronized("orders-eu"))
+	assert.False(t, cs.isTopicSynchronized("orders-local"))
+	assert.False(t, cs.isTopicSynchronized("payments"))
+}
+
+func TestClusterSync_syncAcls(t *testing.T) {
+	ordersTopic := sarama.Resource{
+		ResourceType:        sarama.AclResourceTopic,
+		ResourceName:        "orders",
+		ResourcePatternType: sarama.AclPatternLiteral,
+	}
+	readAcl := &sarama.Acl{Principal: "User:alice", Host: "*",
+		Operation: sarama.AclOperationRead, PermissionType: sarama.AclPermissionAllow}
+	writeAcl := &sarama.Acl{Principal: "User:bob", Host: "*",
+		Operation: sarama.AclOperationWrite, PermissionType: sarama.AclPermissionAllow}
+	source := &fakeClusterAdmin{acls: []sarama.ResourceAcls{{Resource: ordersTopic, Acls: []*sarama.Acl{readAcl}}}}
+	target := &fakeClusterAdmin{acls: []sarama.ResourceAcls{{Resource: ordersTopic, Acls: []*sarama.Acl{writeAcl}}}}
+	cs := &KafkaClusterSync{spec: &kafkaservice.ClusterSync{}}
+
+	divergences, err := cs.syncAcls(source, target, true, false)
+	assert.NoError(t, err)
+	assert.Equal(t, []kafkaservice.ClusterDivergence{{
+		Kind:     divergenceKindAcl,
+		Resource: "Topic:Literal:orders User:alice Allow Read from *",
+		Message:  "ACL does not exist in target cluster",
+	}}, divergences)
+	assert.Len(t, target.acls, 1)
+
+	divergences, err = cs.syncAcls(source, target, false, true)
+	assert.NoError(t, err)
+	assert.Equal(t, []kafkaservice.ClusterDivergence{{
+		Kind:     divergenceKindAcl,
+		Resource: "Topic:Literal:orders User:bob Allow Write from *",
+		Message:  "ACL exists only in target cluster, it is not removed",
+	}}, divergences)
+	assert.Len(t, target.acls, 2)
+}
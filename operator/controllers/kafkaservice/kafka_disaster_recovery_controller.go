@@ -0,0 +1,315 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaservice
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	kafkav1 "github.com/Netcracker/qubership-kafka/operator/api/v1"
+	kafkaservice "github.com/Netcracker/qubership-kafka/operator/api/v7"
+	"github.com/Netcracker/qubership-kafka/operator/controllers"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	switchoverStatusRunning      = "running"
+	switchoverStatusDone         = "done"
+	switchoverStatusFailed       = "failed"
+	defaultSwitchoverHistorySize = 10
+)
+
+var drLog = logf.Log.WithName("controller_kafka_disaster_recovery")
+
+var errMultipleDisasterRecoveries = stderrors.New("several KafkaDisasterRecovery resources refer to the same KafkaService")
+
+// KafkaDisasterRecoveryReconciler reconciles a KafkaDisasterRecovery object
+type KafkaDisasterRecoveryReconciler struct {
+	controllers.Reconciler
+}
+
+//+kubebuilder:rbac:groups=netcracker.com,resources=kafkadisasterrecoveries,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=netcracker.com,resources=kafkadisasterrecoveries/status,verbs=get;update;patch
+
+func (r *KafkaDisasterRecoveryReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	reqLogger := drLog.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	reqLogger.Info("Reconciling KafkaDisasterRecovery")
+
+	instance := &kafkav1.KafkaDisasterRecovery{}
+	if err := r.Client.Get(ctx, request.NamespacedName, instance); err != nil {
+		if errors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	statusUpdater := NewDisasterRecoveryStatusUpdater(r.Client, instance)
+
+	kafkaService := &kafkaservice.KafkaService{}
+	err := r.Client.Get(ctx,
+		types.NamespacedName{Name: instance.Spec.KafkaServiceName, Namespace: instance.Namespace}, kafkaService)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			message := fmt.Sprintf("KafkaService [%s] is not found", instance.Spec.KafkaServiceName)
+			reqLogger.Info(message)
+			return reconcile.Result{}, r.updateSwitchoverStatus(statusUpdater, instance, switchoverStatusFailed, message)
+		}
+		return reconcile.Result{}, err
+	}
+	if kafkaService.Spec.DisasterRecovery == nil {
+		message := fmt.Sprintf("KafkaService [%s] does not have disaster recovery configuration", kafkaService.Name)
+		reqLogger.Info(message)
+		return reconcile.Result{}, r.updateSwitchoverStatus(statusUpdater, instance, switchoverStatusFailed, message)
+	}
+
+	if _, err = findKafkaDisasterRecovery(r.Client, kafkaService); err != nil {
+		if stderrors.Is(err, errMultipleDisasterRecoveries) {
+			reqLogger.Info(err.Error())
+			return reconcile.Result{}, r.updateSwitchoverStatus(statusUpdater, instance, switchoverStatusFailed, err.Error())
+		}
+		return reconcile.Result{}, err
+	}
+
+	if instance.Status.Mode == "" {
+		// take over the mode which was previously set by KafkaService
+		instance.Status.Mode = kafkaService.Status.DisasterRecoveryStatus.Mode
+	}
+	if !isSwitchoverNeeded(instance) {
+		reqLogger.Info("Disaster recovery mode didn't change, skipping switchover")
+		return reconcile.Result{}, nil
+	}
+
+	cr := kafkaService.DeepCopy()
+	applyDisasterRecoveryResource(cr, instance)
+	serviceReconciler := &KafkaServiceReconciler{
+		Reconciler:    r.Reconciler,
+		StatusUpdater: NewStatusUpdater(r.Client, kafkaService),
+	}
+	switchover, message, err := serviceReconciler.startSwitchover(cr, instance)
+	if err == nil {
+		err = serviceReconciler.switchover(cr, instance, reqLogger)
+	}
+	if err != nil {
+		reqLogger.Error(err, "Switchover failed")
+	}
+	if updateErr := serviceReconciler.completeSwitchover(instance, switchover, message, err); updateErr != nil {
+		return reconcile.Result{}, updateErr
+	}
+	return reconcile.Result{}, err
+}
+
+// startSwitchover marks switchover of KafkaDisasterRecovery resource as running and performs replication check
+// for its mode
+func (r *KafkaServiceReconciler) startSwitchover(cr *kafkaservice.KafkaService,
+	dr *kafkav1.KafkaDisasterRecovery) (kafkav1.KafkaDisasterRecoverySwitchover, string, error) {
+	switchover := kafkav1.KafkaDisasterRecoverySwitchover{
+		FromMode:  dr.Status.Mode,
+		ToMode:    dr.Spec.Mode,
+		NoWait:    dr.Spec.NoWait,
+		Status:    switchoverStatusRunning,
+		StartTime: metav1.Now().String(),
+	}
+	if err := NewDisasterRecoveryStatusUpdater(r.Client, dr).UpdateStatusWithRetry(func(instance *kafkav1.KafkaDisasterRecovery) {
+		instance.Status.Status = switchoverStatusRunning
+		instance.Status.Message = "The switchover process for Kafka has been started"
+	}); err != nil {
+		return switchover, "", err
+	}
+
+	checkedCR := cr.DeepCopy()
+	checkedCR.Status.DisasterRecoveryStatus.Mode = dr.Status.Mode
+	checkedCR.Status.DisasterRecoveryStatus.Status = dr.Status.Status
+	if !isCheckNeeded(checkedCR) {
+		return switchover, "Switchover mode has been changed without replication check", nil
+	}
+	replicationAuditor := NewKafkaReplicationAuditor(checkedCR, r)
+	checkCompleted, err := replicationAuditor.CheckFullReplication(
+		secondsOrDefault(dr.Spec.ReplicationCheckTimeoutSeconds, replicationCheckTimeout))
+	if !checkCompleted {
+		return switchover, "", fmt.Errorf("timeout occurred during replication check")
+	}
+	if err != nil {
+		return switchover, "", err
+	}
+	return switchover, "replication has finished successfully", nil
+}
+
+// switchover restarts Kafka Mirror Maker and runs Backup Daemon steps for the mode specified
+// in KafkaDisasterRecovery resource
+func (r *KafkaServiceReconciler) switchover(cr *kafkaservice.KafkaService,
+	dr *kafkav1.KafkaDisasterRecovery, logger logr.Logger) error {
+	if cr.Spec.MirrorMaker != nil {
+		if err := NewReconcileMirrorMaker(r, cr, logger, true).Reconcile(); err != nil {
+			return err
+		}
+	}
+	if cr.Spec.MirrorMakerMonitoring != nil {
+		if err := NewReconcileMirrorMakerMonitoring(r, cr, logger, true).Reconcile(); err != nil {
+			return err
+		}
+	}
+	if cr.Spec.BackupDaemon == nil || !cr.Spec.DisasterRecovery.TopicsBackup.Enabled ||
+		dr.Status.Mode == "" && dr.Status.Status != switchoverStatusFailed {
+		return nil
+	}
+	return NewReconcileBackupDaemon(r, cr, logger, true).switchover(dr.Status.Mode,
+		secondsOrDefault(dr.Spec.BackupTimeoutSeconds, backupRestoreTimeout))
+}
+
+// completeSwitchover writes result of switchover to KafkaDisasterRecovery resource and KafkaService statuses
+func (r *KafkaServiceReconciler) completeSwitchover(dr *kafkav1.KafkaDisasterRecovery,
+	switchover kafkav1.KafkaDisasterRecoverySwitchover, message string, err error) error {
+	switchover.Status = switchoverStatusDone
+	if err != nil {
+		switchover.Status = switchoverStatusFailed
+		message = fmt.Sprintf("Error is occurred during switching: %v", err)
+	}
+	switchover.Message = message
+	switchover.CompletionTime = metav1.Now().String()
+
+	if updateErr := NewDisasterRecoveryStatusUpdater(r.Client, dr).UpdateStatusWithRetry(func(instance *kafkav1.KafkaDisasterRecovery) {
+		instance.Status.Mode = dr.Spec.Mode
+		instance.Status.Status = switchover.Status
+		instance.Status.Message = message
+		instance.Status.ObservedGeneration = dr.Generation
+		instance.Status.History = appendSwitchover(instance.Status.History, switchover, historySize(dr))
+	}); updateErr != nil {
+		return updateErr
+	}
+	return r.StatusUpdater.UpdateStatusWithRetry(func(instance *kafkaservice.KafkaService) {
+		instance.Status.DisasterRecoveryStatus.Mode = dr.Spec.Mode
+		instance.Status.DisasterRecoveryStatus.Status = switchover.Status
+		instance.Status.DisasterRecoveryStatus.Message = message
+	})
+}
+
+func (r *KafkaDisasterRecoveryReconciler) updateSwitchoverStatus(statusUpdater DisasterRecoveryStatusUpdater,
+	instance *kafkav1.KafkaDisasterRecovery, status string, message string) error {
+	return statusUpdater.UpdateStatusWithRetry(func(dr *kafkav1.KafkaDisasterRecovery) {
+		dr.Status.Status = status
+		dr.Status.Message = message
+		dr.Status.ObservedGeneration = instance.Generation
+	})
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *KafkaDisasterRecoveryReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	statusPredicate := predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			// Ignore updates to CR status in which case metadata.Generation does not change
+			return e.ObjectOld.GetGeneration() != e.ObjectNew.GetGeneration()
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return false
+		},
+	}
+	namespacePredicate := predicate.NewPredicateFuncs(func(object client.Object) bool {
+		return object.GetNamespace() == os.Getenv("OPERATOR_NAMESPACE")
+	})
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&kafkav1.KafkaDisasterRecovery{}, builder.WithPredicates(statusPredicate, namespacePredicate)).
+		Complete(r)
+}
+
+// findKafkaDisasterRecovery returns KafkaDisasterRecovery resource which manages switchover of specified KafkaService.
+// Several resources for the same KafkaService are rejected with errMultipleDisasterRecoveries.
+func findKafkaDisasterRecovery(c client.Client, cr *kafkaservice.KafkaService) (*kafkav1.KafkaDisasterRecovery, error) {
+	drList := &kafkav1.KafkaDisasterRecoveryList{}
+	if err := c.List(context.TODO(), drList, client.InNamespace(cr.Namespace)); err != nil {
+		return nil, err
+	}
+	var found []kafkav1.KafkaDisasterRecovery
+	for _, dr := range drList.Items {
+		if dr.Spec.KafkaServiceName == cr.Name {
+			found = append(found, dr)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return nil, nil
+	case 1:
+		return &found[0], nil
+	}
+	names := make([]string, 0, len(found))
+	for _, dr := range found {
+		names = append(names, dr.Name)
+	}
+	sort.Strings(names)
+	return nil, fmt.Errorf("%w: KafkaService [%s] is managed by %s", errMultipleDisasterRecoveries,
+		cr.Name, strings.Join(names, ", "))
+}
+
+// applyDisasterRecoveryResource overrides disaster recovery mode of KafkaService with the one from KafkaDisasterRecovery
+func applyDisasterRecoveryResource(cr *kafkaservice.KafkaService, dr *kafkav1.KafkaDisasterRecovery) {
+	cr.Spec.DisasterRecovery.Mode = dr.Spec.Mode
+	cr.Spec.DisasterRecovery.NoWait = dr.Spec.NoWait
+}
+
+// applySwitchedMode overrides disaster recovery mode of KafkaService with the mode of the last switchover
+// performed by KafkaDisasterRecovery controller, so KafkaService reconciliation does not switch Kafka Mirror Maker
+// before replication check
+func applySwitchedMode(cr *kafkaservice.KafkaService, dr *kafkav1.KafkaDisasterRecovery) {
+	mode := dr.Status.Mode
+	if mode == "" {
+		mode = cr.Status.DisasterRecoveryStatus.Mode
+	}
+	if mode != "" {
+		cr.Spec.DisasterRecovery.Mode = mode
+	}
+	cr.Spec.DisasterRecovery.NoWait = dr.Spec.NoWait
+}
+
+func isSwitchoverNeeded(dr *kafkav1.KafkaDisasterRecovery) bool {
+	return dr.Status.Mode != dr.Spec.Mode ||
+		dr.Status.Status == switchoverStatusRunning ||
+		dr.Status.Status == switchoverStatusFailed
+}
+
+func appendSwitchover(history []kafkav1.KafkaDisasterRecoverySwitchover,
+	switchover kafkav1.KafkaDisasterRecoverySwitchover, limit int) []kafkav1.KafkaDisasterRecoverySwitchover {
+	history = append(history, switchover)
+	if len(history) > limit {
+		history = history[len(history)-limit:]
+	}
+	return history
+}
+
+func historySize(dr *kafkav1.KafkaDisasterRecovery) int {
+	if dr.Spec.HistoryLimit != nil && *dr.Spec.HistoryLimit > 0 {
+		return int(*dr.Spec.HistoryLimit)
+	}
+	return defaultSwitchoverHistorySize
+}
+
+func secondsOrDefault(seconds *int32, defaultValue time.Duration) time.Duration {
+	if seconds != nil && *seconds > 0 {
+		return time.Duration(*seconds) * time.Second
+	}
+	return defaultValue
+}
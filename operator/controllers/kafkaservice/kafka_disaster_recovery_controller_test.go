@@ -0,0 +1,196 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	kafkav1 "github.com/Netcracker/qubership-kafka/operator/api/v1"
+	kafkaservice "github.com/Netcracker/qubership-kafka/operator/api/v7"
+	"github.com/Netcracker/qubership-kafka/operator/controllers"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestDisasterRecovery_isSwitchoverNeeded(t *testing.T) {
+	tests := []struct {
+		specMode       string
+		statusMode     string
+		status         string
+		expectedResult bool
+	}{
+		{"active", "", "", true},
+		{"active", "standby", "done", true},
+		{"active", "active", "done", false},
+		{"active", "active", "failed", true},
+		{"standby", "standby", "running", true},
+	}
+	for _, test := range tests {
+		dr := &kafkav1.KafkaDisasterRecovery{
+			Spec:   kafkav1.KafkaDisasterRecoverySpec{Mode: test.specMode},
+			Status: kafkav1.KafkaDisasterRecoveryStatus{Mode: test.statusMode, Status: test.status},
+		}
+		assert.Equal(t, test.expectedResult, isSwitchoverNeeded(dr),
+			"spec mode is %s, status mode is %s, status is %s", test.specMode, test.statusMode, test.status)
+	}
+}
+
+func TestDisasterRecovery_appendSwitchover(t *testing.T) {
+	var history []kafkav1.KafkaDisasterRecoverySwitchover
+	for _, mode := range []string{"active", "standby", "active", "disable"} {
+		history = appendSwitchover(history, kafkav1.KafkaDisasterRecoverySwitchover{ToMode: mode}, 3)
+	}
+	assert.Len(t, history, 3)
+	assert.Equal(t, "standby", history[0].ToMode)
+	assert.Equal(t, "disable", history[2].ToMode)
+}
+
+func TestDisasterRecovery_applyDisasterRecoveryResource(t *testing.T) {
+	cr := &kafkaservice.KafkaService{
+		Spec: kafkaservice.KafkaServiceSpec{
+			DisasterRecovery: &kafkaservice.DisasterRecovery{Mode: "standby", Region: "dc1"},
+		},
+	}
+	dr := &kafkav1.KafkaDisasterRecovery{Spec: kafkav1.KafkaDisasterRecoverySpec{Mode: "active", NoWait: true}}
+	applyDisasterRecoveryResource(cr, dr)
+	assert.Equal(t, "active", cr.Spec.DisasterRecovery.Mode)
+	assert.True(t, cr.Spec.DisasterRecovery.NoWait)
+	assert.Equal(t, "dc1", cr.Spec.DisasterRecovery.Region)
+}
+
+func TestDisasterRecovery_applySwitchedMode(t *testing.T) {
+	cr := &kafkaservice.KafkaService{
+		Spec: kafkaservice.KafkaServiceSpec{
+			DisasterRecovery: &kafkaservice.DisasterRecovery{Mode: "standby"},
+		},
+		Status: kafkaservice.KafkaServiceStatus{
+			DisasterRecoveryStatus: kafkaservice.DisasterRecoveryStatus{Mode: "disable"},
+		},
+	}
+	dr := &kafkav1.KafkaDisasterRecovery{Spec: kafkav1.KafkaDisasterRecoverySpec{Mode: "active"}}
+	applySwitchedMode(cr, dr)
+	assert.Equal(t, "disable", cr.Spec.DisasterRecovery.Mode)
+
+	dr.Status.Mode = "standby"
+	applySwitchedMode(cr, dr)
+	assert.Equal(t, "standby", cr.Spec.DisasterRecovery.Mode)
+}
+
+func newDisasterRecoveryReconciler(objects ...client.Object) *KafkaServiceReconciler {
+	scheme := runtime.NewScheme()
+	_ = kafkav1.AddToScheme(scheme)
+	_ = kafkaservice.AddToScheme(scheme)
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).
+		WithStatusSubresource(&kafkav1.KafkaDisasterRecovery{}, &kafkaservice.KafkaService{}).Build()
+	return &KafkaServiceReconciler{Reconciler: controllers.Reconciler{Client: fakeClient, Scheme: scheme}}
+}
+
+func newSwitchoverResources() (*kafkaservice.KafkaService, *kafkav1.KafkaDisasterRecovery) {
+	cr := &kafkaservice.KafkaService{
+		ObjectMeta: metav1.ObjectMeta{Name: "kafka-services", Namespace: "kafka"},
+		Spec: kafkaservice.KafkaServiceSpec{
+			DisasterRecovery: &kafkaservice.DisasterRecovery{Mode: "standby"},
+		},
+	}
+	dr := &kafkav1.KafkaDisasterRecovery{
+		ObjectMeta: metav1.ObjectMeta{Name: "kafka-disaster-recovery", Namespace: "kafka", Generation: 2},
+		Spec:       kafkav1.KafkaDisasterRecoverySpec{KafkaServiceName: "kafka-services", Mode: "active"},
+		Status:     kafkav1.KafkaDisasterRecoveryStatus{Mode: "standby", Status: switchoverStatusDone},
+	}
+	return cr, dr
+}
+
+func TestDisasterRecovery_findKafkaDisasterRecovery(t *testing.T) {
+	cr, dr := newSwitchoverResources()
+	other := &kafkav1.KafkaDisasterRecovery{
+		ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "kafka"},
+		Spec:       kafkav1.KafkaDisasterRecoverySpec{KafkaServiceName: "other-services"},
+	}
+	r := newDisasterRecoveryReconciler(dr, other)
+	found, err := findKafkaDisasterRecovery(r.Client, cr)
+	assert.NoError(t, err)
+	assert.Equal(t, "kafka-disaster-recovery", found.Name)
+
+	duplicate := dr.DeepCopy()
+	duplicate.Name = "duplicate"
+	duplicate.ResourceVersion = ""
+	r = newDisasterRecoveryReconciler(dr, other, duplicate)
+	found, err = findKafkaDisasterRecovery(r.Client, cr)
+	assert.ErrorIs(t, err, errMultipleDisasterRecoveries)
+	assert.Contains(t, err.Error(), "duplicate, kafka-disaster-recovery")
+	assert.Nil(t, found)
+}
+
+func TestDisasterRecovery_startSwitchover(t *testing.T) {
+	cr, dr := newSwitchoverResources()
+	r := newDisasterRecoveryReconciler(cr, dr)
+
+	switchover, message, err := r.startSwitchover(cr, dr)
+	assert.NoError(t, err)
+	assert.Equal(t, "Switchover mode has been changed without replication check", message)
+	assert.Equal(t, "standby", switchover.FromMode)
+	assert.Equal(t, "active", switchover.ToMode)
+	assert.Equal(t, switchoverStatusRunning, switchover.Status)
+
+	actual := &kafkav1.KafkaDisasterRecovery{}
+	assert.NoError(t, r.Client.Get(context.Background(),
+		types.NamespacedName{Name: dr.Name, Namespace: dr.Namespace}, actual))
+	assert.Equal(t, switchoverStatusRunning, actual.Status.Status)
+	assert.Equal(t, "standby", actual.Status.Mode)
+}
+
+func TestDisasterRecovery_completeSwitchover(t *testing.T) {
+	cr, dr := newSwitchoverResources()
+	r := newDisasterRecoveryReconciler(cr, dr)
+	r.StatusUpdater = NewStatusUpdater(r.Client, cr)
+	switchover := kafkav1.KafkaDisasterRecoverySwitchover{FromMode: "standby", ToMode: "active"}
+
+	assert.NoError(t, r.completeSwitchover(dr, switchover, "", errors.New("mirror maker is not available")))
+	actual := &kafkav1.KafkaDisasterRecovery{}
+	key := types.NamespacedName{Name: dr.Name, Namespace: dr.Namespace}
+	assert.NoError(t, r.Client.Get(context.Background(), key, actual))
+	assert.Equal(t, "active", actual.Status.Mode)
+	assert.Equal(t, switchoverStatusFailed, actual.Status.Status)
+	assert.Equal(t, "Error is occurred during switching: mirror maker is not available", actual.Status.Message)
+	assert.Equal(t, int64(2), actual.Status.ObservedGeneration)
+	assert.Len(t, actual.Status.History, 1)
+	assert.Equal(t, switchoverStatusFailed, actual.Status.History[0].Status)
+
+	assert.NoError(t, r.completeSwitchover(dr, switchover, "replication has finished successfully", nil))
+	assert.NoError(t, r.Client.Get(context.Background(), key, actual))
+	assert.Equal(t, switchoverStatusDone, actual.Status.Status)
+	assert.Len(t, actual.Status.History, 2)
+	assert.Equal(t, "replication has finished successfully", actual.Status.History[1].Message)
+
+	service := &kafkaservice.KafkaService{}
+	assert.NoError(t, r.Client.Get(context.Background(),
+		types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace}, service))
+	assert.Equal(t, "active", service.Status.DisasterRecoveryStatus.Mode)
+	assert.Equal(t, switchoverStatusDone, service.Status.DisasterRecoveryStatus.Status)
+}
+
+func TestDisasterRecovery_secondsOrDefault(t *testing.T) {
+	assert.Equal(t, replicationCheckTimeout, secondsOrDefault(nil, replicationCheckTimeout))
+	assert.Equal(t, replicationCheckTimeout, secondsOrDefault(ptr.To[int32](0), replicationCheckTimeout))
+	assert.Equal(t, 30*time.Second, secondsOrDefault(ptr.To[int32](30), replicationCheckTimeout))
+}
@@ -0,0 +1,569 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaservice
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/Netcracker/qubership-kafka/operator/controllers"
+)
+
+// MetadataArchiveVersion is the version of metadata archive format produced by ExportKafkaMetadata
+const MetadataArchiveVersion = 1
+
+const (
+	metadataChangeKindTopic           = "topic"
+	metadataChangeKindTopicPartitions = "topicPartitions"
+	metadataChangeKindTopicConfig     = "topicConfig"
+	metadataChangeKindAcl             = "acl"
+	metadataChangeKindScramUser       = "scramUser"
+	metadataChangeKindGroupOffsets    = "consumerGroupOffsets"
+)
+
+var metadataLogger = log.WithName("Kafka metadata backup")
+
+// MetadataArchive contains metadata of Kafka cluster which is required to rebuild it
+type MetadataArchive struct {
+	Version        int                     `json:"version"`
+	CreatedAt      string                  `json:"createdAt"`
+	Topics         []TopicMetadata         `json:"topics"`
+	Acls           []AclMetadata           `json:"acls"`
+	ScramUsers     []ScramUserMetadata     `json:"scramUsers"`
+	ConsumerGroups []ConsumerGroupMetadata `json:"consumerGroups"`
+}
+
+type TopicMetadata struct {
+	Name              string            `json:"name"`
+	Partitions        int32             `json:"partitions"`
+	ReplicationFactor int16             `json:"replicationFactor"`
+	Configs           map[string]string `json:"configs,omitempty"`
+}
+
+type AclMetadata struct {
+	ResourceType   string `json:"resourceType"`
+	PatternType    string `json:"patternType"`
+	ResourceName   string `json:"resourceName"`
+	Principal      string `json:"principal"`
+	Host           string `json:"host"`
+	Operation      string `json:"operation"`
+	PermissionType string `json:"permissionType"`
+}
+
+// ScramUserMetadata describes SCRAM credentials of user, passwords are not available through Kafka API
+// and are not stored
+type ScramUserMetadata struct {
+	Name        string                    `json:"name"`
+	Credentials []ScramCredentialMetadata `json:"credentials"`
+}
+
+type ScramCredentialMetadata struct {
+	Mechanism  string `json:"mechanism"`
+	Iterations int32  `json:"iterations"`
+}
+
+type ConsumerGroupMetadata struct {
+	Group   string                    `json:"group"`
+	Offsets []PartitionOffsetMetadata `json:"offsets"`
+}
+
+type PartitionOffsetMetadata struct {
+	Topic     string `json:"topic"`
+	Partition int32  `json:"partition"`
+	Offset    int64  `json:"offset"`
+	Metadata  string `json:"metadata,omitempty"`
+}
+
+// MetadataChange describes the difference between metadata archive and Kafka cluster
+type MetadataChange struct {
+	Kind     string `json:"kind"`
+	Resource string `json:"resource"`
+	Message  string `json:"message"`
+	Applied  bool   `json:"applied"`
+}
+
+// ExportKafkaMetadata reads topics with configs and partitions count, ACLs, SCRAM users
+// and committed offsets of consumer groups from Kafka cluster. Internal topics are skipped.
+func ExportKafkaMetadata(admin sarama.ClusterAdmin, now time.Time) (*MetadataArchive, error) {
+	archive := &MetadataArchive{Version: MetadataArchiveVersion, CreatedAt: now.UTC().Format(time.RFC3339)}
+	topics, err := admin.ListTopics()
+	if err != nil {
+		return nil, err
+	}
+	archive.Topics = exportTopics(topics)
+	resourceAcls, err := listAcls(admin)
+	if err != nil {
+		return nil, err
+	}
+	archive.Acls = exportAcls(resourceAcls)
+	users, err := admin.DescribeUserScramCredentials(nil)
+	if err != nil {
+		return nil, err
+	}
+	archive.ScramUsers, err = exportScramUsers(users)
+	if err != nil {
+		return nil, err
+	}
+	groups, err := admin.ListConsumerGroups()
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range sortedKeys(groups) {
+		response, err := admin.ListConsumerGroupOffsets(group, nil)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get offsets of consumer group %s: %w", group, err)
+		}
+		offsets := exportGroupOffsets(offsetFetchBlocks(response), topics)
+		if len(offsets) > 0 {
+			archive.ConsumerGroups = append(archive.ConsumerGroups, ConsumerGroupMetadata{Group: group, Offsets: offsets})
+		}
+	}
+	return archive, nil
+}
+
+// MarshalMetadataArchive encodes metadata archive to JSON
+func MarshalMetadataArchive(archive *MetadataArchive) ([]byte, error) {
+	return json.MarshalIndent(archive, "", "  ")
+}
+
+// UnmarshalMetadataArchive decodes metadata archive from JSON and checks that its version is supported
+func UnmarshalMetadataArchive(data []byte) (*MetadataArchive, error) {
+	var archive MetadataArchive
+	if err := json.Unmarshal(data, &archive); err != nil {
+		return nil, err
+	}
+	if archive.Version < 1 || archive.Version > MetadataArchiveVersion {
+		return nil, fmt.Errorf("metadata archive version %d is not supported, supported versions are up to %d",
+			archive.Version, MetadataArchiveVersion)
+	}
+	return &archive, nil
+}
+
+// RestoreKafkaMetadata compares metadata archive with Kafka cluster and applies missing metadata if dry run
+// is disabled. Restore only creates or updates resources, so it can be repeated safely. Partitions count
+// is never decreased, topic configs and ACLs which do not exist in archive are kept, and offsets of consumer groups with active
+// members are not changed. SCRAM credentials cannot be restored because archive does not contain passwords.
+func RestoreKafkaMetadata(admin sarama.ClusterAdmin, archive *MetadataArchive, dryRun bool) ([]MetadataChange, error) {
+	var changes []MetadataChange
+	topicChanges, err := restoreTopics(admin, archive.Topics, dryRun)
+	if err != nil {
+		return nil, err
+	}
+	changes = append(changes, topicChanges...)
+	aclChanges, err := restoreAcls(admin, archive.Acls, dryRun)
+	if err != nil {
+		return nil, err
+	}
+	changes = append(changes, aclChanges...)
+	userChanges, err := checkScramUsers(admin, archive.ScramUsers)
+	if err != nil {
+		return nil, err
+	}
+	changes = append(changes, userChanges...)
+	groupChanges, err := restoreGroupOffsets(admin, archive.ConsumerGroups, dryRun)
+	if err != nil {
+		return nil, err
+	}
+	changes = append(changes, groupChanges...)
+	return changes, nil
+}
+
+func exportTopics(topics map[string]sarama.TopicDetail) []TopicMetadata {
+	var result []TopicMetadata
+	for _, name := range sortedKeys(topics) {
+		if isInternalTopic(name) {
+			continue
+		}
+		detail := topics[name]
+		topic := TopicMetadata{
+			Name:              name,
+			Partitions:        detail.NumPartitions,
+			ReplicationFactor: detail.ReplicationFactor,
+		}
+		for config, value := range detail.ConfigEntries {
+			if value == nil || slices.Contains(clusterSpecificTopicConfigs, config) {
+				continue
+			}
+			if topic.Configs == nil {
+				topic.Configs = make(map[string]string)
+			}
+			topic.Configs[config] = *value
+		}
+		result = append(result, topic)
+	}
+	return result
+}
+
+func exportAcls(resourceAcls []sarama.ResourceAcls) []AclMetadata {
+	var result []AclMetadata
+	for _, entry := range flattenAcls(resourceAcls, func(sarama.Resource) bool { return true }) {
+		result = append(result, AclMetadata{
+			ResourceType:   entry.resource.ResourceType.String(),
+			PatternType:    entry.resource.ResourcePatternType.String(),
+			ResourceName:   entry.resource.ResourceName,
+			Principal:      entry.acl.Principal,
+			Host:           entry.acl.Host,
+			Operation:      entry.acl.Operation.String(),
+			PermissionType: entry.acl.PermissionType.String(),
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].String() < result[j].String()
+	})
+	return result
+}
+
+func exportScramUsers(users []*sarama.DescribeUserScramCredentialsResult) ([]ScramUserMetadata, error) {
+	var result []ScramUserMetadata
+	for _, user := range users {
+		if user.ErrorCode != sarama.ErrNoError {
+			return nil, fmt.Errorf("cannot describe SCRAM credentials of user %s: %w", user.User, user.ErrorCode)
+		}
+		scramUser := ScramUserMetadata{Name: user.User}
+		for _, info := range user.CredentialInfos {
+			scramUser.Credentials = append(scramUser.Credentials, ScramCredentialMetadata{
+				Mechanism:  info.Mechanism.String(),
+				Iterations: info.Iterations,
+			})
+		}
+		result = append(result, scramUser)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
+
+// exportGroupOffsets returns committed offsets of consumer group for topics which are exported
+func exportGroupOffsets(blocks map[string]map[int32]*sarama.OffsetFetchResponseBlock,
+	topics map[string]sarama.TopicDetail) []PartitionOffsetMetadata {
+	var result []PartitionOffsetMetadata
+	for _, topic := range sortedKeys(blocks) {
+		if _, found := topics[topic]; !found || isInternalTopic(topic) {
+			continue
+		}
+		for partition, block := range blocks[topic] {
+			if block == nil || block.Err != sarama.ErrNoError || block.Offset < 0 {
+				continue
+			}
+			result = append(result, PartitionOffsetMetadata{
+				Topic:     topic,
+				Partition: partition,
+				Offset:    block.Offset,
+				Metadata:  block.Metadata,
+			})
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Topic != result[j].Topic {
+			return result[i].Topic < result[j].Topic
+		}
+		return result[i].Partition < result[j].Partition
+	})
+	return result
+}
+
+func restoreTopics(admin sarama.ClusterAdmin, topics []TopicMetadata, dryRun bool) ([]MetadataChange, error) {
+	clusterTopics, err := admin.ListTopics()
+	if err != nil {
+		return nil, err
+	}
+	var changes []MetadataChange
+	for _, topic := range topics {
+		clusterTopic, found := clusterTopics[topic.Name]
+		if !found {
+			change := MetadataChange{
+				Kind:     metadataChangeKindTopic,
+				Resource: topic.Name,
+				Message:  "topic does not exist",
+			}
+			if !dryRun {
+				metadataLogger.Info(fmt.Sprintf("Creating topic %s", topic.Name))
+				change.apply(admin.CreateTopic(topic.Name, topic.detail(), false), "creation")
+			}
+			changes = append(changes, change)
+			continue
+		}
+		if change, found := diffTopicPartitions(topic, clusterTopic); found {
+			if !dryRun && topic.Partitions > clusterTopic.NumPartitions {
+				metadataLogger.Info(fmt.Sprintf("Increasing partitions count of topic %s to %d", topic.Name, topic.Partitions))
+				change.apply(admin.CreatePartitions(topic.Name, topic.Partitions, nil, false), "update")
+			}
+			changes = append(changes, change)
+		}
+		entries, message := diffTopicConfigEntries(topic.Configs, clusterTopic.ConfigEntries)
+		if len(entries) > 0 {
+			change := MetadataChange{Kind: metadataChangeKindTopicConfig, Resource: topic.Name, Message: message}
+			if !dryRun {
+				metadataLogger.Info(fmt.Sprintf("Updating configs of topic %s: %s", topic.Name, message))
+				change.apply(admin.IncrementalAlterConfig(sarama.TopicResource, topic.Name, entries, false), "update")
+			}
+			changes = append(changes, change)
+		}
+	}
+	return changes, nil
+}
+
+func diffTopicPartitions(topic TopicMetadata, clusterTopic sarama.TopicDetail) (MetadataChange, bool) {
+	change := MetadataChange{Kind: metadataChangeKindTopicPartitions, Resource: topic.Name}
+	switch {
+	case topic.Partitions > clusterTopic.NumPartitions:
+		change.Message = fmt.Sprintf("topic has %d partitions instead of %d", clusterTopic.NumPartitions, topic.Partitions)
+	case topic.Partitions < clusterTopic.NumPartitions:
+		change.Message = fmt.Sprintf("topic has %d partitions instead of %d, partitions count cannot be decreased",
+			clusterTopic.NumPartitions, topic.Partitions)
+	default:
+		return change, false
+	}
+	return change, true
+}
+
+// diffTopicConfigEntries returns config entries which set archived values of topic configs,
+// configs which are not archived are kept
+func diffTopicConfigEntries(archived map[string]string,
+	current map[string]*string) (map[string]sarama.IncrementalAlterConfigsEntry, string) {
+	entries := make(map[string]sarama.IncrementalAlterConfigsEntry)
+	for name, value := range archived {
+		if !isConfigValueEqual(&value, current[name]) {
+			entries[name] = sarama.IncrementalAlterConfigsEntry{
+				Operation: sarama.IncrementalAlterConfigsOperationSet,
+				Value:     &value,
+			}
+		}
+	}
+	return entries, fmt.Sprintf("configs differ: %s", strings.Join(sortedKeys(entries), ", "))
+}
+
+func restoreAcls(admin sarama.ClusterAdmin, acls []AclMetadata, dryRun bool) ([]MetadataChange, error) {
+	archived := make([]sarama.ResourceAcls, 0, len(acls))
+	for _, acl := range acls {
+		resourceAcl, err := acl.resourceAcls()
+		if err != nil {
+			return nil, err
+		}
+		archived = append(archived, resourceAcl)
+	}
+	clusterAcls, err := listAcls(admin)
+	if err != nil {
+		return nil, err
+	}
+	missing, _ := diffAcls(archived, clusterAcls, func(sarama.Resource) bool { return true })
+	var changes []MetadataChange
+	for _, entry := range missing {
+		change := MetadataChange{Kind: metadataChangeKindAcl, Resource: entry.String(), Message: "ACL does not exist"}
+		if !dryRun {
+			metadataLogger.Info(fmt.Sprintf("Creating ACL %s", entry))
+			acl := entry.acl
+			change.apply(admin.CreateACLs([]*sarama.ResourceAcls{{Resource: entry.resource, Acls: []*sarama.Acl{&acl}}}),
+				"creation")
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+// checkScramUsers reports SCRAM credentials which are missing in Kafka cluster. Such credentials
+// are recreated by KafkaUser resources or must be set manually.
+func checkScramUsers(admin sarama.ClusterAdmin, users []ScramUserMetadata) ([]MetadataChange, error) {
+	if len(users) == 0 {
+		return nil, nil
+	}
+	clusterUsers, err := admin.DescribeUserScramCredentials(nil)
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[string][]string)
+	for _, user := range clusterUsers {
+		if user.ErrorCode != sarama.ErrNoError {
+			continue
+		}
+		for _, info := range user.CredentialInfos {
+			existing[user.User] = append(existing[user.User], info.Mechanism.String())
+		}
+	}
+	var changes []MetadataChange
+	for _, user := range users {
+		var missing []string
+		for _, credential := range user.Credentials {
+			if !slices.Contains(existing[user.Name], credential.Mechanism) {
+				missing = append(missing, credential.Mechanism)
+			}
+		}
+		if len(missing) > 0 {
+			changes = append(changes, MetadataChange{
+				Kind:     metadataChangeKindScramUser,
+				Resource: user.Name,
+				Message: fmt.Sprintf("SCRAM credentials do not exist: %s, they cannot be restored without password",
+					strings.Join(missing, ", ")),
+			})
+		}
+	}
+	return changes, nil
+}
+
+func restoreGroupOffsets(admin sarama.ClusterAdmin, groups []ConsumerGroupMetadata,
+	dryRun bool) ([]MetadataChange, error) {
+	var changes []MetadataChange
+	for _, group := range groups {
+		partitions := make(map[string][]int32)
+		for _, offset := range group.Offsets {
+			partitions[offset.Topic] = append(partitions[offset.Topic], offset.Partition)
+		}
+		response, err := admin.ListConsumerGroupOffsets(group.Group, partitions)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get offsets of consumer group %s: %w", group.Group, err)
+		}
+		offsets, message := diffGroupOffsets(group.Offsets, offsetFetchBlocks(response))
+		if len(offsets) == 0 {
+			continue
+		}
+		change := MetadataChange{Kind: metadataChangeKindGroupOffsets, Resource: group.Group, Message: message}
+		members, err := controllers.CountConsumerGroupMembers(admin, group.Group)
+		if err != nil {
+			return nil, err
+		}
+		if members > 0 {
+			change.Message = fmt.Sprintf("%s, offsets cannot be changed while group has %d active members",
+				message, members)
+		} else if !dryRun {
+			metadataLogger.Info(fmt.Sprintf("Updating offsets of consumer group %s: %s", group.Group, message))
+			response, err := admin.AlterConsumerGroupOffsets(group.Group, offsets, nil)
+			change.apply(controllers.OffsetCommitError(response, err), "update")
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+// diffGroupOffsets returns archived offsets which differ from committed offsets of consumer group
+func diffGroupOffsets(archived []PartitionOffsetMetadata,
+	committed map[string]map[int32]*sarama.OffsetFetchResponseBlock) (map[string]map[int32]sarama.OffsetAndMetadata, string) {
+	offsets := make(map[string]map[int32]sarama.OffsetAndMetadata)
+	var differentPartitions []string
+	for _, offset := range archived {
+		if block := committed[offset.Topic][offset.Partition]; block != nil && block.Offset == offset.Offset {
+			continue
+		}
+		if offsets[offset.Topic] == nil {
+			offsets[offset.Topic] = make(map[int32]sarama.OffsetAndMetadata)
+		}
+		offsets[offset.Topic][offset.Partition] = sarama.OffsetAndMetadata{
+			Offset:      offset.Offset,
+			Metadata:    offset.Metadata,
+			LeaderEpoch: -1,
+		}
+		differentPartitions = append(differentPartitions, fmt.Sprintf("%s-%d", offset.Topic, offset.Partition))
+	}
+	return offsets, fmt.Sprintf("offsets differ: %s", strings.Join(differentPartitions, ", "))
+}
+
+// offsetFetchBlocks returns committed offsets of the only group requested by ListConsumerGroupOffsets
+func offsetFetchBlocks(response *sarama.OffsetFetchResponse) map[string]map[int32]*sarama.OffsetFetchResponseBlock {
+	if response == nil {
+		return nil
+	}
+	if response.Version >= 8 {
+		if len(response.Groups) == 0 {
+			return nil
+		}
+		return response.Groups[0].Blocks
+	}
+	return response.Blocks
+}
+
+func (c *MetadataChange) apply(err error, operation string) {
+	if err != nil {
+		c.Message = fmt.Sprintf("%s, %s failed: %v", c.Message, operation, err)
+		return
+	}
+	c.Applied = true
+}
+
+func (c MetadataChange) String() string {
+	if c.Applied {
+		return fmt.Sprintf("%s %s: %s, applied", c.Kind, c.Resource, c.Message)
+	}
+	return fmt.Sprintf("%s %s: %s", c.Kind, c.Resource, c.Message)
+}
+
+func (t TopicMetadata) detail() *sarama.TopicDetail {
+	detail := &sarama.TopicDetail{
+		NumPartitions:     t.Partitions,
+		ReplicationFactor: t.ReplicationFactor,
+		ConfigEntries:     make(map[string]*string),
+	}
+	for name, value := range t.Configs {
+		detail.ConfigEntries[name] = &value
+	}
+	return detail
+}
+
+func (a AclMetadata) resourceAcls() (sarama.ResourceAcls, error) {
+	resourceAcls := sarama.ResourceAcls{Resource: sarama.Resource{ResourceName: a.ResourceName}}
+	acl := &sarama.Acl{Principal: a.Principal, Host: a.Host}
+	err := errors.Join(
+		resourceAcls.ResourceType.UnmarshalText([]byte(a.ResourceType)),
+		resourceAcls.ResourcePatternType.UnmarshalText([]byte(a.PatternType)),
+		acl.Operation.UnmarshalText([]byte(a.Operation)),
+		acl.PermissionType.UnmarshalText([]byte(a.PermissionType)),
+	)
+	if err != nil {
+		return resourceAcls, fmt.Errorf("invalid ACL %s: %w", a, err)
+	}
+	resourceAcls.Acls = []*sarama.Acl{acl}
+	return resourceAcls, nil
+}
+
+func (a AclMetadata) String() string {
+	return fmt.Sprintf("%s:%s:%s %s %s %s from %s",
+		a.ResourceType, a.PatternType, a.ResourceName, a.Principal, a.PermissionType, a.Operation, a.Host)
+}
+
+// listAcls returns all ACLs of Kafka cluster, there are no ACLs if security is disabled in Kafka
+func listAcls(admin sarama.ClusterAdmin) ([]sarama.ResourceAcls, error) {
+	resourceAcls, err := admin.ListAcls(anyAclFilter())
+	if errors.Is(err, sarama.ErrSecurityDisabled) {
+		return nil, nil
+	}
+	return resourceAcls, err
+}
+
+func anyAclFilter() sarama.AclFilter {
+	return sarama.AclFilter{
+		ResourceType:              sarama.AclResourceAny,
+		ResourcePatternTypeFilter: sarama.AclPatternAny,
+		PermissionType:            sarama.AclPermissionAny,
+		Operation:                 sarama.AclOperationAny,
+	}
+}
+
+func isInternalTopic(topic string) bool {
+	return strings.HasPrefix(topic, "__")
+}
+
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,299 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaservice
+
+import (
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+)
+
+// fakeClusterAdmin keeps metadata of Kafka cluster in memory, methods which are not overridden panic
+type fakeClusterAdmin struct {
+	sarama.ClusterAdmin
+	topics  map[string]sarama.TopicDetail
+	acls    []sarama.ResourceAcls
+	aclsErr error
+	users   []*sarama.DescribeUserScramCredentialsResult
+	offsets map[string]map[string]map[int32]int64
+	members map[string]int
+}
+
+func (a *fakeClusterAdmin) ListTopics() (map[string]sarama.TopicDetail, error) {
+	return a.topics, nil
+}
+
+func (a *fakeClusterAdmin) CreateTopic(topic string, detail *sarama.TopicDetail, _ bool) error {
+	a.topics[topic] = *detail
+	return nil
+}
+
+func (a *fakeClusterAdmin) CreatePartitions(topic string, count int32, _ [][]int32, _ bool) error {
+	detail := a.topics[topic]
+	detail.NumPartitions = count
+	a.topics[topic] = detail
+	return nil
+}
+
+func (a *fakeClusterAdmin) IncrementalAlterConfig(_ sarama.ConfigResourceType, name string,
+	entries map[string]sarama.IncrementalAlterConfigsEntry, _ bool) error {
+	detail := a.topics[name]
+	for config, entry := range entries {
+		if entry.Operation == sarama.IncrementalAlterConfigsOperationDelete {
+			delete(detail.ConfigEntries, config)
+		} else {
+			detail.ConfigEntries[config] = entry.Value
+		}
+	}
+	return nil
+}
+
+func (a *fakeClusterAdmin) ListAcls(sarama.AclFilter) ([]sarama.ResourceAcls, error) {
+	return a.acls, a.aclsErr
+}
+
+func (a *fakeClusterAdmin) CreateACLs(resourceAcls []*sarama.ResourceAcls) error {
+	for _, resourceAcl := range resourceAcls {
+		a.acls = append(a.acls, *resourceAcl)
+	}
+	return nil
+}
+
+func (a *fakeClusterAdmin) DescribeUserScramCredentials([]string) ([]*sarama.DescribeUserScramCredentialsResult, error) {
+	return a.users, nil
+}
+
+func (a *fakeClusterAdmin) ListConsumerGroups() (map[string]string, error) {
+	groups := make(map[string]string)
+	for group := range a.offsets {
+		groups[group] = "consumer"
+	}
+	return groups, nil
+}
+
+func (a *fakeClusterAdmin) DescribeConsumerGroups(groups []string) ([]*sarama.GroupDescription, error) {
+	var descriptions []*sarama.GroupDescription
+	for _, group := range groups {
+		description := &sarama.GroupDescription{GroupId: group, Members: map[string]*sarama.GroupMemberDescription{}}
+		for i := 0; i < a.members[group]; i++ {
+			description.Members[string(rune('a'+i))] = &sarama.GroupMemberDescription{}
+		}
+		descriptions = append(descriptions, description)
+	}
+	return descriptions, nil
+}
+
+func (a *fakeClusterAdmin) ListConsumerGroupOffsets(group string,
+	topicPartitions map[string][]int32) (*sarama.OffsetFetchResponse, error) {
+	response := &sarama.OffsetFetchResponse{Version: 8, Groups: []sarama.OffsetFetchResponseGroup{{GroupId: group}}}
+	for topic, partitions := range a.offsets[group] {
+		for partition, offset := range partitions {
+			response.Groups[0].AddBlock(topic, partition, &sarama.OffsetFetchResponseBlock{Offset: offset})
+		}
+	}
+	for topic, partitions := range topicPartitions {
+		for _, partition := range partitions {
+			if response.GetBlock(topic, partition) == nil {
+				response.Groups[0].AddBlock(topic, partition, &sarama.OffsetFetchResponseBlock{Offset: -1})
+			}
+		}
+	}
+	return response, nil
+}
+
+func (a *fakeClusterAdmin) AlterConsumerGroupOffsets(group string, offsets map[string]map[int32]sarama.OffsetAndMetadata,
+	_ *sarama.AlterConsumerGroupOffsetsOptions) (*sarama.OffsetCommitResponse, error) {
+	if a.offsets[group] == nil {
+		a.offsets[group] = make(map[string]map[int32]int64)
+	}
+	for topic, partitions := range offsets {
+		if a.offsets[group][topic] == nil {
+			a.offsets[group][topic] = make(map[int32]int64)
+		}
+		for partition, offset := range partitions {
+			a.offsets[group][topic][partition] = offset.Offset
+		}
+	}
+	return &sarama.OffsetCommitResponse{}, nil
+}
+
+func (a *fakeClusterAdmin) Close() error {
+	return nil
+}
+
+func newMetadataSourceAdmin() *fakeClusterAdmin {
+	return &fakeClusterAdmin{
+		topics: map[string]sarama.TopicDetail{
+			"orders": {NumPartitions: 3, ReplicationFactor: 3, ConfigEntries: map[string]*string{
+				"retention.ms":                          ptr.To("3600000"),
+				"leader.replication.throttled.replicas": ptr.To("0:1"),
+			}},
+			"payments":           {NumPartitions: 1, ReplicationFactor: 1, ConfigEntries: map[string]*string{}},
+			"__consumer_offsets": {NumPartitions: 50, ReplicationFactor: 3},
+		},
+		acls: []sarama.ResourceAcls{{
+			Resource: sarama.Resource{
+				ResourceType:        sarama.AclResourceTopic,
+				ResourceName:        "orders",
+				ResourcePatternType: sarama.AclPatternLiteral,
+			},
+			Acls: []*sarama.Acl{{
+				Principal:      "User:client",
+				Host:           "*",
+				Operation:      sarama.AclOperationRead,
+				PermissionType: sarama.AclPermissionAllow,
+			}},
+		}},
+		users: []*sarama.DescribeUserScramCredentialsResult{{
+			User: "client",
+			CredentialInfos: []*sarama.UserScramCredentialsResponseInfo{
+				{Mechanism: sarama.SCRAM_MECHANISM_SHA_512, Iterations: 4096},
+			},
+		}},
+		offsets: map[string]map[string]map[int32]int64{
+			"billing": {"orders": {0: 10, 1: 20}, "__consumer_offsets": {0: 1}},
+			"idle":    {},
+		},
+	}
+}
+
+func TestMetadataBackup_ExportKafkaMetadata(t *testing.T) {
+	archive, err := ExportKafkaMetadata(newMetadataSourceAdmin(), time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.Equal(t, &MetadataArchive{
+		Version:   MetadataArchiveVersion,
+		CreatedAt: "2025-03-01T10:00:00Z",
+		Topics: []TopicMetadata{
+			{Name: "orders", Partitions: 3, ReplicationFactor: 3, Configs: map[string]string{"retention.ms": "3600000"}},
+			{Name: "payments", Partitions: 1, ReplicationFactor: 1},
+		},
+		Acls: []AclMetadata{{
+			ResourceType:   "Topic",
+			PatternType:    "Literal",
+			ResourceName:   "orders",
+			Principal:      "User:client",
+			Host:           "*",
+			Operation:      "Read",
+			PermissionType: "Allow",
+		}},
+		ScramUsers: []ScramUserMetadata{{
+			Name:        "client",
+			Credentials: []ScramCredentialMetadata{{Mechanism: "SCRAM-SHA-512", Iterations: 4096}},
+		}},
+		ConsumerGroups: []ConsumerGroupMetadata{{
+			Group: "billing",
+			Offsets: []PartitionOffsetMetadata{
+				{Topic: "orders", Partition: 0, Offset: 10},
+				{Topic: "orders", Partition: 1, Offset: 20},
+			},
+		}},
+	}, archive)
+}
+
+func TestMetadataBackup_UnmarshalMetadataArchive(t *testing.T) {
+	archive, err := ExportKafkaMetadata(newMetadataSourceAdmin(), time.Now())
+	assert.NoError(t, err)
+	data, err := MarshalMetadataArchive(archive)
+	assert.NoError(t, err)
+	decoded, err := UnmarshalMetadataArchive(data)
+	assert.NoError(t, err)
+	assert.Equal(t, archive, decoded)
+
+	_, err = UnmarshalMetadataArchive([]byte(`{"version": 2}`))
+	assert.EqualError(t, err, "metadata archive version 2 is not supported, supported versions are up to 1")
+	_, err = UnmarshalMetadataArchive([]byte(`{"topics": []}`))
+	assert.Error(t, err)
+}
+
+func TestMetadataBackup_RestoreKafkaMetadata(t *testing.T) {
+	archive, err := ExportKafkaMetadata(newMetadataSourceAdmin(), time.Now())
+	assert.NoError(t, err)
+	target := &fakeClusterAdmin{
+		topics: map[string]sarama.TopicDetail{
+			"orders": {NumPartitions: 1, ReplicationFactor: 3, ConfigEntries: map[string]*string{
+				"segment.bytes": ptr.To("1024"),
+				"follower.replication.throttled.replicas": ptr.To("0:2"),
+			}},
+		},
+		offsets: map[string]map[string]map[int32]int64{},
+	}
+
+	changes, err := RestoreKafkaMetadata(target, archive, true)
+	assert.NoError(t, err)
+	assert.Equal(t, []MetadataChange{
+		{Kind: "topicPartitions", Resource: "orders", Message: "topic has 1 partitions instead of 3"},
+		{Kind: "topicConfig", Resource: "orders", Message: "configs differ: retention.ms"},
+		{Kind: "topic", Resource: "payments", Message: "topic does not exist"},
+		{Kind: "acl", Resource: "Topic:Literal:orders User:client Allow Read from *", Message: "ACL does not exist"},
+		{Kind: "scramUser", Resource: "client",
+			Message: "SCRAM credentials do not exist: SCRAM-SHA-512, they cannot be restored without password"},
+		{Kind: "consumerGroupOffsets", Resource: "billing", Message: "offsets differ: orders-0, orders-1"},
+	}, changes)
+	assert.Equal(t, int32(1), target.topics["orders"].NumPartitions)
+	assert.Empty(t, target.acls)
+
+	changes, err = RestoreKafkaMetadata(target, archive, false)
+	assert.NoError(t, err)
+	assert.Len(t, changes, 6)
+	for _, change := range changes {
+		assert.Equal(t, change.Kind != "scramUser", change.Applied, change.Resource)
+	}
+	assert.Equal(t, int32(3), target.topics["orders"].NumPartitions)
+	assert.Equal(t, map[string]*string{
+		"retention.ms":  ptr.To("3600000"),
+		"segment.bytes": ptr.To("1024"),
+		"follower.replication.throttled.replicas": ptr.To("0:2"),
+	}, target.topics["orders"].ConfigEntries)
+	assert.Equal(t, int32(1), target.topics["payments"].NumPartitions)
+	assert.Len(t, target.acls, 1)
+	assert.Equal(t, map[string]map[int32]int64{"orders": {0: 10, 1: 20}}, target.offsets["billing"])
+
+	changes, err = RestoreKafkaMetadata(target, archive, false)
+	assert.NoError(t, err)
+	assert.Len(t, changes, 1)
+	assert.Equal(t, "scramUser", changes[0].Kind)
+}
+
+func TestMetadataBackup_ExportKafkaMetadata_securityDisabled(t *testing.T) {
+	admin := newMetadataSourceAdmin()
+	admin.aclsErr = sarama.ErrSecurityDisabled
+
+	archive, err := ExportKafkaMetadata(admin, time.Now())
+	assert.NoError(t, err)
+	assert.Empty(t, archive.Acls)
+	assert.Len(t, archive.Topics, 2)
+}
+
+func TestMetadataBackup_restoreGroupOffsets_activeMembers(t *testing.T) {
+	target := &fakeClusterAdmin{
+		offsets: map[string]map[string]map[int32]int64{"billing": {"orders": {0: 5}}},
+		members: map[string]int{"billing": 2},
+	}
+	groups := []ConsumerGroupMetadata{{Group: "billing", Offsets: []PartitionOffsetMetadata{
+		{Topic: "orders", Partition: 0, Offset: 10},
+	}}}
+
+	changes, err := restoreGroupOffsets(target, groups, false)
+	assert.NoError(t, err)
+	assert.Equal(t, []MetadataChange{{
+		Kind:     "consumerGroupOffsets",
+		Resource: "billing",
+		Message:  "offsets differ: orders-0, offsets cannot be changed while group has 2 active members",
+	}}, changes)
+	assert.Equal(t, int64(5), target.offsets["billing"]["orders"][0])
+}
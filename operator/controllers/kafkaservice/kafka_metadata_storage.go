@@ -0,0 +1,244 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaservice
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/IBM/sarama"
+	kafkav1 "github.com/Netcracker/qubership-kafka/operator/api/v1"
+	"github.com/Netcracker/qubership-kafka/operator/controllers"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	backupModeMetadata = "metadata"
+	metadataArchiveKey = "metadata.json.gz"
+	// metadataPartsKey and metadataChecksumKey are stored to the first config map of metadata archive
+	metadataPartsKey    = "parts"
+	metadataChecksumKey = "sha256"
+	// metadataPartSize keeps config map with archive part below 1 MiB limit of Kubernetes objects
+	metadataPartSize = 900 * 1024
+)
+
+// KafkaConnection contains settings which operator uses to connect to Kafka for metadata backup and restore
+type KafkaConnection struct {
+	BootstrapServers string
+	// Secret - Name of secret with admin-username and admin-password of Kafka
+	Secret        string
+	SaslMechanism string
+	SslEnabled    bool
+	SslSecret     string
+	// newAdminClient replaces connection to Kafka in tests
+	newAdminClient func(namespace string, logger logr.Logger) (sarama.ClusterAdmin, error)
+}
+
+// adminClient connects to Kafka with credentials and certificates from secrets of specified namespace
+func (c KafkaConnection) adminClient(r *controllers.Reconciler, namespace string,
+	logger logr.Logger) (sarama.ClusterAdmin, error) {
+	if c.newAdminClient != nil {
+		return c.newAdminClient(namespace, logger)
+	}
+	if c.BootstrapServers == "" {
+		return nil, fmt.Errorf("kafka bootstrap servers are not configured for operator")
+	}
+	saslSettings := &controllers.SaslSettings{Mechanism: c.SaslMechanism}
+	if c.Secret != "" {
+		secret, err := r.FindSecret(c.Secret, namespace, logger)
+		if err != nil {
+			return nil, err
+		}
+		saslSettings.Username = string(secret.Data["admin-username"])
+		saslSettings.Password = string(secret.Data["admin-password"])
+	}
+	sslCertificates := &controllers.SslCertificates{}
+	if c.SslEnabled && c.SslSecret != "" {
+		var err error
+		if sslCertificates, err = r.GetSslCertificates(c.SslSecret, namespace, logger); err != nil {
+			return nil, err
+		}
+	}
+	return controllers.NewKafkaAdminClient(c.BootstrapServers, saslSettings, c.SslEnabled, sslCertificates)
+}
+
+func metadataConfigMapName(backupName string) string {
+	return fmt.Sprintf("%s-metadata", backupName)
+}
+
+// metadataPartConfigMapName returns the name of config map with specified part of metadata archive,
+// the first part is stored to config map which name is written to KafkaBackup status
+func metadataPartConfigMapName(firstConfigMapName string, part int) string {
+	if part == 0 {
+		return firstConfigMapName
+	}
+	return fmt.Sprintf("%s-%d", firstConfigMapName, part)
+}
+
+// saveMetadataArchive stores compressed metadata archive to config maps and returns the name of the first one.
+// The archive is split into parts, so it is not limited by the size of one config map. Config maps are not owned
+// by KafkaBackup resource, so metadata is kept when the resource is removed.
+func saveMetadataArchive(ctx context.Context, r *controllers.Reconciler, backup *kafkav1.KafkaBackup,
+	archive *MetadataArchive, logger logr.Logger) (string, error) {
+	data, err := MarshalMetadataArchive(archive)
+	if err != nil {
+		return "", err
+	}
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	if _, err = writer.Write(data); err != nil {
+		return "", err
+	}
+	if err = writer.Close(); err != nil {
+		return "", err
+	}
+	name := metadataConfigMapName(backup.Name)
+	first, found, err := findMetadataConfigMap(ctx, r, name, backup.Namespace)
+	if err != nil {
+		return "", err
+	}
+	previousParts := 0
+	if found {
+		previousParts, _ = strconv.Atoi(first.Data[metadataPartsKey])
+	}
+	parts := splitMetadataArchive(compressed.Bytes())
+	// the first config map is written last, so it refers to already stored parts only
+	for part := len(parts) - 1; part >= 0; part-- {
+		data := map[string]string{}
+		if part == 0 {
+			checksum := sha256.Sum256(compressed.Bytes())
+			data[metadataPartsKey] = strconv.Itoa(len(parts))
+			data[metadataChecksumKey] = hex.EncodeToString(checksum[:])
+		}
+		if err = saveMetadataPart(ctx, r, metadataPartConfigMapName(name, part), backup.Namespace,
+			data, parts[part]); err != nil {
+			return "", err
+		}
+	}
+	for part := len(parts); part < previousParts; part++ {
+		if err = r.DeleteConfigMapByName(metadataPartConfigMapName(name, part), backup.Namespace, logger); err != nil {
+			return "", err
+		}
+	}
+	return name, nil
+}
+
+func splitMetadataArchive(compressed []byte) [][]byte {
+	var parts [][]byte
+	for len(compressed) > metadataPartSize {
+		parts = append(parts, compressed[:metadataPartSize])
+		compressed = compressed[metadataPartSize:]
+	}
+	return append(parts, compressed)
+}
+
+func saveMetadataPart(ctx context.Context, r *controllers.Reconciler, name string, namespace string,
+	data map[string]string, part []byte) error {
+	configMap, found, err := findMetadataConfigMap(ctx, r, name, namespace)
+	if err != nil {
+		return err
+	}
+	if !found {
+		configMap.ObjectMeta = metav1.ObjectMeta{Name: name, Namespace: namespace}
+	}
+	// config maps of previous versions are owned by KafkaBackup resource
+	configMap.OwnerReferences = nil
+	configMap.Data = data
+	configMap.BinaryData = map[string][]byte{metadataArchiveKey: part}
+	if found {
+		return r.Client.Update(ctx, configMap)
+	}
+	return r.Client.Create(ctx, configMap)
+}
+
+func findMetadataConfigMap(ctx context.Context, r *controllers.Reconciler, name string,
+	namespace string) (*corev1.ConfigMap, bool, error) {
+	configMap := &corev1.ConfigMap{}
+	err := r.Client.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, configMap)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return configMap, false, nil
+		}
+		return nil, false, err
+	}
+	return configMap, true, nil
+}
+
+// readMetadataArchive reads parts of metadata archive from config maps starting with the first one
+// and decodes the archive
+func readMetadataArchive(ctx context.Context, r *controllers.Reconciler,
+	configMap *corev1.ConfigMap) (*MetadataArchive, error) {
+	parts := 1
+	if value, found := configMap.Data[metadataPartsKey]; found {
+		var err error
+		if parts, err = strconv.Atoi(value); err != nil || parts < 1 {
+			return nil, fmt.Errorf("config map %s contains invalid number of parts '%s'", configMap.Name, value)
+		}
+	}
+	var compressed []byte
+	for part := 0; part < parts; part++ {
+		partConfigMap, err := readMetadataPart(ctx, r, configMap, part)
+		if err != nil {
+			return nil, err
+		}
+		data, found := partConfigMap.BinaryData[metadataArchiveKey]
+		if !found {
+			return nil, fmt.Errorf("config map %s does not contain %s", partConfigMap.Name, metadataArchiveKey)
+		}
+		compressed = append(compressed, data...)
+	}
+	if expected, found := configMap.Data[metadataChecksumKey]; found {
+		checksum := sha256.Sum256(compressed)
+		if hex.EncodeToString(checksum[:]) != expected {
+			return nil, fmt.Errorf("checksum of metadata archive from config map %s does not match", configMap.Name)
+		}
+	}
+	reader, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = reader.Close() }()
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	return UnmarshalMetadataArchive(data)
+}
+
+func readMetadataPart(ctx context.Context, r *controllers.Reconciler, firstConfigMap *corev1.ConfigMap,
+	part int) (*corev1.ConfigMap, error) {
+	if part == 0 {
+		return firstConfigMap, nil
+	}
+	name := metadataPartConfigMapName(firstConfigMap.Name, part)
+	configMap, found, err := findMetadataConfigMap(ctx, r, name, firstConfigMap.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("config map %s with part %d of metadata archive is not found", name, part)
+	}
+	return configMap, nil
+}
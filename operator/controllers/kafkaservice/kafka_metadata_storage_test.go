@@ -0,0 +1,213 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaservice
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	kafkav1 "github.com/Netcracker/qubership-kafka/operator/api/v1"
+	"github.com/Netcracker/qubership-kafka/operator/controllers"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newMetadataReconciler(admin sarama.ClusterAdmin, objects ...client.Object) (controllers.Reconciler, KafkaConnection) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = kafkav1.AddToScheme(scheme)
+	reconciler := controllers.Reconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build(),
+		Scheme: scheme,
+	}
+	connection := KafkaConnection{newAdminClient: func(string, logr.Logger) (sarama.ClusterAdmin, error) {
+		if admin == nil {
+			return nil, errors.New("kafka is not available")
+		}
+		return admin, nil
+	}}
+	return reconciler, connection
+}
+
+func TestKafkaBackup_progressMetadataBackup(t *testing.T) {
+	start := time.Date(2025, 5, 13, 10, 0, 0, 0, time.UTC)
+	backup := &kafkav1.KafkaBackup{
+		ObjectMeta: metav1.ObjectMeta{Name: "daily", Namespace: "kafka-service", UID: "backup-uid"},
+		Spec:       kafkav1.KafkaBackupSpec{KafkaServiceName: "kafka", Mode: backupModeMetadata},
+	}
+	reconciler, connection := newMetadataReconciler(newMetadataSourceAdmin(), backup)
+	r := &KafkaBackupReconciler{Reconciler: reconciler, KafkaConnection: connection}
+
+	status := r.progressMetadataBackup(context.Background(), backup, start, logr.Discard())
+	assert.Equal(t, backupPhaseSuccessful, status.Phase)
+	assert.Equal(t, "daily-metadata", status.MetadataConfigMap)
+	assert.Equal(t, "Metadata has been stored to config map daily-metadata", status.Message)
+
+	configMap := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.Background(),
+		types.NamespacedName{Name: "daily-metadata", Namespace: "kafka-service"}, configMap))
+	assert.Nil(t, metav1.GetControllerOf(configMap), "metadata is removed with KafkaBackup resource")
+	assert.Equal(t, "1", configMap.Data[metadataPartsKey])
+	archive, err := readMetadataArchive(context.Background(), &r.Reconciler, configMap)
+	assert.NoError(t, err)
+	assert.Equal(t, "2025-05-13T10:00:00Z", archive.CreatedAt)
+	assert.Len(t, archive.Topics, 2)
+}
+
+func TestKafkaBackup_saveMetadataArchiveInParts(t *testing.T) {
+	backup := &kafkav1.KafkaBackup{ObjectMeta: metav1.ObjectMeta{Name: "daily", Namespace: "kafka-service"}}
+	reconciler, _ := newMetadataReconciler(nil, backup)
+	// random configurations are not compressed, so the archive exceeds the size of one part
+	archive := &MetadataArchive{Version: 1, CreatedAt: "2025-05-13T10:00:00Z"}
+	for i := 0; i < 2; i++ {
+		value := make([]byte, metadataPartSize)
+		_, err := rand.Read(value)
+		assert.NoError(t, err)
+		archive.Topics = append(archive.Topics, TopicMetadata{Name: fmt.Sprintf("topic-%d", i),
+			Configs: map[string]string{"description": hex.EncodeToString(value)}})
+	}
+
+	name, err := saveMetadataArchive(context.Background(), &reconciler, backup, archive, logr.Discard())
+	assert.NoError(t, err)
+	configMap := &corev1.ConfigMap{}
+	assert.NoError(t, reconciler.Client.Get(context.Background(),
+		types.NamespacedName{Name: name, Namespace: "kafka-service"}, configMap))
+	parts, err := strconv.Atoi(configMap.Data[metadataPartsKey])
+	assert.NoError(t, err)
+	assert.Greater(t, parts, 2)
+	restored, err := readMetadataArchive(context.Background(), &reconciler, configMap)
+	assert.NoError(t, err)
+	assert.Equal(t, archive, restored)
+
+	// parts of the previous archive are removed when the archive becomes smaller
+	_, err = saveMetadataArchive(context.Background(), &reconciler, backup, &MetadataArchive{Version: 1},
+		logr.Discard())
+	assert.NoError(t, err)
+	err = reconciler.Client.Get(context.Background(),
+		types.NamespacedName{Name: metadataPartConfigMapName(name, 1), Namespace: "kafka-service"}, configMap)
+	assert.True(t, apierrors.IsNotFound(err))
+
+	// missing part is reported instead of reading the truncated archive
+	_, err = saveMetadataArchive(context.Background(), &reconciler, backup, archive, logr.Discard())
+	assert.NoError(t, err)
+	assert.NoError(t, reconciler.DeleteConfigMapByName(metadataPartConfigMapName(name, 1), "kafka-service",
+		logr.Discard()))
+	assert.NoError(t, reconciler.Client.Get(context.Background(),
+		types.NamespacedName{Name: name, Namespace: "kafka-service"}, configMap))
+	_, err = readMetadataArchive(context.Background(), &reconciler, configMap)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "part 1 of metadata archive is not found")
+	}
+}
+
+func TestKafkaBackup_progressMetadataBackupTimeout(t *testing.T) {
+	start := time.Date(2025, 5, 13, 10, 0, 0, 0, time.UTC)
+	backup := &kafkav1.KafkaBackup{
+		ObjectMeta: metav1.ObjectMeta{Name: "daily", Namespace: "kafka-service"},
+		Spec:       kafkav1.KafkaBackupSpec{Mode: backupModeMetadata, TimeoutSeconds: ptr.To[int32](30)},
+	}
+	reconciler, connection := newMetadataReconciler(nil, backup)
+	r := &KafkaBackupReconciler{Reconciler: reconciler, KafkaConnection: connection}
+
+	backup.Status = r.progressMetadataBackup(context.Background(), backup, start, logr.Discard())
+	assert.Equal(t, backupPhaseRunning, backup.Status.Phase)
+	assert.Equal(t, "Metadata backup failed: kafka is not available", backup.Status.Message)
+
+	backup.Status = r.progressMetadataBackup(context.Background(), backup, start.Add(time.Minute), logr.Discard())
+	assert.Equal(t, backupPhaseFailed, backup.Status.Phase)
+	assert.Equal(t, "1m0s", backup.Status.Duration)
+}
+
+func TestKafkaRestore_progressMetadataRestore(t *testing.T) {
+	start := time.Date(2025, 5, 13, 10, 0, 0, 0, time.UTC)
+	backup := &kafkav1.KafkaBackup{
+		ObjectMeta: metav1.ObjectMeta{Name: "daily", Namespace: "kafka-service"},
+		Spec:       kafkav1.KafkaBackupSpec{Mode: backupModeMetadata},
+	}
+	source, _ := newMetadataReconciler(newMetadataSourceAdmin(), backup)
+	archive, err := ExportKafkaMetadata(newMetadataSourceAdmin(), start)
+	assert.NoError(t, err)
+	backup.Status.MetadataConfigMap, err = saveMetadataArchive(context.Background(), &source, backup, archive,
+		logr.Discard())
+	assert.NoError(t, err)
+	backup.Status.Phase = backupPhaseSuccessful
+	configMap := &corev1.ConfigMap{}
+	assert.NoError(t, source.Client.Get(context.Background(),
+		types.NamespacedName{Name: "daily-metadata", Namespace: "kafka-service"}, configMap))
+
+	target := &fakeClusterAdmin{topics: map[string]sarama.TopicDetail{}, offsets: map[string]map[string]map[int32]int64{}}
+	restore := &kafkav1.KafkaRestore{
+		ObjectMeta: metav1.ObjectMeta{Name: "daily-restore", Namespace: "kafka-service"},
+		Spec:       kafkav1.KafkaRestoreSpec{BackupName: "daily", Mode: backupModeMetadata, DryRun: true},
+	}
+	reconciler, connection := newMetadataReconciler(target, backup, configMap)
+	r := &KafkaRestoreReconciler{Reconciler: reconciler, KafkaConnection: connection}
+
+	status, err := r.progressMetadataRestore(context.Background(), restore, start, logr.Discard())
+	assert.NoError(t, err)
+	assert.Equal(t, backupPhaseSuccessful, status.Phase)
+	assert.Equal(t, "Dry run has found 5 differences between metadata backup and Kafka cluster", status.Message)
+	assert.Contains(t, status.Changes, "topic orders: topic does not exist")
+	assert.Empty(t, target.topics)
+
+	restore.Spec.DryRun = false
+	status, err = r.progressMetadataRestore(context.Background(), restore, start, logr.Discard())
+	assert.NoError(t, err)
+	assert.Equal(t, "Metadata has been restored, 4 of 5 changes are applied", status.Message)
+	assert.Contains(t, status.Changes, "topic orders: topic does not exist, applied")
+	assert.Len(t, target.topics, 2)
+}
+
+func TestKafkaRestore_progressMetadataRestoreOfDataBackup(t *testing.T) {
+	backup := &kafkav1.KafkaBackup{
+		ObjectMeta: metav1.ObjectMeta{Name: "daily", Namespace: "kafka-service"},
+		Status:     kafkav1.KafkaBackupStatus{Phase: backupPhaseSuccessful, VaultId: "20250513T095536"},
+	}
+	reconciler, connection := newMetadataReconciler(nil, backup)
+	r := &KafkaRestoreReconciler{Reconciler: reconciler, KafkaConnection: connection}
+	restore := &kafkav1.KafkaRestore{
+		ObjectMeta: metav1.ObjectMeta{Name: "daily-restore", Namespace: "kafka-service"},
+		Spec:       kafkav1.KafkaRestoreSpec{BackupName: "daily", Mode: backupModeMetadata},
+	}
+
+	status, err := r.progressMetadataRestore(context.Background(), restore, time.Now(), logr.Discard())
+	assert.NoError(t, err)
+	assert.Equal(t, backupPhaseFailed, status.Phase)
+	assert.Equal(t, "KafkaBackup [daily] does not contain metadata", status.Message)
+
+	backup.Spec.Mode = backupModeMetadata
+	reconciler, _ = newMetadataReconciler(nil, backup)
+	r.Reconciler = reconciler
+	restore.Spec.Mode = ""
+	_, phase, message, err := r.findRestoreVault(context.Background(), restore)
+	assert.NoError(t, err)
+	assert.Equal(t, backupPhaseFailed, phase)
+	assert.Equal(t, "KafkaBackup [daily] contains metadata, it can be restored in metadata mode only", message)
+}
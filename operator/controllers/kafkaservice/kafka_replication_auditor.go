@@ -20,6 +20,7 @@ import (
 	kafkaservice "github.com/Netcracker/qubership-kafka/operator/api/v7"
 	"github.com/Netcracker/qubership-kafka/operator/controllers"
 	"github.com/Netcracker/qubership-kafka/operator/controllers/handlers"
+	"github.com/Netcracker/qubership-kafka/operator/controllers/provider"
 	"os"
 	"regexp"
 	"strings"
@@ -65,6 +66,35 @@ func (a *KafkaReplicationAuditor) CheckFullReplication(timeout time.Duration) (b
 }
 
 func (a *KafkaReplicationAuditor) checkFullReplication(stopAudit chan error) error {
+	if err := a.checkMessagesReplication(stopAudit); err != nil {
+		return err
+	}
+	return a.checkClusterSync()
+}
+
+// checkClusterSync fails the audit if topic configs or ACLs of source cluster are not synchronized to target cluster.
+// The check does not change target cluster.
+func (a *KafkaReplicationAuditor) checkClusterSync() error {
+	if !isClusterSyncEnabled(a.cr) || !a.cr.Spec.MirrorMaker.ClusterSync.BlockSwitchover {
+		return nil
+	}
+	repLogger.Info("check topic configs and ACLs synchronization")
+	syncStatus, err := NewKafkaClusterSync(a.cr, a.reconciler).Check()
+	if err != nil {
+		return err
+	}
+	if len(syncStatus.Divergences) > 0 {
+		var resources []string
+		for _, divergence := range syncStatus.Divergences {
+			resources = append(resources, fmt.Sprintf("%s %s: %s", divergence.Kind, divergence.Resource, divergence.Message))
+		}
+		return fmt.Errorf("topic configs or ACLs of %s and %s clusters are different: %s",
+			syncStatus.SourceCluster, syncStatus.TargetCluster, strings.Join(resources, "; "))
+	}
+	return nil
+}
+
+func (a *KafkaReplicationAuditor) checkMessagesReplication(stopAudit chan error) error {
 	kmmConfigHandler, err := handlers.NewKmmConfigHandler(a.reconciler.Client)
 	if err != nil {
 		return err
@@ -174,8 +204,8 @@ func (a *KafkaReplicationAuditor) getKafkaClients(handler *handlers.KmmConfigHan
 }
 
 func (a *KafkaReplicationAuditor) getSslCertificates(cluster *kafkaservice.Cluster, namespace string) (*controllers.SslCertificates, error) {
-	if cluster.EnableSsl && cluster.SslSecretName != "" {
-		sslCertificates, err := a.reconciler.GetSslCertificates(cluster.SslSecretName, namespace, repLogger)
+	if sslSecretName := provider.GetClusterSslSecretName(a.cr, *cluster); cluster.EnableSsl && sslSecretName != "" {
+		sslCertificates, err := a.reconciler.GetSslCertificates(sslSecretName, namespace, repLogger)
 		if err != nil {
 			return nil, err
 		}
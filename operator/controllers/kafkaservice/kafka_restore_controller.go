@@ -0,0 +1,302 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaservice
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	kafkav1 "github.com/Netcracker/qubership-kafka/operator/api/v1"
+	"github.com/Netcracker/qubership-kafka/operator/controllers"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var restoreLog = logf.Log.WithName("controller_kafka_restore")
+
+// KafkaRestoreReconciler reconciles a KafkaRestore object
+type KafkaRestoreReconciler struct {
+	controllers.Reconciler
+	// KafkaConnection - Settings of connection to Kafka for `metadata` mode restores
+	KafkaConnection KafkaConnection
+}
+
+//+kubebuilder:rbac:groups=netcracker.com,resources=kafkarestores,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=netcracker.com,resources=kafkarestores/status,verbs=get;update;patch
+
+func (r *KafkaRestoreReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	reqLogger := restoreLog.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	reqLogger.Info("Reconciling KafkaRestore")
+
+	instance := &kafkav1.KafkaRestore{}
+	if err := r.Client.Get(ctx, request.NamespacedName, instance); err != nil {
+		if errors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	if isBackupJobFinished(instance.Status.Phase) {
+		reqLogger.Info(fmt.Sprintf("Restore is already %s, skipping", instance.Status.Phase))
+		return reconcile.Result{}, nil
+	}
+	statusUpdater := NewRestoreStatusUpdater(r.Client, instance)
+
+	if instance.Spec.Mode == backupModeMetadata {
+		status, err := r.progressMetadataRestore(ctx, instance, time.Now(), reqLogger)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		reqLogger.Info(fmt.Sprintf("Metadata restore is %s: %s", status.Phase, status.Message))
+		if err = statusUpdater.UpdateStatusWithRetry(func(restore *kafkav1.KafkaRestore) {
+			restore.Status = status
+		}); err != nil {
+			return reconcile.Result{}, err
+		}
+		if status.Phase == backupPhaseRunning {
+			return reconcile.Result{RequeueAfter: waitingInterval}, nil
+		}
+		return reconcile.Result{}, nil
+	}
+
+	vaultId, phase, message, err := r.findRestoreVault(ctx, instance)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if vaultId == "" {
+		reqLogger.Info(message)
+		if err = statusUpdater.UpdateStatusWithRetry(func(restore *kafkav1.KafkaRestore) {
+			restore.Status.Phase = phase
+			restore.Status.Message = message
+			restore.Status.ObservedGeneration = instance.Generation
+		}); err != nil {
+			return reconcile.Result{}, err
+		}
+		if phase == backupPhaseRunning {
+			return reconcile.Result{RequeueAfter: waitingInterval}, nil
+		}
+		return reconcile.Result{}, nil
+	}
+
+	backupDaemon, message, err :=
+		findBackupDaemonClient(ctx, &r.Reconciler, instance.Spec.KafkaServiceName, instance.Namespace, reqLogger)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if message != "" {
+		reqLogger.Info(message)
+		return reconcile.Result{}, statusUpdater.UpdateStatusWithRetry(func(restore *kafkav1.KafkaRestore) {
+			restore.Status.Phase = backupPhaseFailed
+			restore.Status.Message = message
+			restore.Status.ObservedGeneration = instance.Generation
+		})
+	}
+
+	status := progressRestore(backupDaemon, instance, vaultId, time.Now())
+	reqLogger.Info(fmt.Sprintf("Restore of %s is %s: %s", status.VaultId, status.Phase, status.Message))
+	if err = statusUpdater.UpdateStatusWithRetry(func(restore *kafkav1.KafkaRestore) {
+		restore.Status = status
+	}); err != nil {
+		return reconcile.Result{}, err
+	}
+	if status.Phase == backupPhaseRunning {
+		return reconcile.Result{RequeueAfter: waitingInterval}, nil
+	}
+	return reconcile.Result{}, nil
+}
+
+// findRestoreVault returns the vault specified in KafkaRestore resource or the vault of referenced KafkaBackup.
+// If the vault is not known yet, the phase and the message describe the reason.
+func (r *KafkaRestoreReconciler) findRestoreVault(ctx context.Context,
+	restore *kafkav1.KafkaRestore) (string, string, string, error) {
+	if restore.Spec.Vault != "" {
+		return restore.Spec.Vault, "", "", nil
+	}
+	if restore.Spec.BackupName == "" {
+		return "", backupPhaseFailed, "Either vault or backupName must be specified", nil
+	}
+	backup, phase, message, err := r.findRestoreBackup(ctx, restore)
+	if backup == nil {
+		return "", phase, message, err
+	}
+	if backup.Spec.Mode == backupModeMetadata {
+		return "", backupPhaseFailed,
+			fmt.Sprintf("KafkaBackup [%s] contains metadata, it can be restored in metadata mode only", backup.Name), nil
+	}
+	return backup.Status.VaultId, "", "", nil
+}
+
+// findRestoreBackup returns successful KafkaBackup referenced by KafkaRestore resource.
+// If the backup is not successful, the phase and the message describe the reason.
+func (r *KafkaRestoreReconciler) findRestoreBackup(ctx context.Context,
+	restore *kafkav1.KafkaRestore) (*kafkav1.KafkaBackup, string, string, error) {
+	backup := &kafkav1.KafkaBackup{}
+	err := r.Client.Get(ctx, types.NamespacedName{Name: restore.Spec.BackupName, Namespace: restore.Namespace}, backup)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, backupPhaseFailed, fmt.Sprintf("KafkaBackup [%s] is not found", restore.Spec.BackupName), nil
+		}
+		return nil, "", "", err
+	}
+	switch backup.Status.Phase {
+	case backupPhaseSuccessful:
+		return backup, "", "", nil
+	case backupPhaseFailed:
+		return nil, backupPhaseFailed, fmt.Sprintf("KafkaBackup [%s] has failed", backup.Name), nil
+	default:
+		return nil, backupPhaseRunning, fmt.Sprintf("Waiting for KafkaBackup [%s] to complete", backup.Name), nil
+	}
+}
+
+// progressMetadataRestore restores metadata of Kafka cluster from metadata backup or reports the differences
+// in dry run and returns the new status of KafkaRestore resource. Failed restore is repeated until the restore
+// timeout is exceeded.
+func (r *KafkaRestoreReconciler) progressMetadataRestore(ctx context.Context, restore *kafkav1.KafkaRestore,
+	now time.Time, logger logr.Logger) (kafkav1.KafkaRestoreStatus, error) {
+	status := restore.Status
+	status.ObservedGeneration = restore.Generation
+	if restore.Spec.BackupName == "" {
+		status.Phase, status.Message = backupPhaseFailed, "backupName must be specified in metadata mode"
+		return status, nil
+	}
+	backup, phase, message, err := r.findRestoreBackup(ctx, restore)
+	if err != nil {
+		return restore.Status, err
+	}
+	if backup == nil {
+		status.Phase, status.Message = phase, message
+		return status, nil
+	}
+	if backup.Spec.Mode != backupModeMetadata {
+		status.Phase = backupPhaseFailed
+		status.Message = fmt.Sprintf("KafkaBackup [%s] does not contain metadata", backup.Name)
+		return status, nil
+	}
+	if status.StartTime == "" {
+		status.Phase = backupPhaseRunning
+		status.StartTime = now.Format(time.RFC3339)
+	}
+	configMap := &corev1.ConfigMap{}
+	err = r.Client.Get(ctx, types.NamespacedName{Name: backup.Status.MetadataConfigMap, Namespace: restore.Namespace},
+		configMap)
+	if err != nil && !errors.IsNotFound(err) {
+		return restore.Status, err
+	}
+	var archive *MetadataArchive
+	if err == nil {
+		archive, err = readMetadataArchive(ctx, &r.Reconciler, configMap)
+	}
+	if err != nil {
+		status.Phase = backupPhaseFailed
+		status.Message = fmt.Sprintf("Metadata of KafkaBackup [%s] cannot be read: %v", backup.Name, err)
+		status.CompletionTime, status.Duration = completeBackupJob(status.StartTime, now)
+		return status, nil
+	}
+	changes, err := r.restoreMetadata(archive, restore.Spec.DryRun, restore.Namespace, logger)
+	if err != nil {
+		status.Message = fmt.Sprintf("Metadata restore failed: %v", err)
+		timeout := secondsOrDefault(restore.Spec.TimeoutSeconds, defaultBackupJobTimeout)
+		if isBackupJobTimedOut(status.StartTime, timeout, now) {
+			status.Phase = backupPhaseFailed
+			status.CompletionTime, status.Duration = completeBackupJob(status.StartTime, now)
+		}
+		return status, nil
+	}
+	status.Changes = nil
+	applied := 0
+	for _, change := range changes {
+		status.Changes = append(status.Changes, change.String())
+		if change.Applied {
+			applied++
+		}
+	}
+	status.Phase = backupPhaseSuccessful
+	if restore.Spec.DryRun {
+		status.Message = fmt.Sprintf("Dry run has found %d differences between metadata backup and Kafka cluster",
+			len(changes))
+	} else {
+		status.Message = fmt.Sprintf("Metadata has been restored, %d of %d changes are applied", applied, len(changes))
+	}
+	status.CompletionTime, status.Duration = completeBackupJob(status.StartTime, now)
+	return status, nil
+}
+
+func (r *KafkaRestoreReconciler) restoreMetadata(archive *MetadataArchive, dryRun bool, namespace string,
+	logger logr.Logger) ([]MetadataChange, error) {
+	admin, err := r.KafkaConnection.adminClient(&r.Reconciler, namespace, logger)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = admin.Close() }()
+	return RestoreKafkaMetadata(admin, archive, dryRun)
+}
+
+// progressRestore starts the restore of the vault in Backup Daemon or checks the status of started restore job
+// and returns the new status of KafkaRestore resource
+func progressRestore(backupDaemon BackupDaemonClient, restore *kafkav1.KafkaRestore, vaultId string,
+	now time.Time) kafkav1.KafkaRestoreStatus {
+	status := restore.Status
+	status.ObservedGeneration = restore.Generation
+	status.VaultId = vaultId
+	if status.StartTime == "" {
+		status.Phase = backupPhaseRunning
+		status.StartTime = now.Format(time.RFC3339)
+	}
+	timeout := secondsOrDefault(restore.Spec.TimeoutSeconds, defaultBackupJobTimeout)
+	if status.TaskId == "" {
+		taskId, err := backupDaemon.Restore(newRestoreRequest(restore.Spec, vaultId))
+		if err != nil {
+			status.Message = fmt.Sprintf("Restore request failed: %v", err)
+			if isBackupJobTimedOut(status.StartTime, timeout, now) {
+				status.Phase = backupPhaseFailed
+				status.CompletionTime, status.Duration = completeBackupJob(status.StartTime, now)
+			}
+			return status
+		}
+		status.TaskId = taskId
+		status.Message = "Restore has been started"
+		return status
+	}
+	status.Phase, status.Message = checkBackupJob(backupDaemon, status.TaskId, status.StartTime, timeout, now)
+	if status.Phase != backupPhaseRunning {
+		status.CompletionTime, status.Duration = completeBackupJob(status.StartTime, now)
+	}
+	return status
+}
+
+func newRestoreRequest(spec kafkav1.KafkaRestoreSpec, vaultId string) RestoreRequest {
+	request := RestoreRequest{
+		Vault:      vaultId,
+		Dbs:        spec.Topics,
+		TopicRegex: spec.TopicRegex,
+	}
+	if spec.IncludeAcl {
+		request.Mode = backupModeAcl
+	}
+	return request
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *KafkaRestoreReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&kafkav1.KafkaRestore{}, builder.WithPredicates(backupJobPredicates()...)).
+		Complete(r)
+}
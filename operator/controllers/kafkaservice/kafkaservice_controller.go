@@ -16,11 +16,15 @@ package kafkaservice
 
 import (
 	"context"
+	stderrors "errors"
 	"fmt"
+	kafkav1 "github.com/Netcracker/qubership-kafka/operator/api/v1"
 	kafkaservice "github.com/Netcracker/qubership-kafka/operator/api/v7"
+	"github.com/Netcracker/qubership-kafka/operator/controllers"
 	"github.com/Netcracker/qubership-kafka/operator/util"
 	"github.com/go-logr/logr"
 	v1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"os"
@@ -36,10 +40,9 @@ import (
 )
 
 const (
-	kafkaServiceConditionReason       = "ReconcileCycleStatus"
-	globalHashName                    = "spec.global"
-	autoRestartAnnotation             = "kafkaservice.netcracker.com/auto-restart"
-	resourceVersionAnnotationTemplate = "%s/resource-version"
+	kafkaServiceConditionReason = "ReconcileCycleStatus"
+	globalHashName              = "spec.global"
+	replicationCheckTimeout     = 300 * time.Second
 )
 
 var (
@@ -55,6 +58,8 @@ type ReconcileService interface {
 //+kubebuilder:rbac:groups=netcracker.com,resources=kafkaservices,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=netcracker.com,resources=kafkaservices/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=netcracker.com,resources=kafkaservices/finalizers,verbs=update
+//+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;delete;deletecollection
+//+kubebuilder:rbac:groups=cert-manager.io,resources=certificates,verbs=get;list;watch;create;update;patch;delete
 
 func (r *KafkaServiceReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
 	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
@@ -103,8 +108,24 @@ func (r *KafkaServiceReconciler) Reconcile(ctx context.Context, request ctrl.Req
 		}
 	}
 
+	var drResource *kafkav1.KafkaDisasterRecovery
+	if r.DrResourceEnabled {
+		if drResource, err = findKafkaDisasterRecovery(r.Client, instance); err != nil {
+			reqLogger.Error(err, "Cannot find KafkaDisasterRecovery resource")
+			r.writeFailedStatus(fmt.Sprintf("Reconciliation cycle failed due to: %v", err))
+			return reconcile.Result{}, err
+		}
+	}
+	drManaged := drResource != nil && instance.Spec.DisasterRecovery != nil
+	switchoverRunning := false
+	if drManaged {
+		reqLogger.Info(fmt.Sprintf("Disaster recovery switchover is managed by KafkaDisasterRecovery [%s]", drResource.Name))
+		applySwitchedMode(instance, drResource)
+		switchoverRunning = drResource.Status.Status == switchoverStatusRunning
+	}
+
 	drChecked := false
-	if instance.Spec.DisasterRecovery != nil &&
+	if !drManaged && instance.Spec.DisasterRecovery != nil &&
 		(instance.Status.DisasterRecoveryStatus.Mode != instance.Spec.DisasterRecovery.Mode ||
 			instance.Status.DisasterRecoveryStatus.Status == "running" ||
 			instance.Status.DisasterRecoveryStatus.Status == "failed") {
@@ -120,7 +141,7 @@ func (r *KafkaServiceReconciler) Reconcile(ctx context.Context, request ctrl.Req
 		message := "replication has finished successfully"
 		if checkNeeded {
 			replicationAuditor := NewKafkaReplicationAuditor(instance, r)
-			checkCompleted, errSwitchover := replicationAuditor.CheckFullReplication(time.Second * 300)
+			checkCompleted, errSwitchover := replicationAuditor.CheckFullReplication(replicationCheckTimeout)
 			if !checkCompleted {
 				status = "failed"
 				message = "timeout occurred during replication check"
@@ -141,6 +162,10 @@ func (r *KafkaServiceReconciler) Reconcile(ctx context.Context, request ctrl.Req
 			if status == "failed" {
 				_ = r.updateDisasterRecoveryStatus(instance, status, message)
 			} else {
+				if stderrors.Is(err, controllers.ErrCertificateNotIssued) {
+					// switchover stays running and is continued when certificates are issued
+					return
+				}
 				if err != nil {
 					status = "failed"
 					message = fmt.Sprintf("Error is occurred during Kafka switching: %v", err)
@@ -150,10 +175,14 @@ func (r *KafkaServiceReconciler) Reconcile(ctx context.Context, request ctrl.Req
 		}()
 	}
 
-	reconcilers := r.buildReconcilers(instance, log, drChecked)
+	reconcilers := r.buildReconcilers(instance, log, drChecked, drManaged, switchoverRunning)
 
 	for _, reconciler := range reconcilers {
 		if err = reconciler.Reconcile(); err != nil {
+			if stderrors.Is(err, controllers.ErrCertificateNotIssued) {
+				reqLogger.Info(fmt.Sprintf("Reconciliation is postponed: %v", err))
+				return reconcile.Result{RequeueAfter: controllers.CertificateCheckInterval}, nil
+			}
 			reqLogger.Error(err, "Error during reconciliation")
 			r.writeFailedStatus(fmt.Sprintf("Reconciliation cycle failed for %T due to: %v", reconciler, err))
 			return reconcile.Result{}, err
@@ -207,7 +236,21 @@ func (r *KafkaServiceReconciler) Reconcile(ctx context.Context, request ctrl.Req
 	r.ResourceHashes["annotations"] = annotationsHash
 	r.ResourceHashes["spec"] = specHash
 	r.ResourceHashes[globalHashName] = globalSpecHash
-	return reconcile.Result{}, nil
+	var requeueAfter time.Duration
+	if isFlowInspectionEnabled(instance, log) {
+		requeueAfter = replicationFlowsCheckInterval
+	}
+	if isClusterSyncEnabled(instance) && instance.Spec.MirrorMaker.ClusterSync.IntervalSeconds != nil {
+		syncInterval := time.Duration(*instance.Spec.MirrorMaker.ClusterSync.IntervalSeconds) * time.Second
+		if requeueAfter == 0 || syncInterval < requeueAfter {
+			requeueAfter = syncInterval
+		}
+	}
+	if switchoverRunning {
+		// Kafka Mirror Maker is reconciled after switchover of KafkaDisasterRecovery is completed
+		requeueAfter = waitingInterval
+	}
+	return reconcile.Result{RequeueAfter: requeueAfter}, nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -242,12 +285,13 @@ func (r *KafkaServiceReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			return e.ObjectNew.GetResourceVersion() != e.ObjectOld.GetResourceVersion()
 		},
 	}
-	return ctrl.NewControllerManagedBy(mgr).
+	controllerBuilder := ctrl.NewControllerManagedBy(mgr).
 		For(&kafkaservice.KafkaService{}, builder.WithPredicates(statusPredicate, namespacePredicate)).
 		Owns(&corev1.Secret{}, builder.WithPredicates(namespacePredicate, dummyPredicate)).
 		Owns(&corev1.ConfigMap{}, builder.WithPredicates(namespacePredicate, dummyPredicate)).
 		Owns(&v1.Deployment{}, builder.WithPredicates(namespacePredicate, dummyPredicate)).
-		Complete(r)
+		Owns(&batchv1.Job{}, builder.WithPredicates(namespacePredicate, dummyPredicate))
+	return controllerBuilder.Complete(r)
 }
 
 func isCheckNeeded(instance *kafkaservice.KafkaService) bool {
@@ -271,25 +315,27 @@ func (r *KafkaServiceReconciler) writeFailedStatus(errorMessage string) {
 
 // buildReconcilers returns service reconcilers in accordance with custom resource.
 func (r *KafkaServiceReconciler) buildReconcilers(cr *kafkaservice.KafkaService, logger logr.Logger,
-	drChecked bool) []ReconcileService {
+	drChecked bool, drManaged bool, switchoverRunning bool) []ReconcileService {
 	var reconcilers []ReconcileService
+	reconcilers = append(reconcilers, NewReconcileCertificates(r, cr, logger))
 	if cr.Spec.Monitoring != nil {
 		reconcilers = append(reconcilers, NewReconcileMonitoring(r, cr, logger))
 	}
 	if cr.Spec.Akhq != nil {
 		reconcilers = append(reconcilers, NewReconcileAkhq(r, cr, logger))
 	}
-	if cr.Spec.MirrorMaker != nil {
+	// Kafka Mirror Maker is restarted by KafkaDisasterRecovery controller during switchover
+	if cr.Spec.MirrorMaker != nil && !switchoverRunning {
 		reconcilers = append(reconcilers, NewReconcileMirrorMaker(r, cr, logger, drChecked))
 	}
-	if cr.Spec.MirrorMakerMonitoring != nil {
+	if cr.Spec.MirrorMakerMonitoring != nil && !switchoverRunning {
 		reconcilers = append(reconcilers, NewReconcileMirrorMakerMonitoring(r, cr, logger, drChecked))
 	}
 	if cr.Spec.IntegrationTests != nil {
 		reconcilers = append(reconcilers, NewReconcileIntegrationTests(r, cr, logger))
 	}
 	if cr.Spec.BackupDaemon != nil {
-		reconcilers = append(reconcilers, NewReconcileBackupDaemon(r, cr, logger))
+		reconcilers = append(reconcilers, NewReconcileBackupDaemon(r, cr, logger, drManaged))
 	}
 	return reconcilers
 }
@@ -15,6 +15,8 @@
 package kafkaservice
 
 import (
+	"time"
+
 	"github.com/Netcracker/qubership-kafka/operator/controllers"
 	_ "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
@@ -22,5 +24,8 @@ import (
 // KafkaServiceReconciler reconciles a KafkaService object
 type KafkaServiceReconciler struct {
 	controllers.Reconciler
-	StatusUpdater StatusUpdater
+	StatusUpdater     StatusUpdater
+	DrResourceEnabled bool
+	clusterSyncHash   string
+	clusterSyncTime   time.Time
 }
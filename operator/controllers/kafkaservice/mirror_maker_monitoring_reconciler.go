@@ -19,6 +19,7 @@ import (
 
 	"time"
 
+	"github.com/Netcracker/qubership-kafka/operator/controllers"
 	"github.com/Netcracker/qubership-kafka/operator/controllers/provider"
 	"github.com/Netcracker/qubership-kafka/operator/util"
 	"github.com/go-logr/logr"
@@ -94,7 +95,7 @@ func (r ReconcileMirrorMakerMonitoring) Reconcile() error {
 		r.logger.Info("Kafka Mirror Maker monitoring configuration didn't change, skipping reconcile loop")
 	}
 
-	if err := updateDeploymentSecretRestartAnnotations(
+	if err := controllers.UpdateDeploymentSecretRestartAnnotations(
 		r.reconciler.Client, r.cr.Namespace, r.mirrorMakerMonitoringProvider.GetServiceName(), r.logger,
 		mirrorMakerMonitoringSecret); err != nil {
 		return err
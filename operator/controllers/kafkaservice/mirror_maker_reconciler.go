@@ -16,16 +16,22 @@ package kafkaservice
 
 import (
 	"fmt"
+	"net/http"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/IBM/sarama"
 	kafkaservice "github.com/Netcracker/qubership-kafka/operator/api/v7"
 	"github.com/Netcracker/qubership-kafka/operator/controllers"
+	"github.com/Netcracker/qubership-kafka/operator/controllers/kmmconfig"
 	"github.com/Netcracker/qubership-kafka/operator/controllers/provider"
 	"github.com/Netcracker/qubership-kafka/operator/util"
 	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
 )
 
@@ -33,6 +39,10 @@ const (
 	mirrorMakerConditionReason = "KafkaMirrorMakerReadinessStatus"
 	mirrorMakerHashName        = "spec.mirrorMaker"
 	disasterRecoveryHashName   = "spec.disasterRecovery"
+	replicationFlowHealthy     = "healthy"
+	replicationFlowUnhealthy   = "unhealthy"
+	// replicationFlowsCheckInterval is the period of replication flows inspection
+	replicationFlowsCheckInterval = time.Minute
 )
 
 type ReconcileMirrorMaker struct {
@@ -57,6 +67,9 @@ func (r ReconcileMirrorMaker) Reconcile() error {
 	mirrorMakerProvider := r.mirrorMakerProvider
 	mirrorMakerSpec := r.cr.Spec.MirrorMaker
 	if len(mirrorMakerSpec.Clusters) > 1 {
+		if err := mirrorMakerProvider.ValidateReplicationFlows(); err != nil {
+			return err
+		}
 
 		secretKey := fmt.Sprintf("%s.%s", mirrorMakerSpec.SecretName, r.cr.Namespace)
 		secret, err := r.reconciler.WatchSecret(mirrorMakerSpec.SecretName, r.cr, r.logger)
@@ -65,6 +78,15 @@ func (r ReconcileMirrorMaker) Reconcile() error {
 		}
 		secretVersion := secret.ResourceVersion
 
+		var sslSecret *corev1.Secret
+		if provider.IsMirrorMakerCertificateRequired(r.cr) {
+			sslSecret, err = r.reconciler.WatchSecret(
+				provider.GetClientSslSecretName(r.cr, provider.MirrorMakerCertificateComponent), r.cr, r.logger)
+			if err != nil {
+				return err
+			}
+		}
+
 		configurationKey := fmt.Sprintf("%s.%s", mirrorMakerSpec.ConfigurationName, r.cr.Namespace)
 		configMap, err := r.reconciler.FindConfigMap(mirrorMakerSpec.ConfigurationName, r.cr.Namespace, r.logger)
 		if err != nil {
@@ -87,6 +109,11 @@ func (r ReconcileMirrorMaker) Reconcile() error {
 		}
 		configurationVersion := configMap.ResourceVersion
 
+		flowConfigurationVersions, err := r.reconcileFlowConfigurations()
+		if err != nil {
+			return err
+		}
+
 		mirrorMakerHash, err := util.Hash(r.cr.Spec.MirrorMaker)
 		if err != nil {
 			return err
@@ -101,28 +128,46 @@ func (r ReconcileMirrorMaker) Reconcile() error {
 		if r.reconciler.ResourceHashes[mirrorMakerHashName] != mirrorMakerHash ||
 			r.drChecked ||
 			secret.Name != "" && r.reconciler.ResourceVersions[secretKey] != secretVersion ||
-			r.reconciler.ResourceVersions[configurationKey] != configurationVersion {
+			sslSecret != nil && r.reconciler.ResourceVersions[sslSecret.Name] != sslSecret.ResourceVersion ||
+			r.reconciler.ResourceVersions[configurationKey] != configurationVersion ||
+			r.areFlowConfigurationsChanged(flowConfigurationVersions) {
 			serviceAccount := provider.NewServiceAccount(r.mirrorMakerProvider.GetServiceAccountName(), r.cr.Namespace, r.cr.Spec.Global.DefaultLabels)
 			if err := r.reconciler.CreateOrUpdateServiceAccount(serviceAccount, r.logger); err != nil {
 				return err
 			}
 
-			if mirrorMakerSpec.RegionName == "" {
+			var deploymentNames []string
+			if mirrorMakerProvider.HasDedicatedFlows() {
+				for _, flow := range mirrorMakerProvider.GetReplicationFlows() {
+					deploymentName, err := r.createFlowDeployment(flow, secret, sslSecret, flowConfigurationVersions)
+					if err != nil {
+						return err
+					}
+					deploymentNames = append(deploymentNames, deploymentName)
+				}
+			} else if mirrorMakerSpec.RegionName == "" {
 				for _, cluster := range mirrorMakerSpec.Clusters {
-					if err := r.createDeployment(cluster, secret, configurationVersion); err != nil {
+					deploymentName, err := r.createDeployment(cluster, secret, sslSecret, configurationVersion)
+					if err != nil {
 						return err
 					}
+					deploymentNames = append(deploymentNames, deploymentName)
 				}
 			} else {
 				for _, cluster := range mirrorMakerSpec.Clusters {
 					if cluster.Name == mirrorMakerSpec.RegionName {
-						if err := r.createDeployment(cluster, secret, configurationVersion); err != nil {
+						deploymentName, err := r.createDeployment(cluster, secret, sslSecret, configurationVersion)
+						if err != nil {
 							return err
 						}
+						deploymentNames = append(deploymentNames, deploymentName)
 						break
 					}
 				}
 			}
+			if err := r.deleteUnusedDeployments(deploymentNames); err != nil {
+				return err
+			}
 
 			r.logger.Info("Updating Kafka Mirror Maker status")
 			if err := r.updateMirrorMakerStatus(r.cr); err != nil {
@@ -132,13 +177,62 @@ func (r ReconcileMirrorMaker) Reconcile() error {
 			r.logger.Info("Kafka mirror maker configuration didn't change, skipping reconcile loop")
 		}
 		r.reconciler.ResourceVersions[secretKey] = secretVersion
+		if sslSecret != nil {
+			r.reconciler.ResourceVersions[sslSecret.Name] = sslSecret.ResourceVersion
+		}
 		r.reconciler.ResourceVersions[configurationKey] = configurationVersion
+		for key, version := range flowConfigurationVersions {
+			r.reconciler.ResourceVersions[key] = version
+		}
 		r.reconciler.ResourceHashes[mirrorMakerHashName] = mirrorMakerHash
 		r.reconciler.ResourceHashes[disasterRecoveryHashName] = disasterRecoveryHash
+
+		if isClusterSyncEnabled(r.cr) && r.isClusterSyncNeeded(mirrorMakerHash) {
+			if err := r.syncClusters(); err != nil {
+				return err
+			}
+		}
+		if err := r.updateReplicationFlowsStatus(); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
+// isClusterSyncNeeded checks whether synchronization interval has passed since the last synchronization,
+// so the health check of replication flows, which requeues reconciliation more often, does not speed it up
+func (r ReconcileMirrorMaker) isClusterSyncNeeded(mirrorMakerHash string) bool {
+	interval := r.cr.Spec.MirrorMaker.ClusterSync.IntervalSeconds
+	if interval == nil || r.reconciler.clusterSyncHash != mirrorMakerHash ||
+		time.Since(r.reconciler.clusterSyncTime) >= time.Duration(*interval)*time.Second {
+		r.reconciler.clusterSyncHash = mirrorMakerHash
+		r.reconciler.clusterSyncTime = time.Now()
+		return true
+	}
+	return false
+}
+
+// syncClusters copies topic configs and ACLs to the standby cluster and updates synchronization status.
+// Errors of synchronization are shown in status only, so unavailable remote cluster does not block reconciliation.
+func (r ReconcileMirrorMaker) syncClusters() error {
+	if r.cr.Spec.DisasterRecovery != nil && r.cr.Spec.DisasterRecovery.Mode != "standby" {
+		r.logger.Info("Topic configs and ACLs are synchronized in standby mode only, skipping synchronization")
+		return nil
+	}
+	r.logger.Info("Synchronizing topic configs and ACLs between Kafka clusters")
+	syncStatus, err := NewKafkaClusterSync(r.cr, r.reconciler).Sync()
+	if err != nil {
+		r.logger.Error(err, "Cannot synchronize topic configs and ACLs between Kafka clusters")
+		syncStatus = &kafkaservice.ClusterSyncStatus{
+			LastSyncTime: metav1.Now().String(),
+			Message:      fmt.Sprintf("Synchronization failed: %v", err),
+		}
+	}
+	return r.reconciler.StatusUpdater.UpdateStatusWithRetry(func(instance *kafkaservice.KafkaService) {
+		instance.Status.MirrorMakerStatus.ClusterSync = syncStatus
+	})
+}
+
 func (r ReconcileMirrorMaker) Status() error {
 	if err := r.reconciler.updateConditions(NewCondition(statusFalse,
 		typeInProgress,
@@ -151,6 +245,9 @@ func (r ReconcileMirrorMaker) Status() error {
 		labels := r.mirrorMakerProvider.GetMirrorMakerSelectorLabels()
 		return r.reconciler.AreDeploymentsReady(labels, r.cr.Namespace, r.logger), nil
 	})
+	if statusErr := r.updateReplicationFlowsStatus(); statusErr != nil {
+		return statusErr
+	}
 	if err != nil {
 		return r.reconciler.updateConditions(NewCondition(statusFalse, typeFailed, mirrorMakerConditionReason, "Kafka Mirror Maker pods are not ready"))
 	}
@@ -158,7 +255,7 @@ func (r ReconcileMirrorMaker) Status() error {
 }
 
 func (r ReconcileMirrorMaker) createDeployment(cluster kafkaservice.Cluster, secret *corev1.Secret,
-	configurationVersion string) error {
+	sslSecret *corev1.Secret, configurationVersion string) (string, error) {
 	mirrorMakerProvider := r.mirrorMakerProvider
 	mirrorMakerSpec := r.cr.Spec.MirrorMaker
 
@@ -168,10 +265,31 @@ func (r ReconcileMirrorMaker) createDeployment(cluster kafkaservice.Cluster, sec
 
 	mirrorMakerDeployment := mirrorMakerProvider.NewMirrorMakerDeploymentForCR(cluster,
 		mirrorMakerSpec.Clusters, secret.ResourceVersion, configurationVersion, currentClusterName, deploymentName)
+	return deploymentName, r.createDeploymentWithService(mirrorMakerDeployment, secret, sslSecret)
+}
+
+// createFlowDeployment creates deployment and service for replication flow which is scaled independently
+func (r ReconcileMirrorMaker) createFlowDeployment(flow kafkaservice.ReplicationFlow, secret *corev1.Secret,
+	sslSecret *corev1.Secret, flowConfigurationVersions map[string]string) (string, error) {
+	flowProvider, err := r.mirrorMakerProvider.ForReplicationFlow(flow)
+	if err != nil {
+		return "", err
+	}
+	deploymentName := r.mirrorMakerProvider.GetReplicationFlowDeploymentName(flow)
+	r.logger.Info(fmt.Sprintf("Create deployment for %s replication flow",
+		kmmconfig.NewReplicationFlow(flow.Source, flow.Target)))
+	configurationKey := fmt.Sprintf("%s.%s", flowProvider.GetConfigurationName(), r.cr.Namespace)
+	mirrorMakerDeployment := flowProvider.NewReplicationFlowDeploymentForCR(
+		secret.ResourceVersion, flowConfigurationVersions[configurationKey], deploymentName)
+	return deploymentName, r.createDeploymentWithService(mirrorMakerDeployment, secret, sslSecret)
+}
+
+func (r ReconcileMirrorMaker) createDeploymentWithService(mirrorMakerDeployment *appsv1.Deployment,
+	secret *corev1.Secret, sslSecret *corev1.Secret) error {
 	if err := r.reconciler.SetControllerReference(r.cr, mirrorMakerDeployment, r.reconciler.Scheme); err != nil {
 		return err
 	}
-	mirrorMakerService := mirrorMakerProvider.GetService(deploymentName)
+	mirrorMakerService := r.mirrorMakerProvider.GetService(mirrorMakerDeployment.Name)
 	if err := r.reconciler.SetControllerReference(r.cr, mirrorMakerService, r.reconciler.Scheme); err != nil {
 		return err
 	}
@@ -181,8 +299,63 @@ func (r ReconcileMirrorMaker) createDeployment(cluster kafkaservice.Cluster, sec
 	if err := r.reconciler.CreateOrUpdateDeployment(mirrorMakerDeployment, r.logger); err != nil {
 		return err
 	}
-	return updateDeploymentSecretRestartAnnotations(
-		r.reconciler.Client, r.cr.Namespace, deploymentName, r.logger, secret)
+	return controllers.UpdateDeploymentSecretRestartAnnotations(
+		r.reconciler.Client, r.cr.Namespace, mirrorMakerDeployment.Name, r.logger, secret, sslSecret)
+}
+
+// reconcileFlowConfigurations creates or updates configuration maps of replication flows
+// and returns their versions by configuration keys
+func (r ReconcileMirrorMaker) reconcileFlowConfigurations() (map[string]string, error) {
+	versions := make(map[string]string)
+	if !r.mirrorMakerProvider.HasDedicatedFlows() {
+		return versions, nil
+	}
+	for _, flow := range r.mirrorMakerProvider.GetReplicationFlows() {
+		flowProvider, err := r.mirrorMakerProvider.ForReplicationFlow(flow)
+		if err != nil {
+			return nil, err
+		}
+		configMap := flowProvider.NewConfigurationMapForCR()
+		if err := r.reconciler.SetControllerReference(r.cr, configMap, r.reconciler.Scheme); err != nil {
+			return nil, err
+		}
+		if err := r.reconciler.CreateOrUpdateConfigMap(configMap, r.logger); err != nil {
+			return nil, err
+		}
+		versions[fmt.Sprintf("%s.%s", configMap.Name, r.cr.Namespace)] = configMap.ResourceVersion
+	}
+	return versions, nil
+}
+
+func (r ReconcileMirrorMaker) areFlowConfigurationsChanged(flowConfigurationVersions map[string]string) bool {
+	for key, version := range flowConfigurationVersions {
+		if r.reconciler.ResourceVersions[key] != version {
+			return true
+		}
+	}
+	return false
+}
+
+// deleteUnusedDeployments removes Kafka Mirror Maker deployments and services which are left
+// after switching between per-cluster and per-flow deployments or removing replication flows
+func (r ReconcileMirrorMaker) deleteUnusedDeployments(deploymentNames []string) error {
+	deployments, err := r.reconciler.FindDeploymentList(r.cr.Namespace, r.mirrorMakerProvider.GetMirrorMakerSelectorLabels())
+	if err != nil {
+		return err
+	}
+	for _, deployment := range deployments.Items {
+		if slices.Contains(deploymentNames, deployment.Name) {
+			continue
+		}
+		r.logger.Info(fmt.Sprintf("Deleting unused Kafka Mirror Maker deployment %s", deployment.Name))
+		if err := r.reconciler.DeleteDeployment(&deployment, r.logger); err != nil {
+			return err
+		}
+		if err := r.reconciler.DeleteService(r.mirrorMakerProvider.GetService(deployment.Name), r.logger); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // updateMirrorMakerStatus updates the status of Kafka Mirror Maker
@@ -196,3 +369,144 @@ func (r *ReconcileMirrorMaker) updateMirrorMakerStatus(cr *kafkaservice.KafkaSer
 		instance.Status.MirrorMakerStatus.Nodes = controllers.GetPodNames(foundPodList.Items)
 	})
 }
+
+// updateReplicationFlowsStatus updates the health of each replication flow in Kafka Mirror Maker status.
+// The flow is handled by the deployment of its target cluster, so it is healthy when this deployment is ready.
+func (r ReconcileMirrorMaker) updateReplicationFlowsStatus() error {
+	var flowsStatus []kafkaservice.ReplicationFlowStatus
+	for _, flow := range r.mirrorMakerProvider.GetReplicationFlows() {
+		deploymentName := r.mirrorMakerProvider.GetReplicationFlowDeploymentName(flow)
+		flowStatus := kafkaservice.ReplicationFlowStatus{
+			Name:   kmmconfig.NewReplicationFlow(strings.ToLower(flow.Source), strings.ToLower(flow.Target)),
+			Status: replicationFlowHealthy,
+		}
+		deployment, err := r.reconciler.FindDeployment(deploymentName, r.cr.Namespace, r.logger)
+		if err != nil {
+			if !errors.IsNotFound(err) {
+				return err
+			}
+			flowStatus.Status = replicationFlowUnhealthy
+			flowStatus.Message = fmt.Sprintf("Deployment %s is not found", deploymentName)
+		} else if !controllers.IsDeploymentReady(*deployment) {
+			flowStatus.Status = replicationFlowUnhealthy
+			flowStatus.Message = fmt.Sprintf("Deployment %s is not ready", deploymentName)
+		} else if r.mirrorMakerProvider.IsInternalRestEnabled() {
+			if err = r.inspectReplicationFlow(flow, deploymentName, &flowStatus); err != nil {
+				return err
+			}
+		}
+		flowsStatus = append(flowsStatus, flowStatus)
+	}
+	return r.reconciler.StatusUpdater.UpdateStatusWithRetry(func(instance *kafkaservice.KafkaService) {
+		instance.Status.MirrorMakerStatus.Flows = flowsStatus
+	})
+}
+
+// inspectReplicationFlow reads the state of connector tasks of the flow from Kafka Connect status topic in target
+// cluster and checks that tasks are run by pods of the flow deployment
+func (r ReconcileMirrorMaker) inspectReplicationFlow(flow kafkaservice.ReplicationFlow, deploymentName string,
+	flowStatus *kafkaservice.ReplicationFlowStatus) error {
+	labels := r.mirrorMakerProvider.GetMirrorMakerSelectorLabels()
+	labels["name"] = deploymentName
+	pods, err := r.reconciler.FindPodList(r.cr.Namespace, labels)
+	if err != nil {
+		return err
+	}
+	if controllers.GetFirstAvailablePod(pods) == nil {
+		// deployment is scaled down, for example, in disaster recovery mode
+		return nil
+	}
+	tasks, failures, err := r.readReplicationFlowTasks(flow)
+	if err != nil {
+		r.logger.Error(err, fmt.Sprintf("Cannot get state of %s replication flow", flowStatus.Name))
+		flowStatus.Status = replicationFlowUnhealthy
+		flowStatus.Message = fmt.Sprintf("Cannot get state of connectors from status topic: %v", err)
+		return nil
+	}
+	flowStatus.Tasks = tasks
+	// worker identifier contains the address of pod only if internal REST API is enabled
+	failures = append(failures, r.checkTaskWorkers(tasks, pods.Items, deploymentName)...)
+	if len(failures) > 0 {
+		flowStatus.Status = replicationFlowUnhealthy
+		flowStatus.Message = strings.Join(failures, "; ")
+	}
+	return nil
+}
+
+// readReplicationFlowTasks returns the state of connector tasks of replication flow from Kafka Connect status topic
+// in target cluster and failures of connectors and tasks
+func (r ReconcileMirrorMaker) readReplicationFlowTasks(flow kafkaservice.ReplicationFlow) (
+	[]kafkaservice.ReplicationTaskStatus, []string, error) {
+	target, err := findCluster(flow.Target, r.cr)
+	if err != nil {
+		return nil, nil, err
+	}
+	client, err := r.newClusterClient(*target)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() { _ = client.Close() }()
+	records, err := readStatusTopic(client, strings.ToLower(flow.Source))
+	if err != nil {
+		return nil, nil, err
+	}
+	return inspectStatusRecords(records)
+}
+
+// checkTaskWorkers checks that running and failed tasks are assigned to existing pods of the deployment
+// and restarts failed tasks if it is needed
+func (r ReconcileMirrorMaker) checkTaskWorkers(tasks []kafkaservice.ReplicationTaskStatus, pods []corev1.Pod,
+	deploymentName string) []string {
+	var failures []string
+	httpClient := &http.Client{Timeout: taskRestartTimeout}
+	for _, task := range tasks {
+		if task.State != connectorStateRunning && task.State != connectorStateFailed {
+			continue
+		}
+		if findWorkerPod(task.WorkerId, pods) == nil {
+			failures = append(failures, fmt.Sprintf("task %s-%d is assigned to worker %s which is not a pod of deployment %s",
+				task.Connector, task.Id, task.WorkerId, deploymentName))
+			continue
+		}
+		if task.State != connectorStateFailed || !r.cr.Spec.MirrorMaker.RestartFailedTasks {
+			continue
+		}
+		r.logger.Info(fmt.Sprintf("Restarting failed task %s-%d on worker %s", task.Connector, task.Id, task.WorkerId))
+		if err := restartConnectorTask(httpClient, task.WorkerId, task.Connector, task.Id); err != nil {
+			failures = append(failures, fmt.Sprintf("restart of task %s-%d failed: %v", task.Connector, task.Id, err))
+		} else {
+			failures = append(failures, fmt.Sprintf("task %s-%d is restarted", task.Connector, task.Id))
+		}
+	}
+	return failures
+}
+
+// isFlowInspectionEnabled checks whether the operator inspects connector tasks of replication flows periodically
+func isFlowInspectionEnabled(cr *kafkaservice.KafkaService, logger logr.Logger) bool {
+	return cr.Spec.MirrorMaker != nil && len(cr.Spec.MirrorMaker.Clusters) > 1 &&
+		provider.NewMirrorMakerResourceProvider(cr, logger).IsInternalRestEnabled()
+}
+
+// newClusterClient returns Kafka client for cluster of Kafka Mirror Maker with credentials from its secret
+func (r ReconcileMirrorMaker) newClusterClient(cluster kafkaservice.Cluster) (sarama.Client, error) {
+	secret, err := r.reconciler.FindSecret(r.cr.Spec.MirrorMaker.SecretName, r.cr.Namespace, r.logger)
+	if err != nil {
+		return nil, err
+	}
+	saslSettings := &controllers.SaslSettings{
+		Mechanism: cluster.SaslMechanism,
+		Username:  string(secret.Data[fmt.Sprintf("%s-kafka-username", strings.ToLower(cluster.Name))]),
+		Password:  string(secret.Data[fmt.Sprintf("%s-kafka-password", strings.ToLower(cluster.Name))]),
+	}
+	sslCertificates := &controllers.SslCertificates{}
+	if sslSecretName := provider.GetClusterSslSecretName(r.cr, cluster); cluster.EnableSsl && sslSecretName != "" {
+		if sslCertificates, err = r.reconciler.GetSslCertificates(sslSecretName, r.cr.Namespace, r.logger); err != nil {
+			return nil, err
+		}
+	}
+	config, err := controllers.NewKafkaClientConfig(saslSettings, cluster.EnableSsl, sslCertificates)
+	if err != nil {
+		return nil, err
+	}
+	return sarama.NewClient(strings.Split(cluster.BootstrapServers, ","), config)
+}
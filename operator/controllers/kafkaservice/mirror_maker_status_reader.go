@@ -0,0 +1,223 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaservice
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/IBM/sarama"
+	kafkaservice "github.com/Netcracker/qubership-kafka/operator/api/v7"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	connectorStateRunning    = "RUNNING"
+	connectorStateFailed     = "FAILED"
+	connectorStatusKeyPrefix = "status-connector-"
+	taskStatusKeyPrefix      = "status-task-"
+	statusTopicReadTimeout   = 10 * time.Second
+	taskRestartTimeout       = 10 * time.Second
+)
+
+// mirrorMakerConnectors are the connectors which Kafka Mirror Maker runs for each replication flow
+var mirrorMakerConnectors = []string{"MirrorSourceConnector", "MirrorCheckpointConnector", "MirrorHeartbeatConnector"}
+
+// connectorState is the value of connector or task record in Kafka Connect status topic
+type connectorState struct {
+	State    string `json:"state"`
+	Trace    string `json:"trace"`
+	WorkerId string `json:"worker_id"`
+}
+
+type offsetRange struct {
+	oldest int64
+	newest int64
+}
+
+// statusTopicName returns the name of Kafka Connect status topic which Kafka Mirror Maker creates in target cluster
+// for the replication flow from specified source cluster
+func statusTopicName(source string) string {
+	return fmt.Sprintf("mm2-status.%s.internal", source)
+}
+
+// readStatusTopic returns the latest records of Kafka Connect status topic of the replication flow
+// from specified source cluster. The topic contains the state of connectors and tasks of all workers.
+func readStatusTopic(client sarama.Client, source string) (map[string][]byte, error) {
+	topic := statusTopicName(source)
+	partitions, err := client.Partitions(topic)
+	if err != nil {
+		return nil, err
+	}
+	ranges := make(map[int32]offsetRange)
+	for _, partition := range partitions {
+		oldest, err := client.GetOffset(topic, partition, sarama.OffsetOldest)
+		if err != nil {
+			return nil, err
+		}
+		newest, err := client.GetOffset(topic, partition, sarama.OffsetNewest)
+		if err != nil {
+			return nil, err
+		}
+		ranges[partition] = offsetRange{oldest: oldest, newest: newest}
+	}
+	consumer, err := sarama.NewConsumerFromClient(client)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = consumer.Close() }()
+	return readCompactedTopic(consumer, topic, ranges, statusTopicReadTimeout)
+}
+
+// readCompactedTopic returns the latest value of each key of the topic within specified offsets,
+// records with empty value remove the key
+func readCompactedTopic(consumer sarama.Consumer, topic string, ranges map[int32]offsetRange,
+	timeout time.Duration) (map[string][]byte, error) {
+	records := make(map[string][]byte)
+	for partition, offsets := range ranges {
+		if offsets.oldest >= offsets.newest {
+			continue
+		}
+		partitionConsumer, err := consumer.ConsumePartition(topic, partition, offsets.oldest)
+		if err != nil {
+			return nil, err
+		}
+		err = consumePartition(partitionConsumer, offsets.newest, timeout, records)
+		_ = partitionConsumer.Close()
+		if err != nil {
+			return nil, fmt.Errorf("cannot read partition %d of topic %s: %w", partition, topic, err)
+		}
+	}
+	return records, nil
+}
+
+func consumePartition(partitionConsumer sarama.PartitionConsumer, newestOffset int64, timeout time.Duration,
+	records map[string][]byte) error {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		select {
+		case message, ok := <-partitionConsumer.Messages():
+			if !ok {
+				return fmt.Errorf("consumer is closed")
+			}
+			if len(message.Value) == 0 {
+				delete(records, string(message.Key))
+			} else {
+				records[string(message.Key)] = message.Value
+			}
+			if message.Offset >= newestOffset-1 {
+				return nil
+			}
+		case consumerErr, ok := <-partitionConsumer.Errors():
+			if ok {
+				return consumerErr
+			}
+		case <-timer.C:
+			return fmt.Errorf("timeout occurred")
+		}
+	}
+}
+
+// inspectStatusRecords returns the state of all connector tasks from records of Kafka Connect status topic.
+// The returned failures describe connectors which are not running and failed tasks.
+func inspectStatusRecords(records map[string][]byte) ([]kafkaservice.ReplicationTaskStatus, []string, error) {
+	var tasks []kafkaservice.ReplicationTaskStatus
+	var failures []string
+	for _, connector := range mirrorMakerConnectors {
+		if value, found := records[connectorStatusKeyPrefix+connector]; found {
+			state := connectorState{}
+			if err := json.Unmarshal(value, &state); err != nil {
+				return nil, nil, err
+			}
+			if state.State != connectorStateRunning {
+				failures = append(failures, fmt.Sprintf("connector %s is %s", connector, state.State))
+			}
+		}
+		var connectorTasks []kafkaservice.ReplicationTaskStatus
+		taskKeyPrefix := fmt.Sprintf("%s%s-", taskStatusKeyPrefix, connector)
+		for key, value := range records {
+			id, err := strconv.ParseInt(strings.TrimPrefix(key, taskKeyPrefix), 10, 32)
+			if !strings.HasPrefix(key, taskKeyPrefix) || err != nil {
+				continue
+			}
+			state := connectorState{}
+			if err = json.Unmarshal(value, &state); err != nil {
+				return nil, nil, err
+			}
+			connectorTasks = append(connectorTasks, kafkaservice.ReplicationTaskStatus{
+				Connector: connector,
+				Id:        int32(id),
+				State:     state.State,
+				WorkerId:  state.WorkerId,
+				Message:   firstLine(state.Trace),
+			})
+		}
+		sort.Slice(connectorTasks, func(i, j int) bool {
+			return connectorTasks[i].Id < connectorTasks[j].Id
+		})
+		tasks = append(tasks, connectorTasks...)
+	}
+	for _, task := range tasks {
+		if task.State == connectorStateFailed {
+			failures = append(failures, fmt.Sprintf("task %s-%d is %s", task.Connector, task.Id, task.State))
+		}
+	}
+	return tasks, failures, nil
+}
+
+// findWorkerPod returns the pod which runs Kafka Connect worker with specified identifier.
+// The identifier of worker is the address of its internal REST API, so it contains either pod IP or host name.
+func findWorkerPod(workerId string, pods []corev1.Pod) *corev1.Pod {
+	host, _, err := net.SplitHostPort(workerId)
+	if err != nil {
+		host = workerId
+	}
+	for i, pod := range pods {
+		if host == pod.Status.PodIP || host == pod.Name || strings.HasPrefix(host, pod.Name+".") {
+			return &pods[i]
+		}
+	}
+	return nil
+}
+
+// restartConnectorTask restarts only the specified task of connector with Kafka Connect REST API of the worker
+// which runs this task
+func restartConnectorTask(httpClient *http.Client, workerId string, connector string, id int32) error {
+	url := fmt.Sprintf("http://%s/connectors/%s/tasks/%d/restart", workerId, connector, id)
+	response, err := httpClient.Post(url, "application/json", nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = response.Body.Close() }()
+	if response.StatusCode >= http.StatusMultipleChoices {
+		body, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+		return fmt.Errorf("worker %s responded with status %d: %s", workerId, response.StatusCode,
+			strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+func firstLine(text string) string {
+	line, _, _ := strings.Cut(text, "\n")
+	return line
+}
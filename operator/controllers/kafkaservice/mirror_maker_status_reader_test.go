@@ -0,0 +1,122 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaservice
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/IBM/sarama/mocks"
+	kafkaservice "github.com/Netcracker/qubership-kafka/operator/api/v7"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func statusRecord(key string, value string, offset int64) *sarama.ConsumerMessage {
+	message := &sarama.ConsumerMessage{Key: []byte(key), Offset: offset}
+	if value != "" {
+		message.Value = []byte(value)
+	}
+	return message
+}
+
+func TestMirrorMakerStatusReader_readCompactedTopic(t *testing.T) {
+	consumer := mocks.NewConsumer(t, nil)
+	topic := statusTopicName("dc1")
+	consumer.ExpectConsumePartition(topic, 0, 3).
+		YieldMessage(statusRecord("status-task-MirrorSourceConnector-0", `{"state":"RUNNING"}`, 3)).
+		YieldMessage(statusRecord("status-task-MirrorSourceConnector-1", `{"state":"RUNNING"}`, 4)).
+		YieldMessage(statusRecord("status-task-MirrorSourceConnector-0", `{"state":"FAILED"}`, 5)).
+		YieldMessage(statusRecord("status-task-MirrorSourceConnector-1", "", 6))
+
+	records, err := readCompactedTopic(consumer, topic,
+		map[int32]offsetRange{0: {oldest: 3, newest: 7}, 1: {oldest: 2, newest: 2}}, time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"status-task-MirrorSourceConnector-0": []byte(`{"state":"FAILED"}`)}, records)
+}
+
+func TestMirrorMakerStatusReader_readCompactedTopicTimeout(t *testing.T) {
+	consumer := mocks.NewConsumer(t, nil)
+	consumer.ExpectConsumePartition("mm2-status.dc1.internal", 0, 0).
+		YieldMessage(statusRecord("status-connector-MirrorSourceConnector", `{"state":"RUNNING"}`, 0))
+
+	_, err := readCompactedTopic(consumer, "mm2-status.dc1.internal",
+		map[int32]offsetRange{0: {oldest: 0, newest: 5}}, 50*time.Millisecond)
+	assert.EqualError(t, err, "cannot read partition 0 of topic mm2-status.dc1.internal: timeout occurred")
+}
+
+func TestMirrorMakerStatusReader_inspectStatusRecords(t *testing.T) {
+	records := map[string][]byte{
+		"status-connector-MirrorSourceConnector":     []byte(`{"state":"RUNNING","trace":null,"worker_id":"10.0.0.1:8083","generation":3}`),
+		"status-connector-MirrorCheckpointConnector": []byte(`{"state":"UNASSIGNED","trace":null,"worker_id":"10.0.0.2:8083","generation":3}`),
+		"status-task-MirrorSourceConnector-1": []byte(`{"state":"FAILED",` +
+			`"trace":"org.apache.kafka.connect.errors.ConnectException: Failed to fetch offsets\nat ...","worker_id":"10.0.0.2:8083"}`),
+		"status-task-MirrorSourceConnector-0":                 []byte(`{"state":"RUNNING","worker_id":"10.0.0.1:8083"}`),
+		"status-topic-orders:connector-MirrorSourceConnector": []byte(`{"topic":{"name":"orders"}}`),
+	}
+	tasks, failures, err := inspectStatusRecords(records)
+	assert.NoError(t, err)
+	assert.Equal(t, []kafkaservice.ReplicationTaskStatus{
+		{Connector: "MirrorSourceConnector", Id: 0, State: "RUNNING", WorkerId: "10.0.0.1:8083"},
+		{Connector: "MirrorSourceConnector", Id: 1, State: "FAILED", WorkerId: "10.0.0.2:8083",
+			Message: "org.apache.kafka.connect.errors.ConnectException: Failed to fetch offsets"},
+	}, tasks)
+	assert.Equal(t, []string{
+		"connector MirrorCheckpointConnector is UNASSIGNED",
+		"task MirrorSourceConnector-1 is FAILED",
+	}, failures)
+
+	_, _, err = inspectStatusRecords(map[string][]byte{"status-connector-MirrorSourceConnector": []byte("{")})
+	assert.Error(t, err)
+}
+
+func TestMirrorMakerStatusReader_findWorkerPod(t *testing.T) {
+	pods := []corev1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Name: "dc2-mirror-maker-1"}, Status: corev1.PodStatus{PodIP: "10.0.0.1"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "dc2-mirror-maker-2"}, Status: corev1.PodStatus{PodIP: "10.0.0.2"}},
+	}
+	assert.Equal(t, "dc2-mirror-maker-2", findWorkerPod("10.0.0.2:8083", pods).Name)
+	assert.Equal(t, "dc2-mirror-maker-1", findWorkerPod("dc2-mirror-maker-1:8083", pods).Name)
+	assert.Equal(t, "dc2-mirror-maker-1",
+		findWorkerPod("dc2-mirror-maker-1.kafka.svc.cluster.local:8083", pods).Name)
+	assert.Nil(t, findWorkerPod("10.0.0.3:8083", pods))
+}
+
+func TestMirrorMakerStatusReader_restartConnectorTask(t *testing.T) {
+	var restartedPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, request *http.Request) {
+		if request.Method != http.MethodPost || request.URL.Path == "/connectors/MirrorCheckpointConnector/tasks/0/restart" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error_code":404,"message":"Unknown connector"}`))
+			return
+		}
+		restartedPath = request.URL.Path
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+	workerId := strings.TrimPrefix(server.URL, "http://")
+
+	assert.NoError(t, restartConnectorTask(server.Client(), workerId, "MirrorSourceConnector", 1))
+	assert.Equal(t, "/connectors/MirrorSourceConnector/tasks/1/restart", restartedPath)
+	err := restartConnectorTask(server.Client(), workerId, "MirrorCheckpointConnector", 0)
+	assert.EqualError(t, err, fmt.Sprintf(
+		`worker %s responded with status 404: {"error_code":404,"message":"Unknown connector"}`, workerId))
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/Netcracker/qubership-kafka/operator/controllers/provider"
 	"github.com/Netcracker/qubership-kafka/operator/util"
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
 )
 
@@ -62,6 +63,14 @@ func (r ReconcileMonitoring) Reconcile() error {
 		return err
 	}
 
+	var sslSecret *corev1.Secret
+	if provider.IsCertManagerEnabled(r.cr) {
+		sslSecret, err = r.reconciler.WatchSecret(r.monitoringProvider.GetSslSecretName(), r.cr, r.logger)
+		if err != nil {
+			return err
+		}
+	}
+
 	currentCMResourceVersion := ""
 	if r.cr.Spec.Monitoring.LagExporter != nil {
 		configMap, err := r.reconciler.FindConfigMap(r.lagExporterConfigMapName, r.cr.Namespace, r.logger)
@@ -120,9 +129,9 @@ func (r ReconcileMonitoring) Reconcile() error {
 		r.logger.Info("Kafka monitoring configuration didn't change, skipping reconcile loop")
 	}
 
-	if err := updateDeploymentSecretRestartAnnotations(
+	if err := controllers.UpdateDeploymentSecretRestartAnnotations(
 		r.reconciler.Client, r.cr.Namespace, r.monitoringProvider.GetServiceName(), r.logger,
-		monitoringSecret, kafkaServicesSecret); err != nil {
+		monitoringSecret, kafkaServicesSecret, sslSecret); err != nil {
 		return err
 	}
 
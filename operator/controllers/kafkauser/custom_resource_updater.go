@@ -65,3 +65,29 @@ func (cru CustomResourceUpdater) GetCustomResource() (*kafka.KafkaUser, error) {
 		types.NamespacedName{Name: cru.name, Namespace: cru.namespace}, instance)
 	return instance, err
 }
+
+type OffsetResetStatusUpdater struct {
+	client    client.Client
+	name      string
+	namespace string
+}
+
+func NewOffsetResetStatusUpdater(client client.Client, cr *kafka.KafkaOffsetReset) OffsetResetStatusUpdater {
+	return OffsetResetStatusUpdater{
+		client:    client,
+		name:      cr.Name,
+		namespace: cr.Namespace,
+	}
+}
+
+func (u OffsetResetStatusUpdater) UpdateStatusWithRetry(statusUpdateFunc func(*kafka.KafkaOffsetReset)) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		instance := &kafka.KafkaOffsetReset{}
+		if err := u.client.Get(context.TODO(),
+			types.NamespacedName{Name: u.name, Namespace: u.namespace}, instance); err != nil {
+			return err
+		}
+		statusUpdateFunc(instance)
+		return u.client.Status().Update(context.TODO(), instance)
+	})
+}
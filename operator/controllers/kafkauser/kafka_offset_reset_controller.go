@@ -0,0 +1,311 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkauser
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/IBM/sarama"
+	kafka "github.com/Netcracker/qubership-kafka/operator/api/v1"
+	"github.com/Netcracker/qubership-kafka/operator/controllers"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+const (
+	offsetResetPhaseSuccessful = "successful"
+	offsetResetPhaseFailed     = "failed"
+	resetToEarliest            = "earliest"
+	resetToLatest              = "latest"
+	resetToTimestamp           = "timestamp"
+)
+
+// KafkaOffsetResetReconciler reconciles a KafkaOffsetReset object
+type KafkaOffsetResetReconciler struct {
+	Client              client.Client
+	Namespace           string
+	ApiGroup            string
+	newKafkaAdminClient func(logr.Logger) (sarama.ClusterAdmin, error)
+}
+
+// NewKafkaOffsetResetReconciler creates reconciler which connects to Kafka the same way as KafkaUser reconciler
+func NewKafkaOffsetResetReconciler(userReconciler *KafkaUserReconciler) *KafkaOffsetResetReconciler {
+	return &KafkaOffsetResetReconciler{
+		Client:              userReconciler.Client,
+		Namespace:           userReconciler.Namespace,
+		ApiGroup:            userReconciler.ApiGroup,
+		newKafkaAdminClient: userReconciler.newKafkaAdminClient,
+	}
+}
+
+//+kubebuilder:rbac:groups=netcracker.com,resources=kafkaoffsetresets,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=netcracker.com,resources=kafkaoffsetresets/status,verbs=get;update;patch
+
+func (r *KafkaOffsetResetReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	logger := logf.Log.WithName("controller_kafka_offset_reset").
+		WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	logger.Info("Reconciling KafkaOffsetReset")
+
+	instance := &kafka.KafkaOffsetReset{}
+	if err := r.Client.Get(ctx, request.NamespacedName, instance); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	if !controllers.ApiGroupMatches(instance.APIVersion, r.ApiGroup) {
+		return ctrl.Result{}, nil
+	}
+	if instance.Status.Phase != "" {
+		logger.Info(fmt.Sprintf("Offsets reset is already %s, skipping", instance.Status.Phase))
+		return ctrl.Result{}, nil
+	}
+
+	kafkaClient, err := r.newKafkaAdminClient(logger)
+	if err != nil {
+		logger.Error(err, "Cannot connect to Kafka")
+		return ctrl.Result{}, err
+	}
+	defer func() { _ = kafkaClient.Close() }()
+
+	status, err := resetGroupOffsets(kafkaClient, instance, r.Namespace, time.Now())
+	if err != nil {
+		logger.Error(err, fmt.Sprintf("Cannot reset offsets of consumer group %s", instance.Spec.Group))
+		return ctrl.Result{}, err
+	}
+	logger.Info(fmt.Sprintf("Offsets reset of consumer group %s is %s: %s",
+		instance.Spec.Group, status.Phase, status.Message))
+	return ctrl.Result{}, NewOffsetResetStatusUpdater(r.Client, instance).
+		UpdateStatusWithRetry(func(reset *kafka.KafkaOffsetReset) {
+			reset.Status = status
+		})
+}
+
+// resetGroupOffsets resolves offsets of all partitions of specified topics and commits them for consumer group.
+// The group must not have active members, otherwise they would override the committed offsets.
+// Kafka errors are returned to repeat the reset, the reset fails only if the resource is invalid
+// or the group has active members.
+func resetGroupOffsets(kafkaClient sarama.ClusterAdmin, reset *kafka.KafkaOffsetReset, operatorNamespace string,
+	now time.Time) (kafka.KafkaOffsetResetStatus, error) {
+	status := kafka.KafkaOffsetResetStatus{
+		Phase:              offsetResetPhaseFailed,
+		CompletionTime:     now.Format(time.RFC3339),
+		ObservedGeneration: reset.Generation,
+	}
+	spec := reset.Spec
+	if reset.Namespace != operatorNamespace && !strings.HasPrefix(spec.Group, reset.Namespace) {
+		status.Message = fmt.Sprintf("Consumer group %s does not belong to namespace %s, "+
+			"the group name must start with the namespace name", spec.Group, reset.Namespace)
+		return status, nil
+	}
+	query, err := offsetQuery(spec)
+	if err != nil {
+		status.Message = err.Error()
+		return status, nil
+	}
+	members, err := controllers.CountConsumerGroupMembers(kafkaClient, spec.Group)
+	if err != nil {
+		return kafka.KafkaOffsetResetStatus{}, err
+	}
+	if members > 0 {
+		status.Message = fmt.Sprintf("Consumer group %s has %d active members, "+
+			"offsets can be reset only when all consumers of the group are stopped", spec.Group, members)
+		return status, nil
+	}
+	partitions, err := listTopicPartitions(kafkaClient, spec.Topics)
+	if stderrors.Is(err, sarama.ErrUnknownTopicOrPartition) {
+		status.Message = err.Error()
+		return status, nil
+	} else if err != nil {
+		return kafka.KafkaOffsetResetStatus{}, err
+	}
+	offsets, err := resolveOffsets(kafkaClient, partitions, query)
+	if err != nil {
+		return kafka.KafkaOffsetResetStatus{}, fmt.Errorf("cannot resolve offsets: %w", err)
+	}
+	committed, err := kafkaClient.ListConsumerGroupOffsets(spec.Group, partitions)
+	if err != nil {
+		return kafka.KafkaOffsetResetStatus{},
+			fmt.Errorf("cannot get committed offsets of consumer group %s: %w", spec.Group, err)
+	}
+	status.Offsets = partitionOffsets(offsets, committed)
+	newOffsets := make(map[string]map[int32]sarama.OffsetAndMetadata)
+	for topic, topicOffsets := range offsets {
+		newOffsets[topic] = make(map[int32]sarama.OffsetAndMetadata)
+		for partition, offset := range topicOffsets {
+			newOffsets[topic][partition] = sarama.OffsetAndMetadata{Offset: offset, LeaderEpoch: -1}
+		}
+	}
+	response, err := kafkaClient.AlterConsumerGroupOffsets(spec.Group, newOffsets, nil)
+	if err = controllers.OffsetCommitError(response, err); err != nil {
+		return kafka.KafkaOffsetResetStatus{},
+			fmt.Errorf("cannot commit offsets of consumer group %s: %w", spec.Group, err)
+	}
+	status.Phase = offsetResetPhaseSuccessful
+	status.Message = fmt.Sprintf("Offsets of %d partitions have been reset to %s", len(status.Offsets), spec.ResetTo)
+	if spec.ResetTo == resetToTimestamp {
+		status.Message = fmt.Sprintf("%s %s", status.Message, spec.Timestamp)
+	}
+	return status, nil
+}
+
+// offsetQuery returns the time which is used to find offsets by ListOffsets request
+func offsetQuery(spec kafka.KafkaOffsetResetSpec) (int64, error) {
+	switch spec.ResetTo {
+	case resetToEarliest:
+		return sarama.OffsetOldest, nil
+	case resetToLatest:
+		return sarama.OffsetNewest, nil
+	case resetToTimestamp:
+		timestamp, err := time.Parse(time.RFC3339, spec.Timestamp)
+		if err != nil {
+			return 0, fmt.Errorf("timestamp %q is not in RFC3339 format", spec.Timestamp)
+		}
+		return timestamp.UnixMilli(), nil
+	}
+	return 0, fmt.Errorf("unsupported reset position: %s", spec.ResetTo)
+}
+
+func listTopicPartitions(kafkaClient sarama.ClusterAdmin, topics []string) (map[string][]int32, error) {
+	metadata, err := kafkaClient.DescribeTopics(topics)
+	if err != nil {
+		return nil, fmt.Errorf("cannot describe topics: %w", err)
+	}
+	partitions := make(map[string][]int32)
+	for _, topic := range metadata {
+		if topic.Err != sarama.ErrNoError {
+			return nil, fmt.Errorf("cannot describe topic %s: %w", topic.Name, topic.Err)
+		}
+		for _, partition := range topic.Partitions {
+			partitions[topic.Name] = append(partitions[topic.Name], partition.ID)
+		}
+	}
+	return partitions, nil
+}
+
+// resolveOffsets finds offsets of partitions by query. If partition does not contain messages
+// with timestamp greater than or equal to the requested one, the latest offset is used.
+func resolveOffsets(kafkaClient sarama.ClusterAdmin, partitions map[string][]int32,
+	query int64) (map[string]map[int32]int64, error) {
+	request := make(map[string]map[int32]int64)
+	for topic, topicPartitions := range partitions {
+		request[topic] = make(map[int32]int64)
+		for _, partition := range topicPartitions {
+			request[topic][partition] = query
+		}
+	}
+	offsets, err := listOffsets(kafkaClient, request)
+	if err != nil || query < 0 {
+		return offsets, err
+	}
+	latestRequest := make(map[string]map[int32]int64)
+	for topic, topicOffsets := range offsets {
+		for partition, offset := range topicOffsets {
+			if offset < 0 {
+				if latestRequest[topic] == nil {
+					latestRequest[topic] = make(map[int32]int64)
+				}
+				latestRequest[topic][partition] = sarama.OffsetNewest
+			}
+		}
+	}
+	if len(latestRequest) == 0 {
+		return offsets, nil
+	}
+	latestOffsets, err := listOffsets(kafkaClient, latestRequest)
+	if err != nil {
+		return nil, err
+	}
+	for topic, topicOffsets := range latestOffsets {
+		for partition, offset := range topicOffsets {
+			offsets[topic][partition] = offset
+		}
+	}
+	return offsets, nil
+}
+
+func listOffsets(kafkaClient sarama.ClusterAdmin, request map[string]map[int32]int64) (map[string]map[int32]int64, error) {
+	results, err := kafkaClient.ListOffsets(request, nil)
+	if err != nil {
+		return nil, err
+	}
+	offsets := make(map[string]map[int32]int64)
+	for topic, topicPartitions := range request {
+		offsets[topic] = make(map[int32]int64)
+		for partition := range topicPartitions {
+			result := results[topic][partition]
+			if result == nil {
+				return nil, fmt.Errorf("offset of partition %s-%d is not found", topic, partition)
+			}
+			if result.Err != nil {
+				return nil, fmt.Errorf("offset of partition %s-%d is not found: %w", topic, partition, result.Err)
+			}
+			offsets[topic][partition] = result.Offset
+		}
+	}
+	return offsets, nil
+}
+
+// partitionOffsets returns sorted offsets of partitions before and after reset
+func partitionOffsets(offsets map[string]map[int32]int64,
+	committed *sarama.OffsetFetchResponse) []kafka.KafkaPartitionOffset {
+	var result []kafka.KafkaPartitionOffset
+	for topic, topicOffsets := range offsets {
+		for partition, offset := range topicOffsets {
+			partitionOffset := kafka.KafkaPartitionOffset{Topic: topic, Partition: partition, After: offset}
+			if committed != nil {
+				if block := committed.GetBlock(topic, partition); block != nil && block.Offset >= 0 {
+					before := block.Offset
+					partitionOffset.Before = &before
+				}
+			}
+			result = append(result, partitionOffset)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Topic != result[j].Topic {
+			return result[i].Topic < result[j].Topic
+		}
+		return result[i].Partition < result[j].Partition
+	})
+	return result
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *KafkaOffsetResetReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	statusPredicate := predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			// Ignore updates to CR status in which case metadata.Generation does not change
+			return e.ObjectOld.GetGeneration() != e.ObjectNew.GetGeneration()
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return false
+		},
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&kafka.KafkaOffsetReset{}, builder.WithPredicates(statusPredicate)).
+		Complete(r)
+}
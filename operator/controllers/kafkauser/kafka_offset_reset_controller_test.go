@@ -0,0 +1,233 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkauser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	kafka "github.com/Netcracker/qubership-kafka/operator/api/v1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+// fakeOffsetsAdmin keeps partitions, committed offsets of consumer group and offsets of messages by timestamp
+type fakeOffsetsAdmin struct {
+	sarama.ClusterAdmin
+	partitions map[string]int32
+	members    int
+	committed  map[string]map[int32]int64
+	// offsets contains offsets of partitions by ListOffsets query
+	offsets        map[string]map[int32]map[int64]int64
+	listOffsetsErr error
+}
+
+func (a *fakeOffsetsAdmin) DescribeConsumerGroups(groups []string) ([]*sarama.GroupDescription, error) {
+	description := &sarama.GroupDescription{GroupId: groups[0], Members: map[string]*sarama.GroupMemberDescription{}}
+	for i := 0; i < a.members; i++ {
+		description.Members[string(rune('a'+i))] = &sarama.GroupMemberDescription{}
+	}
+	return []*sarama.GroupDescription{description}, nil
+}
+
+func (a *fakeOffsetsAdmin) DescribeTopics(topics []string) ([]*sarama.TopicMetadata, error) {
+	var metadata []*sarama.TopicMetadata
+	for _, topic := range topics {
+		count, found := a.partitions[topic]
+		if !found {
+			metadata = append(metadata, &sarama.TopicMetadata{Name: topic, Err: sarama.ErrUnknownTopicOrPartition})
+			continue
+		}
+		topicMetadata := &sarama.TopicMetadata{Name: topic}
+		for partition := int32(0); partition < count; partition++ {
+			topicMetadata.Partitions = append(topicMetadata.Partitions, &sarama.PartitionMetadata{ID: partition})
+		}
+		metadata = append(metadata, topicMetadata)
+	}
+	return metadata, nil
+}
+
+func (a *fakeOffsetsAdmin) ListOffsets(partitions map[string]map[int32]int64,
+	_ *sarama.ListOffsetsOptions) (map[string]map[int32]*sarama.OffsetResult, error) {
+	if a.listOffsetsErr != nil {
+		return nil, a.listOffsetsErr
+	}
+	result := make(map[string]map[int32]*sarama.OffsetResult)
+	for topic, topicPartitions := range partitions {
+		result[topic] = make(map[int32]*sarama.OffsetResult)
+		for partition, query := range topicPartitions {
+			offset, found := a.offsets[topic][partition][query]
+			if !found {
+				offset = -1
+			}
+			result[topic][partition] = &sarama.OffsetResult{Offset: offset}
+		}
+	}
+	return result, nil
+}
+
+func (a *fakeOffsetsAdmin) ListConsumerGroupOffsets(group string,
+	topicPartitions map[string][]int32) (*sarama.OffsetFetchResponse, error) {
+	response := &sarama.OffsetFetchResponse{Version: 8, Groups: []sarama.OffsetFetchResponseGroup{{GroupId: group}}}
+	for topic, partitions := range topicPartitions {
+		for _, partition := range partitions {
+			offset, found := a.committed[topic][partition]
+			if !found {
+				offset = -1
+			}
+			response.Groups[0].AddBlock(topic, partition, &sarama.OffsetFetchResponseBlock{Offset: offset})
+		}
+	}
+	return response, nil
+}
+
+func (a *fakeOffsetsAdmin) AlterConsumerGroupOffsets(_ string, offsets map[string]map[int32]sarama.OffsetAndMetadata,
+	_ *sarama.AlterConsumerGroupOffsetsOptions) (*sarama.OffsetCommitResponse, error) {
+	for topic, partitions := range offsets {
+		if a.committed[topic] == nil {
+			a.committed[topic] = make(map[int32]int64)
+		}
+		for partition, offset := range partitions {
+			a.committed[topic][partition] = offset.Offset
+		}
+	}
+	return &sarama.OffsetCommitResponse{}, nil
+}
+
+var resetTime = time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+func newOffsetsAdmin() *fakeOffsetsAdmin {
+	return &fakeOffsetsAdmin{
+		partitions: map[string]int32{"shop-orders": 2},
+		committed:  map[string]map[int32]int64{"shop-orders": {0: 50}},
+		offsets: map[string]map[int32]map[int64]int64{"shop-orders": {
+			0: {sarama.OffsetOldest: 5, sarama.OffsetNewest: 100, resetTime.UnixMilli(): 30},
+			1: {sarama.OffsetOldest: 0, sarama.OffsetNewest: 20},
+		}},
+	}
+}
+
+func newOffsetReset(namespace string, spec kafka.KafkaOffsetResetSpec) *kafka.KafkaOffsetReset {
+	return &kafka.KafkaOffsetReset{
+		ObjectMeta: metav1.ObjectMeta{Name: "rewind", Namespace: namespace, Generation: 1},
+		Spec:       spec,
+	}
+}
+
+func TestOffsetReset_resetGroupOffsets_timestamp(t *testing.T) {
+	admin := newOffsetsAdmin()
+	reset := newOffsetReset("shop", kafka.KafkaOffsetResetSpec{
+		Group:     "shop-billing",
+		Topics:    []string{"shop-orders"},
+		ResetTo:   resetToTimestamp,
+		Timestamp: "2025-03-01T10:00:00Z",
+	})
+
+	status, err := resetGroupOffsets(admin, reset, "kafka", resetTime.Add(time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, kafka.KafkaOffsetResetStatus{
+		Phase:   offsetResetPhaseSuccessful,
+		Message: "Offsets of 2 partitions have been reset to timestamp 2025-03-01T10:00:00Z",
+		Offsets: []kafka.KafkaPartitionOffset{
+			{Topic: "shop-orders", Partition: 0, Before: ptr.To(int64(50)), After: 30},
+			{Topic: "shop-orders", Partition: 1, After: 20},
+		},
+		CompletionTime:     "2025-03-01T10:01:00Z",
+		ObservedGeneration: 1,
+	}, status)
+	assert.Equal(t, map[int32]int64{0: 30, 1: 20}, admin.committed["shop-orders"])
+}
+
+func TestOffsetReset_resetGroupOffsets_earliest(t *testing.T) {
+	admin := newOffsetsAdmin()
+	reset := newOffsetReset("kafka", kafka.KafkaOffsetResetSpec{
+		Group:   "billing",
+		Topics:  []string{"shop-orders"},
+		ResetTo: resetToEarliest,
+	})
+
+	status, err := resetGroupOffsets(admin, reset, "kafka", resetTime)
+	assert.NoError(t, err)
+	assert.Equal(t, offsetResetPhaseSuccessful, status.Phase, status.Message)
+	assert.Equal(t, map[int32]int64{0: 5, 1: 0}, admin.committed["shop-orders"])
+}
+
+func TestOffsetReset_resetGroupOffsets_refused(t *testing.T) {
+	tests := []struct {
+		name      string
+		namespace string
+		spec      kafka.KafkaOffsetResetSpec
+		members   int
+		message   string
+	}{
+		{
+			name:      "active members",
+			namespace: "shop",
+			spec:      kafka.KafkaOffsetResetSpec{Group: "shop-billing", Topics: []string{"shop-orders"}, ResetTo: resetToLatest},
+			members:   2,
+			message: "Consumer group shop-billing has 2 active members, " +
+				"offsets can be reset only when all consumers of the group are stopped",
+		},
+		{
+			name:      "foreign group",
+			namespace: "shop",
+			spec:      kafka.KafkaOffsetResetSpec{Group: "billing", Topics: []string{"shop-orders"}, ResetTo: resetToLatest},
+			message: "Consumer group billing does not belong to namespace shop, " +
+				"the group name must start with the namespace name",
+		},
+		{
+			name:      "invalid timestamp",
+			namespace: "shop",
+			spec: kafka.KafkaOffsetResetSpec{Group: "shop-billing", Topics: []string{"shop-orders"},
+				ResetTo: resetToTimestamp, Timestamp: "yesterday"},
+			message: `timestamp "yesterday" is not in RFC3339 format`,
+		},
+		{
+			name:      "unknown topic",
+			namespace: "shop",
+			spec:      kafka.KafkaOffsetResetSpec{Group: "shop-billing", Topics: []string{"shop-unknown"}, ResetTo: resetToLatest},
+			message:   "cannot describe topic shop-unknown: " + sarama.ErrUnknownTopicOrPartition.Error(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			admin := newOffsetsAdmin()
+			admin.members = tt.members
+			status, err := resetGroupOffsets(admin, newOffsetReset(tt.namespace, tt.spec), "kafka", resetTime)
+			assert.NoError(t, err)
+			assert.Equal(t, offsetResetPhaseFailed, status.Phase)
+			assert.Equal(t, tt.message, status.Message)
+			assert.Empty(t, status.Offsets)
+			assert.Equal(t, map[int32]int64{0: 50}, admin.committed["shop-orders"])
+		})
+	}
+}
+
+func TestOffsetReset_resetGroupOffsets_kafkaError(t *testing.T) {
+	admin := newOffsetsAdmin()
+	admin.listOffsetsErr = sarama.ErrNotLeaderForPartition
+	reset := newOffsetReset("shop", kafka.KafkaOffsetResetSpec{
+		Group:   "shop-billing",
+		Topics:  []string{"shop-orders"},
+		ResetTo: resetToEarliest,
+	})
+
+	status, err := resetGroupOffsets(admin, reset, "kafka", resetTime)
+	assert.ErrorIs(t, err, sarama.ErrNotLeaderForPartition)
+	assert.Empty(t, status.Phase)
+	assert.Equal(t, map[int32]int64{0: 50}, admin.committed["shop-orders"])
+}
@@ -0,0 +1,164 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+
+	akhqproto "github.com/Netcracker/qubership-kafka/operator/controllers/akhqprotobuf"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+type akhqRoleRule struct {
+	Resources []string `json:"resources"`
+	Actions   []string `json:"actions"`
+}
+
+type akhqGroupBinding struct {
+	Role     string   `json:"role"`
+	Patterns []string `json:"patterns"`
+}
+
+type akhqLdapGroup struct {
+	Name   string   `json:"name"`
+	Groups []string `json:"groups"`
+}
+
+// akhqSecurityGroups is a part of hand-maintained security groups configuration with LDAP groups mapping
+type akhqSecurityGroups struct {
+	Ldap struct {
+		Groups []akhqLdapGroup `json:"groups"`
+	} `json:"ldap"`
+}
+
+// namespaceRoles are AKHQ roles which are bound to LDAP groups by access rules of AkhqConfig custom resources.
+// Topics and consumer groups of the roles are limited by namespace patterns in group bindings.
+var namespaceRoles = map[string][]akhqRoleRule{
+	"reader": {
+		{Resources: []string{"TOPIC", "TOPIC_DATA"}, Actions: []string{"READ"}},
+		{Resources: []string{"TOPIC"}, Actions: []string{"READ_CONFIG"}},
+		{Resources: []string{"CONSUMER_GROUP"}, Actions: []string{"READ"}},
+	},
+	"admin": {
+		{Resources: []string{"TOPIC", "TOPIC_DATA"}, Actions: []string{"READ", "CREATE", "DELETE"}},
+		{Resources: []string{"TOPIC"}, Actions: []string{"UPDATE", "READ_CONFIG", "ALTER_CONFIG"}},
+		{Resources: []string{"CONSUMER_GROUP"}, Actions: []string{"READ", "DELETE", "UPDATE_OFFSET", "DELETE_OFFSET"}},
+	},
+}
+
+// AddAccessGroupsProperties renders access rules to security configuration secret. It must be called after
+// protected fields of the secret are restored, because they can contain hand-maintained LDAP groups mapping.
+func (arp AkhqResourceProvider) AddAccessGroupsProperties(secret *corev1.Secret, accessRules []akhqproto.AccessRule) {
+	secret.StringData[accessGroupsConfigK8SKey] =
+		arp.GetAccessGroupsProperties(accessRules, secret.StringData[securityGroupConfigK8SKey])
+}
+
+// GetAccessGroupsProperties renders access rules to AKHQ configuration file with roles, groups and LDAP groups mapping.
+// Each namespace gets own AKHQ group per role which is limited to topics and consumer groups with the namespace prefix.
+// LDAP groups mapping from Kafka service spec and from hand-maintained security groups configuration is merged
+// to the generated one, because the file overrides the whole mapping list.
+func (arp AkhqResourceProvider) GetAccessGroupsProperties(accessRules []akhqproto.AccessRule,
+	securityGroupsConfig string) string {
+	if len(accessRules) == 0 {
+		return ""
+	}
+	roles := make(map[string][]akhqRoleRule)
+	groups := make(map[string][]akhqGroupBinding)
+	ldapGroups := make(map[string][]string)
+	for _, rule := range accessRules {
+		rules, found := namespaceRoles[rule.Role]
+		if !found {
+			arp.logger.Info(fmt.Sprintf("Unknown AKHQ role [%s] is specified for [%s] LDAP group in [%s] config, skipping it",
+				rule.Role, rule.LdapGroup, rule.Name))
+			continue
+		}
+		roleName := getNamespaceRoleName(rule.Role)
+		groupName := getNamespaceGroupName(rule.Namespace, rule.Role)
+		roles[roleName] = rules
+		groups[groupName] = []akhqGroupBinding{
+			{Role: roleName, Patterns: []string{regexp.QuoteMeta(rule.Namespace) + ".*"}},
+		}
+		ldapGroups[rule.LdapGroup] = appendUnique(ldapGroups[rule.LdapGroup], groupName)
+	}
+	if len(groups) == 0 {
+		return ""
+	}
+
+	security := map[string]any{
+		"roles":  roles,
+		"groups": groups,
+	}
+	if arp.cr.Spec.Akhq.Ldap != nil && arp.cr.Spec.Akhq.Ldap.Enabled {
+		for _, group := range arp.cr.Spec.Akhq.Ldap.UsersConfig.Groups {
+			mergeLdapGroup(ldapGroups, group.Name, group.Groups)
+		}
+		securityGroups := akhqSecurityGroups{}
+		if err := yaml.Unmarshal([]byte(securityGroupsConfig), &securityGroups); err != nil {
+			arp.logger.Error(err, "Cannot parse LDAP groups mapping of AKHQ security groups configuration, skipping it")
+		}
+		for _, group := range securityGroups.Ldap.Groups {
+			mergeLdapGroup(ldapGroups, group.Name, group.Groups)
+		}
+		security["ldap"] = map[string]any{"groups": sortLdapGroups(ldapGroups)}
+	}
+	content, err := yaml.Marshal(map[string]any{"akhq": map[string]any{"security": security}})
+	if err != nil {
+		arp.logger.Error(err, "Cannot render AKHQ access groups")
+		return ""
+	}
+	return string(content)
+}
+
+func getNamespaceRoleName(role string) string {
+	return fmt.Sprintf("namespace-%s", role)
+}
+
+func getNamespaceGroupName(namespace string, role string) string {
+	return fmt.Sprintf("%s-namespace-%s", namespace, role)
+}
+
+func mergeLdapGroup(ldapGroups map[string][]string, name string, akhqGroups []string) {
+	if name == "" {
+		return
+	}
+	for _, akhqGroup := range akhqGroups {
+		if akhqGroup != "" {
+			ldapGroups[name] = appendUnique(ldapGroups[name], akhqGroup)
+		}
+	}
+}
+
+func sortLdapGroups(ldapGroups map[string][]string) []akhqLdapGroup {
+	result := make([]akhqLdapGroup, 0, len(ldapGroups))
+	for name, groups := range ldapGroups {
+		sort.Strings(groups)
+		result = append(result, akhqLdapGroup{Name: name, Groups: groups})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+func appendUnique(values []string, value string) []string {
+	if slices.Contains(values, value) {
+		return values
+	}
+	return append(values, value)
+}
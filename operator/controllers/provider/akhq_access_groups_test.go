@@ -0,0 +1,125 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"testing"
+
+	kafkaservice "github.com/Netcracker/qubership-kafka/operator/api/v7"
+	akhqproto "github.com/Netcracker/qubership-kafka/operator/controllers/akhqprotobuf"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+type renderedAccessGroups struct {
+	Akhq struct {
+		Security struct {
+			Roles  map[string][]akhqRoleRule     `json:"roles"`
+			Groups map[string][]akhqGroupBinding `json:"groups"`
+			Ldap   *struct {
+				Groups []akhqLdapGroup `json:"groups"`
+			} `json:"ldap"`
+		} `json:"security"`
+	} `json:"akhq"`
+}
+
+func newAkhqProvider(ldap *kafkaservice.LdapConfig) AkhqResourceProvider {
+	cr := &kafkaservice.KafkaService{
+		Spec: kafkaservice.KafkaServiceSpec{Akhq: &kafkaservice.Akhq{Ldap: ldap}},
+	}
+	return NewAkhqResourceProvider(cr, logr.Discard())
+}
+
+func TestAkhqProvider_GetAccessGroupsProperties(t *testing.T) {
+	arp := newAkhqProvider(&kafkaservice.LdapConfig{
+		Enabled: true,
+		UsersConfig: kafkaservice.LdapUsersConfig{
+			Groups: []kafkaservice.LdapGroup{{Name: "kafka-admins", Groups: []string{"admin"}}},
+		},
+	})
+	accessRules := []akhqproto.AccessRule{
+		{Name: "orders-team-a", Namespace: "team-a", LdapGroup: "team-a-developers", Role: "admin"},
+		{Name: "orders-team-a", Namespace: "team-a", LdapGroup: "support", Role: "reader"},
+		{Name: "payments-team.b", Namespace: "team.b", LdapGroup: "support", Role: "reader"},
+	}
+
+	rendered := renderedAccessGroups{}
+	assert.NoError(t, yaml.Unmarshal([]byte(arp.GetAccessGroupsProperties(accessRules, "")), &rendered))
+
+	security := rendered.Akhq.Security
+	assert.Equal(t, namespaceRoles["admin"], security.Roles["namespace-admin"])
+	assert.Equal(t, namespaceRoles["reader"], security.Roles["namespace-reader"])
+	assert.Equal(t, map[string][]akhqGroupBinding{
+		"team-a-namespace-admin":  {{Role: "namespace-admin", Patterns: []string{"team-a.*"}}},
+		"team-a-namespace-reader": {{Role: "namespace-reader", Patterns: []string{"team-a.*"}}},
+		"team.b-namespace-reader": {{Role: "namespace-reader", Patterns: []string{`team\.b.*`}}},
+	}, security.Groups)
+	assert.NotNil(t, security.Ldap)
+	assert.Equal(t, []akhqLdapGroup{
+		{Name: "kafka-admins", Groups: []string{"admin"}},
+		{Name: "support", Groups: []string{"team-a-namespace-reader", "team.b-namespace-reader"}},
+		{Name: "team-a-developers", Groups: []string{"team-a-namespace-admin"}},
+	}, security.Ldap.Groups)
+}
+
+func TestAkhqProvider_AddAccessGroupsProperties(t *testing.T) {
+	arp := newAkhqProvider(&kafkaservice.LdapConfig{Enabled: true})
+	// hand-maintained configuration restored from the existing secret
+	secret := &corev1.Secret{StringData: map[string]string{securityGroupConfigK8SKey: `groups:
+  topic-reader:
+    - role: topic-read
+ldap:
+  groups:
+    - name: support
+      groups:
+        - topic-reader
+    - name: kafka-operators
+      groups:
+        - admin
+`}}
+	arp.AddAccessGroupsProperties(secret, []akhqproto.AccessRule{
+		{Name: "orders-team-a", Namespace: "team-a", LdapGroup: "support", Role: "reader"},
+	})
+
+	rendered := renderedAccessGroups{}
+	assert.NoError(t, yaml.Unmarshal([]byte(secret.StringData[accessGroupsConfigK8SKey]), &rendered))
+	assert.NotNil(t, rendered.Akhq.Security.Ldap)
+	assert.Equal(t, []akhqLdapGroup{
+		{Name: "kafka-operators", Groups: []string{"admin"}},
+		{Name: "support", Groups: []string{"team-a-namespace-reader", "topic-reader"}},
+	}, rendered.Akhq.Security.Ldap.Groups)
+}
+
+func TestAkhqProvider_GetAccessGroupsProperties_withoutLdap(t *testing.T) {
+	arp := newAkhqProvider(&kafkaservice.LdapConfig{})
+	accessRules := []akhqproto.AccessRule{
+		{Name: "orders-team-a", Namespace: "team-a", LdapGroup: "team-a-developers", Role: "reader"},
+	}
+
+	rendered := renderedAccessGroups{}
+	assert.NoError(t, yaml.Unmarshal([]byte(arp.GetAccessGroupsProperties(accessRules, "")), &rendered))
+	assert.Len(t, rendered.Akhq.Security.Groups, 1)
+	assert.Nil(t, rendered.Akhq.Security.Ldap)
+}
+
+func TestAkhqProvider_GetAccessGroupsProperties_empty(t *testing.T) {
+	arp := newAkhqProvider(&kafkaservice.LdapConfig{Enabled: true})
+	assert.Empty(t, arp.GetAccessGroupsProperties(nil, ""))
+	assert.Empty(t, arp.GetAccessGroupsProperties([]akhqproto.AccessRule{
+		{Name: "orders-team-a", Namespace: "team-a", LdapGroup: "team-a-developers", Role: "owner"},
+	}, ""))
+}
@@ -0,0 +1,132 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	kafkaservice "github.com/Netcracker/qubership-kafka/operator/api/v7"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// OidcProviderName is a name of OpenID Connect client in AKHQ, it is a part of callback URL
+	OidcProviderName         = "oidc"
+	defaultOidcSecretName    = "akhq-oidc-secret"
+	defaultOidcSecretKey     = "client-secret"
+	defaultOidcLabel         = "Login with SSO"
+	defaultOidcUsernameField = "preferred_username"
+	defaultOidcGroupsField   = "roles"
+	oidcClientSecretPath     = "oidc_client_secret"
+	// oidcClientSecretPlaceholder is resolved by Micronaut from environment variable
+	// which AKHQ entrypoint reads from the mounted client secret
+	oidcClientSecretPlaceholder = "${OIDC_CLIENT_SECRET}"
+)
+
+type akhqOidcGroup struct {
+	Name   string   `json:"name"`
+	Groups []string `json:"groups"`
+}
+
+type akhqOidcProvider struct {
+	Label         string          `json:"label"`
+	UsernameField string          `json:"username-field"`
+	GroupsField   string          `json:"groups-field"`
+	DefaultGroup  string          `json:"default-group,omitempty"`
+	Groups        []akhqOidcGroup `json:"groups,omitempty"`
+}
+
+// IsOidcEnabled checks whether AKHQ authenticates users with OpenID Connect provider
+func (arp AkhqResourceProvider) IsOidcEnabled() bool {
+	return arp.spec.Oidc != nil && arp.spec.Oidc.Enabled
+}
+
+// GetOidcSecretName returns the name of the secret with OpenID Connect client secret
+func (arp AkhqResourceProvider) GetOidcSecretName() string {
+	return valueOrDefault(arp.spec.Oidc.ClientSecretName, defaultOidcSecretName)
+}
+
+// GetOidcSecretKey returns the key of OpenID Connect client secret in the secret
+func (arp AkhqResourceProvider) GetOidcSecretKey() string {
+	return valueOrDefault(arp.spec.Oidc.ClientSecretKey, defaultOidcSecretKey)
+}
+
+// GetOidcProperties renders OpenID Connect client for Micronaut and provider for AKHQ with groups mapping.
+// Client secret is not rendered, Micronaut resolves it from the secret mounted to AKHQ pod.
+func (arp AkhqResourceProvider) GetOidcProperties() string {
+	if !arp.IsOidcEnabled() {
+		return ""
+	}
+	oidc := arp.spec.Oidc
+	provider := akhqOidcProvider{
+		Label:         valueOrDefault(oidc.Label, defaultOidcLabel),
+		UsernameField: valueOrDefault(oidc.UsernameField, defaultOidcUsernameField),
+		GroupsField:   valueOrDefault(oidc.GroupsField, defaultOidcGroupsField),
+		DefaultGroup:  oidc.DefaultGroup,
+		Groups:        getOidcGroups(oidc.Groups),
+	}
+	properties := map[string]any{
+		"micronaut": map[string]any{
+			"security": map[string]any{
+				"oauth2": map[string]any{
+					"enabled": true,
+					"clients": map[string]any{
+						OidcProviderName: map[string]any{
+							"client-id":     oidc.ClientId,
+							"client-secret": oidcClientSecretPlaceholder,
+							"openid":        map[string]any{"issuer": oidc.Issuer},
+						},
+					},
+				},
+			},
+		},
+		"akhq": map[string]any{
+			"security": map[string]any{
+				"oidc": map[string]any{
+					"enabled":   true,
+					"providers": map[string]any{OidcProviderName: provider},
+				},
+			},
+		},
+	}
+	content, err := yaml.Marshal(properties)
+	if err != nil {
+		arp.logger.Error(err, "Cannot render AKHQ OpenID Connect configuration")
+		return ""
+	}
+	return string(content)
+}
+
+func getOidcGroups(groups []kafkaservice.OidcGroup) []akhqOidcGroup {
+	var result []akhqOidcGroup
+	for _, group := range groups {
+		if group.Name == "" {
+			continue
+		}
+		akhqGroups := make([]string, 0, len(group.Groups))
+		for _, akhqGroup := range group.Groups {
+			if akhqGroup != "" {
+				akhqGroups = append(akhqGroups, akhqGroup)
+			}
+		}
+		result = append(result, akhqOidcGroup{Name: group.Name, Groups: akhqGroups})
+	}
+	return result
+}
+
+func valueOrDefault(value string, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
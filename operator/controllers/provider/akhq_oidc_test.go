@@ -0,0 +1,123 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"encoding/base64"
+	"testing"
+
+	kafkaservice "github.com/Netcracker/qubership-kafka/operator/api/v7"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/yaml"
+)
+
+type renderedOidc struct {
+	Micronaut struct {
+		Security struct {
+			Oauth2 struct {
+				Enabled bool `json:"enabled"`
+				Clients map[string]struct {
+					ClientId     string `json:"client-id"`
+					ClientSecret string `json:"client-secret"`
+					Openid       struct {
+						Issuer string `json:"issuer"`
+					} `json:"openid"`
+				} `json:"clients"`
+			} `json:"oauth2"`
+		} `json:"security"`
+	} `json:"micronaut"`
+	Akhq struct {
+		Security struct {
+			Oidc struct {
+				Enabled   bool                        `json:"enabled"`
+				Providers map[string]akhqOidcProvider `json:"providers"`
+			} `json:"oidc"`
+		} `json:"security"`
+	} `json:"akhq"`
+}
+
+func newOidcAkhqProvider(oidc *kafkaservice.OidcConfig) AkhqResourceProvider {
+	cr := &kafkaservice.KafkaService{
+		Spec: kafkaservice.KafkaServiceSpec{Akhq: &kafkaservice.Akhq{Oidc: oidc}},
+	}
+	return NewAkhqResourceProvider(cr, logr.Discard())
+}
+
+func TestAkhqProvider_GetOidcProperties(t *testing.T) {
+	arp := newOidcAkhqProvider(&kafkaservice.OidcConfig{
+		Enabled:      true,
+		Issuer:       "https://sso.example.com/realms/kafka",
+		ClientId:     "akhq",
+		GroupsField:  "groups",
+		DefaultGroup: "no-roles",
+		Groups: []kafkaservice.OidcGroup{
+			{Name: "kafka-admins", Groups: []string{"admin"}},
+			{Name: "kafka-readers", Groups: []string{"reader", ""}},
+			{Name: "", Groups: []string{"admin"}},
+		},
+	})
+
+	rendered := renderedOidc{}
+	assert.NoError(t, yaml.Unmarshal([]byte(arp.GetOidcProperties()), &rendered))
+
+	oauth2 := rendered.Micronaut.Security.Oauth2
+	assert.True(t, oauth2.Enabled)
+	assert.Equal(t, "akhq", oauth2.Clients[OidcProviderName].ClientId)
+	assert.Equal(t, oidcClientSecretPlaceholder, oauth2.Clients[OidcProviderName].ClientSecret)
+	assert.Equal(t, "https://sso.example.com/realms/kafka", oauth2.Clients[OidcProviderName].Openid.Issuer)
+
+	oidc := rendered.Akhq.Security.Oidc
+	assert.True(t, oidc.Enabled)
+	assert.Equal(t, akhqOidcProvider{
+		Label:         defaultOidcLabel,
+		UsernameField: defaultOidcUsernameField,
+		GroupsField:   "groups",
+		DefaultGroup:  "no-roles",
+		Groups: []akhqOidcGroup{
+			{Name: "kafka-admins", Groups: []string{"admin"}},
+			{Name: "kafka-readers", Groups: []string{"reader"}},
+		},
+	}, oidc.Providers[OidcProviderName])
+}
+
+func TestAkhqProvider_GetOidcProperties_disabled(t *testing.T) {
+	assert.Empty(t, newOidcAkhqProvider(nil).GetOidcProperties())
+	assert.Empty(t, newOidcAkhqProvider(&kafkaservice.OidcConfig{Issuer: "https://sso.example.com"}).GetOidcProperties())
+}
+
+func TestAkhqProvider_GetOidcSecret(t *testing.T) {
+	arp := newOidcAkhqProvider(&kafkaservice.OidcConfig{Enabled: true})
+	assert.Equal(t, defaultOidcSecretName, arp.GetOidcSecretName())
+	assert.Equal(t, defaultOidcSecretKey, arp.GetOidcSecretKey())
+
+	arp = newOidcAkhqProvider(&kafkaservice.OidcConfig{Enabled: true, ClientSecretName: "sso", ClientSecretKey: "secret"})
+	assert.Equal(t, "sso", arp.GetOidcSecretName())
+	assert.Equal(t, "secret", arp.GetOidcSecretKey())
+}
+
+func TestAkhqProvider_NewLdapTrustedSecret_oidcTrustedCert(t *testing.T) {
+	oidc := &kafkaservice.OidcConfig{Enabled: true, Issuer: "https://sso.example.com/realms/kafka", ClientId: "akhq",
+		TrustedCert: base64.StdEncoding.EncodeToString([]byte("issuer-ca"))}
+	arp := newOidcAkhqProvider(oidc)
+	arp.cr.Spec.Global = &kafkaservice.Global{}
+	arp.cr.Spec.Akhq.Ldap = &kafkaservice.LdapConfig{}
+
+	secret := arp.NewLdapTrustedSecret("akhq-trusted-certs")
+	assert.Equal(t, map[string][]byte{oidcTrustedCertKey: []byte("issuer-ca")}, secret.Data)
+
+	oidc.TrustedCert = ""
+	assert.Empty(t, arp.NewLdapTrustedSecret("akhq-trusted-certs").Data)
+}
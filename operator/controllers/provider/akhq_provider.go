@@ -17,6 +17,7 @@ package provider
 import (
 	"encoding/base64"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -27,11 +28,13 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
 )
 
 const (
 	defaultKafkaPollTimeout          = 10000
 	deserializationSourcesVolumeName = "proto-keys"
+	oidcTrustedCertKey               = "oidc-issuer-ca.crt"
 )
 
 // TODO I propose to make additional structs (a.k.a models) for storing such information like consts, and additional logic.
@@ -41,6 +44,8 @@ const (
 	basicGroupsConfigK8SKey   = "basic_auth_users_config"
 	ldapServerConfigK8SKey    = "ldap_server_config"
 	ldapUsersConfigK8SKey     = "ldap_users_config"
+	accessGroupsConfigK8SKey  = "access_groups_config"
+	oidcConfigK8SKey          = "oidc_config"
 )
 
 type AkhqResourceProvider struct {
@@ -84,6 +89,11 @@ func (arp AkhqResourceProvider) GetServiceName() string {
 	return arp.serviceName
 }
 
+// GetSslSecretName returns name of secret with TLS certificates which AKHQ uses to connect to Kafka
+func (arp AkhqResourceProvider) GetSslSecretName() string {
+	return GetClientSslSecretName(arp.cr, AkhqCertificateComponent)
+}
+
 func (arp AkhqResourceProvider) GetServiceAccountName() string {
 	return arp.GetServiceName()
 }
@@ -135,6 +145,16 @@ func (arp AkhqResourceProvider) NewLdapTrustedSecret(secretName string) *corev1.
 			secret.Data[key] = []byte(decodedCert)
 		}
 	}
+	if arp.IsOidcEnabled() && arp.cr.Spec.Akhq.Oidc.TrustedCert != "" {
+		decodedCert, err := base64.StdEncoding.DecodeString(arp.cr.Spec.Akhq.Oidc.TrustedCert)
+		if err != nil {
+			return nil
+		}
+		if secret.Data == nil {
+			secret.Data = make(map[string][]byte)
+		}
+		secret.Data[oidcTrustedCertKey] = decodedCert
+	}
 	return secret
 }
 
@@ -156,6 +176,8 @@ func (arp AkhqResourceProvider) NewSecurityConfiguration() *corev1.Secret {
 		basicGroupsConfigK8SKey:   "",
 		ldapServerConfigK8SKey:    "",
 		ldapUsersConfigK8SKey:     "",
+		accessGroupsConfigK8SKey:  "",
+		oidcConfigK8SKey:          arp.GetOidcProperties(),
 	}
 
 	if arp.cr != nil && arp.cr.Spec.Akhq != nil && arp.cr.Spec.Akhq.Ldap != nil && arp.cr.Spec.Akhq.Ldap.Enabled {
@@ -224,7 +246,8 @@ func (arp *AkhqResourceProvider) NewConfigurationMapForLdap(configMapName string
 	return configurationMap
 }
 
-func (arp AkhqResourceProvider) NewAkhqDeployment(protobufConfigMapVersion string, deserializationConfigMaps []*corev1.ConfigMap) *appsv1.Deployment {
+func (arp AkhqResourceProvider) NewAkhqDeployment(protobufConfigMapVersion string, accessConfigMapVersion string,
+	deserializationConfigMaps []*corev1.ConfigMap) *appsv1.Deployment {
 	deploymentName := arp.serviceName
 	akhqLabels := arp.GetAkhqLabels()
 	akhqLabels["app.kubernetes.io/technology"] = "java-others"
@@ -244,7 +267,7 @@ func (arp AkhqResourceProvider) NewAkhqDeployment(protobufConfigMapVersion strin
 			ContainerPort: 8081,
 		},
 	}
-	envVars := arp.getAkhqEnvironmentVariables(protobufConfigMapVersion)
+	envVars := arp.getAkhqEnvironmentVariables(protobufConfigMapVersion, accessConfigMapVersion)
 
 	return &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
@@ -333,12 +356,12 @@ func (arp AkhqResourceProvider) getAkhqVolumes(deserializationConfigMaps []*core
 		volumes = append(volumes, arp.createProjectedVolumeForProtoKeysMaps(deserializationConfigMaps))
 	}
 
-	if arp.cr.Spec.Global.KafkaSsl.Enabled && arp.cr.Spec.Global.KafkaSsl.SecretName != "" {
+	if arp.cr.Spec.Global.KafkaSsl.Enabled && arp.GetSslSecretName() != "" {
 		volumes = append(volumes, corev1.Volume{
 			Name: "ssl-certs",
 			VolumeSource: corev1.VolumeSource{
 				Secret: &corev1.SecretVolumeSource{
-					SecretName: arp.cr.Spec.Global.KafkaSsl.SecretName,
+					SecretName: arp.GetSslSecretName(),
 				},
 			},
 		})
@@ -388,6 +411,8 @@ func (arp AkhqResourceProvider) getAkhqVolumes(deserializationConfigMaps []*core
 					{Key: "basic_auth_users_config", Path: "basic_auth_users_config"},
 					{Key: "ldap_server_config", Path: "ldap_server_config"},
 					{Key: "ldap_users_config", Path: "ldap_users_config"},
+					{Key: "access_groups_config", Path: "access_groups_config"},
+					{Key: "oidc_config", Path: "oidc_config"},
 				},
 			},
 		},
@@ -405,6 +430,18 @@ func (arp AkhqResourceProvider) getAkhqVolumes(deserializationConfigMaps []*core
 			},
 		})
 	}
+	if arp.IsOidcEnabled() {
+		projections = append(projections, corev1.VolumeProjection{
+			Secret: &corev1.SecretProjection{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: arp.GetOidcSecretName(),
+				},
+				Items: []corev1.KeyToPath{
+					{Key: arp.GetOidcSecretKey(), Path: oidcClientSecretPath},
+				},
+			},
+		})
+	}
 	if arp.spec.SchemaRegistryUrl != "" {
 		projections = append(projections, corev1.VolumeProjection{
 			Secret: &corev1.SecretProjection{
@@ -433,15 +470,23 @@ func (arp AkhqResourceProvider) getAkhqVolumes(deserializationConfigMaps []*core
 	return volumes
 }
 
+// createProjectedVolumeForProtoKeysMaps mounts all shards of deserialization files to one directory.
+// Shards are sorted by name to keep the deployment unchanged, and they are optional because
+// AkhqConfig controller can remove orphan shards before the deployment is updated.
 func (arp AkhqResourceProvider) createProjectedVolumeForProtoKeysMaps(deserializationConfigMaps []*corev1.ConfigMap) corev1.Volume {
 	projectedVolumeSources := make([]corev1.VolumeProjection, 0, len(deserializationConfigMaps))
 
-	for _, cm := range deserializationConfigMaps {
+	sortedConfigMaps := slices.Clone(deserializationConfigMaps)
+	slices.SortFunc(sortedConfigMaps, func(first, second *corev1.ConfigMap) int {
+		return strings.Compare(first.Name, second.Name)
+	})
+	for _, cm := range sortedConfigMaps {
 		volumeProjection := corev1.VolumeProjection{
 			ConfigMap: &corev1.ConfigMapProjection{
 				LocalObjectReference: corev1.LocalObjectReference{
 					Name: cm.Name,
 				},
+				Optional: ptr.To(true),
 			},
 		}
 		projectedVolumeSources = append(projectedVolumeSources, volumeProjection)
@@ -468,7 +513,7 @@ func (arp AkhqResourceProvider) getAkhqVolumeMounts(deserializationConfigMaps []
 	if len(deserializationConfigMaps) > 0 {
 		volumeMounts = append(volumeMounts, corev1.VolumeMount{Name: deserializationSourcesVolumeName, MountPath: "/app/config/descs-compressed"})
 	}
-	if arp.cr.Spec.Global.KafkaSsl.Enabled && arp.cr.Spec.Global.KafkaSsl.SecretName != "" {
+	if arp.cr.Spec.Global.KafkaSsl.Enabled && arp.GetSslSecretName() != "" {
 		volumeMounts = append(volumeMounts, corev1.VolumeMount{Name: "ssl-certs", MountPath: "/tls"})
 	}
 	if arp.cr.Spec.Akhq.Ldap.Enabled {
@@ -484,7 +529,8 @@ func (arp AkhqResourceProvider) getAkhqVolumeMounts(deserializationConfigMaps []
 }
 
 // getAkhqEnvironmentVariables configures the list of AKHQ environment variables
-func (arp AkhqResourceProvider) getAkhqEnvironmentVariables(protobufConfigMapVersion string) []corev1.EnvVar {
+func (arp AkhqResourceProvider) getAkhqEnvironmentVariables(protobufConfigMapVersion string,
+	accessConfigMapVersion string) []corev1.EnvVar {
 	protobufConfigMapName := fmt.Sprintf("%s-protobuf-configuration", arp.GetServiceName())
 	envVars := []corev1.EnvVar{
 		{
@@ -519,6 +565,10 @@ func (arp AkhqResourceProvider) getAkhqEnvironmentVariables(protobufConfigMapVer
 			Name:  "PROTOBUF_CONFIGURATION_VERSION",
 			Value: protobufConfigMapVersion,
 		},
+		{
+			Name:  "ACCESS_CONFIGURATION_VERSION",
+			Value: accessConfigMapVersion,
+		},
 	}
 	if arp.cr.Spec.Akhq.HeapSize != nil {
 		envVar := corev1.EnvVar{
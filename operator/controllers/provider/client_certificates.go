@@ -0,0 +1,75 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+
+	kafkaservice "github.com/Netcracker/qubership-kafka/operator/api/v7"
+)
+
+const (
+	AkhqCertificateComponent        = "akhq"
+	MonitoringCertificateComponent  = "monitoring"
+	MirrorMakerCertificateComponent = "mirror-maker"
+)
+
+// CertificateComponents are all components which client certificates can be issued by cert-manager for
+var CertificateComponents = []string{AkhqCertificateComponent, MonitoringCertificateComponent,
+	MirrorMakerCertificateComponent}
+
+// IsCertManagerEnabled checks whether client certificates of Kafka services are issued by cert-manager
+// on behalf of operator
+func IsCertManagerEnabled(cr *kafkaservice.KafkaService) bool {
+	global := cr.Spec.Global
+	return global != nil && global.KafkaSsl.Enabled && global.CertManager != nil && global.CertManager.Enabled
+}
+
+// GetClientCertificateName returns name of cert-manager Certificate for specified component
+func GetClientCertificateName(cr *kafkaservice.KafkaService, component string) string {
+	return fmt.Sprintf("%s-%s-tls-certificate", cr.Name, component)
+}
+
+// GetClientSslSecretName returns name of secret with TLS certificates which specified component uses
+// to connect to Kafka. Each component has its own client certificate when they are issued by cert-manager.
+func GetClientSslSecretName(cr *kafkaservice.KafkaService, component string) string {
+	if IsCertManagerEnabled(cr) {
+		return fmt.Sprintf("%s-%s-tls-secret", cr.Name, component)
+	}
+	return cr.Spec.Global.KafkaSsl.SecretName
+}
+
+// GetClusterSslSecretName returns name of secret with TLS certificates which Kafka Mirror Maker uses
+// to connect to specified cluster. Client certificate of Kafka Mirror Maker is used for clusters without secret.
+func GetClusterSslSecretName(cr *kafkaservice.KafkaService, cluster kafkaservice.Cluster) string {
+	if cluster.EnableSsl && cluster.SslSecretName == "" && IsCertManagerEnabled(cr) {
+		return GetClientSslSecretName(cr, MirrorMakerCertificateComponent)
+	}
+	return cluster.SslSecretName
+}
+
+// IsMirrorMakerCertificateRequired checks whether Kafka Mirror Maker uses its own client certificate
+// to connect to any cluster
+func IsMirrorMakerCertificateRequired(cr *kafkaservice.KafkaService) bool {
+	if cr.Spec.MirrorMaker == nil {
+		return false
+	}
+	for _, cluster := range cr.Spec.MirrorMaker.Clusters {
+		if GetClusterSslSecretName(cr, cluster) != cluster.SslSecretName {
+			return true
+		}
+	}
+	return false
+}
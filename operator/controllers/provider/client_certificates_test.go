@@ -0,0 +1,105 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"testing"
+
+	kafkav1 "github.com/Netcracker/qubership-kafka/operator/api/v1"
+	kafkaservice "github.com/Netcracker/qubership-kafka/operator/api/v7"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newCertManagerKafkaService(certManagerEnabled bool, clusters ...kafkaservice.Cluster) *kafkaservice.KafkaService {
+	return &kafkaservice.KafkaService{
+		ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka-service"},
+		Spec: kafkaservice.KafkaServiceSpec{
+			Global: &kafkaservice.Global{
+				KafkaSsl:    kafkaservice.KafkaSsl{Enabled: true, SecretName: "kafka-tls-secret"},
+				CertManager: &kafkaservice.CertManager{Enabled: certManagerEnabled, IssuerName: "issuer"},
+			},
+			MirrorMaker: &kafkaservice.MirrorMaker{Clusters: clusters},
+		},
+	}
+}
+
+func TestGetClientSslSecretName(t *testing.T) {
+	cr := newCertManagerKafkaService(true)
+	assert.True(t, IsCertManagerEnabled(cr))
+	assert.Equal(t, "kafka-akhq-tls-secret", GetClientSslSecretName(cr, AkhqCertificateComponent))
+	assert.Equal(t, "kafka-monitoring-tls-certificate", GetClientCertificateName(cr, MonitoringCertificateComponent))
+
+	cr = newCertManagerKafkaService(false)
+	assert.False(t, IsCertManagerEnabled(cr))
+	assert.Equal(t, "kafka-tls-secret", GetClientSslSecretName(cr, AkhqCertificateComponent))
+
+	cr = newCertManagerKafkaService(true)
+	cr.Spec.Global.KafkaSsl.Enabled = false
+	assert.False(t, IsCertManagerEnabled(cr))
+}
+
+func TestGetClusterSslSecretName(t *testing.T) {
+	local := kafkaservice.Cluster{Name: "dc1", EnableSsl: true}
+	remote := kafkaservice.Cluster{Name: "dc2", EnableSsl: true, SslSecretName: "dc2-tls-secret"}
+	plain := kafkaservice.Cluster{Name: "dc3"}
+
+	cr := newCertManagerKafkaService(true, local, remote, plain)
+	assert.Equal(t, "kafka-mirror-maker-tls-secret", GetClusterSslSecretName(cr, local))
+	assert.Equal(t, "dc2-tls-secret", GetClusterSslSecretName(cr, remote))
+	assert.Empty(t, GetClusterSslSecretName(cr, plain))
+	assert.True(t, IsMirrorMakerCertificateRequired(cr))
+
+	assert.False(t, IsMirrorMakerCertificateRequired(newCertManagerKafkaService(true, remote, plain)))
+	assert.False(t, IsMirrorMakerCertificateRequired(newCertManagerKafkaService(false, local, remote)))
+}
+
+func TestGetCertificateHostNames(t *testing.T) {
+	cr := &kafkav1.Kafka{
+		ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "streaming"},
+		Spec: kafkav1.KafkaSpec{
+			Replicas:          2,
+			ExternalHostNames: []string{"kafka-1.example.com", "10.0.0.2"},
+			Ssl: kafkav1.Ssl{
+				Enabled: true,
+				CertManager: &kafkav1.CertManager{
+					Enabled:               true,
+					AdditionalDnsNames:    []string{"kafka.example.com"},
+					AdditionalIpAddresses: []string{"10.0.0.10"},
+				},
+			},
+		},
+	}
+	krp := NewKafkaResourceProvider(cr, logr.Discard())
+	assert.True(t, krp.IsCertManagerEnabled())
+	assert.Equal(t, "kafka-tls-secret", krp.GetSslSecretName())
+
+	hostNames := krp.GetCertificateHostNames()
+	assert.Subset(t, hostNames, []string{
+		"localhost", "127.0.0.1", "kafka", "kafka.streaming", "kafka.streaming.svc",
+		"kafka-1", "kafka-1.streaming", "kafka-1.kafka-broker.streaming",
+		"kafka-2", "kafka-2.streaming", "kafka-2.kafka-broker.streaming",
+		"kafka-1.example.com", "10.0.0.2", "kafka.example.com", "10.0.0.10",
+	})
+	assert.NotContains(t, hostNames, "kafka-3")
+	assert.NotContains(t, hostNames, "kafka-kraft-controller")
+
+	cr.Spec.Ssl.SecretName = "custom-tls-secret"
+	assert.Equal(t, "custom-tls-secret", NewKafkaResourceProvider(cr, logr.Discard()).GetSslSecretName())
+	cr.Spec.Ssl.CertManager = nil
+	cr.Spec.Ssl.SecretName = ""
+	assert.Empty(t, NewKafkaResourceProvider(cr, logr.Discard()).GetSslSecretName())
+}
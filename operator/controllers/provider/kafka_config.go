@@ -0,0 +1,151 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const brokerConfigEnvPrefix = "CONF_KAFKA_"
+
+// brokerConfigKeyPattern matches keys which can be passed to broker through environment variables,
+// because entrypoint converts underscores of variable names to dots
+var brokerConfigKeyPattern = regexp.MustCompile(`^[a-z0-9]+(\.[a-z0-9]+)*$`)
+
+// dynamicBrokerConfigs are broker configs which Kafka allows to update cluster-wide without restart
+var dynamicBrokerConfigs = map[string]bool{
+	"background.threads":                        true,
+	"compression.type":                          true,
+	"log.cleaner.backoff.ms":                    true,
+	"log.cleaner.dedupe.buffer.size":            true,
+	"log.cleaner.delete.retention.ms":           true,
+	"log.cleaner.io.buffer.load.factor":         true,
+	"log.cleaner.io.buffer.size":                true,
+	"log.cleaner.io.max.bytes.per.second":       true,
+	"log.cleaner.max.compaction.lag.ms":         true,
+	"log.cleaner.min.cleanable.ratio":           true,
+	"log.cleaner.min.compaction.lag.ms":         true,
+	"log.cleaner.threads":                       true,
+	"log.cleanup.policy":                        true,
+	"log.flush.interval.messages":               true,
+	"log.flush.interval.ms":                     true,
+	"log.index.interval.bytes":                  true,
+	"log.index.size.max.bytes":                  true,
+	"log.local.retention.bytes":                 true,
+	"log.local.retention.ms":                    true,
+	"log.message.timestamp.after.max.ms":        true,
+	"log.message.timestamp.before.max.ms":       true,
+	"log.message.timestamp.type":                true,
+	"log.preallocate":                           true,
+	"log.retention.bytes":                       true,
+	"log.retention.ms":                          true,
+	"log.roll.jitter.ms":                        true,
+	"log.roll.ms":                               true,
+	"log.segment.bytes":                         true,
+	"log.segment.delete.delay.ms":               true,
+	"max.connection.creation.rate":              true,
+	"max.connections":                           true,
+	"max.connections.per.ip":                    true,
+	"max.connections.per.ip.overrides":          true,
+	"message.max.bytes":                         true,
+	"metric.reporters":                          true,
+	"min.insync.replicas":                       true,
+	"num.io.threads":                            true,
+	"num.network.threads":                       true,
+	"num.recovery.threads.per.data.dir":         true,
+	"num.replica.fetchers":                      true,
+	"producer.id.expiration.ms":                 true,
+	"transaction.partition.verification.enable": true,
+	"unclean.leader.election.enable":            true,
+}
+
+// reservedBrokerConfigs are broker configs which operator and entrypoint of Kafka image manage by themselves
+var reservedBrokerConfigs = map[string]bool{
+	"advertised.listeners":           true,
+	"broker.id":                      true,
+	"broker.rack":                    true,
+	"controller.listener.names":      true,
+	"controller.quorum.voters":       true,
+	"inter.broker.listener.name":     true,
+	"listener.security.protocol.map": true,
+	"listeners":                      true,
+	"log.dirs":                       true,
+	"node.id":                        true,
+	"process.roles":                  true,
+	"zookeeper.connect":              true,
+}
+
+// IsDynamicBrokerConfig checks whether broker config can be updated cluster-wide without restart of brokers
+func IsDynamicBrokerConfig(key string) bool {
+	return dynamicBrokerConfigs[key]
+}
+
+// ValidateBrokerConfig checks that broker configs can be applied by operator
+func ValidateBrokerConfig(config map[string]string) error {
+	for _, key := range SortedKeys(config) {
+		if !brokerConfigKeyPattern.MatchString(key) {
+			return fmt.Errorf("broker config [%s] is incorrect, it must consist of lower case words separated by dots", key)
+		}
+		if reservedBrokerConfigs[key] {
+			return fmt.Errorf("broker config [%s] is managed by operator and cannot be overridden", key)
+		}
+	}
+	return nil
+}
+
+// SplitBrokerConfig separates broker configs into dynamic ones, which are applied to running brokers,
+// and static ones, which are applied by restart of brokers
+func SplitBrokerConfig(config map[string]string) (map[string]string, map[string]string) {
+	dynamicConfig := map[string]string{}
+	staticConfig := map[string]string{}
+	for key, value := range config {
+		if IsDynamicBrokerConfig(key) {
+			dynamicConfig[key] = value
+		} else {
+			staticConfig[key] = value
+		}
+	}
+	return dynamicConfig, staticConfig
+}
+
+// GetBrokerConfigEnvName returns name of environment variable which entrypoint of Kafka image converts to broker config
+func GetBrokerConfigEnvName(key string) string {
+	return brokerConfigEnvPrefix + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+// SortedKeys returns keys of map in alphabetical order
+func SortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// getStaticBrokerConfigEnvs returns environment variables for static broker configs
+func (krp KafkaResourceProvider) getStaticBrokerConfigEnvs() []corev1.EnvVar {
+	_, staticConfig := SplitBrokerConfig(krp.cr.Spec.Config)
+	var envVars []corev1.EnvVar
+	for _, key := range SortedKeys(staticConfig) {
+		envVars = append(envVars, corev1.EnvVar{Name: GetBrokerConfigEnvName(key), Value: staticConfig[key]})
+	}
+	return envVars
+}
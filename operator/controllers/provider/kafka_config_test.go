@@ -0,0 +1,60 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"testing"
+
+	kafkav1 "github.com/Netcracker/qubership-kafka/operator/api/v1"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidateBrokerConfig(t *testing.T) {
+	assert.NoError(t, ValidateBrokerConfig(nil))
+	assert.NoError(t, ValidateBrokerConfig(map[string]string{"log.retention.ms": "1000", "num.io.threads": "8"}))
+	assert.Error(t, ValidateBrokerConfig(map[string]string{"Log.Retention.ms": "1000"}))
+	assert.Error(t, ValidateBrokerConfig(map[string]string{"log_retention_ms": "1000"}))
+	assert.Error(t, ValidateBrokerConfig(map[string]string{"listeners": "PLAINTEXT://:9092"}))
+}
+
+func TestSplitBrokerConfig(t *testing.T) {
+	dynamicConfig, staticConfig := SplitBrokerConfig(map[string]string{
+		"log.retention.ms":        "604800000",
+		"min.insync.replicas":     "2",
+		"replica.fetch.max.bytes": "2097152",
+	})
+	assert.Equal(t, map[string]string{"log.retention.ms": "604800000", "min.insync.replicas": "2"}, dynamicConfig)
+	assert.Equal(t, map[string]string{"replica.fetch.max.bytes": "2097152"}, staticConfig)
+	assert.Equal(t, "CONF_KAFKA_REPLICA_FETCH_MAX_BYTES", GetBrokerConfigEnvName("replica.fetch.max.bytes"))
+}
+
+func TestStaticBrokerConfigEnvs(t *testing.T) {
+	cr := &kafkav1.Kafka{
+		ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "streaming"},
+		Spec: kafkav1.KafkaSpec{
+			Replicas: 1,
+			Config: map[string]string{
+				"log.retention.ms":        "604800000",
+				"replica.fetch.max.bytes": "2097152",
+			},
+		},
+	}
+	envs := NewKafkaResourceProvider(cr, logr.Discard()).NewKafkaBrokerDeploymentForCR(1, "", false, "").
+		Spec.Template.Spec.Containers[0].Env
+	assert.Equal(t, "2097152", getEnvValue(envs, "CONF_KAFKA_REPLICA_FETCH_MAX_BYTES"))
+	assert.Empty(t, getEnvValue(envs, "CONF_KAFKA_LOG_RETENTION_MS"))
+}
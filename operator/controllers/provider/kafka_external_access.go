@@ -0,0 +1,201 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	kafkaservice "github.com/Netcracker/qubership-kafka/operator/api/v1"
+	"github.com/Netcracker/qubership-kafka/operator/util"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	ExternalAccessLoadBalancer = "LoadBalancer"
+	ExternalAccessNodePort     = "NodePort"
+	ExternalAccessIngress      = "Ingress"
+	ExternalAccessLabel        = "externalAccess"
+	ExternalListenerPort       = 9094
+	IngressExternalPort        = 443
+	sslPassthroughAnnotation   = "nginx.ingress.kubernetes.io/ssl-passthrough"
+	externalKafkaClientPort    = "external-kafka-client"
+)
+
+// WithExternalAddresses returns provider which configures brokers to advertise specified external addresses
+func (krp KafkaResourceProvider) WithExternalAddresses(addresses []kafkaservice.ExternalBrokerAddress) KafkaResourceProvider {
+	krp.externalAddresses = addresses
+	return krp
+}
+
+// GetExternalAddresses returns external addresses of brokers which are discovered by operator
+func (krp KafkaResourceProvider) GetExternalAddresses() []kafkaservice.ExternalBrokerAddress {
+	return krp.externalAddresses
+}
+
+// GetExternalBootstrapServers returns address which external clients use to connect to Kafka.
+// It is the bootstrap Ingress host if it is specified and the list of all external broker addresses otherwise.
+func (krp KafkaResourceProvider) GetExternalBootstrapServers() string {
+	externalAccess := krp.cr.Spec.ExternalAccess
+	if externalAccess != nil && externalAccess.Type == ExternalAccessIngress && externalAccess.BootstrapHostName != "" {
+		return fmt.Sprintf("%s:%d", externalAccess.BootstrapHostName, IngressExternalPort)
+	}
+	servers := make([]string, 0, len(krp.externalAddresses))
+	for _, address := range krp.externalAddresses {
+		servers = append(servers, fmt.Sprintf("%s:%d", address.Host, address.Port))
+	}
+	return strings.Join(servers, ",")
+}
+
+// GetExternalAccessLabels returns labels of Services and Ingresses which are created for external access
+func (krp KafkaResourceProvider) GetExternalAccessLabels() map[string]string {
+	return util.JoinMaps(krp.GetSelectorLabels(), map[string]string{ExternalAccessLabel: "true"})
+}
+
+// GetExternalServiceName returns name of Service which exposes broker outside Kubernetes
+func (krp KafkaResourceProvider) GetExternalServiceName(brokerId int) string {
+	return fmt.Sprintf("%s-%d-external", krp.cr.Name, brokerId)
+}
+
+// GetExternalBootstrapIngressName returns name of Ingress for bootstrap host
+func (krp KafkaResourceProvider) GetExternalBootstrapIngressName() string {
+	return fmt.Sprintf("%s-bootstrap-external", krp.cr.Name)
+}
+
+// NewKafkaExternalServiceForCR returns Service which exposes external listener of broker.
+// It has LoadBalancer or NodePort type, or ClusterIP type if it is a backend of Ingress.
+func (krp KafkaResourceProvider) NewKafkaExternalServiceForCR(brokerId int) *corev1.Service {
+	externalAccess := krp.cr.Spec.ExternalAccess
+	serviceName := krp.GetExternalServiceName(brokerId)
+	labels := util.JoinMaps(krp.GetKafkaLabels(), krp.GetExternalAccessLabels())
+	labels["name"] = serviceName
+	selectorLabels := krp.GetSelectorLabels()
+	selectorLabels["name"] = fmt.Sprintf("%s-%d", krp.cr.Name, brokerId)
+	port := corev1.ServicePort{
+		Name:       externalKafkaClientPort,
+		Port:       ExternalListenerPort,
+		TargetPort: intstr.FromInt32(ExternalListenerPort),
+		Protocol:   corev1.ProtocolTCP,
+	}
+	requestedPort := krp.getExternalPort(brokerId)
+	serviceType := corev1.ServiceTypeClusterIP
+	switch externalAccess.Type {
+	case ExternalAccessLoadBalancer:
+		serviceType = corev1.ServiceTypeLoadBalancer
+		if requestedPort > 0 {
+			port.Port = requestedPort
+		}
+	case ExternalAccessNodePort:
+		serviceType = corev1.ServiceTypeNodePort
+		port.NodePort = requestedPort
+	}
+	service := newServiceForBroker(serviceName, krp.cr.Namespace, labels, selectorLabels, []corev1.ServicePort{port})
+	service.Spec.Type = serviceType
+	if serviceType != corev1.ServiceTypeClusterIP {
+		service.ObjectMeta.Annotations = util.JoinMaps(service.ObjectMeta.Annotations, externalAccess.Annotations)
+		if externalAccess.ExternalTrafficPolicy != "" {
+			service.Spec.ExternalTrafficPolicy = corev1.ServiceExternalTrafficPolicy(externalAccess.ExternalTrafficPolicy)
+		}
+	}
+	return service
+}
+
+// NewKafkaExternalIngressForCR returns Ingress which passes TLS traffic for broker host to its external Service
+func (krp KafkaResourceProvider) NewKafkaExternalIngressForCR(brokerId int) *networkingv1.Ingress {
+	serviceName := krp.GetExternalServiceName(brokerId)
+	return krp.newPassthroughIngress(serviceName, krp.cr.Spec.ExternalHostNames[brokerId-1], serviceName)
+}
+
+// NewKafkaBootstrapIngressForCR returns Ingress which passes TLS traffic for bootstrap host to any broker
+func (krp KafkaResourceProvider) NewKafkaBootstrapIngressForCR() *networkingv1.Ingress {
+	return krp.newPassthroughIngress(krp.GetExternalBootstrapIngressName(),
+		krp.cr.Spec.ExternalAccess.BootstrapHostName, krp.NewKafkaClientServiceForCR().Name)
+}
+
+func (krp KafkaResourceProvider) newPassthroughIngress(name string, host string, serviceName string) *networkingv1.Ingress {
+	externalAccess := krp.cr.Spec.ExternalAccess
+	labels := util.JoinMaps(krp.GetKafkaLabels(), krp.GetExternalAccessLabels())
+	labels["name"] = name
+	pathType := networkingv1.PathTypeImplementationSpecific
+	ingress := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   krp.cr.Namespace,
+			Labels:      labels,
+			Annotations: util.JoinMaps(map[string]string{sslPassthroughAnnotation: "true"}, externalAccess.Annotations),
+		},
+		Spec: networkingv1.IngressSpec{
+			TLS: []networkingv1.IngressTLS{{Hosts: []string{host}}},
+			Rules: []networkingv1.IngressRule{
+				{
+					Host: host,
+					IngressRuleValue: networkingv1.IngressRuleValue{
+						HTTP: &networkingv1.HTTPIngressRuleValue{
+							Paths: []networkingv1.HTTPIngressPath{
+								{
+									Path:     "/",
+									PathType: &pathType,
+									Backend: networkingv1.IngressBackend{
+										Service: &networkingv1.IngressServiceBackend{
+											Name: serviceName,
+											Port: networkingv1.ServiceBackendPort{Name: externalKafkaClientPort},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	if externalAccess.IngressClassName != "" {
+		ingressClassName := externalAccess.IngressClassName
+		ingress.Spec.IngressClassName = &ingressClassName
+	}
+	return ingress
+}
+
+// getExternalPort returns port which is requested for broker in external ports list
+func (krp KafkaResourceProvider) getExternalPort(brokerId int) int32 {
+	if len(krp.cr.Spec.ExternalPorts) >= brokerId {
+		return int32(krp.cr.Spec.ExternalPorts[brokerId-1])
+	}
+	return 0
+}
+
+// getExternalAddress returns host and port which broker advertises for external clients
+func (krp KafkaResourceProvider) getExternalAddress(brokerId int) (string, string) {
+	if krp.cr.Spec.ExternalAccess != nil {
+		for _, address := range krp.externalAddresses {
+			if address.Id == brokerId {
+				return address.Host, strconv.Itoa(int(address.Port))
+			}
+		}
+		return "", ""
+	}
+	if len(krp.cr.Spec.ExternalHostNames) == 0 {
+		return "", ""
+	}
+	externalPort := strconv.Itoa(ExternalListenerPort)
+	if len(krp.cr.Spec.ExternalPorts) > 0 {
+		externalPort = strconv.Itoa(krp.cr.Spec.ExternalPorts[brokerId-1])
+	}
+	return krp.cr.Spec.ExternalHostNames[brokerId-1], externalPort
+}
@@ -0,0 +1,118 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"testing"
+
+	kafkav1 "github.com/Netcracker/qubership-kafka/operator/api/v1"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newExternalAccessKafka(accessType string) *kafkav1.Kafka {
+	return &kafkav1.Kafka{
+		ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "streaming"},
+		Spec: kafkav1.KafkaSpec{
+			Replicas: 2,
+			ExternalAccess: &kafkav1.ExternalAccess{
+				Type:        accessType,
+				Annotations: map[string]string{"example.com/annotation": "value"},
+			},
+		},
+	}
+}
+
+func getEnvValue(envs []corev1.EnvVar, name string) string {
+	for _, env := range envs {
+		if env.Name == name {
+			return env.Value
+		}
+	}
+	return ""
+}
+
+func TestNewKafkaExternalServiceForCR(t *testing.T) {
+	cr := newExternalAccessKafka(ExternalAccessLoadBalancer)
+	cr.Spec.ExternalAccess.ExternalTrafficPolicy = "Local"
+	service := NewKafkaResourceProvider(cr, logr.Discard()).NewKafkaExternalServiceForCR(2)
+	assert.Equal(t, "kafka-2-external", service.Name)
+	assert.Equal(t, corev1.ServiceTypeLoadBalancer, service.Spec.Type)
+	assert.Equal(t, corev1.ServiceExternalTrafficPolicyLocal, service.Spec.ExternalTrafficPolicy)
+	assert.Equal(t, "kafka-2", service.Spec.Selector["name"])
+	assert.Equal(t, "true", service.Labels[ExternalAccessLabel])
+	assert.Equal(t, "value", service.Annotations["example.com/annotation"])
+	assert.Equal(t, int32(ExternalListenerPort), service.Spec.Ports[0].Port)
+	assert.Equal(t, int32(ExternalListenerPort), service.Spec.Ports[0].TargetPort.IntVal)
+
+	cr = newExternalAccessKafka(ExternalAccessNodePort)
+	cr.Spec.ExternalPorts = []int{31001, 31002}
+	service = NewKafkaResourceProvider(cr, logr.Discard()).NewKafkaExternalServiceForCR(1)
+	assert.Equal(t, corev1.ServiceTypeNodePort, service.Spec.Type)
+	assert.Equal(t, int32(31001), service.Spec.Ports[0].NodePort)
+
+	cr = newExternalAccessKafka(ExternalAccessIngress)
+	service = NewKafkaResourceProvider(cr, logr.Discard()).NewKafkaExternalServiceForCR(1)
+	assert.Equal(t, corev1.ServiceTypeClusterIP, service.Spec.Type)
+	assert.NotContains(t, service.Annotations, "example.com/annotation")
+}
+
+func TestNewKafkaExternalIngressForCR(t *testing.T) {
+	cr := newExternalAccessKafka(ExternalAccessIngress)
+	cr.Spec.ExternalHostNames = []string{"kafka-1.example.com", "kafka-2.example.com"}
+	cr.Spec.ExternalAccess.IngressClassName = "nginx"
+	cr.Spec.ExternalAccess.BootstrapHostName = "kafka.example.com"
+	krp := NewKafkaResourceProvider(cr, logr.Discard())
+
+	ingress := krp.NewKafkaExternalIngressForCR(2)
+	assert.Equal(t, "kafka-2-external", ingress.Name)
+	assert.Equal(t, "nginx", *ingress.Spec.IngressClassName)
+	assert.Equal(t, "true", ingress.Annotations[sslPassthroughAnnotation])
+	assert.Equal(t, "kafka-2.example.com", ingress.Spec.Rules[0].Host)
+	assert.Equal(t, "kafka-2-external", ingress.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Name)
+
+	ingress = krp.NewKafkaBootstrapIngressForCR()
+	assert.Equal(t, "kafka-bootstrap-external", ingress.Name)
+	assert.Equal(t, "kafka.example.com", ingress.Spec.Rules[0].Host)
+	assert.Equal(t, "kafka", ingress.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Name)
+	assert.Equal(t, "kafka.example.com:443", krp.GetExternalBootstrapServers())
+}
+
+func TestExternalAddresses(t *testing.T) {
+	cr := newExternalAccessKafka(ExternalAccessLoadBalancer)
+	cr.Spec.ExternalHostNames = []string{"ignored-1", "ignored-2"}
+	krp := NewKafkaResourceProvider(cr, logr.Discard()).WithExternalAddresses([]kafkav1.ExternalBrokerAddress{
+		{Id: 1, Host: "10.0.0.1", Port: 9094},
+		{Id: 2, Host: "lb.example.com", Port: 9095},
+	})
+	assert.Equal(t, "10.0.0.1:9094,lb.example.com:9095", krp.GetExternalBootstrapServers())
+	assert.Subset(t, krp.GetCertificateHostNames(), []string{"10.0.0.1", "lb.example.com"})
+
+	host, port := krp.getExternalAddress(2)
+	assert.Equal(t, "lb.example.com", host)
+	assert.Equal(t, "9095", port)
+
+	envs := krp.NewKafkaBrokerDeploymentForCR(1, "", false, "").Spec.Template.Spec.Containers[0].Env
+	assert.Equal(t, "10.0.0.1", getEnvValue(envs, "EXTERNAL_HOST_NAME"))
+	assert.Equal(t, "9094", getEnvValue(envs, "EXTERNAL_PORT"))
+
+	cr.Spec.ExternalAccess = nil
+	cr.Spec.ExternalPorts = []int{31001, 31002}
+	host, port = NewKafkaResourceProvider(cr, logr.Discard()).getExternalAddress(2)
+	assert.Equal(t, "ignored-2", host)
+	assert.Equal(t, "31002", port)
+}
@@ -51,6 +51,8 @@ type KafkaResourceProvider struct {
 	cr     *kafkaservice.Kafka
 	logger logr.Logger
 	spec   kafkaservice.KafkaSpec
+	// externalAddresses are addresses of brokers which are discovered from external Services and Ingresses
+	externalAddresses []kafkaservice.ExternalBrokerAddress
 }
 
 func NewKafkaResourceProvider(cr *kafkaservice.Kafka, logger logr.Logger) KafkaResourceProvider {
@@ -85,6 +87,64 @@ func (krp KafkaResourceProvider) GetServiceAccountName() string {
 	return krp.GetServiceName()
 }
 
+// IsCertManagerEnabled checks whether TLS certificate of brokers is issued by cert-manager on behalf of operator
+func (krp KafkaResourceProvider) IsCertManagerEnabled() bool {
+	return krp.cr.Spec.Ssl.Enabled && krp.cr.Spec.Ssl.CertManager != nil && krp.cr.Spec.Ssl.CertManager.Enabled
+}
+
+// GetSslSecretName returns name of secret with TLS certificates of brokers
+func (krp KafkaResourceProvider) GetSslSecretName() string {
+	if krp.cr.Spec.Ssl.SecretName == "" && krp.IsCertManagerEnabled() {
+		return fmt.Sprintf("%s-tls-secret", krp.cr.Name)
+	}
+	return krp.cr.Spec.Ssl.SecretName
+}
+
+// GetCertificateName returns name of cert-manager Certificate for brokers
+func (krp KafkaResourceProvider) GetCertificateName() string {
+	return fmt.Sprintf("%s-tls-certificate", krp.cr.Name)
+}
+
+// GetCertificateHostNames returns host names and IP addresses which brokers certificate must be valid for.
+// They include names of client, domain and broker services, external host names and additional names.
+func (krp KafkaResourceProvider) GetCertificateHostNames() []string {
+	namespace := krp.cr.Namespace
+	clientServiceName := krp.NewKafkaClientServiceForCR().Name
+	domainServiceName := krp.NewKafkaDomainClientServiceForCR().Name
+	hostNames := []string{
+		"localhost",
+		"127.0.0.1",
+		clientServiceName,
+		fmt.Sprintf("%s.%s", clientServiceName, namespace),
+		fmt.Sprintf("%s.%s.svc", clientServiceName, namespace),
+		fmt.Sprintf("%s.%s", clientServiceName, domainServiceName),
+		fmt.Sprintf("%s.%s.%s", clientServiceName, domainServiceName, namespace),
+	}
+	if krp.cr.Spec.Kraft.Enabled {
+		hostNames = append(hostNames, krp.NewKafkaControllerServiceForCR().Name)
+	}
+	for brokerId := 1; brokerId <= krp.cr.Spec.Replicas; brokerId++ {
+		brokerServiceName := krp.NewKafkaBrokerServiceForCR(brokerId).Name
+		hostNames = append(hostNames,
+			brokerServiceName,
+			fmt.Sprintf("%s.%s", brokerServiceName, namespace),
+			fmt.Sprintf("%s.%s.svc", brokerServiceName, namespace),
+			fmt.Sprintf("%s.%s.%s", brokerServiceName, domainServiceName, namespace))
+	}
+	hostNames = append(hostNames, krp.cr.Spec.ExternalHostNames...)
+	for _, address := range krp.externalAddresses {
+		hostNames = append(hostNames, address.Host)
+	}
+	if krp.cr.Spec.ExternalAccess != nil {
+		hostNames = append(hostNames, krp.cr.Spec.ExternalAccess.BootstrapHostName)
+	}
+	if certManager := krp.cr.Spec.Ssl.CertManager; certManager != nil {
+		hostNames = append(hostNames, certManager.AdditionalDnsNames...)
+		hostNames = append(hostNames, certManager.AdditionalIpAddresses...)
+	}
+	return hostNames
+}
+
 func (krp KafkaResourceProvider) NewKafkaClientServiceForCR() *corev1.Service {
 	kafkaLabels := krp.GetKafkaLabels()
 	selectorLabels := krp.GetSelectorLabels()
@@ -385,15 +445,7 @@ func (krp KafkaResourceProvider) NewKafkaBrokerDeploymentForCR(brokerId int, rac
 	terminationGracePeriod := getTerminationGracePeriod(krp.cr.Spec)
 	rollbackTimeout := getRollbackTimeout(krp.cr.Spec)
 
-	externalHostName := ""
-	externalPort := ""
-	if len(krp.cr.Spec.ExternalHostNames) > 0 {
-		externalHostName = krp.cr.Spec.ExternalHostNames[brokerId-1]
-		externalPort = "9094"
-		if len(krp.cr.Spec.ExternalPorts) > 0 {
-			externalPort = strconv.Itoa(krp.cr.Spec.ExternalPorts[brokerId-1])
-		}
-	}
+	externalHostName, externalPort := krp.getExternalAddress(brokerId)
 
 	volumes := []corev1.Volume{
 		{Name: "data", VolumeSource: dataVolumeSource},
@@ -476,7 +528,7 @@ func (krp KafkaResourceProvider) NewKafkaBrokerDeploymentForCR(brokerId int, rac
 			{Name: "ZOOKEEPER_SET_ACL", Value: strconv.FormatBool(krp.isZookeeperSetACL())},
 		}...)
 	}
-	if krp.cr.Spec.Ssl.Enabled && krp.cr.Spec.Ssl.SecretName != "" {
+	if krp.cr.Spec.Ssl.Enabled && krp.GetSslSecretName() != "" {
 		envVars = append(envVars, []corev1.EnvVar{
 			{Name: "ENABLE_SSL", Value: "true"},
 			{Name: "SSL_CIPHER_SUITES", Value: strings.Join(krp.cr.Spec.Ssl.CipherSuites, ",")},
@@ -488,7 +540,7 @@ func (krp KafkaResourceProvider) NewKafkaBrokerDeploymentForCR(brokerId int, rac
 			Name: "ssl-certs",
 			VolumeSource: corev1.VolumeSource{
 				Secret: &corev1.SecretVolumeSource{
-					SecretName: krp.cr.Spec.Ssl.SecretName,
+					SecretName: krp.GetSslSecretName(),
 				},
 			},
 		})
@@ -517,6 +569,7 @@ func (krp KafkaResourceProvider) NewKafkaBrokerDeploymentForCR(brokerId int, rac
 			{Name: "METRIC_COLLECTOR_ENABLED", Value: "true"},
 		}...)
 	}
+	envVars = append(envVars, krp.getStaticBrokerConfigEnvs()...)
 
 	brokerDeployment := &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
@@ -671,7 +724,7 @@ func (krp KafkaResourceProvider) NewKafkaKraftControllerDeploymentForCR(zkCluste
 		}...)
 	}
 
-	if krp.cr.Spec.Ssl.Enabled && krp.cr.Spec.Ssl.SecretName != "" {
+	if krp.cr.Spec.Ssl.Enabled && krp.GetSslSecretName() != "" {
 		envVars = append(envVars, []corev1.EnvVar{
 			{Name: "ENABLE_SSL", Value: "true"},
 			{Name: "SSL_CIPHER_SUITES", Value: strings.Join(krp.cr.Spec.Ssl.CipherSuites, ",")},
@@ -683,7 +736,7 @@ func (krp KafkaResourceProvider) NewKafkaKraftControllerDeploymentForCR(zkCluste
 			Name: "ssl-certs",
 			VolumeSource: corev1.VolumeSource{
 				Secret: &corev1.SecretVolumeSource{
-					SecretName: krp.cr.Spec.Ssl.SecretName,
+					SecretName: krp.GetSslSecretName(),
 				},
 			},
 		})
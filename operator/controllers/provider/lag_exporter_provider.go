@@ -82,7 +82,7 @@ func (lep LagExporterResourceProvider) getVolumeMounts() []corev1.VolumeMount {
 			ReadOnly:  true,
 		},
 	}
-	if lep.cr.Spec.Global.KafkaSsl.Enabled && lep.cr.Spec.Global.KafkaSsl.SecretName != "" {
+	if lep.cr.Spec.Global.KafkaSsl.Enabled && GetClientSslSecretName(lep.cr, MonitoringCertificateComponent) != "" {
 		volumeMounts = append(volumeMounts, corev1.VolumeMount{Name: "ssl-certs", MountPath: "/tls"})
 	}
 	volumeMounts = append(volumeMounts, getTmpVolumeMount())
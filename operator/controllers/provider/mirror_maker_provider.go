@@ -16,6 +16,7 @@ package provider
 
 import (
 	"fmt"
+	"github.com/Netcracker/qubership-kafka/operator/api/kmm"
 	kafkaservice "github.com/Netcracker/qubership-kafka/operator/api/v7"
 	"github.com/Netcracker/qubership-kafka/operator/controllers/kmmconfig"
 	"github.com/Netcracker/qubership-kafka/operator/util"
@@ -27,7 +28,13 @@ import (
 	"strings"
 )
 
-const TopicBlackList = "topics.blacklist"
+const (
+	TopicBlackList              = "topics.blacklist"
+	defaultOriginHeader         = "kafka.origin.cluster"
+	loopFilterTransformName     = "loopFilter"
+	loopOriginTransformName     = "loopOriginHeader"
+	loopReplicatedPredicateName = "loopReplicated"
+)
 
 type MirrorMakerResourceProvider struct {
 	cr          *kafkaservice.KafkaService
@@ -106,9 +113,14 @@ func (mmrp MirrorMakerResourceProvider) GetMirrorMakerProperties() string {
 		fmt.Sprintf("offset-syncs.topic.replication.factor = %d", mmrp.spec.ReplicationFactor),
 		fmt.Sprintf("refresh.topics.interval.seconds = %d", getIntValueOrDefault(mmrp.spec.RefreshTopicsIntervalSeconds, 5)),
 		fmt.Sprintf("refresh.groups.interval.seconds = %d", getIntValueOrDefault(mmrp.spec.RefreshGroupsIntervalSeconds, 5)),
-		fmt.Sprintf("dedicated.mode.enable.internal.rest = %t", getBoolValueOrDefault(mmrp.spec.InternalRestEnabled, true)),
+		fmt.Sprintf("dedicated.mode.enable.internal.rest = %t", mmrp.IsInternalRestEnabled()),
 		fmt.Sprintf("tasks.max = %d", getIntValueOrDefault(mmrp.spec.TasksMax, int32(mmrp.spec.Replicas))),
-		"sync.topic.acls.enabled = false", "")
+		"sync.topic.acls.enabled = false")
+	if mmrp.spec.ClusterSync != nil && mmrp.spec.ClusterSync.TopicConfigsEnabled {
+		// topic configs are synchronized by the operator
+		clustersConfiguration = append(clustersConfiguration, "sync.topic.configs.enabled = false")
+	}
+	clustersConfiguration = append(clustersConfiguration, "")
 
 	for _, cluster := range mmrp.spec.Clusters {
 		clusterName := strings.ToLower(cluster.Name)
@@ -146,7 +158,8 @@ func (mmrp MirrorMakerResourceProvider) createReplicationFlowConfig(sourceNames
 			"sync.group.offsets.interval.seconds = 5")
 	}
 
-	identityReplicationEnabled := drEnabled || !mmrp.spec.ReplicationPrefixEnabled
+	activeActiveEnabled := mmrp.IsActiveActive()
+	identityReplicationEnabled := drEnabled || activeActiveEnabled || !mmrp.spec.ReplicationPrefixEnabled
 	if identityReplicationEnabled {
 		replicationConfig = append(replicationConfig,
 			fmt.Sprintf("%s = %s", kmmconfig.ReplicationPolicyClassConfig, kmmconfig.IdentityReplicationPolicy))
@@ -162,10 +175,14 @@ func (mmrp MirrorMakerResourceProvider) createReplicationFlowConfig(sourceNames
 		for _, targetClusterName := range targetNames {
 			if sourceClusterName != targetClusterName {
 				replicationFlow := kmmconfig.NewReplicationFlow(sourceClusterName, targetClusterName)
-				replicationFlowEnabled := drEnabled || mmrp.spec.ReplicationFlowEnabled
+				replicationFlowEnabled := drEnabled || activeActiveEnabled || mmrp.spec.ReplicationFlowEnabled
 				replicationConfig = append(replicationConfig,
 					fmt.Sprintf("%s.enabled = %t", replicationFlow, replicationFlowEnabled))
-				if replicationFlowEnabled {
+				if activeActiveEnabled {
+					mmrp.logger.Info(fmt.Sprintf("Configuring loop prevention for %s replication flow", replicationFlow))
+					replicationConfig = mmrp.addActiveActiveProperties(
+						replicationConfig, sourceClusterName, targetClusterName, repeatedReplication)
+				} else if replicationFlowEnabled {
 					if !mmrp.spec.ConfiguratorEnabled {
 						mmrp.logger.Info(fmt.Sprintf("Configuring transformation for %s replication flow", replicationFlow))
 						transformationConfigurator := kmmconfig.NewTransformationConfigurator(mmrp.spec.Transformation, "")
@@ -199,6 +216,235 @@ func (mmrp MirrorMakerResourceProvider) createReplicationFlowConfig(sourceNames
 	return replicationConfig
 }
 
+// addActiveActiveProperties adds topic filters of specified replication flow and transforms which mark replicated
+// records with origin header and drop records having this header, so they are never replicated back
+func (mmrp MirrorMakerResourceProvider) addActiveActiveProperties(replicationConfig []string,
+	sourceClusterName string, targetClusterName string, repeatedReplication bool) []string {
+	replicationFlow := kmmconfig.NewReplicationFlow(sourceClusterName, targetClusterName)
+	if flow := mmrp.findActiveActiveFlow(sourceClusterName, targetClusterName); flow != nil {
+		if flow.Topics != "" && !mmrp.spec.ConfiguratorEnabled {
+			replicationConfig = append(replicationConfig, fmt.Sprintf("%s.topics = %s", replicationFlow, flow.Topics))
+		}
+		if flow.TopicsExclude != "" {
+			// flow property overrides the global one, so default exclusions have to be repeated
+			topicsExclude := []string{flow.TopicsExclude}
+			if !repeatedReplication {
+				topicsExclude = append(topicsExclude, ".*\\..*-internal", ".*\\..*\\.internal", "__.*")
+			}
+			replicationConfig = append(replicationConfig,
+				fmt.Sprintf("%s.%s = %s", replicationFlow, TopicBlackList, strings.Join(topicsExclude, ",")))
+		}
+	}
+	transformation := mmrp.getLoopPreventionTransformation(sourceClusterName)
+	if mmrp.spec.Transformation != nil && !mmrp.spec.ConfiguratorEnabled {
+		transformation.Transforms = append(transformation.Transforms, mmrp.spec.Transformation.Transforms...)
+		transformation.Predicates = append(transformation.Predicates, mmrp.spec.Transformation.Predicates...)
+	}
+	transformationConfigurator := kmmconfig.NewTransformationConfigurator(transformation, "")
+	replicationConfig = transformationConfigurator.AddTransformationProperties(
+		replicationConfig, sourceClusterName, targetClusterName, true)
+	return orderLoopPreventionTransforms(replicationConfig, replicationFlow)
+}
+
+// orderLoopPreventionTransforms moves loop filter to the beginning of transformation chain and origin header
+// to its end, so custom transforms can neither remove the header before the filter nor after it is inserted
+func orderLoopPreventionTransforms(replicationConfig []string, replicationFlow string) []string {
+	transformsPropertyPrefix := fmt.Sprintf("%s.transforms = ", replicationFlow)
+	for i, line := range replicationConfig {
+		if !strings.HasPrefix(line, transformsPropertyPrefix) {
+			continue
+		}
+		transformNames := []string{loopFilterTransformName}
+		for _, name := range strings.Split(strings.TrimPrefix(line, transformsPropertyPrefix), ",") {
+			if name != loopFilterTransformName && name != loopOriginTransformName {
+				transformNames = append(transformNames, name)
+			}
+		}
+		transformNames = append(transformNames, loopOriginTransformName)
+		replicationConfig[i] = transformsPropertyPrefix + strings.Join(transformNames, ",")
+	}
+	return replicationConfig
+}
+
+// getLoopPreventionTransformation returns transformation which drops records replicated from another cluster
+// and marks the rest records with the name of source cluster
+func (mmrp MirrorMakerResourceProvider) getLoopPreventionTransformation(sourceClusterName string) *kmm.Transformation {
+	originHeader := mmrp.GetOriginHeader()
+	return &kmm.Transformation{
+		Transforms: []kmm.Transform{
+			{
+				Name:      loopFilterTransformName,
+				Type:      "org.apache.kafka.connect.transforms.Filter",
+				Predicate: loopReplicatedPredicateName,
+			},
+			{
+				Name: loopOriginTransformName,
+				Type: "org.apache.kafka.connect.transforms.InsertHeader",
+				Params: map[string]string{
+					"header":        originHeader,
+					"value.literal": sourceClusterName,
+				},
+			},
+		},
+		Predicates: []kmm.Predicate{
+			{
+				Name:   loopReplicatedPredicateName,
+				Type:   "org.apache.kafka.connect.transforms.predicates.HasHeaderKey",
+				Params: map[string]string{"name": originHeader},
+			},
+		},
+	}
+}
+
+func (mmrp MirrorMakerResourceProvider) findActiveActiveFlow(sourceClusterName string,
+	targetClusterName string) *kafkaservice.ActiveActiveFlow {
+	for _, flow := range mmrp.spec.ActiveActive.Flows {
+		if strings.EqualFold(flow.Source, sourceClusterName) && strings.EqualFold(flow.Target, targetClusterName) {
+			return &flow
+		}
+	}
+	return nil
+}
+
+// IsActiveActive checks whether Kafka Mirror Maker replicates records in both directions between writable clusters
+func (mmrp MirrorMakerResourceProvider) IsActiveActive() bool {
+	return mmrp.spec.ActiveActive != nil && mmrp.spec.ActiveActive.Enabled
+}
+
+// GetOriginHeader returns the name of record header which contains the cluster where the record was produced
+func (mmrp MirrorMakerResourceProvider) GetOriginHeader() string {
+	if mmrp.spec.ActiveActive != nil && mmrp.spec.ActiveActive.OriginHeader != "" {
+		return mmrp.spec.ActiveActive.OriginHeader
+	}
+	return defaultOriginHeader
+}
+
+// GetReplicationFlows returns the list of source->target replication flows handled by Kafka Mirror Maker
+func (mmrp MirrorMakerResourceProvider) GetReplicationFlows() []kafkaservice.ReplicationFlow {
+	var flows []kafkaservice.ReplicationFlow
+	if mmrp.HasDedicatedFlows() {
+		for _, flow := range mmrp.spec.Flows {
+			if mmrp.spec.RegionName == "" || strings.EqualFold(flow.Target, mmrp.spec.RegionName) {
+				flows = append(flows, flow)
+			}
+		}
+		return flows
+	}
+	for _, source := range mmrp.spec.Clusters {
+		for _, target := range mmrp.spec.Clusters {
+			if source.Name == target.Name ||
+				mmrp.spec.RegionName != "" && !strings.EqualFold(target.Name, mmrp.spec.RegionName) {
+				continue
+			}
+			flows = append(flows, kafkaservice.ReplicationFlow{
+				Source: strings.ToLower(source.Name),
+				Target: strings.ToLower(target.Name),
+			})
+		}
+	}
+	return flows
+}
+
+// IsInternalRestEnabled checks whether Kafka Mirror Maker pods expose internal REST API
+func (mmrp MirrorMakerResourceProvider) IsInternalRestEnabled() bool {
+	return getBoolValueOrDefault(mmrp.spec.InternalRestEnabled, true)
+}
+
+// HasDedicatedFlows checks whether each replication flow runs in its own deployment
+func (mmrp MirrorMakerResourceProvider) HasDedicatedFlows() bool {
+	return len(mmrp.spec.Flows) > 0
+}
+
+// ValidateReplicationFlows checks that dedicated replication flows refer to different clusters from Kafka Mirror Maker
+// clusters, are not duplicated and are not used together with Kafka Mirror Maker configurator
+func (mmrp MirrorMakerResourceProvider) ValidateReplicationFlows() error {
+	if !mmrp.HasDedicatedFlows() {
+		return nil
+	}
+	if mmrp.spec.ConfiguratorEnabled {
+		return fmt.Errorf("replication flows cannot be used when Kafka Mirror Maker configurator is enabled")
+	}
+	flowNames := make(map[string]bool)
+	for _, flow := range mmrp.spec.Flows {
+		flowName := kmmconfig.NewReplicationFlow(strings.ToLower(flow.Source), strings.ToLower(flow.Target))
+		if flowNames[flowName] {
+			return fmt.Errorf("replication flow %s is specified more than once", flowName)
+		}
+		flowNames[flowName] = true
+		if _, err := mmrp.ForReplicationFlow(flow); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetReplicationFlowDeploymentName returns the name of deployment which handles specified replication flow
+func (mmrp MirrorMakerResourceProvider) GetReplicationFlowDeploymentName(flow kafkaservice.ReplicationFlow) string {
+	if mmrp.HasDedicatedFlows() {
+		return fmt.Sprintf("%s-%s-%s", strings.ToLower(flow.Source), strings.ToLower(flow.Target), mmrp.GetServiceName())
+	}
+	return fmt.Sprintf("%s-%s", strings.ToLower(flow.Target), mmrp.GetServiceName())
+}
+
+// ForReplicationFlow returns the provider of configuration map and deployment for specified replication flow.
+// The flow is configured as cross-datacenter replication from source cluster to target one with its own
+// topics, tasks, replicas and resources. Kafka Mirror Maker configurator is not supported for such flows,
+// it is rejected by ValidateReplicationFlows.
+func (mmrp MirrorMakerResourceProvider) ForReplicationFlow(flow kafkaservice.ReplicationFlow) (MirrorMakerResourceProvider, error) {
+	source := mmrp.findCluster(flow.Source)
+	target := mmrp.findCluster(flow.Target)
+	if source == nil || target == nil || source.Name == target.Name {
+		return mmrp, fmt.Errorf("replication flow %s must refer to two different clusters from Kafka Mirror Maker clusters",
+			kmmconfig.NewReplicationFlow(flow.Source, flow.Target))
+	}
+	spec := mmrp.spec.DeepCopy()
+	spec.Clusters = []kafkaservice.Cluster{*source, *target}
+	spec.RegionName = target.Name
+	spec.ConfigurationName = fmt.Sprintf("%s-%s-%s",
+		mmrp.spec.ConfigurationName, strings.ToLower(source.Name), strings.ToLower(target.Name))
+	spec.ConfiguratorEnabled = false
+	spec.ReplicationFlowEnabled = true
+	spec.Flows = nil
+	if flow.Topics != "" {
+		spec.TopicsToReplicate = flow.Topics
+	}
+	if flow.TasksMax != nil {
+		spec.TasksMax = flow.TasksMax
+	}
+	if flow.Replicas != nil {
+		spec.Replicas = int(*flow.Replicas)
+	}
+	if flow.Resources != nil {
+		spec.Resources = *flow.Resources
+	}
+	flowProvider := mmrp
+	flowProvider.spec = spec
+	return flowProvider, nil
+}
+
+// GetConfigurationName returns the name of configuration map with Kafka Mirror Maker properties
+func (mmrp MirrorMakerResourceProvider) GetConfigurationName() string {
+	return mmrp.spec.ConfigurationName
+}
+
+// NewReplicationFlowDeploymentForCR returns the deployment for the provider of replication flow
+// which is created by ForReplicationFlow
+func (mmrp MirrorMakerResourceProvider) NewReplicationFlowDeploymentForCR(secretVersion string,
+	configMapVersion string, deploymentName string) *appsv1.Deployment {
+	target := mmrp.spec.Clusters[1]
+	return mmrp.NewMirrorMakerDeploymentForCR(target, mmrp.spec.Clusters, secretVersion, configMapVersion,
+		strings.ToLower(target.Name), deploymentName)
+}
+
+func (mmrp MirrorMakerResourceProvider) findCluster(name string) *kafkaservice.Cluster {
+	for _, cluster := range mmrp.spec.Clusters {
+		if strings.EqualFold(cluster.Name, name) {
+			return &cluster
+		}
+	}
+	return nil
+}
+
 func (mmrp MirrorMakerResourceProvider) GetServiceName() string {
 	return mmrp.serviceName
 }
@@ -272,12 +518,12 @@ func (mmrp MirrorMakerResourceProvider) NewMirrorMakerDeploymentForCR(cluster ka
 		}
 		envVars = append(envVars, clusterNameVariables...)
 
-		if cluster.EnableSsl && cluster.SslSecretName != "" {
+		if sslSecretName := GetClusterSslSecretName(mmrp.cr, cluster); cluster.EnableSsl && sslSecretName != "" {
 			volumes = append(volumes, corev1.Volume{
 				Name: fmt.Sprintf("%s-ssl-certs", cluster.Name),
 				VolumeSource: corev1.VolumeSource{
 					Secret: &corev1.SecretVolumeSource{
-						SecretName: cluster.SslSecretName,
+						SecretName: sslSecretName,
 					},
 				},
 			})
@@ -0,0 +1,180 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Netcracker/qubership-kafka/operator/api/kmm"
+	kafkaservice "github.com/Netcracker/qubership-kafka/operator/api/v7"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func newMirrorMakerProvider(mirrorMaker *kafkaservice.MirrorMaker) MirrorMakerResourceProvider {
+	cr := &kafkaservice.KafkaService{
+		ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka-service"},
+		Spec:       kafkaservice.KafkaServiceSpec{Global: &kafkaservice.Global{}, MirrorMaker: mirrorMaker},
+	}
+	return NewMirrorMakerResourceProvider(cr, logr.Discard())
+}
+
+func TestCreateReplicationFlowConfigForActiveActive(t *testing.T) {
+	mmrp := newMirrorMakerProvider(&kafkaservice.MirrorMaker{
+		TopicsToReplicate: "orders,payments",
+		ActiveActive: &kafkaservice.ActiveActive{
+			Enabled: true,
+			Flows: []kafkaservice.ActiveActiveFlow{
+				{Source: "DC1", Target: "DC2", Topics: "orders", TopicsExclude: "orders-local"},
+			},
+		},
+	})
+	replicationConfig := mmrp.createReplicationFlowConfig([]string{"dc1", "dc2"}, []string{"dc1", "dc2"}, false)
+
+	assert.Contains(t, replicationConfig, "replication.policy.class = io.strimzi.kafka.connect.mirror.IdentityReplicationPolicy")
+	assert.Contains(t, replicationConfig, "topics = orders,payments")
+	assert.Contains(t, replicationConfig, "dc1->dc2.enabled = true")
+	assert.Contains(t, replicationConfig, "dc2->dc1.enabled = true")
+	assert.Contains(t, replicationConfig, "dc1->dc2.topics = orders")
+	assert.Contains(t, replicationConfig, "dc1->dc2.topics.blacklist = orders-local,.*\\..*-internal,.*\\..*\\.internal,__.*")
+	assert.NotContains(t, replicationConfig, "dc2->dc1.topics = orders")
+	assert.Contains(t, replicationConfig, "dc1->dc2.transforms = loopFilter,loopOriginHeader")
+	assert.Contains(t, replicationConfig, "dc1->dc2.transforms.loopFilter.predicate = loopReplicated")
+	assert.Contains(t, replicationConfig, "dc1->dc2.transforms.loopOriginHeader.header = kafka.origin.cluster")
+	assert.Contains(t, replicationConfig, "dc1->dc2.transforms.loopOriginHeader.value.literal = dc1")
+	assert.Contains(t, replicationConfig, "dc2->dc1.transforms.loopOriginHeader.value.literal = dc2")
+	assert.Contains(t, replicationConfig,
+		"dc2->dc1.predicates.loopReplicated.type = org.apache.kafka.connect.transforms.predicates.HasHeaderKey")
+	assert.Contains(t, replicationConfig, "dc2->dc1.predicates.loopReplicated.name = kafka.origin.cluster")
+}
+
+func TestCreateReplicationFlowConfigForActiveActiveWithUserTransformation(t *testing.T) {
+	mmrp := newMirrorMakerProvider(&kafkaservice.MirrorMaker{
+		ActiveActive: &kafkaservice.ActiveActive{Enabled: true, OriginHeader: "origin"},
+		Transformation: &kmm.Transformation{
+			Transforms: []kmm.Transform{{Name: "dropHeaders", Type: "org.apache.kafka.connect.transforms.DropHeaders"}},
+		},
+	})
+	replicationConfig := mmrp.createReplicationFlowConfig([]string{"dc1", "dc2"}, []string{"dc1"}, false)
+
+	assert.Contains(t, replicationConfig, "dc2->dc1.transforms = loopFilter,dropHeaders,loopOriginHeader")
+	assert.Contains(t, replicationConfig, "dc2->dc1.predicates.loopReplicated.name = origin")
+	assert.NotContains(t, replicationConfig, "dc1->dc2.enabled = true")
+}
+
+func TestCreateReplicationFlowConfigWithoutActiveActive(t *testing.T) {
+	mmrp := newMirrorMakerProvider(&kafkaservice.MirrorMaker{ReplicationPrefixEnabled: true})
+	replicationConfig := mmrp.createReplicationFlowConfig([]string{"dc1", "dc2"}, []string{"dc1", "dc2"}, false)
+
+	assert.Contains(t, replicationConfig, "dc1->dc2.enabled = false")
+	assert.NotContains(t, replicationConfig, "replication.policy.class = io.strimzi.kafka.connect.mirror.IdentityReplicationPolicy")
+	assert.Contains(t, replicationConfig, "topics.blacklist = dc1\\.*,dc2\\.*,.*\\..*-internal,.*\\..*\\.internal,__.*")
+}
+
+func TestGetReplicationFlows(t *testing.T) {
+	clusters := []kafkaservice.Cluster{{Name: "DC1"}, {Name: "DC2"}, {Name: "DC3"}}
+	mmrp := newMirrorMakerProvider(&kafkaservice.MirrorMaker{Clusters: clusters})
+	assert.Len(t, mmrp.GetReplicationFlows(), 6)
+
+	mmrp = newMirrorMakerProvider(&kafkaservice.MirrorMaker{Clusters: clusters, RegionName: "dc2"})
+	assert.Equal(t, []kafkaservice.ReplicationFlow{
+		{Source: "dc1", Target: "dc2"},
+		{Source: "dc3", Target: "dc2"},
+	}, mmrp.GetReplicationFlows())
+}
+
+func TestForReplicationFlow(t *testing.T) {
+	mmrp := newMirrorMakerProvider(&kafkaservice.MirrorMaker{
+		Clusters: []kafkaservice.Cluster{
+			{Name: "DC1", BootstrapServers: "kafka-dc1:9092"},
+			{Name: "DC2", BootstrapServers: "kafka-dc2:9092", NodeLabel: "region=dc2"},
+			{Name: "DC3", BootstrapServers: "kafka-dc3:9092"},
+		},
+		TopicsToReplicate: "orders",
+		ConfigurationName: "kafka-mirror-maker-configuration",
+		Replicas:          1,
+		Flows: []kafkaservice.ReplicationFlow{
+			{Source: "dc1", Target: "dc2", Topics: "hot-topic", TasksMax: ptr.To[int32](8), Replicas: ptr.To[int32](3)},
+		},
+	})
+	flow := mmrp.spec.Flows[0]
+	assert.True(t, mmrp.HasDedicatedFlows())
+	assert.Equal(t, "dc1-dc2-kafka-mirror-maker", mmrp.GetReplicationFlowDeploymentName(flow))
+
+	flowProvider, err := mmrp.ForReplicationFlow(flow)
+	assert.NoError(t, err)
+	assert.Equal(t, "kafka-mirror-maker-configuration-dc1-dc2", flowProvider.GetConfigurationName())
+	properties := strings.Split(flowProvider.GetMirrorMakerProperties(), "\n")
+	assert.Contains(t, properties, "clusters = dc1, dc2")
+	assert.Contains(t, properties, "tasks.max = 8")
+	assert.Contains(t, properties, "topics = hot-topic")
+	assert.Contains(t, properties, "dc1->dc2.enabled = true")
+	assert.NotContains(t, properties, "dc2->dc1.enabled = true")
+
+	deployment := flowProvider.NewReplicationFlowDeploymentForCR("1", "2", "dc1-dc2-kafka-mirror-maker")
+	assert.Equal(t, int32(3), *deployment.Spec.Replicas)
+	assert.Equal(t, "kafka-mirror-maker-configuration-dc1-dc2",
+		deployment.Spec.Template.Spec.Volumes[2].ConfigMap.Name)
+	assert.Contains(t, deployment.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{Name: "CLUSTER", Value: "dc2"})
+	assert.Equal(t, "region",
+		deployment.Spec.Template.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.
+			NodeSelectorTerms[0].MatchExpressions[0].Key)
+
+	_, err = mmrp.ForReplicationFlow(kafkaservice.ReplicationFlow{Source: "dc1", Target: "dc4"})
+	assert.Error(t, err)
+}
+
+func TestGetReplicationFlowsWithDedicatedFlows(t *testing.T) {
+	mmrp := newMirrorMakerProvider(&kafkaservice.MirrorMaker{
+		Clusters:   []kafkaservice.Cluster{{Name: "dc1"}, {Name: "dc2"}},
+		RegionName: "DC2",
+		Flows:      []kafkaservice.ReplicationFlow{{Source: "dc1", Target: "dc2"}, {Source: "dc2", Target: "dc1"}},
+	})
+	assert.Equal(t, []kafkaservice.ReplicationFlow{{Source: "dc1", Target: "dc2"}}, mmrp.GetReplicationFlows())
+}
+
+func TestValidateReplicationFlows(t *testing.T) {
+	clusters := []kafkaservice.Cluster{{Name: "dc1"}, {Name: "dc2"}}
+	tests := []struct {
+		name     string
+		spec     *kafkaservice.MirrorMaker
+		expected string
+	}{
+		{"no flows", &kafkaservice.MirrorMaker{Clusters: clusters, ConfiguratorEnabled: true}, ""},
+		{"valid flows", &kafkaservice.MirrorMaker{Clusters: clusters,
+			Flows: []kafkaservice.ReplicationFlow{{Source: "dc1", Target: "dc2"}, {Source: "dc2", Target: "dc1"}}}, ""},
+		{"configurator", &kafkaservice.MirrorMaker{Clusters: clusters, ConfiguratorEnabled: true,
+			Flows: []kafkaservice.ReplicationFlow{{Source: "dc1", Target: "dc2"}}},
+			"replication flows cannot be used when Kafka Mirror Maker configurator is enabled"},
+		{"duplicate flows", &kafkaservice.MirrorMaker{Clusters: clusters,
+			Flows: []kafkaservice.ReplicationFlow{{Source: "dc1", Target: "dc2"}, {Source: "DC1", Target: "DC2"}}},
+			"replication flow dc1->dc2 is specified more than once"},
+		{"unknown cluster", &kafkaservice.MirrorMaker{Clusters: clusters,
+			Flows: []kafkaservice.ReplicationFlow{{Source: "dc1", Target: "dc3"}}},
+			"replication flow dc1->dc3 must refer to two different clusters from Kafka Mirror Maker clusters"},
+	}
+	for _, test := range tests {
+		err := newMirrorMakerProvider(test.spec).ValidateReplicationFlows()
+		if test.expected == "" {
+			assert.NoError(t, err, test.name)
+		} else {
+			assert.EqualError(t, err, test.expected, test.name)
+		}
+	}
+}
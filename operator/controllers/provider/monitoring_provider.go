@@ -26,6 +26,13 @@ import (
 	"strconv"
 )
 
+const (
+	topicPolicyMountPath = "/etc/kafka-monitoring/topic-policy"
+	// additionalMetricsPort is the port of additional metrics endpoint in prometheus mode,
+	// 8096 is already used by Telegraf Prometheus output
+	additionalMetricsPort = 8097
+)
+
 type MonitoringResourceProvider struct {
 	cr                          *kafkaservice.KafkaService
 	logger                      logr.Logger
@@ -70,6 +77,11 @@ func (mrp MonitoringResourceProvider) GetServiceName() string {
 	return mrp.serviceName
 }
 
+// GetSslSecretName returns name of secret with TLS certificates which Kafka Monitoring uses to connect to Kafka
+func (mrp MonitoringResourceProvider) GetSslSecretName() string {
+	return GetClientSslSecretName(mrp.cr, MonitoringCertificateComponent)
+}
+
 func (mrp MonitoringResourceProvider) GetServiceAccountName() string {
 	return mrp.GetServiceName()
 }
@@ -102,6 +114,13 @@ func (mrp MonitoringResourceProvider) NewMonitoringClientService() *corev1.Servi
 			Protocol: corev1.ProtocolTCP,
 		})
 	}
+	if mrp.isAdditionalMetricsServed() {
+		ports = append(ports, corev1.ServicePort{
+			Name:     "additional-cli",
+			Port:     additionalMetricsPort,
+			Protocol: corev1.ProtocolTCP,
+		})
+	}
 	if mrp.spec.LagExporter != nil {
 		ports = append(ports, corev1.ServicePort{
 			Name:     "http",
@@ -212,10 +231,23 @@ func (mrp MonitoringResourceProvider) getMonitoringContainerPorts() []corev1.Con
 		ContainerPort: 8096,
 		Protocol:      corev1.ProtocolTCP,
 	})
+	if mrp.isAdditionalMetricsServed() {
+		ports = append(ports, corev1.ContainerPort{
+			Name:          "additional-cli",
+			ContainerPort: additionalMetricsPort,
+			Protocol:      corev1.ProtocolTCP,
+		})
+	}
 
 	return ports
 }
 
+// isAdditionalMetricsServed checks if additional metrics are served on HTTP endpoint for Prometheus
+// instead of being collected by Telegraf exec plugin
+func (mrp MonitoringResourceProvider) isAdditionalMetricsServed() bool {
+	return mrp.spec.AdditionalMetricsMode == "prometheus"
+}
+
 func (mrp MonitoringResourceProvider) getMonitoringEnvs() []corev1.EnvVar {
 	envVars := mrp.getMonitoringEnvironmentVariables()
 
@@ -311,12 +343,12 @@ func (mrp MonitoringResourceProvider) getMonitoringVolumes() []corev1.Volume {
 			},
 		},
 	})
-	if mrp.cr.Spec.Global.KafkaSsl.Enabled && mrp.cr.Spec.Global.KafkaSsl.SecretName != "" {
+	if mrp.cr.Spec.Global.KafkaSsl.Enabled && mrp.GetSslSecretName() != "" {
 		volumes = append(volumes, corev1.Volume{
 			Name: "ssl-certs",
 			VolumeSource: corev1.VolumeSource{
 				Secret: &corev1.SecretVolumeSource{
-					SecretName: mrp.cr.Spec.Global.KafkaSsl.SecretName,
+					SecretName: mrp.GetSslSecretName(),
 				},
 			},
 		})
@@ -324,6 +356,16 @@ func (mrp MonitoringResourceProvider) getMonitoringVolumes() []corev1.Volume {
 	if mrp.spec.LagExporter != nil {
 		volumes = append(volumes, mrp.lagExporterResourceProvider.getVolume())
 	}
+	if mrp.spec.TopicPolicyConfigMap != "" {
+		volumes = append(volumes, corev1.Volume{
+			Name: "topic-policy",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: mrp.spec.TopicPolicyConfigMap},
+				},
+			},
+		})
+	}
 	volumes = append(volumes, getTmpVolume("32Mi")) // Telegraf + optional Python lag-exporter share /tmp
 	return volumes
 }
@@ -341,9 +383,13 @@ func (mrp MonitoringResourceProvider) getMonitoringVolumeMounts() []corev1.Volum
 			ReadOnly:  true,
 		},
 	}
-	if mrp.cr.Spec.Global.KafkaSsl.Enabled && mrp.cr.Spec.Global.KafkaSsl.SecretName != "" {
+	if mrp.cr.Spec.Global.KafkaSsl.Enabled && mrp.GetSslSecretName() != "" {
 		volumeMounts = append(volumeMounts, corev1.VolumeMount{Name: "ssl-certs", MountPath: "/tls"})
 	}
+	if mrp.spec.TopicPolicyConfigMap != "" {
+		volumeMounts = append(volumeMounts,
+			corev1.VolumeMount{Name: "topic-policy", MountPath: topicPolicyMountPath, ReadOnly: true})
+	}
 	volumeMounts = append(volumeMounts, getTmpVolumeMount())
 	return volumeMounts
 }
@@ -391,6 +437,23 @@ func (mrp MonitoringResourceProvider) getMonitoringEnvironmentVariables() []core
 		// filesystem when the image's Python helper scripts are invoked.
 		{Name: "PYTHONDONTWRITEBYTECODE", Value: "1"},
 	}
+	if mrp.spec.KafkaStorageSize != "" {
+		envs = append(envs, corev1.EnvVar{Name: "KAFKA_STORAGE_SIZE", Value: mrp.spec.KafkaStorageSize})
+	}
+	if mrp.spec.TopicPolicyConfigMap != "" {
+		envs = append(envs, corev1.EnvVar{Name: "TOPIC_POLICY_FILE", Value: topicPolicyMountPath + "/policy.yaml"})
+	}
+	if mrp.spec.ConsumerLagEnabled {
+		envs = append(envs,
+			corev1.EnvVar{Name: "CONSUMER_LAG_ENABLED", Value: "true"},
+			corev1.EnvVar{Name: "CONSUMER_GROUPS_INCLUDE", Value: util.DefaultIfEmpty(mrp.spec.ConsumerGroupsInclude, ".*")},
+			corev1.EnvVar{Name: "CONSUMER_GROUPS_EXCLUDE", Value: mrp.spec.ConsumerGroupsExclude})
+	}
+	if mrp.isAdditionalMetricsServed() {
+		envs = append(envs,
+			corev1.EnvVar{Name: "METRICS_MODE", Value: mrp.spec.AdditionalMetricsMode},
+			corev1.EnvVar{Name: "METRICS_LISTEN_ADDRESS", Value: fmt.Sprintf(":%d", additionalMetricsPort)})
+	}
 	return envs
 }
 
@@ -0,0 +1,76 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"testing"
+
+	kafkaservice "github.com/Netcracker/qubership-kafka/operator/api/v7"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newMonitoringProvider(monitoring *kafkaservice.Monitoring) MonitoringResourceProvider {
+	cr := &kafkaservice.KafkaService{
+		ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka-service"},
+		Spec:       kafkaservice.KafkaServiceSpec{Global: &kafkaservice.Global{}, Monitoring: monitoring},
+	}
+	return NewMonitoringResourceProvider(cr, logr.Discard())
+}
+
+func findEnv(envs []corev1.EnvVar, name string) *corev1.EnvVar {
+	for i := range envs {
+		if envs[i].Name == name {
+			return &envs[i]
+		}
+	}
+	return nil
+}
+
+func TestMonitoringDeploymentServesAdditionalMetrics(t *testing.T) {
+	mrp := newMonitoringProvider(&kafkaservice.Monitoring{
+		MonitoringType:        "prometheus",
+		AdditionalMetricsMode: "prometheus",
+	})
+
+	container := mrp.NewMonitoringDeployment("1").Spec.Template.Spec.Containers[0]
+	assert.Equal(t, "prometheus", findEnv(container.Env, "METRICS_MODE").Value)
+	assert.Equal(t, ":8097", findEnv(container.Env, "METRICS_LISTEN_ADDRESS").Value)
+	assert.Contains(t, container.Ports,
+		corev1.ContainerPort{Name: "additional-cli", ContainerPort: 8097, Protocol: corev1.ProtocolTCP})
+	assert.Contains(t, mrp.NewMonitoringClientService().Spec.Ports,
+		corev1.ServicePort{Name: "additional-cli", Port: 8097, Protocol: corev1.ProtocolTCP})
+}
+
+func TestMonitoringDeploymentRunsAdditionalMetricsByTelegraf(t *testing.T) {
+	mrp := newMonitoringProvider(&kafkaservice.Monitoring{MonitoringType: "prometheus"})
+
+	container := mrp.NewMonitoringDeployment("1").Spec.Template.Spec.Containers[0]
+	assert.Nil(t, findEnv(container.Env, "METRICS_MODE"))
+	for _, port := range mrp.NewMonitoringClientService().Spec.Ports {
+		assert.NotEqual(t, "additional-cli", port.Name)
+	}
+}
+
+func TestMonitoringDeploymentCollectsConsumerLag(t *testing.T) {
+	mrp := newMonitoringProvider(&kafkaservice.Monitoring{ConsumerLagEnabled: true, ConsumerGroupsExclude: "-test$"})
+
+	envs := mrp.NewMonitoringDeployment("1").Spec.Template.Spec.Containers[0].Env
+	assert.Equal(t, "true", findEnv(envs, "CONSUMER_LAG_ENABLED").Value)
+	assert.Equal(t, ".*", findEnv(envs, "CONSUMER_GROUPS_INCLUDE").Value)
+	assert.Equal(t, "-test$", findEnv(envs, "CONSUMER_GROUPS_EXCLUDE").Value)
+}
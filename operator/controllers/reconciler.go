@@ -26,10 +26,12 @@ import (
 	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
+	"github.com/Netcracker/qubership-kafka/kafkaconn"
 	"github.com/Netcracker/qubership-kafka/operator/util"
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -187,6 +189,51 @@ func (r *Reconciler) GetServiceIp(namespace, serviceName string) (string, error)
 	return foundService.Spec.ClusterIP, err
 }
 
+// FindService finds service by name
+func (r *Reconciler) FindService(name string, namespace string) (*corev1.Service, error) {
+	foundService := &corev1.Service{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, foundService)
+	return foundService, err
+}
+
+// FindServiceList finds services which have specified labels
+func (r *Reconciler) FindServiceList(namespace string, serviceLabels map[string]string) (*corev1.ServiceList, error) {
+	foundServiceList := &corev1.ServiceList{}
+	err := r.Client.List(context.TODO(), foundServiceList, &client.ListOptions{
+		Namespace:     namespace,
+		LabelSelector: labels.SelectorFromSet(serviceLabels),
+	})
+	return foundServiceList, err
+}
+
+// CreateOrUpdateIngress creates the ingress if it doesn't exist and updates otherwise
+func (r *Reconciler) CreateOrUpdateIngress(ingress *networkingv1.Ingress, logger logr.Logger) error {
+	logger.Info(fmt.Sprintf("Checking Existence of [%s] ingress", ingress.Name))
+	foundIngress := &networkingv1.Ingress{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: ingress.Name, Namespace: ingress.Namespace}, foundIngress)
+	if err != nil && errors.IsNotFound(err) {
+		logger.Info("Creating a new ingress",
+			"Ingress.Namespace", ingress.Namespace, "Ingress.Name", ingress.Name)
+		return r.Client.Create(context.TODO(), ingress)
+	} else if err != nil {
+		return err
+	}
+	logger.Info("Updating the found ingress",
+		"Ingress.Namespace", ingress.Namespace, "Ingress.Name", ingress.Name)
+	ingress.ResourceVersion = foundIngress.ResourceVersion
+	return r.Client.Update(context.TODO(), ingress)
+}
+
+// FindIngressList finds ingresses which have specified labels
+func (r *Reconciler) FindIngressList(namespace string, ingressLabels map[string]string) (*networkingv1.IngressList, error) {
+	foundIngressList := &networkingv1.IngressList{}
+	err := r.Client.List(context.TODO(), foundIngressList, &client.ListOptions{
+		Namespace:     namespace,
+		LabelSelector: labels.SelectorFromSet(ingressLabels),
+	})
+	return foundIngressList, err
+}
+
 func (r *Reconciler) CreatePersistentVolumeClaim(persistentVolumeClaim *corev1.PersistentVolumeClaim, logger logr.Logger) error {
 	logger.Info(fmt.Sprintf("Checking Existence of [%s] persistent volume claim", persistentVolumeClaim.Name))
 	foundPersistentVolumeClaim := &corev1.PersistentVolumeClaim{}
@@ -564,13 +611,11 @@ func (r *Reconciler) GetSslCertificates(secretName, namespace string, logger log
 	if err != nil {
 		return nil, err
 	}
-	caCert := foundSecret.Data["ca.crt"]
-	tlsCert := foundSecret.Data["tls.crt"]
-	tlsKey := foundSecret.Data["tls.key"]
-	if len(caCert) == 0 {
+	settings := kafkaconn.TLSFromSecretData(foundSecret.Data)
+	if len(settings.CACert) == 0 {
 		return nil, fmt.Errorf("TLS certificates must be provided by secret with name: %s", secretName)
 	}
-	return &SslCertificates{CaCert: caCert, TlsCert: tlsCert, TlsKey: tlsKey}, nil
+	return &SslCertificates{CaCert: settings.CACert, TlsCert: settings.Cert, TlsKey: settings.Key}, nil
 }
 
 func (r *Reconciler) ScaleDeployment(name string, replicas int32, namespace string, logger logr.Logger) error {
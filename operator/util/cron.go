@@ -0,0 +1,172 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronSearchLimit limits the search of the next activation for expressions which never match, for example, "0 0 30 2 *"
+const cronSearchLimit = 5 * 366 * 24 * time.Hour
+
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+type cronBounds struct {
+	name string
+	min  int
+	max  int
+}
+
+var (
+	minuteBounds  = cronBounds{name: "minute", min: 0, max: 59}
+	hourBounds    = cronBounds{name: "hour", min: 0, max: 23}
+	dayBounds     = cronBounds{name: "day of month", min: 1, max: 31}
+	monthBounds   = cronBounds{name: "month", min: 1, max: 12}
+	weekdayBounds = cronBounds{name: "day of week", min: 0, max: 7}
+)
+
+// CronSchedule is a parsed standard cron expression with minute, hour, day of month, month and day of week fields
+type CronSchedule struct {
+	minute     uint64
+	hour       uint64
+	day        uint64
+	month      uint64
+	weekday    uint64
+	anyDay     bool
+	anyWeekday bool
+}
+
+// ParseCronSchedule parses cron expression with five fields or one of @yearly, @monthly, @weekly, @daily
+// and @hourly descriptors. Fields support lists, ranges and steps, for example, "0 */6 * * 1-5".
+func ParseCronSchedule(expression string) (*CronSchedule, error) {
+	if descriptor, found := cronDescriptors[strings.TrimSpace(expression)]; found {
+		expression = descriptor
+	}
+	fields := strings.Fields(expression)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron expression '%s' must have 5 fields, but has %d", expression, len(fields))
+	}
+	schedule := &CronSchedule{anyDay: fields[2] == "*", anyWeekday: fields[4] == "*"}
+	var err error
+	if schedule.minute, err = parseCronField(fields[0], minuteBounds); err != nil {
+		return nil, err
+	}
+	if schedule.hour, err = parseCronField(fields[1], hourBounds); err != nil {
+		return nil, err
+	}
+	if schedule.day, err = parseCronField(fields[2], dayBounds); err != nil {
+		return nil, err
+	}
+	if schedule.month, err = parseCronField(fields[3], monthBounds); err != nil {
+		return nil, err
+	}
+	if schedule.weekday, err = parseCronField(fields[4], weekdayBounds); err != nil {
+		return nil, err
+	}
+	// both 0 and 7 mean Sunday
+	if schedule.weekday&(1<<7) != 0 {
+		schedule.weekday |= 1
+	}
+	return schedule, nil
+}
+
+// Next returns the first activation time after specified time or zero time if the schedule never activates
+func (s *CronSchedule) Next(after time.Time) time.Time {
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(cronSearchLimit)
+	for t.Before(limit) {
+		switch {
+		case s.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+		case !s.matchDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+		case s.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// matchDay follows cron rule: if both day fields are restricted, the day matches when either of them matches
+func (s *CronSchedule) matchDay(t time.Time) bool {
+	dayMatch := s.day&(1<<uint(t.Day())) != 0
+	weekdayMatch := s.weekday&(1<<uint(t.Weekday())) != 0
+	if s.anyDay || s.anyWeekday {
+		return dayMatch && weekdayMatch
+	}
+	return dayMatch || weekdayMatch
+}
+
+func parseCronField(field string, bounds cronBounds) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			value, err := strconv.Atoi(stepPart)
+			if err != nil || value <= 0 {
+				return 0, fmt.Errorf("invalid step '%s' in %s field", stepPart, bounds.name)
+			}
+			step = value
+		}
+		start, end := bounds.min, bounds.max
+		if rangePart != "*" {
+			first, last, isRange := strings.Cut(rangePart, "-")
+			value, err := parseCronValue(first, bounds)
+			if err != nil {
+				return 0, err
+			}
+			start = value
+			if isRange {
+				if end, err = parseCronValue(last, bounds); err != nil {
+					return 0, err
+				}
+			} else if !hasStep {
+				end = start
+			}
+			if start > end {
+				return 0, fmt.Errorf("invalid range '%s' in %s field", rangePart, bounds.name)
+			}
+		}
+		for value := start; value <= end; value += step {
+			bits |= 1 << uint(value)
+		}
+	}
+	return bits, nil
+}
+
+func parseCronValue(value string, bounds cronBounds) (int, error) {
+	number, err := strconv.Atoi(value)
+	if err != nil || number < bounds.min || number > bounds.max {
+		return 0, fmt.Errorf("value '%s' of %s field must be between %d and %d",
+			value, bounds.name, bounds.min, bounds.max)
+	}
+	return number, nil
+}
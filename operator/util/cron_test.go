@@ -0,0 +1,67 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCronSchedule_Next(t *testing.T) {
+	from := time.Date(2025, 5, 13, 9, 55, 36, 0, time.UTC)
+	tests := []struct {
+		expression string
+		expected   time.Time
+	}{
+		{"0 0 * * *", time.Date(2025, 5, 14, 0, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2025, 5, 14, 0, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2025, 5, 13, 10, 0, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2025, 5, 13, 10, 0, 0, 0, time.UTC)},
+		{"30 9,18 * * *", time.Date(2025, 5, 13, 18, 30, 0, 0, time.UTC)},
+		{"0 2 * * 0", time.Date(2025, 5, 18, 2, 0, 0, 0, time.UTC)},
+		{"0 2 * * 7", time.Date(2025, 5, 18, 2, 0, 0, 0, time.UTC)},
+		{"0 0 1 */3 *", time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 * 1-5", time.Date(2025, 5, 14, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, test := range tests {
+		schedule, err := ParseCronSchedule(test.expression)
+		require.NoError(t, err, test.expression)
+		assert.Equal(t, test.expected, schedule.Next(from), test.expression)
+	}
+}
+
+func TestCronSchedule_NextIsAfterActivation(t *testing.T) {
+	schedule, err := ParseCronSchedule("0 0 * * *")
+	require.NoError(t, err)
+	midnight := time.Date(2025, 5, 14, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, midnight.Add(24*time.Hour), schedule.Next(midnight))
+}
+
+func TestCronSchedule_NeverActivates(t *testing.T) {
+	schedule, err := ParseCronSchedule("0 0 30 2 *")
+	require.NoError(t, err)
+	assert.True(t, schedule.Next(time.Now()).IsZero())
+}
+
+func TestParseCronSchedule_Invalid(t *testing.T) {
+	for _, expression := range []string{"", "0 0 * *", "60 0 * * *", "0 0 0 * *", "0 0 * 13 *", "5-1 * * * *", "*/0 * * * *", "a * * * *"} {
+		_, err := ParseCronSchedule(expression)
+		assert.Error(t, err, expression)
+	}
+}
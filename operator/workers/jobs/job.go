@@ -105,6 +105,11 @@ func duplicateScheme(apiGroup string) (*runtime.Scheme, error) {
 	additionalSchemeBuilder.Register(&qubershiporgv1.Kafka{}, &qubershiporgv1.KafkaList{})
 	additionalSchemeBuilder.Register(&qubershiporgv1.KafkaUser{}, &qubershiporgv1.KafkaUserList{})
 	additionalSchemeBuilder.Register(&qubershiporgv1.KmmConfig{}, &qubershiporgv1.KmmConfigList{})
+	additionalSchemeBuilder.Register(&qubershiporgv1.KafkaDisasterRecovery{}, &qubershiporgv1.KafkaDisasterRecoveryList{})
+	additionalSchemeBuilder.Register(&qubershiporgv1.KafkaBackup{}, &qubershiporgv1.KafkaBackupList{})
+	additionalSchemeBuilder.Register(&qubershiporgv1.KafkaRestore{}, &qubershiporgv1.KafkaRestoreList{})
+	additionalSchemeBuilder.Register(&qubershiporgv1.KafkaBackupSchedule{}, &qubershiporgv1.KafkaBackupScheduleList{})
+	additionalSchemeBuilder.Register(&qubershiporgv1.KafkaOffsetReset{}, &qubershiporgv1.KafkaOffsetResetList{})
 	err = additionalSchemeBuilder.AddToScheme(dblScheme)
 	if err != nil {
 		return nil, err
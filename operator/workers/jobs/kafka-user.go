@@ -72,7 +72,7 @@ func (rj KafkaUserJob) Build(ctx context.Context, opts cfg.Cfg, apiGroup string,
 
 	kafkaSslEnabled := opts.KafkaSslEnabled
 
-	if err = (&kafkauser.KafkaUserReconciler{
+	kafkaUserReconciler := &kafkauser.KafkaUserReconciler{
 		BootstrapServers:      opts.KafkaBootstrapServers,
 		Client:                kafkaUserMgr.GetClient(),
 		SecretCreatingEnabled: secretCreatingEnabled,
@@ -85,11 +85,19 @@ func (rj KafkaUserJob) Build(ctx context.Context, opts cfg.Cfg, apiGroup string,
 		KafkaSslEnabled:       kafkaSslEnabled,
 		KafkaSslSecret:        opts.KafkaSslSecret,
 		ApiGroup:              apiGroup,
-	}).SetupWithManager(kafkaUserMgr); err != nil {
+	}
+	if err = kafkaUserReconciler.SetupWithManager(kafkaUserMgr); err != nil {
 		logger.Error(err, "unable to create controller", "controller", "KafkaUsers")
 		return nil, err
 	}
 
+	if opts.KafkaOffsetResetEnabled {
+		if err = kafkauser.NewKafkaOffsetResetReconciler(kafkaUserReconciler).SetupWithManager(kafkaUserMgr); err != nil {
+			logger.Error(err, "unable to create controller", "controller", "KafkaOffsetReset")
+			return nil, err
+		}
+	}
+
 	if err = kafkaUserMgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		logger.Error(err, "unable to set up health check")
 		return nil, err
@@ -93,10 +93,72 @@ func (rj KafkaJob) Build(ctx context.Context, opts cfg.Cfg, apiGroup string, log
 				ResourceHashes:   map[string]string{},
 				ApiGroup:         apiGroup,
 			},
+			DrResourceEnabled: opts.DisasterRecoveryResourceEnabled,
 		}).SetupWithManager(mgr); err != nil {
 			logger.Error(err, "unable to create controller", "controller", "KafkaService")
 			return nil, err
 		}
+		if opts.DisasterRecoveryResourceEnabled {
+			if err = (&kafkaservice.KafkaDisasterRecoveryReconciler{
+				Reconciler: controllers.Reconciler{
+					Client:           mgr.GetClient(),
+					Scheme:           mgr.GetScheme(),
+					ResourceVersions: map[string]string{},
+					ResourceHashes:   map[string]string{},
+					ApiGroup:         apiGroup,
+				},
+			}).SetupWithManager(mgr); err != nil {
+				logger.Error(err, "unable to create controller", "controller", "KafkaDisasterRecovery")
+				return nil, err
+			}
+		}
+		if opts.BackupResourcesEnabled {
+			kafkaConnection := kafkaservice.KafkaConnection{
+				BootstrapServers: opts.KafkaBootstrapServers,
+				Secret:           opts.KafkaSecret,
+				SaslMechanism:    opts.KafkaSaslMechanism,
+				SslEnabled:       opts.KafkaSslEnabled,
+				SslSecret:        opts.KafkaSslSecret,
+			}
+			if err = (&kafkaservice.KafkaBackupReconciler{
+				Reconciler: controllers.Reconciler{
+					Client:           mgr.GetClient(),
+					Scheme:           mgr.GetScheme(),
+					ResourceVersions: map[string]string{},
+					ResourceHashes:   map[string]string{},
+					ApiGroup:         apiGroup,
+				},
+				KafkaConnection: kafkaConnection,
+			}).SetupWithManager(mgr); err != nil {
+				logger.Error(err, "unable to create controller", "controller", "KafkaBackup")
+				return nil, err
+			}
+			if err = (&kafkaservice.KafkaRestoreReconciler{
+				Reconciler: controllers.Reconciler{
+					Client:           mgr.GetClient(),
+					Scheme:           mgr.GetScheme(),
+					ResourceVersions: map[string]string{},
+					ResourceHashes:   map[string]string{},
+					ApiGroup:         apiGroup,
+				},
+				KafkaConnection: kafkaConnection,
+			}).SetupWithManager(mgr); err != nil {
+				logger.Error(err, "unable to create controller", "controller", "KafkaRestore")
+				return nil, err
+			}
+			if err = (&kafkaservice.KafkaBackupScheduleReconciler{
+				Reconciler: controllers.Reconciler{
+					Client:           mgr.GetClient(),
+					Scheme:           mgr.GetScheme(),
+					ResourceVersions: map[string]string{},
+					ResourceHashes:   map[string]string{},
+					ApiGroup:         apiGroup,
+				},
+			}).SetupWithManager(mgr); err != nil {
+				logger.Error(err, "unable to create controller", "controller", "KafkaBackupSchedule")
+				return nil, err
+			}
+		}
 	}
 
 	if err = mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {